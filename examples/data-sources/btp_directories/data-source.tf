@@ -0,0 +1,7 @@
+# look up all directories of a global account
+data "btp_directories" "all" {}
+
+# look up all directories of a global account that have a specific label attached
+data "btp_directories" "filtered" {
+  labels_filter = "my-label=my-value"
+}
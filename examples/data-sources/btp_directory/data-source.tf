@@ -1,3 +1,9 @@
 data "btp_directory" "by_id" {
   id = "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0"
 }
+
+# Count the subaccounts located in a directory
+data "btp_directory" "with_subaccount_count" {
+  id                     = "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0"
+  fetch_subaccount_count = true
+}
@@ -0,0 +1,4 @@
+# sum up the quota assigned to the subaccounts of a directory and its subdirectories
+data "btp_directory_entitlement_usage" "usage" {
+  directory_id = "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0"
+}
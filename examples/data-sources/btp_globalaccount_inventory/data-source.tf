@@ -0,0 +1,2 @@
+# Read the directories and subaccounts of the global account together with their entitlements and subscriptions
+data "btp_globalaccount_inventory" "all" {}
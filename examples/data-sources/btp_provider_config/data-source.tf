@@ -0,0 +1,2 @@
+# Read the effective configuration of the provider
+data "btp_provider_config" "current" {}
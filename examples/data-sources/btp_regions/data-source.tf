@@ -1 +1,5 @@
 data "btp_regions" "all" {}
+
+data "btp_regions" "entitled" {
+  scope = "globalaccount"
+}
@@ -0,0 +1,2 @@
+# Read the rate limit reported by the CLI server
+data "btp_server_info" "current" {}
@@ -0,0 +1,6 @@
+# look up the role collections to which a group of a custom identity provider is mapped on subaccount level
+data "btp_subaccount_group_role_collections" "auditors" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  group_name    = "auditors"
+  origin        = "my-custom-idp"
+}
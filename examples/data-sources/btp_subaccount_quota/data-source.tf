@@ -0,0 +1,9 @@
+# Read the quota of all entitled service plans of a subaccount
+data "btp_subaccount_quota" "all" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+}
+
+# Read the remaining quota of a specific service plan
+output "remaining_hana_quota" {
+  value = data.btp_subaccount_quota.all.values["hana-cloud:hana"].remaining
+}
@@ -5,3 +5,9 @@ data "btp_subaccounts" "all" {}
 data "btp_subaccounts" "filtered" {
   labels_filter = "my-label=my-value"
 }
+
+# look up all subaccounts of a directory that have been created in a specific region
+data "btp_subaccounts" "filtered_by_region_and_parent" {
+  region    = "eu10"
+  parent_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+}
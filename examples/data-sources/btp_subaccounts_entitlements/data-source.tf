@@ -0,0 +1,6 @@
+data "btp_subaccounts_entitlements" "all" {
+  subaccount_ids = [
+    "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f",
+    "ef23ace8-6ade-4d78-9c1f-8df729548bbf"
+  ]
+}
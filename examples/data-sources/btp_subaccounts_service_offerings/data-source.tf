@@ -0,0 +1,7 @@
+# look up the service offerings available in the subaccounts of the global account
+data "btp_subaccounts_service_offerings" "all" {}
+
+# look up the service offerings available on kubernetes in the subaccounts of the global account
+data "btp_subaccounts_service_offerings" "k8s" {
+  environment = "kubernetes"
+}
@@ -0,0 +1,10 @@
+# look up the role collections of a user of the default identity provider in all accounts
+data "btp_user_effective_permissions" "someone" {
+  user_name = "john.doe@mycompany.com"
+}
+
+# look up the role collections of a user which belongs to a custom identity provider
+data "btp_user_effective_permissions" "someone_else" {
+  user_name = "jane.doe@mycompany.com"
+  origin    = "my-custom-idp"
+}
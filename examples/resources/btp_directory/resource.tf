@@ -8,3 +8,10 @@ resource "btp_directory" "child" {
   name        = "my-child-directory"
   description = "This is a child directory."
 }
+
+# Destroying this directory also deletes all subaccounts and directories it contains
+resource "btp_directory" "sandbox" {
+  name         = "my-sandbox-directory"
+  description  = "This is a directory which is cleaned up completely on destroy."
+  force_delete = true
+}
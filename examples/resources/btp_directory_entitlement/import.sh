@@ -0,0 +1,3 @@
+# terraform import btp_directory_entitlement.<resource_name> <directory_id>,<service_name>,<plan_name>
+
+terraform import btp_directory_entitlement.alert_notification_service 5357bda0-8651-4eab-a69d-12d282bc3247,alert-notification,free
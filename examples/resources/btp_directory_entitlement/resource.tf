@@ -0,0 +1,16 @@
+# entitle service plan without quota in a directory
+resource "btp_directory_entitlement" "alert_notification_service" {
+  directory_id = "5357bda0-8651-4eab-a69d-12d282bc3247"
+  service_name = "alert-notification"
+  plan_name    = "free"
+}
+
+# entitle service plan with quota in a directory and assign some of it to new subaccounts automatically
+resource "btp_directory_entitlement" "hana_cloud" {
+  directory_id           = "5357bda0-8651-4eab-a69d-12d282bc3247"
+  service_name           = "hana-cloud"
+  plan_name              = "hana"
+  amount                 = 10
+  auto_assign            = true
+  auto_distribute_amount = 2
+}
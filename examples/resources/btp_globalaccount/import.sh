@@ -0,0 +1,3 @@
+# terraform import btp_globalaccount.<resource_name> <globalaccount_id>
+
+terraform import btp_globalaccount.this 795b53bb-a3f0-4769-adf0-26173282a975
@@ -0,0 +1,7 @@
+# manage the labels of the global account
+resource "btp_globalaccount" "this" {
+  labels = {
+    "cost-center" = ["4711"]
+    "owner"       = ["platform-team"]
+  }
+}
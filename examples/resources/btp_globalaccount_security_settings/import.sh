@@ -0,0 +1,3 @@
+# terraform import btp_globalaccount_security_settings.<resource_name> '<globalaccount_subdomain>'
+
+terraform import btp_globalaccount_security_settings.this 'my-globalaccount'
\ No newline at end of file
@@ -0,0 +1,7 @@
+# use a custom identity provider as default for the login screen of the global account
+# and only allow the company portal to embed the login screen
+resource "btp_globalaccount_security_settings" "this" {
+  default_identity_provider = "terraformint-platform"
+  access_token_validity     = 3600
+  iframe_domains            = ["https://portal.example.com"]
+}
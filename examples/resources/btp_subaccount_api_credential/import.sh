@@ -0,0 +1,3 @@
+# terraform import btp_subaccount_api_credential.<resource_name> '<subaccount_id>,<name>'
+
+terraform import btp_subaccount_api_credential.pipeline '6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f,my-pipeline'
\ No newline at end of file
@@ -0,0 +1,13 @@
+# Create an API credential that authenticates with a client secret
+resource "btp_subaccount_api_credential" "pipeline" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  name          = "my-pipeline"
+}
+
+# Create a read-only API credential that authenticates with a certificate
+resource "btp_subaccount_api_credential" "monitoring" {
+  subaccount_id      = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  name               = "my-monitoring"
+  certificate_passed = file("monitoring.pem")
+  read_only          = true
+}
@@ -0,0 +1,32 @@
+# create a subaccount with its entitlements and subscriptions in one go
+resource "btp_subaccount_blueprint" "my_project" {
+  name      = "My Project"
+  subdomain = "my-project"
+  region    = "eu10"
+  labels = {
+    "cost-center" = ["4711"]
+  }
+
+  entitlements = [
+    {
+      service_name = "alert-notification"
+      plan_name    = "free"
+    },
+    {
+      service_name = "hana-cloud"
+      plan_name    = "hana"
+      amount       = 1
+    },
+    {
+      service_name = "auditlog-viewer"
+      plan_name    = "free"
+    }
+  ]
+
+  subscriptions = [
+    {
+      app_name  = "auditlog-viewer"
+      plan_name = "free"
+    }
+  ]
+}
@@ -20,3 +20,21 @@ resource "btp_subaccount_environment_instance" "cloudfoundry" {
     ]
   })
 }
+
+# creates a kyma environment and waits up to one hour for the cluster to be provisioned
+resource "btp_subaccount_environment_instance" "kyma" {
+  subaccount_id    = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  name             = "my-kyma-environment"
+  environment_type = "kyma"
+  service_name     = "kymaruntime"
+  plan_name        = "azure"
+  parameters = jsonencode({
+    name   = "my-kyma-cluster"
+    region = "westeurope"
+  })
+  timeouts = {
+    create = "1h"
+    update = "45m"
+    delete = "30m"
+  }
+}
@@ -0,0 +1,16 @@
+# manage some labels of a subaccount, labels set by others are kept
+resource "btp_subaccount_labels" "this" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  labels = {
+    "cost-center" = ["4711"]
+  }
+}
+
+# manage all labels of a subaccount, labels set by others are removed
+resource "btp_subaccount_labels" "exclusive" {
+  subaccount_id    = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  remove_unmanaged = true
+  labels = {
+    "owner" = ["platform-team"]
+  }
+}
@@ -11,3 +11,11 @@ resource "btp_subaccount_role_collection_assignment" "subaccount_viewer_group" {
   role_collection_name = "Subaccount Viewer"
   group_name           = "subaccount-viewer-group"
 }
+
+# assign a user of the only custom identity provider trusted by the subaccount to a role collection
+resource "btp_subaccount_role_collection_assignment" "jd_custom_idp" {
+  subaccount_id        = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  role_collection_name = "Subaccount Viewer"
+  user_name            = "john.doe@mycompany.com"
+  origin_from_trust    = true
+}
@@ -0,0 +1,3 @@
+# terraform import btp_subaccount_role_collections.<resource_name> <subaccount_id>
+
+terraform import btp_subaccount_role_collections.team 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f
\ No newline at end of file
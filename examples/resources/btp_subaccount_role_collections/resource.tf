@@ -0,0 +1,27 @@
+resource "btp_subaccount_role_collections" "team" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+
+  role_collections = [
+    {
+      name        = "Team Administrators"
+      description = "Administrators of the team subaccounts."
+      roles = [
+        {
+          name                 = "Subaccount Admin"
+          role_template_app_id = "cis-local!b2"
+          role_template_name   = "Subaccount_Admin"
+        }
+      ]
+    },
+    {
+      name = "Team Auditors"
+      roles = [
+        {
+          name                 = "Subaccount Viewer"
+          role_template_app_id = "cis-local!b2"
+          role_template_name   = "Subaccount_Viewer"
+        }
+      ]
+    }
+  ]
+}
@@ -0,0 +1,13 @@
+# use a custom identity provider as default for the login screen of a subaccount
+resource "btp_subaccount_security_settings" "subaccount" {
+  subaccount_id             = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  default_identity_provider = "terraformint-platform"
+}
+
+# limit the validity of tokens and allow users with email addresses of custom domains
+resource "btp_subaccount_security_settings" "tokens" {
+  subaccount_id          = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  access_token_validity  = 3600
+  refresh_token_validity = 86400
+  custom_email_domains   = ["example.com"]
+}
@@ -15,3 +15,16 @@ resource "btp_subaccount_service_binding" "my_parameterized_binding" {
     param_b = ""
   })
 }
+
+# create a service binding whose credentials are rotated by changing the trigger
+# the new binding is created before the old one is deleted
+resource "btp_subaccount_service_binding" "my_rotated_binding" {
+  subaccount_id       = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  service_instance_id = "8911491d-0e1d-425d-a233-785512602d6f"
+  name                = "my-rotated-binding-2023-10"
+  rotate_trigger      = "2023-10"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
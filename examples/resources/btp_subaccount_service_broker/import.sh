@@ -0,0 +1,3 @@
+# terraform import btp_subaccount_service_broker.<resource_name> <subaccount_id>,<service_broker_id>
+
+terraform import btp_subaccount_service_broker.my_broker 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f,b8e8e6d6-3d8b-4cf3-b5a6-3c1c7b4a2f19
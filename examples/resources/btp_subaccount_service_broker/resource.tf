@@ -0,0 +1,19 @@
+# Register a service broker in a subaccount
+resource "btp_subaccount_service_broker" "my_broker" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  name          = "my-broker"
+  description   = "Service broker for my services"
+  url           = "https://my-broker.example.com"
+  username      = var.broker_username
+  password      = var.broker_password
+}
+
+# Register a service broker which must be registered again if its URL changes
+resource "btp_subaccount_service_broker" "my_other_broker" {
+  subaccount_id            = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  name                     = "my-other-broker"
+  url                      = "https://my-other-broker.example.com"
+  username                 = var.broker_username
+  password                 = var.broker_password
+  reregister_on_url_change = true
+}
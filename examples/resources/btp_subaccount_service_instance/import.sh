@@ -1,3 +1,7 @@
 # terraform import btp_subaccount_service_instance.<resource_name> <subaccount_id>,<service_instance_id>
 
 terraform import btp_subaccount_service_instance.alert_notification_free 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f,6a55f158-41b5-4e63-aa77-84089fa0ab98
+
+# terraform import btp_subaccount_service_instance.<resource_name> <subaccount_id>,<service_instance_name>
+
+terraform import btp_subaccount_service_instance.alert_notification_free 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f,alert-notification-free
@@ -0,0 +1,3 @@
+# terraform import btp_subaccount_service_instance_sharing.<resource_name> <subaccount_id>,<service_instance_id>
+
+terraform import btp_subaccount_service_instance_sharing.shared 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f,bc8a216f-1184-49dc-b4b4-17cfe2828965
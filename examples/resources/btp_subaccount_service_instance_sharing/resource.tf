@@ -0,0 +1,5 @@
+# share a service instance with the other subaccounts of the global account
+resource "btp_subaccount_service_instance_sharing" "shared" {
+  subaccount_id       = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  service_instance_id = "bc8a216f-1184-49dc-b4b4-17cfe2828965"
+}
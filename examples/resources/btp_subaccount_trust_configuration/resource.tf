@@ -13,3 +13,12 @@ resource "btp_subaccount_trust_configuration" "fully_customized" {
   name              = "my-name"
   description       = "my-description"
 }
+
+# create a new trust configuration for a subaccount
+# for an OpenID Connect identity provider
+resource "btp_subaccount_trust_configuration" "oidc" {
+  subaccount_id = "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+  issuer_url    = "https://my-oidc-provider.example.com"
+  client_id     = "my-client-id"
+  client_secret = var.oidc_client_secret
+}
@@ -22,6 +22,11 @@ const (
 	ActionUpdate      Action = "update"
 )
 
+// isReadingAction reports whether the action only reads objects instead of changing them.
+func isReadingAction(action Action) bool {
+	return action == ActionGet || action == ActionList
+}
+
 // NewAddRequest creates a new add request
 func NewAddRequest(command string, args any) *CommandRequest {
 	return NewCommandRequest(ActionAdd, command, args)
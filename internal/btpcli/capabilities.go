@@ -0,0 +1,80 @@
+package btpcli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+)
+
+// ErrCommandNotSupported is returned for commands the CLI server doesn't support in its current version.
+var ErrCommandNotSupported = errors.New("not supported on this server version")
+
+// ServerCapabilities holds the commands and actions supported by the CLI server. A nil value represents a server
+// whose capabilities are unknown, which is assumed to support everything.
+type ServerCapabilities struct {
+	commands map[string]map[Action]bool
+}
+
+func newServerCapabilities(commands map[string][]string) *ServerCapabilities {
+	capabilities := &ServerCapabilities{commands: map[string]map[Action]bool{}}
+
+	for command, actions := range commands {
+		capabilities.commands[command] = map[Action]bool{}
+
+		for _, action := range actions {
+			capabilities.commands[command][Action(action)] = true
+		}
+	}
+
+	return capabilities
+}
+
+// Supports reports whether the CLI server supports the given action of the command.
+func (c *ServerCapabilities) Supports(command string, action Action) bool {
+	if c == nil {
+		return true
+	}
+
+	return c.commands[command][action]
+}
+
+// ProbeCapabilities queries the commands supported by the CLI server. Once probed successfully, commands the server
+// doesn't support fail with ErrCommandNotSupported before being sent. If the server doesn't report its capabilities,
+// they remain unknown and all commands are sent as is.
+//
+// The capabilities are requested from `capabilities/<protocol version>`, next to the `login` and `command` endpoints.
+// This endpoint isn't part of the documented CLI server API and has only been verified against simulated servers, so
+// servers without it are expected: they respond with 404 (Not Found), which is returned as error like any other failure.
+func (v2 *v2Client) ProbeCapabilities(ctx context.Context) (*ServerCapabilities, error) {
+	ctx = v2.initTrace(ctx)
+
+	// the probe must not consume the refresh token of the session, as servers which don't know it won't replace it
+	res, err := v2.doRequestWithoutSession(ctx, http.MethodPost, path.Join("capabilities", cliTargetProtocolVersion), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var capabilitiesResponse CapabilitiesResponse
+	err = v2.parseResponse(ctx, res, &capabilitiesResponse, http.StatusOK, map[int]string{
+		http.StatusGatewayTimeout: "Capabilities probe timed out. Please try again later.",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(capabilitiesResponse.Commands) == 0 {
+		return nil, nil
+	}
+
+	v2.capabilities = newServerCapabilities(capabilitiesResponse.Commands)
+
+	return v2.capabilities, nil
+}
+
+// GetCapabilities returns the capabilities of the CLI server, or nil if they haven't been probed (successfully).
+func (v2 *v2Client) GetCapabilities() *ServerCapabilities {
+	return v2.capabilities
+}
@@ -0,0 +1,132 @@
+package btpcli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV2Client_ProbeCapabilities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		simulation  v2SimulationConfig
+	}{
+		{
+			description: "happy path",
+			simulation: v2SimulationConfig{
+				srvReturnStatus:  http.StatusOK,
+				srvReturnContent: `{"commands": {"accounts/subaccount": ["create", "get"], "security/user": ["list"]}}`,
+				expectResponse: &ServerCapabilities{commands: map[string]map[Action]bool{
+					"accounts/subaccount": {ActionCreate: true, ActionGet: true},
+					"security/user":       {ActionList: true},
+				}},
+			},
+		},
+		{
+			description: "error path - probe times out [504]",
+			simulation: v2SimulationConfig{
+				srvReturnStatus: http.StatusGatewayTimeout,
+				expectErrorMsg:  "Capabilities probe timed out. Please try again later. [Status: 504; Correlation ID: fake-correlation-id]",
+			},
+		},
+		{
+			description: "error path - server doesn't know the probe [404]",
+			simulation: v2SimulationConfig{
+				srvReturnStatus: http.StatusNotFound,
+				expectErrorMsg:  "Received response with unexpected status [Status: 404; Correlation ID: fake-correlation-id]",
+			},
+		},
+		{
+			description: "error path - server doesn't know the probe [404] - session is kept",
+			simulation: v2SimulationConfig{
+				initSession: &Session{
+					GlobalAccountSubdomain: "my-subdomain",
+					RefreshToken:           "abc",
+				},
+				srvReturnStatus: http.StatusNotFound,
+				expectErrorMsg:  "Received response with unexpected status [Status: 404; Correlation ID: fake-correlation-id]",
+				expectClientSession: &Session{
+					GlobalAccountSubdomain: "my-subdomain",
+					RefreshToken:           "abc",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.simulation.srvExpectPath = path.Join("/capabilities", cliTargetProtocolVersion)
+			test.simulation.callFunctionUnderTest = func(ctx context.Context, uut *v2Client) (any, error) {
+				return uut.ProbeCapabilities(ctx)
+			}
+
+			simulateV2Call(t, test.simulation)
+		})
+	}
+
+	t.Run("happy path - no capabilities reported", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{}`))
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+
+		capabilities, err := uut.ProbeCapabilities(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Nil(t, capabilities)
+		assert.Nil(t, uut.GetCapabilities())
+	})
+}
+
+func TestServerCapabilities_Supports(t *testing.T) {
+	t.Parallel()
+	t.Run("unknown capabilities", func(t *testing.T) {
+		var uut *ServerCapabilities
+
+		assert.True(t, uut.Supports("accounts/subaccount", ActionCreate))
+	})
+	t.Run("known capabilities", func(t *testing.T) {
+		uut := newServerCapabilities(map[string][]string{"accounts/subaccount": {"create", "get"}})
+
+		assert.True(t, uut.Supports("accounts/subaccount", ActionCreate))
+		assert.False(t, uut.Supports("accounts/subaccount", ActionDelete))
+		assert.False(t, uut.Supports("accounts/directory", ActionCreate))
+	})
+}
+
+func TestV2Client_ExecuteUnsupportedCommand(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Header().Set(HeaderCLIBackendStatus, "200")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	srvUrl, _ := url.Parse(srv.URL)
+	uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+	uut.capabilities = newServerCapabilities(map[string][]string{"accounts/subaccount": {"get"}})
+
+	_, err := uut.Execute(context.TODO(), NewCreateRequest("accounts/subaccount", map[string]string{}))
+
+	assert.ErrorIs(t, err, ErrCommandNotSupported)
+	assert.EqualError(t, err, "the command 'accounts/subaccount' with action 'create' is not supported on this server version")
+	assert.False(t, called)
+
+	_, err = uut.Execute(context.TODO(), NewGetRequest("accounts/subaccount", map[string]string{}))
+
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
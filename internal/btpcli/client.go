@@ -4,17 +4,35 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	uuid "github.com/hashicorp/go-uuid"
 )
 
 const DefaultServerURL string = "https://cpcli.cf.eu10.hana.ondemand.com"
 
+// ErrLoginFailed is returned if the CLI server rejects the credentials of a login.
+var ErrLoginFailed = errors.New("Login failed. Check your credentials.")
+
+// ErrLoginTimeout is returned if a login doesn't complete within the LoginTimeout of the client.
+var ErrLoginTimeout = errors.New("Login timed out.")
+
+// ErrConcurrentModification is returned if a changing command is rejected, because the object has been modified since
+// the ETag passed with WithIfMatch has been read.
+var ErrConcurrentModification = errors.New("The object has been modified in the meantime.")
+
+// ErrOperationInProgress is returned if a command is rejected, because another operation on the same object, e.g. a
+// service instance, is still in progress. The command may succeed once that operation has finished.
+var ErrOperationInProgress = errors.New("Another operation on the object is still in progress.")
+
 func NewV2Client(serverURL *url.URL) *v2Client {
 	return NewV2ClientWithHttpClient(http.DefaultClient, serverURL)
 }
@@ -44,20 +62,49 @@ const (
 	HeaderCLIBackendStatus           string = "X-Cpcli-Backend-Status"
 	HeaderCLIBackendMessage          string = "X-Cpcli-Backend-Message"
 	HeaderCLIBackendMediaType        string = "X-Cpcli-Backend-Mediatype"
+	HeaderETag                       string = "Etag"
+	HeaderIfMatch                    string = "If-Match"
 )
 
 const cliTargetProtocolVersion string = "v2.38.0"
 
 type v2ContextKey string
 
+// ifMatchContextKey holds the ETag given to WithIfMatch. It's only turned into the If-Match header of changing commands.
+const ifMatchContextKey v2ContextKey = "ifMatch"
+
+// WithIfMatch returns a context, in which changing commands are only executed if the object still has the given ETag,
+// as reported in CommandResponse.ETag of a previous read. Otherwise, the command fails with ErrConcurrentModification.
+func WithIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchContextKey, etag)
+}
+
 type v2Client struct {
 	httpClient *http.Client
 	serverURL  *url.URL
 
 	newCorrelationID func() string
 
-	session   *Session
-	UserAgent string
+	session      *Session
+	capabilities *ServerCapabilities
+	UserAgent    string
+
+	// LoginTimeout bounds the authentication request, e.g. in case of a slow identity provider. If zero, the login is
+	// only bound by the context.
+	LoginTimeout time.Duration
+
+	// ValidateResponseSchema rejects responses with fields unknown to the expected type for the facades which opted
+	// in, so that tests detect when the types drift apart from the responses of the CLI server.
+	ValidateResponseSchema bool
+
+	// sessionCache persists the session under sessionCacheKey whenever its refresh token changes, if set
+	sessionCache    *SessionCache
+	sessionCacheKey string
+
+	// rateLimit is the rate limit reported with the latest response, guarded by rateLimitMutex as requests are sent
+	// concurrently
+	rateLimit      *RateLimit
+	rateLimitMutex sync.Mutex
 }
 
 func (v2 *v2Client) initTrace(ctx context.Context) context.Context {
@@ -65,6 +112,47 @@ func (v2 *v2Client) initTrace(ctx context.Context) context.Context {
 }
 
 func (v2 *v2Client) doRequest(ctx context.Context, method string, endpoint string, body any) (*http.Response, error) {
+	req, err := v2.newRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// the lock only guards the session, so that requests are sent concurrently
+	var refreshToken string
+	if v2.session != nil {
+		v2.session.Lock()
+		refreshToken = v2.session.RefreshToken
+		req.Header.Set(HeaderCLIRefreshToken, refreshToken)
+		req.Header.Set(HeaderCLISubdomain, v2.session.GlobalAccountSubdomain)
+		req.Header.Set(HeaderCLICustomIDP, v2.session.IdentityProvider)
+		v2.session.Unlock()
+	}
+
+	res, err := v2.httpClient.Do(req)
+
+	if err == nil {
+		v2.observeRateLimit(res)
+	}
+
+	if v2.session != nil && err == nil {
+		v2.replaceRefreshToken(refreshToken, res.Header.Get(HeaderCLIReplacementRefreshToken))
+	}
+
+	return res, err
+}
+
+// doRequestWithoutSession sends a request which doesn't act on behalf of the logged-in user, so that neither the
+// refresh token is passed on nor its replacement is expected.
+func (v2 *v2Client) doRequestWithoutSession(ctx context.Context, method string, endpoint string, body any) (*http.Response, error) {
+	req, err := v2.newRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return v2.httpClient.Do(req)
+}
+
+func (v2 *v2Client) newRequest(ctx context.Context, method string, endpoint string, body any) (*http.Request, error) {
 	endpointURL, err := url.Parse(endpoint)
 
 	if err != nil {
@@ -90,26 +178,45 @@ func (v2 *v2Client) doRequest(ctx context.Context, method string, endpoint strin
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set(HeaderCLIFormat, "json")
 
-	if v2.session != nil {
-		v2.session.Lock()
-		defer v2.session.Unlock()
+	if correlationID := ctx.Value(v2ContextKey(HeaderCorrelationID)); correlationID != nil {
+		req.Header.Set(HeaderCorrelationID, correlationID.(string))
+	}
 
-		req.Header.Set(HeaderCLIRefreshToken, v2.session.RefreshToken)
-		req.Header.Set(HeaderCLISubdomain, v2.session.GlobalAccountSubdomain)
-		req.Header.Set(HeaderCLICustomIDP, v2.session.IdentityProvider)
+	if etag, ok := ctx.Value(v2ContextKey(HeaderIfMatch)).(string); ok && len(etag) > 0 {
+		req.Header.Set(HeaderIfMatch, etag)
 	}
 
-	if correlationID := ctx.Value(v2ContextKey(HeaderCorrelationID)); correlationID != nil {
-		req.Header.Set(HeaderCorrelationID, correlationID.(string))
+	return req, nil
+}
+
+// replaceRefreshToken replaces the refresh token which was sent with a request by the replacement of its response.
+// Responses which don't replace the token, e.g. errors of the CLI server itself, leave the session as is. So do
+// responses of concurrent requests, which were sent with a token already replaced in the meantime. The session is only
+// persisted if the token actually changes.
+func (v2 *v2Client) replaceRefreshToken(sentRefreshToken string, replacementRefreshToken string) {
+	if len(replacementRefreshToken) == 0 || replacementRefreshToken == sentRefreshToken {
+		return
 	}
 
-	res, err := v2.httpClient.Do(req)
+	v2.session.Lock()
+	defer v2.session.Unlock()
 
-	if v2.session != nil && err == nil {
-		v2.session.RefreshToken = res.Header.Get(HeaderCLIReplacementRefreshToken)
+	if v2.session.RefreshToken != sentRefreshToken {
+		return
 	}
 
-	return res, err
+	v2.session.RefreshToken = replacementRefreshToken
+	v2.persistSession()
+}
+
+// persistSession stores the current session in the session cache, if any. Failing to do so isn't fatal, as the session
+// is still valid and the next run simply logs in again.
+func (v2 *v2Client) persistSession() {
+	if v2.sessionCache == nil || len(v2.session.RefreshToken) == 0 {
+		return
+	}
+
+	_ = v2.sessionCache.store(v2.sessionCacheKey, v2.session)
 }
 
 func (v2 *v2Client) doPostRequest(ctx context.Context, endpoint string, body any) (*http.Response, error) {
@@ -137,6 +244,10 @@ func (v2 *v2Client) checkResponseForErrors(ctx context.Context, res *http.Respon
 		err = v2.parseResponseError(ctx, res)
 	}
 
+	return v2.annotateResponseError(ctx, res, err)
+}
+
+func (v2 *v2Client) annotateResponseError(ctx context.Context, res *http.Response, err error) error {
 	return fmt.Errorf("%w [Status: %d; Correlation ID: %s]", err, res.StatusCode, ctx.Value(v2ContextKey(HeaderCorrelationID)))
 }
 
@@ -144,26 +255,36 @@ func (v2 *v2Client) parseResponseError(ctx context.Context, res *http.Response)
 	return fmt.Errorf("Received response with unexpected status")
 }
 
-// Login authenticates a user using username + password
+// Login authenticates a user using username + password or a client certificate, or a technical user using client credentials
 func (v2 *v2Client) Login(ctx context.Context, loginReq *LoginRequest) (*LoginResponse, error) {
 	ctx = v2.initTrace(ctx)
+	parentCtx := ctx
+
+	if v2.LoginTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v2.LoginTimeout)
+		defer cancel()
+	}
 
 	res, err := v2.doPostRequest(ctx, path.Join("login", cliTargetProtocolVersion), loginReq)
 
 	if err != nil {
-		return nil, err
+		return nil, v2.loginTimeoutError(parentCtx, err)
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return nil, v2.annotateResponseError(ctx, res, ErrLoginFailed)
 	}
 
 	var loginResponse LoginResponse
 	err = v2.parseResponse(ctx, res, &loginResponse, http.StatusOK, map[int]string{
-		http.StatusUnauthorized:   "Login failed. Check your credentials.",
 		http.StatusForbidden:      fmt.Sprintf("You cannot access global account '%s'. Make sure you have at least read access to the global account, a directory, or a subaccount.", loginReq.GlobalAccountSubdomain),
 		http.StatusNotFound:       fmt.Sprintf("Global account '%s' not found. Try again and make sure to provide the global account's subdomain.", loginReq.GlobalAccountSubdomain),
 		http.StatusGatewayTimeout: "Login timed out. Please try again later.",
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, v2.loginTimeoutError(parentCtx, err)
 	}
 
 	v2.session = &Session{
@@ -173,6 +294,7 @@ func (v2 *v2Client) Login(ctx context.Context, loginReq *LoginRequest) (*LoginRe
 			Username: loginResponse.Username,
 			Email:    loginResponse.Email,
 			Issuer:   loginResponse.Issuer,
+			Groups:   loginResponse.Groups,
 		},
 		RefreshToken: loginResponse.RefreshToken,
 	}
@@ -180,6 +302,16 @@ func (v2 *v2Client) Login(ctx context.Context, loginReq *LoginRequest) (*LoginRe
 	return &loginResponse, nil
 }
 
+// loginTimeoutError replaces the given error with ErrLoginTimeout, if the login has been aborted because of the
+// LoginTimeout rather than because of the given parent context.
+func (v2 *v2Client) loginTimeoutError(parentCtx context.Context, err error) error {
+	if v2.LoginTimeout > 0 && parentCtx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w The authentication didn't complete within %s. [Correlation ID: %s]", ErrLoginTimeout, v2.LoginTimeout, parentCtx.Value(v2ContextKey(HeaderCorrelationID)))
+	}
+
+	return err
+}
+
 // Logout invalidates the current user session
 func (v2 *v2Client) Logout(ctx context.Context, logoutReq *LogoutRequest) (*LogoutResponse, error) {
 	ctx = v2.initTrace(ctx)
@@ -200,6 +332,15 @@ func (v2 *v2Client) Logout(ctx context.Context, logoutReq *LogoutRequest) (*Logo
 func (v2 *v2Client) Execute(ctx context.Context, cmdReq *CommandRequest, options ...CommandOptions) (cmdRes CommandResponse, err error) {
 	ctx = v2.initTrace(ctx)
 
+	if !v2.capabilities.Supports(cmdReq.Command, cmdReq.Action) {
+		err = fmt.Errorf("the command '%s' with action '%s' is %w", cmdReq.Command, cmdReq.Action, ErrCommandNotSupported)
+		return
+	}
+
+	if etag, ok := ctx.Value(ifMatchContextKey).(string); ok && !isReadingAction(cmdReq.Action) {
+		ctx = context.WithValue(ctx, v2ContextKey(HeaderIfMatch), etag)
+	}
+
 	wrappedArgs := struct {
 		ParamValues any `json:"paramValues"`
 	}{
@@ -215,6 +356,11 @@ func (v2 *v2Client) Execute(ctx context.Context, cmdReq *CommandRequest, options
 	opts := firstElementOrDefault(options, CommandOptions{GoodState: http.StatusOK, KnownErrorStates: map[int]string{}})
 	opts.KnownErrorStates[http.StatusGatewayTimeout] = "Command timed out. Please try again later."
 
+	if res.StatusCode == http.StatusPreconditionFailed {
+		err = v2.annotateResponseError(ctx, res, ErrConcurrentModification)
+		return
+	}
+
 	if err = v2.checkResponseForErrors(ctx, res, opts.GoodState, opts.KnownErrorStates); err != nil {
 		return
 	}
@@ -235,11 +381,21 @@ func (v2 *v2Client) Execute(ctx context.Context, cmdReq *CommandRequest, options
 			err = fmt.Errorf("the backend responded with an unknown error: %d", cmdRes.StatusCode)
 		}
 
+		if cmdRes.StatusCode == http.StatusPreconditionFailed {
+			err = fmt.Errorf("%w %s", ErrConcurrentModification, err)
+		}
+
+		// the status alone is ambiguous, as it's also reported for invalid parameters
+		if cmdRes.StatusCode == http.StatusUnprocessableEntity && strings.Contains(strings.ToLower(backendError.Message), "concurrent operation in progress") {
+			err = fmt.Errorf("%w %s", ErrOperationInProgress, err)
+		}
+
 		return
 	}
 
 	cmdRes.Body = res.Body
 	cmdRes.ContentType = res.Header.Get(HeaderCLIBackendMediaType)
+	cmdRes.ETag = res.Header.Get(HeaderETag)
 	return
 }
 
@@ -258,3 +414,19 @@ func (v2 *v2Client) GetLoggedInUser() *v2LoggedInUser {
 
 	return v2.session.LoggedInUser
 }
+
+func (v2 *v2Client) GetServerURL() string {
+	if v2.serverURL == nil {
+		return ""
+	}
+
+	return v2.serverURL.String()
+}
+
+func (v2 *v2Client) GetIdentityProvider() string {
+	if v2.session == nil {
+		return ""
+	}
+
+	return v2.session.IdentityProvider
+}
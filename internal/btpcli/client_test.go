@@ -2,15 +2,19 @@ package btpcli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -104,6 +108,63 @@ func TestV2Client_Login(t *testing.T) {
 				},
 			},
 		},
+		{
+			description:  "happy path - with groups",
+			loginRequest: NewLoginRequestWithCustomIDP("my.custom.idp", "subdomain", "john.doe", "pass"),
+			simulation: v2SimulationConfig{
+				srvExpectBody:    `{"customIdp":"my.custom.idp","subdomain":"subdomain","userName":"john.doe","password":"pass"}`,
+				srvReturnStatus:  http.StatusOK,
+				srvReturnContent: `{"issuer": "customidp.accounts.ondemand.com","user":"john.doe","mail":"john.doe@test.com","refreshToken":"abc","groups":["admins","developers"]}`,
+				expectResponse: &LoginResponse{
+					Issuer:       "customidp.accounts.ondemand.com",
+					Username:     "john.doe",
+					Email:        "john.doe@test.com",
+					RefreshToken: "abc",
+					Groups:       []string{"admins", "developers"},
+				},
+				expectClientSession: &Session{
+					RefreshToken:           "abc",
+					GlobalAccountSubdomain: "subdomain",
+					IdentityProvider:       "my.custom.idp",
+					LoggedInUser: &v2LoggedInUser{
+						Issuer:   "customidp.accounts.ondemand.com",
+						Username: "john.doe",
+						Email:    "john.doe@test.com",
+						Groups:   []string{"admins", "developers"},
+					},
+				},
+			},
+		},
+		{
+			description:  "happy path - with client credentials",
+			loginRequest: NewLoginRequestWithClientCredentials("subdomain", "sb-client-id", "client-secret", "https://subdomain.authentication.eu10.hana.ondemand.com/oauth/token"),
+			simulation: v2SimulationConfig{
+				srvExpectBody:    `{"customIdp":"","subdomain":"subdomain","userName":"","password":"","clientId":"sb-client-id","clientSecret":"client-secret","tokenUrl":"https://subdomain.authentication.eu10.hana.ondemand.com/oauth/token"}`,
+				srvReturnStatus:  http.StatusOK,
+				srvReturnContent: `{"issuer": "subdomain.authentication.eu10.hana.ondemand.com","user":"sb-client-id","mail":"","refreshToken":"abc"}`,
+				expectResponse: &LoginResponse{
+					Issuer:       "subdomain.authentication.eu10.hana.ondemand.com",
+					Username:     "sb-client-id",
+					RefreshToken: "abc",
+				},
+				expectClientSession: &Session{
+					RefreshToken:           "abc",
+					GlobalAccountSubdomain: "subdomain",
+					LoggedInUser: &v2LoggedInUser{
+						Issuer:   "subdomain.authentication.eu10.hana.ondemand.com",
+						Username: "sb-client-id",
+					},
+				},
+			},
+		},
+		{
+			description:  "error path - wrong client credentials [401]",
+			loginRequest: NewLoginRequestWithClientCredentials("subdomain", "sb-client-id", "this.is.wrong", "https://subdomain.authentication.eu10.hana.ondemand.com/oauth/token"),
+			simulation: v2SimulationConfig{
+				srvReturnStatus: http.StatusUnauthorized,
+				expectErrorMsg:  "Login failed. Check your credentials. [Status: 401; Correlation ID: fake-correlation-id]",
+			},
+		},
 		{
 			description:  "error path - wrong credentials [401]",
 			loginRequest: NewLoginRequest("subdomain", "john.doe", "this.is.wrong"),
@@ -158,6 +219,68 @@ func TestV2Client_Login(t *testing.T) {
 	}
 }
 
+func TestV2Client_LoginTimeout(t *testing.T) {
+	t.Parallel()
+
+	const delay = 200 * time.Millisecond
+
+	// the server responds slowly to logins and commands alike
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(delay):
+		}
+
+		w.Header().Set(HeaderCLIBackendStatus, "200")
+		fmt.Fprintf(w, `{"issuer": "accounts.sap.com","user":"john.doe","mail":"john.doe@test.com","refreshToken":"abc"}`)
+	}))
+	defer srv.Close()
+
+	srvUrl, _ := url.Parse(srv.URL)
+
+	t.Run("error path - login exceeds the login timeout", func(t *testing.T) {
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.LoginTimeout = delay / 4
+
+		_, err := uut.Login(context.TODO(), NewLoginRequest("subdomain", "john.doe", "pass"))
+
+		assert.ErrorIs(t, err, ErrLoginTimeout)
+		assert.Nil(t, uut.session)
+	})
+
+	t.Run("happy path - login within the login timeout", func(t *testing.T) {
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.LoginTimeout = delay * 10
+
+		_, err := uut.Login(context.TODO(), NewLoginRequest("subdomain", "john.doe", "pass"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("happy path - commands aren't bound by the login timeout", func(t *testing.T) {
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.LoginTimeout = delay / 4
+
+		_, err := uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{}))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error path - cancelled context isn't reported as login timeout", func(t *testing.T) {
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.LoginTimeout = delay * 10
+
+		ctx, cancel := context.WithTimeout(context.TODO(), delay/4)
+		defer cancel()
+
+		_, err := uut.Login(ctx, NewLoginRequest("subdomain", "john.doe", "pass"))
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrLoginTimeout)
+	})
+}
+
 func TestV2Client_Logout(t *testing.T) {
 	t.Parallel()
 
@@ -296,6 +419,96 @@ func TestV2Client_Execute(t *testing.T) {
 		assert.EqualError(t, err, "this is a backend error")
 		assert.Equal(t, 500, cmdRes.StatusCode)
 	})
+	t.Run("refresh token is kept if the response doesn't replace it", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "abc", r.Header.Get(HeaderCLIRefreshToken))
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.session = &Session{
+			GlobalAccountSubdomain: "globalaccount-subdomain",
+			RefreshToken:           "abc",
+		}
+
+		_, err := uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{}))
+
+		assert.Error(t, err)
+		assert.Equal(t, "abc", uut.session.RefreshToken)
+	})
+	t.Run("session is only persisted if the refresh token changes", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, "200")
+			w.Header().Set(HeaderCLIReplacementRefreshToken, r.URL.Query().Get("replacement"))
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		cachePath := filepath.Join(t.TempDir(), "session.json")
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.session = &Session{
+			GlobalAccountSubdomain: "globalaccount-subdomain",
+			RefreshToken:           "abc",
+		}
+		uut.sessionCache = NewSessionCache(cachePath)
+		uut.sessionCacheKey = srv.URL + "|globalaccount-subdomain||john.doe@int.test"
+
+		_, err := uut.doPostRequest(context.TODO(), "/command?replacement=abc", nil)
+		assert.NoError(t, err)
+		assert.NoFileExists(t, cachePath)
+
+		_, err = uut.doPostRequest(context.TODO(), "/command?replacement=def", nil)
+		assert.NoError(t, err)
+		assert.FileExists(t, cachePath)
+		assert.Equal(t, "def", uut.session.RefreshToken)
+	})
+	t.Run("concurrent requests don't revert a replaced refresh token", func(t *testing.T) {
+		firstReceived, secondReplied := make(chan struct{}), make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "abc", r.Header.Get(HeaderCLIRefreshToken))
+
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+			w.Header().Set(HeaderCLIBackendStatus, "200")
+			if payload.ParamValues["subaccount"] == "first" {
+				close(firstReceived)
+				<-secondReplied
+				w.Header().Set(HeaderCLIReplacementRefreshToken, "from-first")
+			} else {
+				w.Header().Set(HeaderCLIReplacementRefreshToken, "from-second")
+			}
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		uut.session = &Session{
+			GlobalAccountSubdomain: "globalaccount-subdomain",
+			RefreshToken:           "abc",
+		}
+
+		firstDone := make(chan error)
+		go func() {
+			_, err := uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{"subaccount": "first"}))
+			firstDone <- err
+		}()
+		<-firstReceived
+
+		_, err := uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{"subaccount": "second"}))
+		close(secondReplied)
+
+		assert.NoError(t, err)
+		assert.NoError(t, <-firstDone)
+		assert.Equal(t, "from-second", uut.session.RefreshToken)
+	})
 	t.Run("backend error handling - incompatible error message", func(t *testing.T) {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			assert.Equal(t, "my.custom.idp", r.Header.Get(HeaderCLICustomIDP))
@@ -322,6 +535,120 @@ func TestV2Client_Execute(t *testing.T) {
 		assert.EqualError(t, err, "the backend responded with an unknown error: 500")
 		assert.Equal(t, 500, cmdRes.StatusCode)
 	})
+	t.Run("etag: if-match header is only sent with changing commands", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if Action(r.URL.RawQuery) == ActionUpdate {
+				assert.Equal(t, `"v1"`, r.Header.Get(HeaderIfMatch))
+			} else {
+				assert.Empty(t, r.Header.Get(HeaderIfMatch))
+			}
+
+			w.Header().Set(HeaderCLIBackendStatus, "200")
+			w.Header().Set(HeaderETag, `"v1"`)
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		ctx := WithIfMatch(context.TODO(), `"v1"`)
+
+		res, err := uut.Execute(ctx, NewGetRequest("security/settings", map[string]string{}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `"v1"`, res.ETag)
+		}
+
+		_, err = uut.Execute(ctx, NewUpdateRequest("security/settings", map[string]string{}))
+		assert.NoError(t, err)
+	})
+	t.Run("etag: concurrent modification is rejected", func(t *testing.T) {
+		var lock sync.Mutex
+		version := 1
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			if Action(r.URL.RawQuery) == ActionUpdate {
+				if ifMatch := r.Header.Get(HeaderIfMatch); len(ifMatch) > 0 && ifMatch != fmt.Sprintf(`"v%d"`, version) {
+					w.Header().Set(HeaderCLIBackendStatus, "412")
+					fmt.Fprintf(w, `{"error":"The ETag doesn't match."}`)
+					return
+				}
+				version++
+			}
+
+			w.Header().Set(HeaderCLIBackendStatus, "200")
+			w.Header().Set(HeaderETag, fmt.Sprintf(`"v%d"`, version))
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		pipelineA := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+		pipelineB := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+
+		// both pipelines read the same version of the object
+		readA, err := pipelineA.Execute(context.TODO(), NewGetRequest("security/settings", map[string]string{}))
+		assert.NoError(t, err)
+		readB, err := pipelineB.Execute(context.TODO(), NewGetRequest("security/settings", map[string]string{}))
+		assert.NoError(t, err)
+		assert.Equal(t, readA.ETag, readB.ETag)
+
+		updateA, err := pipelineA.Execute(WithIfMatch(context.TODO(), readA.ETag), NewUpdateRequest("security/settings", map[string]string{}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `"v2"`, updateA.ETag)
+		}
+
+		updateB, err := pipelineB.Execute(WithIfMatch(context.TODO(), readB.ETag), NewUpdateRequest("security/settings", map[string]string{}))
+		assert.ErrorIs(t, err, ErrConcurrentModification)
+		assert.EqualError(t, err, "The object has been modified in the meantime. The ETag doesn't match.")
+		assert.Equal(t, http.StatusPreconditionFailed, updateB.StatusCode)
+	})
+	t.Run("backend error handling - operation in progress", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, fmt.Sprintf("%d", 422))
+			fmt.Fprintf(w, `{"error":"Another concurrent operation in progress for this resource"}`)
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+
+		cmdRes, err := uut.Execute(context.TODO(), NewCreateRequest("services/binding", map[string]string{}))
+
+		assert.ErrorIs(t, err, ErrOperationInProgress)
+		assert.EqualError(t, err, "Another operation on the object is still in progress. Another concurrent operation in progress for this resource")
+		assert.Equal(t, 422, cmdRes.StatusCode)
+	})
+	t.Run("backend error handling - other unprocessable entities", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, fmt.Sprintf("%d", 422))
+			fmt.Fprintf(w, `{"error":"The service plan doesn't support bindings"}`)
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+
+		_, err := uut.Execute(context.TODO(), NewCreateRequest("services/binding", map[string]string{}))
+
+		assert.NotErrorIs(t, err, ErrOperationInProgress)
+		assert.EqualError(t, err, "The service plan doesn't support bindings")
+	})
+	t.Run("etag: precondition failure of the CLI server", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+
+		_, err := uut.Execute(WithIfMatch(context.TODO(), `"v1"`), NewUpdateRequest("security/settings", map[string]string{}))
+
+		assert.ErrorIs(t, err, ErrConcurrentModification)
+	})
 }
 
 type v2SimulationConfig struct {
@@ -440,3 +767,31 @@ func TestV2Client_GetGlobalAccountSubdomain(t *testing.T) {
 		assert.Equal(t, "my-subdomain", uut.GetGlobalAccountSubdomain())
 	})
 }
+
+func TestV2Client_GetServerURL(t *testing.T) {
+	t.Parallel()
+	t.Run("no server URL given", func(t *testing.T) {
+		uut := NewV2Client(nil)
+		assert.Empty(t, uut.GetServerURL())
+	})
+	t.Run("server URL given", func(t *testing.T) {
+		serverURL, _ := url.Parse("https://cpcli.cf.eu10.hana.ondemand.com")
+
+		uut := NewV2Client(serverURL)
+		assert.Equal(t, "https://cpcli.cf.eu10.hana.ondemand.com", uut.GetServerURL())
+	})
+}
+
+func TestV2Client_GetIdentityProvider(t *testing.T) {
+	t.Parallel()
+	t.Run("no one logged in so far", func(t *testing.T) {
+		uut := NewV2Client(nil)
+		assert.Empty(t, uut.GetIdentityProvider())
+	})
+	t.Run("someone logged in", func(t *testing.T) {
+		uut := NewV2Client(nil)
+		uut.session = &Session{IdentityProvider: "my-idp"}
+
+		assert.Equal(t, "my-idp", uut.GetIdentityProvider())
+	})
+}
@@ -20,11 +20,34 @@ func NewLoginRequestWithCustomIDP(idp string, globalaccountSubdomain string, use
 	}
 }
 
+// NewLoginRequestWithClientCredentials creates a login request for a technical user, e.g. taken from the
+// credentials of an xsuaa service binding, instead of a named user with username and password.
+func NewLoginRequestWithClientCredentials(globalaccountSubdomain string, clientId string, clientSecret string, tokenUrl string) *LoginRequest {
+	return &LoginRequest{
+		GlobalAccountSubdomain: globalaccountSubdomain,
+		ClientId:               clientId,
+		ClientSecret:           clientSecret,
+		TokenUrl:               tokenUrl,
+	}
+}
+
+// NewLoginRequestWithClientCertificate creates a login request for a user which is authenticated by the client certificate
+// presented on the TLS handshake, see WithClientCertificate. Hence, the request doesn't contain any credentials.
+func NewLoginRequestWithClientCertificate(idp string, globalaccountSubdomain string) *LoginRequest {
+	return &LoginRequest{
+		IdentityProvider:       idp,
+		GlobalAccountSubdomain: globalaccountSubdomain,
+	}
+}
+
 type LoginRequest struct {
 	IdentityProvider       string `json:"customIdp"`
 	GlobalAccountSubdomain string `json:"subdomain"`
 	Username               string `json:"userName"`
 	Password               string `json:"password"`
+	ClientId               string `json:"clientId,omitempty"`
+	ClientSecret           string `json:"clientSecret,omitempty"`
+	TokenUrl               string `json:"tokenUrl,omitempty"`
 }
 
 type LoginResponse struct {
@@ -32,6 +55,8 @@ type LoginResponse struct {
 	Username     string `json:"user"`
 	Email        string `json:"mail"`
 	Issuer       string `json:"issuer"`
+	// Groups are the group memberships of the user, if the identity provider passes them on in the token.
+	Groups []string `json:"groups,omitempty"`
 }
 
 /* Logout */
@@ -56,6 +81,13 @@ type LogoutRequest struct {
 type LogoutResponse struct {
 }
 
+/* Capabilities */
+
+type CapabilitiesResponse struct {
+	// Commands lists the supported actions per command, e.g. "accounts/subaccount": ["create", "delete", "get"]
+	Commands map[string][]string `json:"commands"`
+}
+
 /* Command */
 func NewCommandRequest(action Action, command string, args any) *CommandRequest {
 	return &CommandRequest{
@@ -81,5 +113,7 @@ type CommandRequest struct {
 type CommandResponse struct {
 	StatusCode  int
 	ContentType string
-	Body        io.ReadCloser
+	// ETag identifies the version of the object, if supported by the server. See WithIfMatch.
+	ETag string
+	Body io.ReadCloser
 }
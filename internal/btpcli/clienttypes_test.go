@@ -35,6 +35,34 @@ func TestLoginRequest(t *testing.T) {
 			assert.Equal(t, `{"customIdp":"my-idp","subdomain":"my-subdomain","userName":"my-user","password":"my-pass"}`, string(b))
 		}
 	})
+	t.Run("NewLoginRequestWithClientCredentials(...) uses all given values", func(t *testing.T) {
+		uut := NewLoginRequestWithClientCredentials("my-subdomain", "my-client-id", "my-client-secret", "https://my-tenant.authentication.eu10.hana.ondemand.com/oauth/token")
+		assert.Empty(t, uut.IdentityProvider)
+		assert.Empty(t, uut.Username)
+		assert.Empty(t, uut.Password)
+		assert.Equal(t, "my-subdomain", uut.GlobalAccountSubdomain)
+		assert.Equal(t, "my-client-id", uut.ClientId)
+		assert.Equal(t, "my-client-secret", uut.ClientSecret)
+		assert.Equal(t, "https://my-tenant.authentication.eu10.hana.ondemand.com/oauth/token", uut.TokenUrl)
+	})
+	t.Run("LoginRequest with client credentials can be marshalled", func(t *testing.T) {
+		uut := NewLoginRequestWithClientCredentials("my-subdomain", "my-client-id", "my-client-secret", "https://my-tenant.authentication.eu10.hana.ondemand.com/oauth/token")
+
+		b, err := json.Marshal(uut)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, `{"customIdp":"","subdomain":"my-subdomain","userName":"","password":"","clientId":"my-client-id","clientSecret":"my-client-secret","tokenUrl":"https://my-tenant.authentication.eu10.hana.ondemand.com/oauth/token"}`, string(b))
+		}
+	})
+	t.Run("NewLoginRequestWithClientCertificate(...) doesn't contain credentials", func(t *testing.T) {
+		uut := NewLoginRequestWithClientCertificate("my-idp", "my-subdomain")
+
+		b, err := json.Marshal(uut)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, `{"customIdp":"my-idp","subdomain":"my-subdomain","userName":"","password":""}`, string(b))
+		}
+	})
 }
 
 func TestLogoutRequest(t *testing.T) {
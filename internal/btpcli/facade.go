@@ -1,5 +1,10 @@
 package btpcli
 
+import (
+	"context"
+	"time"
+)
+
 // TODO generate
 
 func NewClientFacade(cliClient *v2Client) *ClientFacade {
@@ -16,4 +21,52 @@ type ClientFacade struct {
 	Accounts accountsFacade
 	Services servicesFacade
 	Security securityFacade
+
+	// PollInterval is the base interval in which the state of long-running operations is polled. If zero, the default
+	// of the consumer applies.
+	PollInterval time.Duration
+	// MaxPollInterval is the upper bound of the exponential backoff while polling long-running operations. If zero,
+	// the default of the consumer applies.
+	MaxPollInterval time.Duration
+	// DefaultOrigin is the origin of users and groups which applies if none is given. If empty, the default of the
+	// consumer applies.
+	DefaultOrigin string
+}
+
+// LoginWithSessionCache reuses the session of the user from the cache if it hasn't expired and is still accepted by the
+// CLI server, and performs a full login otherwise. From then on, the session is persisted in the cache whenever its
+// refresh token is replaced.
+func (f *ClientFacade) LoginWithSessionCache(ctx context.Context, cache *SessionCache, loginReq *LoginRequest) (*LoginResponse, error) {
+	key := sessionCacheKey(f.GetServerURL(), loginReq)
+
+	if session, ok := cache.load(key); ok {
+		f.session = session
+
+		if _, _, err := f.Accounts.GlobalAccount.Get(ctx); err == nil && len(f.session.RefreshToken) > 0 {
+			f.sessionCache = cache
+			f.sessionCacheKey = key
+			f.persistSession()
+
+			return &LoginResponse{
+				RefreshToken: f.session.RefreshToken,
+				Username:     session.LoggedInUser.Username,
+				Email:        session.LoggedInUser.Email,
+				Issuer:       session.LoggedInUser.Issuer,
+				Groups:       session.LoggedInUser.Groups,
+			}, nil
+		}
+
+		f.session = nil
+	}
+
+	loginRes, err := f.Login(ctx, loginReq)
+	if err != nil {
+		return nil, err
+	}
+
+	f.sessionCache = cache
+	f.sessionCacheKey = key
+	f.persistSession()
+
+	return loginRes, nil
 }
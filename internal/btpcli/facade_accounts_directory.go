@@ -26,12 +26,60 @@ func (f *accountsDirectoryFacade) Get(ctx context.Context, directoryId string) (
 	}))
 }
 
+// GetWithHierarchy returns the directory including its subaccounts and all its subdirectories as children.
+func (f *accountsDirectoryFacade) GetWithHierarchy(ctx context.Context, directoryId string) (cis.DirectoryResponseObject, CommandResponse, error) {
+	return doExecute[cis.DirectoryResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
+		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
+		"directoryID":   directoryId,
+		"showHierarchy": "true",
+	}))
+}
+
+// List returns all directories of the global account in depth-first order, which meet the labels filter.
+func (f *accountsDirectoryFacade) List(ctx context.Context, labelsFilter string) ([]cis.DirectoryResponseObject, CommandResponse, error) {
+	// the directories are only available as part of the global account hierarchy
+	globalAccountFacade := newAccountsGlobalAccountFacade(f.cliClient)
+
+	params := map[string]string{
+		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
+		"showHierarchy": "true",
+	}
+
+	filtered := applyLabelsFilter(globalAccountFacade.getCommand(), params, labelsFilter)
+
+	globalAccount, cmdRes, err := doExecute[cis.GlobalAccountResponseObject](f.cliClient, ctx, NewGetRequest(globalAccountFacade.getCommand(), params))
+	if err != nil {
+		return nil, cmdRes, err
+	}
+
+	directories := flattenDirectories(globalAccount.Children)
+	if !filtered {
+		directories = filterByLabels(directories, func(directory cis.DirectoryResponseObject) map[string][]string {
+			return directory.Labels
+		}, labelsFilter)
+	}
+
+	return directories, cmdRes, nil
+}
+
+// flattenDirectories returns the given directories and all their nested directories in depth-first order.
+func flattenDirectories(directories []cis.DirectoryResponseObject) []cis.DirectoryResponseObject {
+	flattened := []cis.DirectoryResponseObject{}
+
+	for _, directory := range directories {
+		flattened = append(flattened, directory)
+		flattened = append(flattened, flattenDirectories(directory.Children)...)
+	}
+
+	return flattened
+}
+
 type DirectoryCreateInput struct {
 	DisplayName   string              `btpcli:"displayName"`
 	Description   *string             `btpcli:"description"`
 	ParentID      *string             `btpcli:"parentID"`
 	Subdomain     *string             `btpcli:"subdomain"`
-	Labels        map[string][]string `btpcli:"labels"`
+	Labels        map[string][]string `btpcli:"labels,encodeasjson"`
 	Globalaccount string              `btpcli:"globalAccount"`
 	//DirectoryAdmins string          `btpcli:"directoryAdmins"`
 }
@@ -41,7 +89,7 @@ type DirectoryUpdateInput struct {
 	Globalaccount string              `btpcli:"globalAccount"`
 	DisplayName   *string             `btpcli:"displayName"`
 	Description   *string             `btpcli:"description"`
-	Labels        map[string][]string `btpcli:"labels"`
+	Labels        map[string][]string `btpcli:"labels,encodeasjson"`
 }
 
 func (f *accountsDirectoryFacade) Create(ctx context.Context, args *DirectoryCreateInput) (cis.DirectoryResponseObject, CommandResponse, error) {
@@ -2,6 +2,7 @@ package btpcli
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -32,6 +33,88 @@ func TestAccountsDirectoryFacade_Get(t *testing.T) {
 	})
 }
 
+func TestAccountsDirectoryFacade_GetWithHierarchy(t *testing.T) {
+	command := "accounts/directory"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"directoryID":   "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0",
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"showHierarchy": "true",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Directory.GetWithHierarchy(context.TODO(), "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0")
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestAccountsDirectoryFacade_List(t *testing.T) {
+	command := "accounts/global-account"
+
+	hierarchy := `{"guid": "795b53bb-a3f0-4769-adf0-26173282a975", "children": [
+		{"guid": "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0", "labels": {"env": ["dev"]}, "children": [
+			{"guid": "5357bda0-8651-4eab-a69d-12d282bc3247", "labels": {"env": ["prod"]}}
+		]},
+		{"guid": "1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b"}
+	]}`
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"showHierarchy": "true",
+			})
+
+			fmt.Fprint(w, hierarchy)
+		}))
+		defer srv.Close()
+
+		directories, res, err := uut.Accounts.Directory.List(context.TODO(), "")
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+
+			if assert.Len(t, directories, 3) {
+				assert.Equal(t, "dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0", directories[0].Guid)
+				assert.Equal(t, "5357bda0-8651-4eab-a69d-12d282bc3247", directories[1].Guid)
+				assert.Equal(t, "1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b", directories[2].Guid)
+			}
+		}
+	})
+	t.Run("filters by labels on the client", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the CLI server doesn't filter the hierarchy, so the filter must not be sent
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"showHierarchy": "true",
+			})
+
+			fmt.Fprint(w, hierarchy)
+		}))
+		defer srv.Close()
+
+		directories, _, err := uut.Accounts.Directory.List(context.TODO(), "env=prod")
+
+		if assert.NoError(t, err) && assert.Len(t, directories, 1) {
+			assert.Equal(t, "5357bda0-8651-4eab-a69d-12d282bc3247", directories[0].Guid)
+		}
+	})
+}
+
 func TestAccountsDirectoryFacade_Create(t *testing.T) {
 	command := "accounts/directory"
 	globalAccount := "795b53bb-a3f0-4769-adf0-26173282a975"
@@ -31,12 +31,41 @@ func (f *accountsEntitlementFacade) ListBySubaccount(ctx context.Context, subacc
 	}))
 }
 
+// ListBySubaccounts fetches the entitlements of all given subaccounts with at most maxConcurrency requests
+// in flight. The result is keyed by the subaccount ID. If requests fail, the error of the first failing
+// subaccount in the order of subaccountIds is returned, so that the outcome does not depend on scheduling.
+func (f *accountsEntitlementFacade) ListBySubaccounts(ctx context.Context, subaccountIds []string, maxConcurrency int) (map[string]cis_entitlements.EntitledAndAssignedServicesResponseObject, error) {
+	entitlements, failedSubaccountId, err := executeConcurrently(subaccountIds, maxConcurrency, func(subaccountId string) (cis_entitlements.EntitledAndAssignedServicesResponseObject, error) {
+		entitlements, _, err := f.ListBySubaccount(ctx, subaccountId)
+		return entitlements, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the entitlements of subaccount %s: %w", failedSubaccountId, err)
+	}
+
+	return entitlements, nil
+}
+
 func (f *accountsEntitlementFacade) ListByDirectory(ctx context.Context, directoryId string) (cis_entitlements.EntitledAndAssignedServicesResponseObject, CommandResponse, error) {
 	return doExecute[cis_entitlements.EntitledAndAssignedServicesResponseObject](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
 		"directory": directoryId,
 	}))
 }
 
+// ListByDirectories fetches the entitlements of all given directories with at most maxConcurrency requests in flight,
+// see ListBySubaccounts.
+func (f *accountsEntitlementFacade) ListByDirectories(ctx context.Context, directoryIds []string, maxConcurrency int) (map[string]cis_entitlements.EntitledAndAssignedServicesResponseObject, error) {
+	entitlements, failedDirectoryId, err := executeConcurrently(directoryIds, maxConcurrency, func(directoryId string) (cis_entitlements.EntitledAndAssignedServicesResponseObject, error) {
+		entitlements, _, err := f.ListByDirectory(ctx, directoryId)
+		return entitlements, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the entitlements of directory %s: %w", failedDirectoryId, err)
+	}
+
+	return entitlements, nil
+}
+
 func (f *accountsEntitlementFacade) AssignToSubaccount(ctx context.Context, subaccountId string, serviceName string, servicePlanName string, amount int) (CommandResponse, error) {
 	_, res, err := doExecute[cis_entitlements.EntitlementAssignmentResponseObject](f.cliClient, ctx, NewAssignRequest(f.getCommand(), map[string]string{
 		"subaccount":      subaccountId,
@@ -70,6 +99,51 @@ func (f *accountsEntitlementFacade) DisableInSubaccount(ctx context.Context, sub
 	return res, err
 }
 
+// AssignToDirectory assigns the amount of a quota-based plan to a directory. If autoAssign is set, autoDistributeAmount
+// is assigned to each subaccount which is created in the directory in the future.
+func (f *accountsEntitlementFacade) AssignToDirectory(ctx context.Context, directoryId string, serviceName string, servicePlanName string, amount int, autoAssign bool, autoDistributeAmount int) (CommandResponse, error) {
+	args := map[string]string{
+		"directory":       directoryId,
+		"serviceName":     serviceName,
+		"servicePlanName": servicePlanName,
+		"amount":          fmt.Sprintf("%d", amount),
+		"autoAssign":      fmt.Sprintf("%t", autoAssign),
+	}
+
+	if autoAssign && autoDistributeAmount > 0 {
+		args["autoDistributeAmount"] = fmt.Sprintf("%d", autoDistributeAmount)
+	}
+
+	_, res, err := doExecute[cis_entitlements.EntitlementAssignmentResponseObject](f.cliClient, ctx, NewAssignRequest(f.getCommand(), args))
+
+	return res, err
+}
+
+// EnableInDirectory enables a plan without a numeric quota in a directory. If autoAssign is set, the plan is also
+// enabled in each subaccount which is created in the directory in the future.
+func (f *accountsEntitlementFacade) EnableInDirectory(ctx context.Context, directoryId string, serviceName string, servicePlanName string, autoAssign bool) (CommandResponse, error) {
+	_, res, err := doExecute[cis_entitlements.EntitlementAssignmentResponseObject](f.cliClient, ctx, NewAssignRequest(f.getCommand(), map[string]string{
+		"directory":       directoryId,
+		"serviceName":     serviceName,
+		"servicePlanName": servicePlanName,
+		"enable":          "true",
+		"autoAssign":      fmt.Sprintf("%t", autoAssign),
+	}))
+
+	return res, err
+}
+
+func (f *accountsEntitlementFacade) DisableInDirectory(ctx context.Context, directoryId string, serviceName string, servicePlanName string) (CommandResponse, error) {
+	_, res, err := doExecute[cis_entitlements.EntitlementAssignmentResponseObject](f.cliClient, ctx, NewAssignRequest(f.getCommand(), map[string]string{
+		"directory":       directoryId,
+		"serviceName":     serviceName,
+		"servicePlanName": servicePlanName,
+		"enable":          "false",
+	}))
+
+	return res, err
+}
+
 type UnfoldedEntitlement struct {
 	Service    cis_entitlements.AssignedServiceResponseObject
 	Plan       cis_entitlements.AssignedServicePlanResponseObject
@@ -83,6 +157,21 @@ func (f *accountsEntitlementFacade) GetAssignedBySubaccount(ctx context.Context,
 		return nil, comRes, err
 	}
 
+	return findAssignment(cliRes, "SUBACCOUNT", subaccountId, serviceName, servicePlanName), comRes, nil
+}
+
+func (f *accountsEntitlementFacade) GetAssignedByDirectory(ctx context.Context, directoryId, serviceName string, servicePlanName string) (*UnfoldedEntitlement, CommandResponse, error) {
+	cliRes, comRes, err := f.ListByDirectory(ctx, directoryId)
+
+	if err != nil {
+		return nil, comRes, err
+	}
+
+	return findAssignment(cliRes, "DIRECTORY", directoryId, serviceName, servicePlanName), comRes, nil
+}
+
+// findAssignment returns the assignment of the plan to the given entity, or nil if the plan isn't assigned to it.
+func findAssignment(cliRes cis_entitlements.EntitledAndAssignedServicesResponseObject, entityType string, entityId string, serviceName string, servicePlanName string) *UnfoldedEntitlement {
 	for _, assignedService := range cliRes.AssignedServices {
 		if assignedService.Name != serviceName {
 			continue
@@ -94,16 +183,16 @@ func (f *accountsEntitlementFacade) GetAssignedBySubaccount(ctx context.Context,
 			}
 
 			for _, assignment := range servicePlan.AssignmentInfo {
-				if assignment.EntityType == "SUBACCOUNT" && assignment.EntityId == subaccountId {
+				if assignment.EntityType == entityType && assignment.EntityId == entityId {
 					return &UnfoldedEntitlement{
 						Service:    assignedService,
 						Plan:       servicePlan,
 						Assignment: assignment,
-					}, comRes, nil
+					}
 				}
 			}
 		}
 	}
 
-	return nil, comRes, nil
+	return nil
 }
@@ -2,8 +2,13 @@ package btpcli
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,6 +59,92 @@ func TestAccountsEntitlementFacade_ListBySubaccount(t *testing.T) {
 	})
 }
 
+func TestAccountsEntitlementFacade_ListBySubaccounts(t *testing.T) {
+	command := "accounts/entitlement"
+
+	subaccountIds := []string{
+		"6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f",
+		"ef23ace8-6ade-4d78-9c1f-8df729548bbf",
+		"59cd458e-e66e-4b60-b6d8-8f219379f9a5",
+		"b8a4a0cd-8d6a-4b2c-9d91-1d6b4e8b2a3f",
+		"77395f91-1c1b-4d4c-a6f3-2e3d9b5f3e11",
+	}
+
+	t.Run("fetches the entitlements of all subaccounts", func(t *testing.T) {
+		var mu sync.Mutex
+		var calls []string
+		var inFlight, maxInFlight int32
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+
+			for {
+				observed := atomic.LoadInt32(&maxInFlight)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+					break
+				}
+			}
+
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				assert.Equal(t, fmt.Sprintf("/command/%s/%s", cliTargetProtocolVersion, command), r.URL.Path)
+				assert.Equal(t, string(ActionList), r.URL.RawQuery)
+
+				mu.Lock()
+				calls = append(calls, payload.ParamValues["subaccountFilter"])
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+				fmt.Fprintf(w, `{"entitledServices": [{"name": "service-%s"}]}`, payload.ParamValues["subaccountFilter"])
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.ListBySubaccounts(context.TODO(), subaccountIds, 2)
+
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, subaccountIds, calls)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+
+			if assert.Len(t, res, len(subaccountIds)) {
+				for _, subaccountId := range subaccountIds {
+					if assert.Len(t, res[subaccountId].EntitledServices, 1) {
+						assert.Equal(t, "service-"+subaccountId, res[subaccountId].EntitledServices[0].Name)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("reports the first failing subaccount in the given order", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				switch payload.ParamValues["subaccountFilter"] {
+				case subaccountIds[1], subaccountIds[3]:
+					w.Header().Set(HeaderCLIBackendStatus, "404")
+					fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				default:
+					fmt.Fprintf(w, "{}")
+				}
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.ListBySubaccounts(context.TODO(), subaccountIds, 3)
+
+		assert.Nil(t, res)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), subaccountIds[1])
+		}
+	})
+}
+
 func TestAccountsEntitlementFacade_ListByDirectory(t *testing.T) {
 	command := "accounts/entitlement"
 
@@ -77,6 +168,32 @@ func TestAccountsEntitlementFacade_ListByDirectory(t *testing.T) {
 	})
 }
 
+func TestAccountsEntitlementFacade_ListByDirectories(t *testing.T) {
+	directoryIds := []string{"f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d", "05368777-4934-41e8-9f3c-6ec5f4d564b9"}
+
+	t.Run("fetches the entitlements of all directories", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				fmt.Fprintf(w, `{"entitledServices": [{"name": "service-%s"}]}`, payload.ParamValues["directory"])
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.ListByDirectories(context.TODO(), directoryIds, 2)
+
+		if assert.NoError(t, err) && assert.Len(t, res, 2) {
+			for _, directoryId := range directoryIds {
+				if assert.Len(t, res[directoryId].EntitledServices, 1) {
+					assert.Equal(t, "service-"+directoryId, res[directoryId].EntitledServices[0].Name)
+				}
+			}
+		}
+	})
+}
+
 func TestAccountsEntitlementFacade_AssignToSubaccount(t *testing.T) {
 	command := "accounts/entitlement"
 
@@ -167,3 +284,154 @@ func TestAccountsEntitlementFacade_DisableInSubaccount(t *testing.T) {
 		}
 	})
 }
+
+func TestAccountsEntitlementFacade_AssignToDirectory(t *testing.T) {
+	command := "accounts/entitlement"
+
+	directoryId := "5357bda0-8651-4eab-a69d-12d282bc3247"
+	serviceName := "alert-notification"
+	planName := "free"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionAssign, map[string]string{
+				"directory":            directoryId,
+				"serviceName":          serviceName,
+				"servicePlanName":      planName,
+				"amount":               "10",
+				"autoAssign":           "true",
+				"autoDistributeAmount": "2",
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.AssignToDirectory(context.TODO(), directoryId, serviceName, planName, 10, true, 2)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("omits the auto distribute amount without auto assignment", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionAssign, map[string]string{
+				"directory":       directoryId,
+				"serviceName":     serviceName,
+				"servicePlanName": planName,
+				"amount":          "10",
+				"autoAssign":      "false",
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.AssignToDirectory(context.TODO(), directoryId, serviceName, planName, 10, false, 2)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestAccountsEntitlementFacade_EnableInDirectory(t *testing.T) {
+	command := "accounts/entitlement"
+
+	directoryId := "5357bda0-8651-4eab-a69d-12d282bc3247"
+	serviceName := "alert-notification"
+	planName := "free"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionAssign, map[string]string{
+				"directory":       directoryId,
+				"serviceName":     serviceName,
+				"servicePlanName": planName,
+				"enable":          "true",
+				"autoAssign":      "true",
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.EnableInDirectory(context.TODO(), directoryId, serviceName, planName, true)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestAccountsEntitlementFacade_DisableInDirectory(t *testing.T) {
+	command := "accounts/entitlement"
+
+	directoryId := "5357bda0-8651-4eab-a69d-12d282bc3247"
+	serviceName := "alert-notification"
+	planName := "free"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionAssign, map[string]string{
+				"directory":       directoryId,
+				"serviceName":     serviceName,
+				"servicePlanName": planName,
+				"enable":          "false",
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Entitlement.DisableInDirectory(context.TODO(), directoryId, serviceName, planName)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestAccountsEntitlementFacade_GetAssignedByDirectory(t *testing.T) {
+	directoryId := "5357bda0-8651-4eab-a69d-12d282bc3247"
+
+	t.Run("finds the assignment of the directory", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertCall(t, r, "accounts/entitlement", ActionList, map[string]string{
+				"directory": directoryId,
+			})
+
+			fmt.Fprintf(w, `{"assignedServices": [{"name": "alert-notification", "servicePlans": [{"name": "free", "assignmentInfo": [{"entityId": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "entityType": "SUBACCOUNT"}, {"entityId": "%s", "entityType": "DIRECTORY", "autoAssign": true, "autoDistributeAmount": 2}]}]}]}`, directoryId)
+		}))
+		defer srv.Close()
+
+		entitlement, _, err := uut.Accounts.Entitlement.GetAssignedByDirectory(context.TODO(), directoryId, "alert-notification", "free")
+
+		if assert.NoError(t, err) && assert.NotNil(t, entitlement) {
+			assert.Equal(t, directoryId, entitlement.Assignment.EntityId)
+			assert.True(t, entitlement.Assignment.AutoAssign)
+			assert.Equal(t, int32(2), entitlement.Assignment.AutoDistributeAmount)
+		}
+	})
+
+	t.Run("returns nil if the plan isn't assigned to the directory", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"assignedServices": [{"name": "alert-notification", "servicePlans": [{"name": "free", "assignmentInfo": [{"entityId": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "entityType": "SUBACCOUNT"}]}]}]}`)
+		}))
+		defer srv.Close()
+
+		entitlement, _, err := uut.Accounts.Entitlement.GetAssignedByDirectory(context.TODO(), directoryId, "alert-notification", "free")
+
+		assert.NoError(t, err)
+		assert.Nil(t, entitlement)
+	})
+}
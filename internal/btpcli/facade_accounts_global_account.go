@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
 )
 
 func newAccountsGlobalAccountFacade(cliClient *v2Client) accountsGlobalAccountFacade {
@@ -23,3 +24,29 @@ func (f *accountsGlobalAccountFacade) Get(ctx context.Context) (cis.GlobalAccoun
 		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
 	}))
 }
+
+// GetWithHierarchy returns the global account including all its directories as children.
+func (f *accountsGlobalAccountFacade) GetWithHierarchy(ctx context.Context) (cis.GlobalAccountResponseObject, CommandResponse, error) {
+	return doExecute[cis.GlobalAccountResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
+		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
+		"showHierarchy": "true",
+	}))
+}
+
+type GlobalAccountUpdateInput struct {
+	Labels        map[string][]string `btpcli:"labels,encodeasjson"`
+	Globalaccount string              `btpcli:"globalAccount"`
+}
+
+// Update updates the global account. The given labels replace all existing labels, an empty map removes them.
+func (f *accountsGlobalAccountFacade) Update(ctx context.Context, args *GlobalAccountUpdateInput) (cis.GlobalAccountResponseObject, CommandResponse, error) {
+	args.Globalaccount = f.cliClient.GetGlobalAccountSubdomain()
+
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return cis.GlobalAccountResponseObject{}, CommandResponse{}, err
+	}
+
+	return doExecute[cis.GlobalAccountResponseObject](f.cliClient, ctx, NewUpdateRequest(f.getCommand(), params))
+}
@@ -30,3 +30,75 @@ func TestAccountsGlobalAccountFacade_Get(t *testing.T) {
 		}
 	})
 }
+
+func TestAccountsGlobalAccountFacade_GetWithHierarchy(t *testing.T) {
+	command := "accounts/global-account"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"showHierarchy": "true",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.GlobalAccount.GetWithHierarchy(context.TODO())
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestAccountsGlobalAccountFacade_Update(t *testing.T) {
+	command := "accounts/global-account"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"labels":        `{"costcenter":["4711"]}`,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.GlobalAccount.Update(context.TODO(), &GlobalAccountUpdateInput{
+			Labels: map[string][]string{"costcenter": {"4711"}},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("removes all labels", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"labels":        `{}`,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.GlobalAccount.Update(context.TODO(), &GlobalAccountUpdateInput{
+			Labels: map[string][]string{},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package btpcli
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/saas_manager_service"
@@ -25,12 +26,16 @@ func (f *accountsSubaccountFacade) List(ctx context.Context, labelsFilter string
 		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
 	}
 
-	if len(labelsFilter) > 0 {
-		params["labelsFilter"] = labelsFilter
+	filtered := applyLabelsFilter(f.getCommand(), params, labelsFilter)
 
+	res, cmdRes, err := doExecute[cis.ResponseCollectionSubaccountResponseObject](f.cliClient, ctx, NewListRequest(f.getCommand(), params))
+	if err == nil && !filtered {
+		res.Value = filterByLabels(res.Value, func(subaccount cis.SubaccountResponseObject) map[string][]string {
+			return subaccount.Labels
+		}, labelsFilter)
 	}
 
-	return doExecute[cis.ResponseCollectionSubaccountResponseObject](f.cliClient, ctx, NewListRequest(f.getCommand(), params))
+	return res, cmdRes, err
 }
 
 func (f *accountsSubaccountFacade) Get(ctx context.Context, subaccountId string) (cis.SubaccountResponseObject, CommandResponse, error) {
@@ -39,28 +44,40 @@ func (f *accountsSubaccountFacade) Get(ctx context.Context, subaccountId string)
 	}))
 }
 
+type SubaccountCustomPropertyInput struct {
+	Key string `json:"key"`
+	// Properties without a value are removed from the subaccount on update.
+	Value string `json:"value,omitempty"`
+}
+
 type SubaccountCreateInput struct { // TODO support all options
-	BetaEnabled       bool                `btpcli:"betaEnabled"`
-	Description       string              `btpcli:"description"`
-	Directory         string              `btpcli:"directoryID"`
-	DisplayName       string              `btpcli:"displayName"`
-	Labels            map[string][]string `btpcli:"labels"`
-	Region            string              `btpcli:"region"`
-	Subdomain         string              `btpcli:"subdomain"`
-	UsedForProduction string              `btpcli:"usedForProduction"`
-	Globalaccount     string              `btpcli:"globalAccount"`
+	BetaEnabled       bool                            `btpcli:"betaEnabled"`
+	BetaFeatures      []string                        `btpcli:"betaFeatures,encodeasjson"`
+	CustomProperties  []SubaccountCustomPropertyInput `btpcli:"customProperties,encodeasjson"`
+	Description       string                          `btpcli:"description"`
+	Directory         string                          `btpcli:"directoryID"`
+	DisplayName       string                          `btpcli:"displayName"`
+	Labels            map[string][]string             `btpcli:"labels,encodeasjson"`
+	Region            string                          `btpcli:"region"`
+	Subdomain         string                          `btpcli:"subdomain"`
+	UsedForProduction string                          `btpcli:"usedForProduction"`
+	Globalaccount     string                          `btpcli:"globalAccount"`
 	//SubaccountAdmins  string `json:"subaccountAdmins"`
 }
 
 type SubaccountUpdateInput struct {
-	BetaEnabled       bool                `btpcli:"betaEnabled"`
-	Description       string              `btpcli:"description"`
-	Directory         string              `btpcli:"directoryID"`
-	DisplayName       string              `btpcli:"displayName"`
-	Labels            map[string][]string `btpcli:"labels"`
-	SubaccountId      string              `btpcli:"subaccount"`
-	UsedForProduction string              `btpcli:"usedForProduction"`
-	Globalaccount     string              `btpcli:"globalAccount"`
+	// The beta setting and the beta features which are neither enabled nor disabled are kept unchanged if not given.
+	BetaEnabled         *bool                           `btpcli:"betaEnabled"`
+	CustomProperties    []SubaccountCustomPropertyInput `btpcli:"customProperties,encodeasjson"`
+	Description         string                          `btpcli:"description"`
+	Directory           string                          `btpcli:"directoryID"`
+	DisableBetaFeatures []string                        `btpcli:"disableBetaFeatures,encodeasjson"`
+	DisplayName         string                          `btpcli:"displayName"`
+	EnableBetaFeatures  []string                        `btpcli:"enableBetaFeatures,encodeasjson"`
+	Labels              map[string][]string             `btpcli:"labels,encodeasjson"`
+	SubaccountId        string                          `btpcli:"subaccount"`
+	UsedForProduction   string                          `btpcli:"usedForProduction"`
+	Globalaccount       string                          `btpcli:"globalAccount"`
 }
 
 func (f *accountsSubaccountFacade) Create(ctx context.Context, args *SubaccountCreateInput) (cis.SubaccountResponseObject, CommandResponse, error) {
@@ -98,7 +115,9 @@ func (f *accountsSubaccountFacade) Delete(ctx context.Context, subaccountId stri
 	}))
 }
 
-func (f *accountsSubaccountFacade) Subscribe(ctx context.Context, subaccountId string, appName string, planName string, parameters string) (saas_manager_service.SubscriptionAssignmentResponseObject, CommandResponse, error) {
+// Subscribe subscribes the subaccount to the application. The labels are assigned to the subscription, unless they're
+// nil. If wait is true, the CLI server is asked to wait for the subscription to be completed before responding.
+func (f *accountsSubaccountFacade) Subscribe(ctx context.Context, subaccountId string, appName string, planName string, parameters string, labels map[string][]string, wait bool) (saas_manager_service.SubscriptionAssignmentResponseObject, CommandResponse, error) {
 	commandOptions := map[string]string{
 		"subaccount":         subaccountId,
 		"appName":            appName,
@@ -108,13 +127,35 @@ func (f *accountsSubaccountFacade) Subscribe(ctx context.Context, subaccountId s
 	if len(planName) > 0 {
 		commandOptions["planName"] = planName
 	}
+
+	if labels != nil {
+		encodedLabels, err := json.Marshal(labels)
+		if err != nil {
+			return saas_manager_service.SubscriptionAssignmentResponseObject{}, CommandResponse{}, err
+		}
+
+		commandOptions["labels"] = string(encodedLabels)
+	}
+
+	if wait {
+		commandOptions["wait"] = "true"
+	}
+
 	return doExecute[saas_manager_service.SubscriptionAssignmentResponseObject](f.cliClient, ctx, NewSubscribeRequest(f.getCommand(), commandOptions))
 }
 
-func (f *accountsSubaccountFacade) Unsubscribe(ctx context.Context, subaccountId string, appName string) (saas_manager_service.SubscriptionAssignmentResponseObject, CommandResponse, error) {
-	return doExecute[saas_manager_service.SubscriptionAssignmentResponseObject](f.cliClient, ctx, NewUnsubscribeRequest(f.getCommand(), map[string]string{
+// Unsubscribe unsubscribes the subaccount from the application. If wait is true, the CLI server is asked to wait for the
+// unsubscription to be completed before responding.
+func (f *accountsSubaccountFacade) Unsubscribe(ctx context.Context, subaccountId string, appName string, wait bool) (saas_manager_service.SubscriptionAssignmentResponseObject, CommandResponse, error) {
+	commandOptions := map[string]string{
 		"subaccount": subaccountId,
 		"appName":    appName,
 		"confirm":    "true",
-	}))
+	}
+
+	if wait {
+		commandOptions["wait"] = "true"
+	}
+
+	return doExecute[saas_manager_service.SubscriptionAssignmentResponseObject](f.cliClient, ctx, NewUnsubscribeRequest(f.getCommand(), commandOptions))
 }
@@ -2,6 +2,7 @@ package btpcli
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -48,6 +49,24 @@ func TestAccountsSubaccountFacade_List(t *testing.T) {
 			assert.Equal(t, 200, res.StatusCode)
 		}
 	})
+	t.Run("leaves the filtering by labels to the CLI server", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertCall(t, r, command, ActionList, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"labelsFilter":  "env=dev",
+			})
+
+			fmt.Fprint(w, `{"value": [{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "labels": {"env": ["prod"]}}]}`)
+		}))
+		defer srv.Close()
+
+		subaccounts, _, err := uut.Accounts.Subaccount.List(context.TODO(), "env=dev")
+
+		if assert.NoError(t, err) {
+			// the response is taken as is, since the server already applied the filter
+			assert.Len(t, subaccounts.Value, 1)
+		}
+	})
 }
 
 func TestAccountsSubaccountFacade_Get(t *testing.T) {
@@ -111,6 +130,67 @@ func TestAccountsSubaccountFacade_Create(t *testing.T) {
 			assert.Equal(t, 200, res.StatusCode)
 		}
 	})
+
+	t.Run("passes the custom properties as JSON", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"displayName":      displayName,
+				"subdomain":        subdomain,
+				"region":           region,
+				"betaEnabled":      "false",
+				"globalAccount":    globalAccount,
+				"customProperties": `[{"key":"costcenter","value":"4711"}]`,
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Create(context.TODO(), &SubaccountCreateInput{
+			DisplayName:      displayName,
+			Subdomain:        subdomain,
+			Region:           region,
+			CustomProperties: []SubaccountCustomPropertyInput{{Key: "costcenter", Value: "4711"}},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("passes the beta features as JSON", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"displayName":   displayName,
+				"subdomain":     subdomain,
+				"region":        region,
+				"betaEnabled":   "true",
+				"globalAccount": globalAccount,
+				"betaFeatures":  `["feature-a","feature-b"]`,
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Create(context.TODO(), &SubaccountCreateInput{
+			DisplayName:  displayName,
+			Subdomain:    subdomain,
+			Region:       region,
+			BetaEnabled:  true,
+			BetaFeatures: []string{"feature-a", "feature-b"},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
 }
 
 func TestAccountsSubaccountFacade_Update(t *testing.T) {
@@ -121,6 +201,7 @@ func TestAccountsSubaccountFacade_Update(t *testing.T) {
 	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
 	displayName := "my-account"
 	description := "My Account Description"
+	betaEnabled := false
 
 	t.Run("constructs the CLI params correctly", func(t *testing.T) {
 		var srvCalled bool
@@ -140,6 +221,7 @@ func TestAccountsSubaccountFacade_Update(t *testing.T) {
 		defer srv.Close()
 
 		_, res, err := uut.Accounts.Subaccount.Update(context.TODO(), &SubaccountUpdateInput{
+			BetaEnabled:  &betaEnabled,
 			SubaccountId: subaccountId,
 			DisplayName:  displayName,
 			Description:  description,
@@ -149,6 +231,92 @@ func TestAccountsSubaccountFacade_Update(t *testing.T) {
 			assert.Equal(t, 200, res.StatusCode)
 		}
 	})
+
+	t.Run("passes the custom properties to be updated and removed as JSON", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount":       subaccountId,
+				"displayName":      displayName,
+				"betaEnabled":      "false",
+				"globalAccount":    globalAccount,
+				"customProperties": `[{"key":"costcenter","value":"4712"},{"key":"owner"}]`,
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Update(context.TODO(), &SubaccountUpdateInput{
+			BetaEnabled:  &betaEnabled,
+			SubaccountId: subaccountId,
+			DisplayName:  displayName,
+			CustomProperties: []SubaccountCustomPropertyInput{
+				{Key: "costcenter", Value: "4712"},
+				{Key: "owner"},
+			},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("passes the beta features to be enabled and disabled as JSON", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount":          subaccountId,
+				"displayName":         displayName,
+				"globalAccount":       globalAccount,
+				"enableBetaFeatures":  `["feature-c"]`,
+				"disableBetaFeatures": `["feature-a"]`,
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Update(context.TODO(), &SubaccountUpdateInput{
+			SubaccountId:        subaccountId,
+			DisplayName:         displayName,
+			EnableBetaFeatures:  []string{"feature-c"},
+			DisableBetaFeatures: []string{"feature-a"},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("keeps the beta setting if not given", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount":    subaccountId,
+				"displayName":   displayName,
+				"globalAccount": globalAccount,
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Update(context.TODO(), &SubaccountUpdateInput{
+			SubaccountId: subaccountId,
+			DisplayName:  displayName,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
 }
 
 func TestAccountsSubaccountFacade_Delete(t *testing.T) {
@@ -203,7 +371,55 @@ func TestAccountsSubaccountFacade_Subscribe(t *testing.T) {
 		}))
 		defer srv.Close()
 
-		_, res, err := uut.Accounts.Subaccount.Subscribe(context.TODO(), subaccountId, appName, planName, parameters)
+		_, res, err := uut.Accounts.Subaccount.Subscribe(context.TODO(), subaccountId, appName, planName, parameters, nil, false)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("constructs the CLI params correctly - with labels", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionSubscribe, map[string]string{
+				"subaccount":         subaccountId,
+				"appName":            appName,
+				"planName":           planName,
+				"subscriptionParams": parameters,
+				"labels":             `{"cost-center":["4711"]}`,
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Subscribe(context.TODO(), subaccountId, appName, planName, parameters, map[string][]string{"cost-center": {"4711"}}, false)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("asks the CLI server to wait", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionSubscribe, map[string]string{
+				"subaccount":         subaccountId,
+				"appName":            appName,
+				"planName":           planName,
+				"subscriptionParams": parameters,
+				"wait":               "true",
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Subscribe(context.TODO(), subaccountId, appName, planName, parameters, nil, true)
 
 		if assert.True(t, srvCalled) && assert.NoError(t, err) {
 			assert.Equal(t, 200, res.StatusCode)
@@ -232,7 +448,30 @@ func TestAccountsSubaccountFacade_Unsubscribe(t *testing.T) {
 		}))
 		defer srv.Close()
 
-		_, res, err := uut.Accounts.Subaccount.Unsubscribe(context.TODO(), subaccountId, appName)
+		_, res, err := uut.Accounts.Subaccount.Unsubscribe(context.TODO(), subaccountId, appName, false)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("asks the CLI server to wait", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUnsubscribe, map[string]string{
+				"subaccount": subaccountId,
+				"appName":    appName,
+				"confirm":    "true",
+				"wait":       "true",
+			})
+
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Accounts.Subaccount.Unsubscribe(context.TODO(), subaccountId, appName, true)
 
 		if assert.True(t, srvCalled) && assert.NoError(t, err) {
 			assert.Equal(t, 200, res.StatusCode)
@@ -2,8 +2,10 @@ package btpcli
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/saas_manager_service"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
 )
 
 func newAccountsSubscriptionFacade(cliClient *v2Client) accountsSubscriptionFacade {
@@ -23,13 +25,28 @@ func (f *accountsSubscriptionFacade) List(ctx context.Context, subaccountId stri
 		Applications []saas_manager_service.EntitledApplicationsResponseObject `json:"applications"`
 	}
 
-	data, res, err := doExecute[wrapper](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
+	data, res, err := doExecuteWithSchemaValidation[wrapper](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
 		"subaccount": subaccountId,
 	}))
 
 	return data.Applications, res, err
 }
 
+// ListBySubaccounts fetches the applications of all given subaccounts with at most maxConcurrency requests in flight.
+// The result is keyed by the subaccount ID. If requests fail, the error of the first failing subaccount in the order
+// of subaccountIds is returned.
+func (f *accountsSubscriptionFacade) ListBySubaccounts(ctx context.Context, subaccountIds []string, maxConcurrency int) (map[string][]saas_manager_service.EntitledApplicationsResponseObject, error) {
+	applications, failedSubaccountId, err := executeConcurrently(subaccountIds, maxConcurrency, func(subaccountId string) ([]saas_manager_service.EntitledApplicationsResponseObject, error) {
+		applications, _, err := f.List(ctx, subaccountId)
+		return applications, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the subscriptions of subaccount %s: %w", failedSubaccountId, err)
+	}
+
+	return applications, nil
+}
+
 func (f *accountsSubscriptionFacade) Get(ctx context.Context, subaccountId string, appName string, planName string) (saas_manager_service.EntitledApplicationsResponseObject, CommandResponse, error) {
 	params := map[string]string{
 		"subaccount": subaccountId,
@@ -40,5 +57,26 @@ func (f *accountsSubscriptionFacade) Get(ctx context.Context, subaccountId strin
 		params["planName"] = planName
 	}
 
-	return doExecute[saas_manager_service.EntitledApplicationsResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), params))
+	return doExecuteWithSchemaValidation[saas_manager_service.EntitledApplicationsResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), params))
+}
+
+type SubscriptionUpdateInput struct {
+	Subaccount string              `btpcli:"subaccount"`
+	AppName    string              `btpcli:"appName"`
+	PlanName   string              `btpcli:"planName"`
+	Labels     map[string][]string `btpcli:"labels,encodeasjson"`
+}
+
+// Update updates the subscription of the subaccount to the application. The given labels replace all existing labels,
+// an empty map removes them.
+func (f *accountsSubscriptionFacade) Update(ctx context.Context, args *SubscriptionUpdateInput) (CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return CommandResponse{}, err
+	}
+
+	_, res, err := doExecuteWithSchemaValidation[saas_manager_service.EntitledApplicationsResponseObject](f.cliClient, ctx, NewUpdateRequest(f.getCommand(), params))
+
+	return res, err
 }
@@ -2,6 +2,8 @@ package btpcli
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -34,6 +36,61 @@ func TestAccountsSubscriptionFacade_List(t *testing.T) {
 	})
 }
 
+func TestAccountsSubscriptionFacade_ListBySubaccounts(t *testing.T) {
+	command := "accounts/subscription"
+
+	subaccountIds := []string{"6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"}
+
+	t.Run("fetches the applications of all subaccounts", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				assert.Equal(t, fmt.Sprintf("/command/%s/%s", cliTargetProtocolVersion, command), r.URL.Path)
+				assert.Equal(t, string(ActionList), r.URL.RawQuery)
+
+				fmt.Fprintf(w, `{"applications": [{"appName": "app-%s"}]}`, payload.ParamValues["subaccount"])
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Subscription.ListBySubaccounts(context.TODO(), subaccountIds, 2)
+
+		if assert.NoError(t, err) && assert.Len(t, res, 2) {
+			for _, subaccountId := range subaccountIds {
+				if assert.Len(t, res[subaccountId], 1) {
+					assert.Equal(t, "app-"+subaccountId, res[subaccountId][0].AppName)
+				}
+			}
+		}
+	})
+	t.Run("reports the failing subaccount", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, "403")
+			fmt.Fprintf(w, `{"error": "access denied"}`)
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Subscription.ListBySubaccounts(context.TODO(), subaccountIds, 2)
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "failed to list the subscriptions of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f: access denied")
+	})
+	t.Run("reports malformed responses", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"applications": [{"appName": "app"`)
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Subscription.ListBySubaccounts(context.TODO(), subaccountIds, 2)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrMalformedResponse)
+		assert.EqualError(t, err, "failed to list the subscriptions of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f: unable to decode the response of command 'accounts/subscription' with action 'list': the response is not valid JSON: unexpected end of the response")
+	})
+}
+
 func TestAccountsSubscriptionFacade_Get(t *testing.T) {
 	command := "accounts/subscription"
 
@@ -82,3 +139,37 @@ func TestAccountsSubscriptionFacade_Get(t *testing.T) {
 		}
 	})
 }
+
+func TestAccountsSubscriptionFacade_Update(t *testing.T) {
+	command := "accounts/subscription"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	appName := "content-agent-ui"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount": subaccountId,
+				"appName":    appName,
+				"planName":   "free",
+				"labels":     `{"cost-center":["4711"]}`,
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Accounts.Subscription.Update(context.TODO(), &SubscriptionUpdateInput{
+			Subaccount: subaccountId,
+			AppName:    appName,
+			PlanName:   "free",
+			Labels:     map[string][]string{"cost-center": {"4711"}},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
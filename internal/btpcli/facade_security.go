@@ -2,18 +2,22 @@ package btpcli
 
 func newSecurityFacade(cliClient *v2Client) securityFacade {
 	return securityFacade{
+		ApiCredential:  newSecurityApiCredentialFacade(cliClient),
 		App:            newSecurityAppFacade(cliClient),
 		Role:           newSecurityRoleFacade(cliClient),
 		RoleCollection: newSecurityRoleCollectionFacade(cliClient),
+		Settings:       newSecuritySettingsFacade(cliClient),
 		Trust:          newSecurityTrustFacade(cliClient),
 		User:           newSecurityUserFacade(cliClient),
 	}
 }
 
 type securityFacade struct {
+	ApiCredential  securityApiCredentialFacade
 	App            securityAppFacade
 	Role           securityRoleFacade
 	RoleCollection securityRoleCollectionFacade
+	Settings       securitySettingsFacade
 	Trust          securityTrustFacade
 	User           securityUserFacade
 }
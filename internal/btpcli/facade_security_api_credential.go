@@ -0,0 +1,50 @@
+package btpcli
+
+import (
+	"context"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_api"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+)
+
+func newSecurityApiCredentialFacade(cliClient *v2Client) securityApiCredentialFacade {
+	return securityApiCredentialFacade{cliClient: cliClient}
+}
+
+type securityApiCredentialFacade struct {
+	cliClient *v2Client
+}
+
+func (f *securityApiCredentialFacade) getCommand() string {
+	return "security/api-credential"
+}
+
+type SubaccountApiCredentialCreateInput struct {
+	Subaccount string `btpcli:"subaccount"`
+	Name       string `btpcli:"name"`
+	// The client authenticates with a client secret, if no certificate is given.
+	Certificate string `btpcli:"certificate"`
+	ReadOnly    bool   `btpcli:"readOnly"`
+}
+
+func (f *securityApiCredentialFacade) CreateBySubaccount(ctx context.Context, args *SubaccountApiCredentialCreateInput) (xsuaa_api.ApiCredential, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return xsuaa_api.ApiCredential{}, CommandResponse{}, err
+	}
+
+	return doExecute[xsuaa_api.ApiCredential](f.cliClient, ctx, NewCreateRequest(f.getCommand(), params))
+}
+
+func (f *securityApiCredentialFacade) GetBySubaccount(ctx context.Context, subaccountId string, name string) (xsuaa_api.ApiCredential, CommandResponse, error) {
+	return doExecute[xsuaa_api.ApiCredential](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"name": name,
+	})))
+}
+
+func (f *securityApiCredentialFacade) DeleteBySubaccount(ctx context.Context, subaccountId string, name string) (xsuaa_api.ApiCredential, CommandResponse, error) {
+	return doExecute[xsuaa_api.ApiCredential](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"name": name,
+	})))
+}
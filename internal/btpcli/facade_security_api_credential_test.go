@@ -0,0 +1,117 @@
+package btpcli
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityApiCredentialFacade_CreateBySubaccount(t *testing.T) {
+	command := "security/api-credential"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+
+	t.Run("constructs the CLI params correctly - client secret", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"subaccount": subaccountId,
+				"name":       "my-pipeline",
+				"readOnly":   "false",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.ApiCredential.CreateBySubaccount(context.TODO(), &SubaccountApiCredentialCreateInput{
+			Subaccount: subaccountId,
+			Name:       "my-pipeline",
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+	t.Run("constructs the CLI params correctly - certificate", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"subaccount":  subaccountId,
+				"name":        "my-pipeline",
+				"certificate": "-----BEGIN CERTIFICATE-----",
+				"readOnly":    "true",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.ApiCredential.CreateBySubaccount(context.TODO(), &SubaccountApiCredentialCreateInput{
+			Subaccount:  subaccountId,
+			Name:        "my-pipeline",
+			Certificate: "-----BEGIN CERTIFICATE-----",
+			ReadOnly:    true,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestSecurityApiCredentialFacade_GetBySubaccount(t *testing.T) {
+	command := "security/api-credential"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"subaccount": subaccountId,
+				"name":       "my-pipeline",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.ApiCredential.GetBySubaccount(context.TODO(), subaccountId, "my-pipeline")
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestSecurityApiCredentialFacade_DeleteBySubaccount(t *testing.T) {
+	command := "security/api-credential"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionDelete, map[string]string{
+				"subaccount": subaccountId,
+				"name":       "my-pipeline",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.ApiCredential.DeleteBySubaccount(context.TODO(), subaccountId, "my-pipeline")
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
@@ -19,40 +19,31 @@ func (f *securityAppFacade) getCommand() string {
 }
 
 func (f *securityAppFacade) ListByGlobalAccount(ctx context.Context) ([]xsuaa_authz.App, CommandResponse, error) {
-	return doExecute[[]xsuaa_authz.App](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-	}))
+	return doExecute[[]xsuaa_authz.App](f.cliClient, ctx, NewListRequest(f.getCommand(), globalAccountScope(f.cliClient).params(nil)))
 }
 
 func (f *securityAppFacade) GetByGlobalAccount(ctx context.Context, appId string) (xsuaa_authz.App, CommandResponse, error) {
-	return doExecute[xsuaa_authz.App](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-		"appId":         appId,
-	}))
+	return doExecute[xsuaa_authz.App](f.cliClient, ctx, NewGetRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
+		"appId": appId,
+	})))
 }
 
 func (f *securityAppFacade) ListBySubaccount(ctx context.Context, subaccountId string) ([]xsuaa_authz.App, CommandResponse, error) {
-	return doExecute[[]xsuaa_authz.App](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-	}))
+	return doExecute[[]xsuaa_authz.App](f.cliClient, ctx, NewListRequest(f.getCommand(), subaccountScope(subaccountId).params(nil)))
 }
 
 func (f *securityAppFacade) GetBySubaccount(ctx context.Context, subaccountId string, appId string) (xsuaa_authz.App, CommandResponse, error) {
-	return doExecute[xsuaa_authz.App](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-		"appId":      appId,
-	}))
+	return doExecute[xsuaa_authz.App](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"appId": appId,
+	})))
 }
 
 func (f *securityAppFacade) ListByDirectory(ctx context.Context, directoryId string) ([]xsuaa_authz.App, CommandResponse, error) {
-	return doExecute[[]xsuaa_authz.App](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"directory": directoryId,
-	}))
+	return doExecute[[]xsuaa_authz.App](f.cliClient, ctx, NewListRequest(f.getCommand(), directoryScope(directoryId).params(nil)))
 }
 
 func (f *securityAppFacade) GetByDirectory(ctx context.Context, directoryId string, appId string) (xsuaa_authz.App, CommandResponse, error) {
-	return doExecute[xsuaa_authz.App](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"directory": directoryId,
-		"appId":     appId,
-	}))
+	return doExecute[xsuaa_authz.App](f.cliClient, ctx, NewGetRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
+		"appId": appId,
+	})))
 }
@@ -20,48 +20,39 @@ func (f *securityRoleFacade) getCommand() string {
 }
 
 func (f *securityRoleFacade) ListByGlobalAccount(ctx context.Context) ([]xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[[]xsuaa_authz.Role](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-	}))
+	return doExecute[[]xsuaa_authz.Role](f.cliClient, ctx, NewListRequest(f.getCommand(), globalAccountScope(f.cliClient).params(nil)))
 }
 
 func (f *securityRoleFacade) GetByGlobalAccount(ctx context.Context, roleName string, roleTemplateAppId string, roleTemplateName string) (xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"globalAccount":    f.cliClient.GetGlobalAccountSubdomain(),
+	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewGetRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
 		"roleName":         roleName,
 		"appId":            roleTemplateAppId,
 		"roleTemplateName": roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) ListBySubaccount(ctx context.Context, subaccountId string) ([]xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[[]xsuaa_authz.Role](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-	}))
+	return doExecute[[]xsuaa_authz.Role](f.cliClient, ctx, NewListRequest(f.getCommand(), subaccountScope(subaccountId).params(nil)))
 }
 
 func (f *securityRoleFacade) GetBySubaccount(ctx context.Context, subaccountId string, roleName string, roleTemplateAppId string, roleTemplateName string) (xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"subaccount":       subaccountId,
+	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
 		"roleName":         roleName,
 		"appId":            roleTemplateAppId,
 		"roleTemplateName": roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) ListByDirectory(ctx context.Context, directoryId string) ([]xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[[]xsuaa_authz.Role](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"directory": directoryId,
-	}))
+	return doExecute[[]xsuaa_authz.Role](f.cliClient, ctx, NewListRequest(f.getCommand(), directoryScope(directoryId).params(nil)))
 }
 
 func (f *securityRoleFacade) GetByDirectory(ctx context.Context, directoryId string, roleName string, roleTemplateAppId string, roleTemplateName string) (xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"directory":        directoryId,
+	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewGetRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
 		"roleName":         roleName,
 		"appId":            roleTemplateAppId,
 		"roleTemplateName": roleTemplateName,
-	}))
+	})))
 }
 
 type DirectoryRoleCreateInput struct {
@@ -82,12 +73,11 @@ func (f *securityRoleFacade) CreateByDirectory(ctx context.Context, args *Direct
 }
 
 func (f *securityRoleFacade) DeleteByDirectory(ctx context.Context, directoryId string, roleName string, roleTemplateAppId string, roleTemplateName string) (xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), map[string]string{
-		"directory":        directoryId,
+	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
 		"roleName":         roleName,
 		"appId":            roleTemplateAppId,
 		"roleTemplateName": roleTemplateName,
-	}))
+	})))
 }
 
 type SubaccountRoleCreateInput struct {
@@ -108,12 +98,11 @@ func (f *securityRoleFacade) CreateBySubaccount(ctx context.Context, args *Subac
 }
 
 func (f *securityRoleFacade) DeleteBySubaccount(ctx context.Context, subaccountId string, roleName string, roleTemplateAppId string, roleTemplateName string) (xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), map[string]string{
-		"subaccount":       subaccountId,
+	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
 		"roleName":         roleName,
 		"appId":            roleTemplateAppId,
 		"roleTemplateName": roleTemplateName,
-	}))
+	})))
 }
 
 type GlobalAccountRoleCreateInput struct {
@@ -129,76 +118,69 @@ func (f *securityRoleFacade) CreateByGlobalAccount(ctx context.Context, args *Gl
 		return xsuaa_authz.Role{}, CommandResponse{}, err
 	}
 
-	params["globalAccount"] = f.cliClient.GetGlobalAccountSubdomain()
+	params = globalAccountScope(f.cliClient).params(params)
 
 	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewCreateRequest(f.getCommand(), params))
 }
 
 func (f *securityRoleFacade) DeleteByGlobalAccount(ctx context.Context, roleName string, roleTemplateAppId string, roleTemplateName string) (xsuaa_authz.Role, CommandResponse, error) {
-	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), map[string]string{
-		"globalAccount":    f.cliClient.GetGlobalAccountSubdomain(),
+	return doExecute[xsuaa_authz.Role](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
 		"roleName":         roleName,
 		"appId":            roleTemplateAppId,
 		"roleTemplateName": roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) AddBySubaccount(ctx context.Context, subaccountId string, targetRoleCollection string, roleName string, roleTemplateAppId string, roleTemplateName string) (CommandResponse, error) {
-	return f.cliClient.Execute(ctx, NewAddRequest(f.getCommand(), map[string]string{
-		"subaccount":         subaccountId,
+	return f.cliClient.Execute(ctx, NewAddRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
 		"roleName":           roleName,
 		"roleCollectionName": targetRoleCollection,
 		"roleTemplateAppID":  roleTemplateAppId,
 		"roleTemplateName":   roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) AddByDirectory(ctx context.Context, directoryId string, targetRoleCollection string, roleName string, roleTemplateAppId string, roleTemplateName string) (CommandResponse, error) {
-	return f.cliClient.Execute(ctx, NewAddRequest(f.getCommand(), map[string]string{
-		"directory":          directoryId,
+	return f.cliClient.Execute(ctx, NewAddRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
 		"roleName":           roleName,
 		"roleCollectionName": targetRoleCollection,
 		"roleTemplateAppID":  roleTemplateAppId,
 		"roleTemplateName":   roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) AddByGlobalAccount(ctx context.Context, targetRoleCollection string, roleName string, roleTemplateAppId string, roleTemplateName string) (CommandResponse, error) {
-	return f.cliClient.Execute(ctx, NewAddRequest(f.getCommand(), map[string]string{
-		"globalAccount":      f.cliClient.GetGlobalAccountSubdomain(),
+	return f.cliClient.Execute(ctx, NewAddRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
 		"roleName":           roleName,
 		"roleCollectionName": targetRoleCollection,
 		"roleTemplateAppID":  roleTemplateAppId,
 		"roleTemplateName":   roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) RemoveBySubaccount(ctx context.Context, subaccountId string, targetRoleCollection string, roleName string, roleTemplateAppId string, roleTemplateName string) (CommandResponse, error) {
-	return f.cliClient.Execute(ctx, NewRemoveRequest(f.getCommand(), map[string]string{
-		"subaccount":         subaccountId,
+	return f.cliClient.Execute(ctx, NewRemoveRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
 		"roleName":           roleName,
 		"roleCollectionName": targetRoleCollection,
 		"roleTemplateAppID":  roleTemplateAppId,
 		"roleTemplateName":   roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) RemoveByDirectory(ctx context.Context, directoryId string, targetRoleCollection string, roleName string, roleTemplateAppId string, roleTemplateName string) (CommandResponse, error) {
-	return f.cliClient.Execute(ctx, NewRemoveRequest(f.getCommand(), map[string]string{
-		"directory":          directoryId,
+	return f.cliClient.Execute(ctx, NewRemoveRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
 		"roleName":           roleName,
 		"roleCollectionName": targetRoleCollection,
 		"roleTemplateAppID":  roleTemplateAppId,
 		"roleTemplateName":   roleTemplateName,
-	}))
+	})))
 }
 
 func (f *securityRoleFacade) RemoveByGlobalAccount(ctx context.Context, targetRoleCollection string, roleName string, roleTemplateAppId string, roleTemplateName string) (CommandResponse, error) {
-	return f.cliClient.Execute(ctx, NewRemoveRequest(f.getCommand(), map[string]string{
-		"globalAccount":      f.cliClient.GetGlobalAccountSubdomain(),
+	return f.cliClient.Execute(ctx, NewRemoveRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
 		"roleName":           roleName,
 		"roleCollectionName": targetRoleCollection,
 		"roleTemplateAppID":  roleTemplateAppId,
 		"roleTemplateName":   roleTemplateName,
-	}))
+	})))
 }
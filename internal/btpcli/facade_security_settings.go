@@ -0,0 +1,59 @@
+package btpcli
+
+import (
+	"context"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_settings"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+)
+
+func newSecuritySettingsFacade(cliClient *v2Client) securitySettingsFacade {
+	return securitySettingsFacade{cliClient: cliClient}
+}
+
+type securitySettingsFacade struct {
+	cliClient *v2Client
+}
+
+func (f *securitySettingsFacade) getCommand() string {
+	return "security/settings"
+}
+
+func (f *securitySettingsFacade) GetByGlobalAccount(ctx context.Context) (xsuaa_settings.TenantSettingsResp, CommandResponse, error) {
+	return doExecute[xsuaa_settings.TenantSettingsResp](f.cliClient, ctx, NewGetRequest(f.getCommand(), globalAccountScope(f.cliClient).params(nil)))
+}
+
+func (f *securitySettingsFacade) GetBySubaccount(ctx context.Context, subaccountId string) (xsuaa_settings.TenantSettingsResp, CommandResponse, error) {
+	return doExecute[xsuaa_settings.TenantSettingsResp](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(nil)))
+}
+
+type SecuritySettingsUpdateInput struct {
+	DefaultIdp string `btpcli:"defaultIdp"`
+	// The validities are given in seconds, -1 resets them to the default of the tenant.
+	AccessTokenValidity  *int `btpcli:"accessTokenValidity"`
+	RefreshTokenValidity *int `btpcli:"refreshTokenValidity"`
+	// The custom email domains are replaced as a whole, an empty slice removes all of them.
+	CustomEmailDomains []string `btpcli:"customEmailDomains,encodeasjson"`
+	// The domains which may embed the login screen in an iframe, separated by spaces. An empty string removes all of them.
+	IframeDomains *string `btpcli:"iframeDomains"`
+}
+
+func (f *securitySettingsFacade) UpdateByGlobalAccount(ctx context.Context, args SecuritySettingsUpdateInput) (xsuaa_settings.TenantSettingsResp, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return xsuaa_settings.TenantSettingsResp{}, CommandResponse{}, err
+	}
+
+	return doExecute[xsuaa_settings.TenantSettingsResp](f.cliClient, ctx, NewUpdateRequest(f.getCommand(), globalAccountScope(f.cliClient).params(params)))
+}
+
+func (f *securitySettingsFacade) UpdateBySubaccount(ctx context.Context, subaccountId string, args SecuritySettingsUpdateInput) (xsuaa_settings.TenantSettingsResp, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return xsuaa_settings.TenantSettingsResp{}, CommandResponse{}, err
+	}
+
+	return doExecute[xsuaa_settings.TenantSettingsResp](f.cliClient, ctx, NewUpdateRequest(f.getCommand(), subaccountScope(subaccountId).params(params)))
+}
@@ -0,0 +1,167 @@
+package btpcli
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecuritySettingsFacade_GetByGlobalAccount(t *testing.T) {
+	command := "security/settings"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Settings.GetByGlobalAccount(context.TODO())
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestSecuritySettingsFacade_GetBySubaccount(t *testing.T) {
+	command := "security/settings"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"subaccount": subaccountId,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Settings.GetBySubaccount(context.TODO(), subaccountId)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestSecuritySettingsFacade_UpdateByGlobalAccount(t *testing.T) {
+	command := "security/settings"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"defaultIdp":    "my-idp-platform",
+				"iframeDomains": "https://example.com https://example.org",
+			})
+		}))
+		defer srv.Close()
+
+		iframeDomains := "https://example.com https://example.org"
+
+		_, res, err := uut.Security.Settings.UpdateByGlobalAccount(context.TODO(), SecuritySettingsUpdateInput{
+			DefaultIdp:    "my-idp-platform",
+			IframeDomains: &iframeDomains,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("constructs the CLI params for removing the iframe domains correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"globalAccount": "795b53bb-a3f0-4769-adf0-26173282a975",
+				"iframeDomains": "",
+			})
+		}))
+		defer srv.Close()
+
+		iframeDomains := ""
+
+		_, res, err := uut.Security.Settings.UpdateByGlobalAccount(context.TODO(), SecuritySettingsUpdateInput{
+			IframeDomains: &iframeDomains,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestSecuritySettingsFacade_UpdateBySubaccount(t *testing.T) {
+	command := "security/settings"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount": subaccountId,
+				"defaultIdp": "my-idp-platform",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Settings.UpdateBySubaccount(context.TODO(), subaccountId, SecuritySettingsUpdateInput{
+			DefaultIdp: "my-idp-platform",
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("constructs the CLI params for the token validities and email domains correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount":           subaccountId,
+				"accessTokenValidity":  "3600",
+				"refreshTokenValidity": "-1",
+				"customEmailDomains":   `["example.com","example.org"]`,
+			})
+		}))
+		defer srv.Close()
+
+		accessTokenValidity, refreshTokenValidity := 3600, -1
+
+		_, res, err := uut.Security.Settings.UpdateBySubaccount(context.TODO(), subaccountId, SecuritySettingsUpdateInput{
+			AccessTokenValidity:  &accessTokenValidity,
+			RefreshTokenValidity: &refreshTokenValidity,
+			CustomEmailDomains:   []string{"example.com", "example.org"},
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
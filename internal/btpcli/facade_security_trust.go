@@ -20,29 +20,30 @@ func (f *securityTrustFacade) getCommand() string {
 }
 
 func (f *securityTrustFacade) ListByGlobalAccount(ctx context.Context) (xsuaa_trust.TrustConfigurationResponseCollectionObject, CommandResponse, error) {
-	return doExecute[xsuaa_trust.TrustConfigurationResponseCollectionObject](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-	}))
+	return doExecute[xsuaa_trust.TrustConfigurationResponseCollectionObject](f.cliClient, ctx, NewListRequest(f.getCommand(), globalAccountScope(f.cliClient).params(nil)))
 }
 
 func (f *securityTrustFacade) GetByGlobalAccount(ctx context.Context, origin string) (xsuaa_trust.TrustConfigurationResponseObject, CommandResponse, error) {
-	return doExecute[xsuaa_trust.TrustConfigurationResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-		"origin":        origin,
-	}))
+	return doExecute[xsuaa_trust.TrustConfigurationResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
+		"origin": origin,
+	})))
 }
 
 func (f *securityTrustFacade) ListBySubaccount(ctx context.Context, subaccountId string) (xsuaa_trust.TrustConfigurationResponseCollectionObject, CommandResponse, error) {
-	return doExecute[xsuaa_trust.TrustConfigurationResponseCollectionObject](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-	}))
+	return doExecute[xsuaa_trust.TrustConfigurationResponseCollectionObject](f.cliClient, ctx, NewListRequest(f.getCommand(), subaccountScope(subaccountId).params(nil)))
 }
 
 func (f *securityTrustFacade) GetBySubaccount(ctx context.Context, subaccountId string, origin string) (xsuaa_trust.TrustConfigurationResponseObject, CommandResponse, error) {
-	return doExecute[xsuaa_trust.TrustConfigurationResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-		"origin":     origin,
-	}))
+	return doExecute[xsuaa_trust.TrustConfigurationResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"origin": origin,
+	})))
+}
+
+func (f *securityTrustFacade) GetWithMetadataBySubaccount(ctx context.Context, subaccountId string, origin string) (xsuaa_trust.TrustConfigurationResponseObject, CommandResponse, error) {
+	return doExecute[xsuaa_trust.TrustConfigurationResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"origin":          origin,
+		"includeMetadata": "true",
+	})))
 }
 
 type TrustConfigurationInput struct {
@@ -60,7 +61,7 @@ func (f *securityTrustFacade) CreateByGlobalAccount(ctx context.Context, args Tr
 		return xsuaa_trust.ModifyTrustConfigurationResponseObject{}, CommandResponse{}, err
 	}
 
-	params["globalAccount"] = f.cliClient.GetGlobalAccountSubdomain()
+	params = globalAccountScope(f.cliClient).params(params)
 
 	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewCreateRequest(f.getCommand(), params))
 }
@@ -72,23 +73,66 @@ func (f *securityTrustFacade) CreateBySubaccount(ctx context.Context, subaccount
 		return xsuaa_trust.ModifyTrustConfigurationResponseObject{}, CommandResponse{}, err
 	}
 
-	params["subaccount"] = subaccountId
+	params = subaccountScope(subaccountId).params(params)
 
 	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewCreateRequest(f.getCommand(), params))
 }
 
 func (f *securityTrustFacade) DeleteByGlobalAccount(ctx context.Context, originKey string) (xsuaa_trust.ModifyTrustConfigurationResponseObject, CommandResponse, error) {
-	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-		"originKey":     originKey,
-		"confirm":       "true",
-	}))
+	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
+		"originKey": originKey,
+		"confirm":   "true",
+	})))
 }
 
 func (f *securityTrustFacade) DeleteBySubaccount(ctx context.Context, subaccountId string, originKey string) (xsuaa_trust.ModifyTrustConfigurationResponseObject, CommandResponse, error) {
-	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-		"originKey":  originKey,
-		"confirm":    "true",
-	}))
+	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewDeleteRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"originKey": originKey,
+		"confirm":   "true",
+	})))
+}
+
+func (f *securityTrustFacade) getOidcCommand() string {
+	return "security/trust/oidc"
+}
+
+type OidcTrustConfigurationInput struct {
+	IssuerUrl    string  `btpcli:"issuerUrl"`
+	ClientId     string  `btpcli:"clientId"`
+	ClientSecret string  `btpcli:"clientSecret"`
+	Name         *string `btpcli:"name"`
+	Description  *string `btpcli:"description"`
+	Origin       *string `btpcli:"origin"`
+}
+
+func (f *securityTrustFacade) CreateOidcBySubaccount(ctx context.Context, subaccountId string, args OidcTrustConfigurationInput) (xsuaa_trust.ModifyTrustConfigurationResponseObject, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return xsuaa_trust.ModifyTrustConfigurationResponseObject{}, CommandResponse{}, err
+	}
+
+	params = subaccountScope(subaccountId).params(params)
+
+	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewCreateRequest(f.getOidcCommand(), params))
+}
+
+type OidcTrustConfigurationUpdateInput struct {
+	OriginKey    string  `btpcli:"originKey"`
+	ClientId     *string `btpcli:"clientId"`
+	ClientSecret *string `btpcli:"clientSecret"`
+	Name         *string `btpcli:"name"`
+	Description  *string `btpcli:"description"`
+}
+
+func (f *securityTrustFacade) UpdateOidcBySubaccount(ctx context.Context, subaccountId string, args OidcTrustConfigurationUpdateInput) (xsuaa_trust.ModifyTrustConfigurationResponseObject, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return xsuaa_trust.ModifyTrustConfigurationResponseObject{}, CommandResponse{}, err
+	}
+
+	params = subaccountScope(subaccountId).params(params)
+
+	return doExecute[xsuaa_trust.ModifyTrustConfigurationResponseObject](f.cliClient, ctx, NewUpdateRequest(f.getOidcCommand(), params))
 }
@@ -110,6 +110,34 @@ func TestSecurityTrustFacade_GetBySubaccount(t *testing.T) {
 	})
 }
 
+func TestSecurityTrustFacade_GetWithMetadataBySubaccount(t *testing.T) {
+	command := "security/trust"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	origin := "ldap"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionGet, map[string]string{
+				"subaccount":      subaccountId,
+				"origin":          origin,
+				"includeMetadata": "true",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Trust.GetWithMetadataBySubaccount(context.TODO(), subaccountId, origin)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
 func TestSecurityTrustFacade_CreateByGlobalAccount(t *testing.T) {
 	command := "security/trust"
 
@@ -283,3 +311,101 @@ func TestSecurityTrustFacade_DeleteBySubaccount(t *testing.T) {
 		}
 	})
 }
+
+func TestSecurityTrustFacade_CreateOidcBySubaccount(t *testing.T) {
+	command := "security/trust/oidc"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	issuerUrl := "https://my-oidc-provider.local"
+	clientId := "my-client"
+	clientSecret := "my-secret"
+	name := "my-oidc"
+	origin := "custom-origin-platform"
+
+	t.Run("constructs the CLI params correctly - minimal", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"subaccount":   subaccountId,
+				"issuerUrl":    issuerUrl,
+				"clientId":     clientId,
+				"clientSecret": clientSecret,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Trust.CreateOidcBySubaccount(context.TODO(), subaccountId, OidcTrustConfigurationInput{
+			IssuerUrl:    issuerUrl,
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+	t.Run("constructs the CLI params correctly - fully customized", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"subaccount":   subaccountId,
+				"issuerUrl":    issuerUrl,
+				"clientId":     clientId,
+				"clientSecret": clientSecret,
+				"name":         name,
+				"origin":       origin,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Trust.CreateOidcBySubaccount(context.TODO(), subaccountId, OidcTrustConfigurationInput{
+			IssuerUrl:    issuerUrl,
+			ClientId:     clientId,
+			ClientSecret: clientSecret,
+			Name:         &name,
+			Origin:       &origin,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestSecurityTrustFacade_UpdateOidcBySubaccount(t *testing.T) {
+	command := "security/trust/oidc"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	originKey := "my-idp-platform"
+	clientSecret := "my-rotated-secret"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount":   subaccountId,
+				"originKey":    originKey,
+				"clientSecret": clientSecret,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Security.Trust.UpdateOidcBySubaccount(context.TODO(), subaccountId, OidcTrustConfigurationUpdateInput{
+			OriginKey:    originKey,
+			ClientSecret: &clientSecret,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
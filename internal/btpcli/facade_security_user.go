@@ -2,6 +2,8 @@ package btpcli
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
 )
@@ -19,46 +21,89 @@ func (f *securityUserFacade) getCommand() string {
 }
 
 func (f *securityUserFacade) ListByGlobalAccount(ctx context.Context, origin string) ([]string, CommandResponse, error) {
-	return doExecute[[]string](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-		"origin":        origin,
-	}))
+	return doExecute[[]string](f.cliClient, ctx, NewListRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
+		"origin": origin,
+	})))
 }
 
 func (f *securityUserFacade) GetByGlobalAccount(ctx context.Context, username string, origin string) (xsuaa_authz.UserReference, CommandResponse, error) {
-	return doExecute[xsuaa_authz.UserReference](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"globalAccount": f.cliClient.GetGlobalAccountSubdomain(),
-		"userName":      username,
-		"origin":        origin,
-	}))
+	return doExecute[xsuaa_authz.UserReference](f.cliClient, ctx, NewGetRequest(f.getCommand(), globalAccountScope(f.cliClient).params(map[string]string{
+		"userName": username,
+		"origin":   origin,
+	})))
 }
 
 func (f *securityUserFacade) ListBySubaccount(ctx context.Context, subaccountId string, origin string) ([]string, CommandResponse, error) {
-	return doExecute[[]string](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-		"origin":     origin,
-	}))
+	return doExecute[[]string](f.cliClient, ctx, NewListRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"origin": origin,
+	})))
 }
 
 func (f *securityUserFacade) GetBySubaccount(ctx context.Context, subaccountId string, username string, origin string) (xsuaa_authz.UserReference, CommandResponse, error) {
-	return doExecute[xsuaa_authz.UserReference](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"subaccount": subaccountId,
-		"userName":   username,
-		"origin":     origin,
-	}))
+	return doExecute[xsuaa_authz.UserReference](f.cliClient, ctx, NewGetRequest(f.getCommand(), subaccountScope(subaccountId).params(map[string]string{
+		"userName": username,
+		"origin":   origin,
+	})))
 }
 
 func (f *securityUserFacade) ListByDirectory(ctx context.Context, directoryId string, origin string) ([]string, CommandResponse, error) {
-	return doExecute[[]string](f.cliClient, ctx, NewListRequest(f.getCommand(), map[string]string{
-		"directory": directoryId,
-		"origin":    origin,
-	}))
+	return doExecute[[]string](f.cliClient, ctx, NewListRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
+		"origin": origin,
+	})))
 }
 
 func (f *securityUserFacade) GetByDirectory(ctx context.Context, directoryId string, username string, origin string) (xsuaa_authz.UserReference, CommandResponse, error) {
-	return doExecute[xsuaa_authz.UserReference](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
-		"directory": directoryId,
-		"userName":  username,
-		"origin":    origin,
-	}))
+	return doExecute[xsuaa_authz.UserReference](f.cliClient, ctx, NewGetRequest(f.getCommand(), directoryScope(directoryId).params(map[string]string{
+		"userName": username,
+		"origin":   origin,
+	})))
+}
+
+// GetBySubaccounts reads the user in all given subaccounts with at most maxConcurrency requests in flight. The result
+// is keyed by the subaccount ID and doesn't contain the subaccounts the user is unknown to.
+func (f *securityUserFacade) GetBySubaccounts(ctx context.Context, subaccountIds []string, username string, origin string, maxConcurrency int) (map[string]xsuaa_authz.UserReference, error) {
+	users, failedSubaccountId, err := executeConcurrently(subaccountIds, maxConcurrency, func(subaccountId string) (*xsuaa_authz.UserReference, error) {
+		return userOrNilIfNotFound(f.GetBySubaccount(ctx, subaccountId, username, origin))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the user of subaccount %s: %w", failedSubaccountId, err)
+	}
+
+	return knownUsers(users), nil
+}
+
+// GetByDirectories reads the user in all given directories with at most maxConcurrency requests in flight, see
+// GetBySubaccounts.
+func (f *securityUserFacade) GetByDirectories(ctx context.Context, directoryIds []string, username string, origin string, maxConcurrency int) (map[string]xsuaa_authz.UserReference, error) {
+	users, failedDirectoryId, err := executeConcurrently(directoryIds, maxConcurrency, func(directoryId string) (*xsuaa_authz.UserReference, error) {
+		return userOrNilIfNotFound(f.GetByDirectory(ctx, directoryId, username, origin))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the user of directory %s: %w", failedDirectoryId, err)
+	}
+
+	return knownUsers(users), nil
+}
+
+func userOrNilIfNotFound(user xsuaa_authz.UserReference, res CommandResponse, err error) (*xsuaa_authz.UserReference, error) {
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func knownUsers(users map[string]*xsuaa_authz.UserReference) map[string]xsuaa_authz.UserReference {
+	known := make(map[string]xsuaa_authz.UserReference, len(users))
+	for id, user := range users {
+		if user != nil {
+			known[id] = *user
+		}
+	}
+
+	return known
 }
@@ -2,6 +2,8 @@ package btpcli
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -175,3 +177,85 @@ func TestSecurityUserFacade_GetByDirectory(t *testing.T) {
 		}
 	})
 }
+
+func TestSecurityUserFacade_GetBySubaccounts(t *testing.T) {
+	subaccountIds := []string{"6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"}
+	userName := "john.doe@mycompany.com"
+	origin := "ldap"
+
+	t.Run("skips the subaccounts the user is unknown to", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				assert.Equal(t, userName, payload.ParamValues["userName"])
+
+				if payload.ParamValues["subaccount"] != subaccountIds[0] {
+					w.Header().Set(HeaderCLIBackendStatus, "404")
+					fmt.Fprintf(w, `{"error": "user not found"}`)
+					return
+				}
+
+				fmt.Fprintf(w, `{"username": "%s", "roleCollections": ["Subaccount Viewer"]}`, userName)
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Security.User.GetBySubaccounts(context.TODO(), subaccountIds, userName, origin, 2)
+
+		if assert.NoError(t, err) && assert.Len(t, res, 1) {
+			assert.Equal(t, []string{"Subaccount Viewer"}, res[subaccountIds[0]].RoleCollections)
+		}
+	})
+	t.Run("reports the failing subaccount", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, "403")
+			fmt.Fprintf(w, `{"error": "access denied"}`)
+		}))
+		defer srv.Close()
+
+		res, err := uut.Security.User.GetBySubaccounts(context.TODO(), subaccountIds[:1], userName, origin, 2)
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "failed to read the user of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f: access denied")
+	})
+}
+
+func TestSecurityUserFacade_GetByDirectories(t *testing.T) {
+	directoryIds := []string{"05368777-4934-41e8-9f3c-6ec5f4d564b9", "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d"}
+	userName := "john.doe@mycompany.com"
+	origin := "ldap"
+
+	t.Run("fetches the user of all directories", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				fmt.Fprintf(w, `{"username": "%s", "roleCollections": ["Viewer of %s"]}`, userName, payload.ParamValues["directory"])
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Security.User.GetByDirectories(context.TODO(), directoryIds, userName, origin, 2)
+
+		if assert.NoError(t, err) && assert.Len(t, res, 2) {
+			for _, directoryId := range directoryIds {
+				assert.Equal(t, []string{"Viewer of " + directoryId}, res[directoryId].RoleCollections)
+			}
+		}
+	})
+	t.Run("reports the failing directory", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, "403")
+			fmt.Fprintf(w, `{"error": "access denied"}`)
+		}))
+		defer srv.Close()
+
+		res, err := uut.Security.User.GetByDirectories(context.TODO(), directoryIds[:1], userName, origin, 2)
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "failed to read the user of directory 05368777-4934-41e8-9f3c-6ec5f4d564b9: access denied")
+	})
+}
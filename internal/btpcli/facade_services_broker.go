@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
 )
 
 func newServicesBrokerFacade(cliClient *v2Client) servicesBrokerFacade {
@@ -47,3 +48,51 @@ func (f servicesBrokerFacade) GetByName(ctx context.Context, subaccountId string
 		"name":       brokerName,
 	}))
 }
+
+type ServiceBrokerRegisterInput struct {
+	Subaccount  string `btpcli:"subaccount"`
+	Name        string `btpcli:"name"`
+	Description string `btpcli:"description"`
+	Url         string `btpcli:"url"`
+	User        string `btpcli:"user"`
+	Password    string `btpcli:"password"`
+}
+
+func (f servicesBrokerFacade) Register(ctx context.Context, args *ServiceBrokerRegisterInput) (servicemanager.ServiceBrokerResponseObject, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return servicemanager.ServiceBrokerResponseObject{}, CommandResponse{}, err
+	}
+
+	return doExecute[servicemanager.ServiceBrokerResponseObject](f.cliClient, ctx, NewRegisterRequest(f.getCommand(), params))
+}
+
+type ServiceBrokerUpdateInput struct {
+	Subaccount string `btpcli:"subaccount"`
+	Id         string `btpcli:"id"`
+	NewName    string `btpcli:"newName"`
+	// The description is kept unchanged if not given.
+	Description *string `btpcli:"description"`
+	// The URL and the credentials are kept unchanged if not given.
+	Url      string `btpcli:"url"`
+	User     string `btpcli:"user"`
+	Password string `btpcli:"password"`
+}
+
+func (f servicesBrokerFacade) Update(ctx context.Context, args *ServiceBrokerUpdateInput) (servicemanager.ServiceBrokerResponseObject, CommandResponse, error) {
+	params, err := tfutils.ToBTPCLIParamsMap(args)
+
+	if err != nil {
+		return servicemanager.ServiceBrokerResponseObject{}, CommandResponse{}, err
+	}
+
+	return doExecute[servicemanager.ServiceBrokerResponseObject](f.cliClient, ctx, NewUpdateRequest(f.getCommand(), params))
+}
+
+func (f servicesBrokerFacade) Unregister(ctx context.Context, subaccountId string, brokerId string) (servicemanager.ServiceBrokerResponseObject, CommandResponse, error) {
+	return doExecute[servicemanager.ServiceBrokerResponseObject](f.cliClient, ctx, NewUnregisterRequest(f.getCommand(), map[string]string{
+		"subaccount": subaccountId,
+		"id":         brokerId,
+	}))
+}
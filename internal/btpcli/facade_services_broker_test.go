@@ -144,3 +144,136 @@ func TestServicesBrokerFacade_GetByName(t *testing.T) {
 		}
 	})
 }
+
+func TestServicesBrokerFacade_Register(t *testing.T) {
+	command := "services/broker"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	brokerName := "my-broker"
+	brokerUrl := "https://my-broker.example.com"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionRegister, map[string]string{
+				"subaccount":  subaccountId,
+				"name":        brokerName,
+				"description": "my description",
+				"url":         brokerUrl,
+				"user":        "jenny",
+				"password":    "secret",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Services.Broker.Register(context.TODO(), &ServiceBrokerRegisterInput{
+			Subaccount:  subaccountId,
+			Name:        brokerName,
+			Description: "my description",
+			Url:         brokerUrl,
+			User:        "jenny",
+			Password:    "secret",
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestServicesBrokerFacade_Update(t *testing.T) {
+	command := "services/broker"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	brokerId := "9ff44f1b-b2a8-43ae-9072-32bd1dce60e4"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		description := ""
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount":  subaccountId,
+				"id":          brokerId,
+				"newName":     "my-new-broker",
+				"description": "",
+				"url":         "https://my-new-broker.example.com",
+				"user":        "jenny",
+				"password":    "secret",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Services.Broker.Update(context.TODO(), &ServiceBrokerUpdateInput{
+			Subaccount:  subaccountId,
+			Id:          brokerId,
+			NewName:     "my-new-broker",
+			Description: &description,
+			Url:         "https://my-new-broker.example.com",
+			User:        "jenny",
+			Password:    "secret",
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+
+	t.Run("keeps the URL, the credentials and the description if not given", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUpdate, map[string]string{
+				"subaccount": subaccountId,
+				"id":         brokerId,
+				"newName":    "my-new-broker",
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Services.Broker.Update(context.TODO(), &ServiceBrokerUpdateInput{
+			Subaccount: subaccountId,
+			Id:         brokerId,
+			NewName:    "my-new-broker",
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestServicesBrokerFacade_Unregister(t *testing.T) {
+	command := "services/broker"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	brokerId := "9ff44f1b-b2a8-43ae-9072-32bd1dce60e4"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUnregister, map[string]string{
+				"subaccount": subaccountId,
+				"id":         brokerId,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Services.Broker.Unregister(context.TODO(), subaccountId, brokerId)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
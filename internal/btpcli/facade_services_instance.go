@@ -33,11 +33,11 @@ func (f servicesInstanceFacade) List(ctx context.Context, subaccountId string, f
 		params["labelsFilter"] = labelsFilter
 	}
 
-	return doExecute[[]servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewListRequest(f.getCommand(), params))
+	return doExecuteWithSchemaValidation[[]servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewListRequest(f.getCommand(), params))
 }
 
 func (f servicesInstanceFacade) GetById(ctx context.Context, subaccountId string, instanceId string) (servicemanager.ServiceInstanceResponseObject, CommandResponse, error) {
-	return doExecute[servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
+	return doExecuteWithSchemaValidation[servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
 		"subaccount": subaccountId,
 		"id":         instanceId,
 		"parameters": "false",
@@ -45,7 +45,7 @@ func (f servicesInstanceFacade) GetById(ctx context.Context, subaccountId string
 }
 
 func (f servicesInstanceFacade) GetByName(ctx context.Context, subaccountId string, instanceName string) (servicemanager.ServiceInstanceResponseObject, CommandResponse, error) {
-	return doExecute[servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
+	return doExecuteWithSchemaValidation[servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
 		"subaccount": subaccountId,
 		"name":       instanceName,
 		"parameters": "false",
@@ -53,11 +53,12 @@ func (f servicesInstanceFacade) GetByName(ctx context.Context, subaccountId stri
 }
 
 type ServiceInstanceCreateInput struct {
+	Id            string              `btpcli:"id"`
 	Name          string              `btpcli:"name"`
 	Subaccount    string              `btpcli:"subaccount"`
 	ServicePlanId string              `btpcli:"plan"`
 	Parameters    *string             `btpcli:"parameters"`
-	Labels        map[string][]string `btpcli:"labels"`
+	Labels        map[string][]string `btpcli:"labels,encodeasjson"`
 }
 
 func (f servicesInstanceFacade) Create(ctx context.Context, args *ServiceInstanceCreateInput) (servicemanager.ServiceInstanceResponseObject, CommandResponse, error) {
@@ -67,7 +68,7 @@ func (f servicesInstanceFacade) Create(ctx context.Context, args *ServiceInstanc
 		return servicemanager.ServiceInstanceResponseObject{}, CommandResponse{}, err
 	}
 
-	return doExecute[servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewCreateRequest(f.getCommand(), params))
+	return doExecuteWithSchemaValidation[servicemanager.ServiceInstanceResponseObject](f.cliClient, ctx, NewCreateRequest(f.getCommand(), params))
 }
 
 type ServiceInstanceUpdateInput struct {
@@ -77,7 +78,7 @@ type ServiceInstanceUpdateInput struct {
 	Subaccount    string              `btpcli:"subaccount"`
 	ServicePlanId string              `btpcli:"plan"`
 	Parameters    *string             `btpcli:"parameters"`
-	Labels        map[string][]string `btpcli:"labels"`
+	Labels        map[string][]string `btpcli:"labels,encodeasjson"`
 }
 
 func (f servicesInstanceFacade) Update(ctx context.Context, args *ServiceInstanceUpdateInput) (servicemanager.ServiceInstanceResponseObject, CommandResponse, error) {
@@ -107,6 +108,22 @@ func (f servicesInstanceFacade) Update(ctx context.Context, args *ServiceInstanc
 
 }
 
+// Share enables the sharing of the service instance with other subaccounts of the global account.
+func (f servicesInstanceFacade) Share(ctx context.Context, subaccountId string, instanceId string) (CommandResponse, error) {
+	return f.cliClient.Execute(ctx, NewShareRequest(f.getCommand(), map[string]string{
+		"subaccount": subaccountId,
+		"id":         instanceId,
+	}))
+}
+
+// Unshare disables the sharing of the service instance with other subaccounts of the global account.
+func (f servicesInstanceFacade) Unshare(ctx context.Context, subaccountId string, instanceId string) (CommandResponse, error) {
+	return f.cliClient.Execute(ctx, NewUnshareRequest(f.getCommand(), map[string]string{
+		"subaccount": subaccountId,
+		"id":         instanceId,
+	}))
+}
+
 func (f servicesInstanceFacade) Delete(ctx context.Context, subaccountId string, serviceId string) (CommandResponse, error) {
 	res, err := f.cliClient.Execute(ctx, NewDeleteRequest(f.getCommand(), map[string]string{
 		"subaccount": subaccountId,
@@ -2,6 +2,7 @@ package btpcli
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -117,6 +118,31 @@ func TestServicesInstanceFacade_GetById(t *testing.T) {
 			assert.Equal(t, 200, res.StatusCode)
 		}
 	})
+	t.Run("reports malformed responses", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"id": "bc8a216f-1184-49dc-b4b4-17cfe2828965", "ready": "true"}`)
+		}))
+		defer srv.Close()
+
+		_, _, err := uut.Services.Instance.GetById(context.TODO(), subaccountId, instanceId)
+
+		assert.ErrorIs(t, err, ErrUnexpectedFieldType)
+		assert.EqualError(t, err, "unable to decode the response of command 'services/instance' with action 'get' (field 'ready', offset 62): the response contains a field of unexpected type: expected bool, got string")
+	})
+	t.Run("reports unknown fields in responses", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"id": "bc8a216f-1184-49dc-b4b4-17cfe2828965", "unknown": true}`)
+		}))
+		defer srv.Close()
+
+		_, _, err := uut.Services.Instance.GetById(context.TODO(), subaccountId, instanceId)
+
+		var decodingErr *ResponseDecodingError
+		if assert.ErrorAs(t, err, &decodingErr) {
+			assert.Equal(t, "unknown", decodingErr.Field)
+			assert.ErrorIs(t, err, ErrUnknownResponseField)
+		}
+	})
 }
 
 func TestServicesInstanceFacade_GetByName(t *testing.T) {
@@ -208,6 +234,86 @@ func TestServicesInstanceFacade_Create(t *testing.T) {
 			Labels:        labels,
 		})
 
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+	t.Run("constructs the CLI params correctly - with requested id", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionCreate, map[string]string{
+				"id":         "df532d07-57a7-415e-a261-23a398ef068a",
+				"subaccount": subaccountId,
+				"name":       instanceName,
+				"plan":       servicePlanId,
+			})
+		}))
+		defer srv.Close()
+
+		_, res, err := uut.Services.Instance.Create(context.TODO(), &ServiceInstanceCreateInput{
+			Id:            "df532d07-57a7-415e-a261-23a398ef068a",
+			Name:          instanceName,
+			Subaccount:    subaccountId,
+			ServicePlanId: servicePlanId,
+		})
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestServicesInstanceFacade_Share(t *testing.T) {
+	command := "services/instance"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	instanceId := "bc8a216f-1184-49dc-b4b4-17cfe2828965"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionShare, map[string]string{
+				"subaccount": subaccountId,
+				"id":         instanceId,
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Services.Instance.Share(context.TODO(), subaccountId, instanceId)
+
+		if assert.True(t, srvCalled) && assert.NoError(t, err) {
+			assert.Equal(t, 200, res.StatusCode)
+		}
+	})
+}
+
+func TestServicesInstanceFacade_Unshare(t *testing.T) {
+	command := "services/instance"
+
+	subaccountId := "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"
+	instanceId := "bc8a216f-1184-49dc-b4b4-17cfe2828965"
+
+	t.Run("constructs the CLI params correctly", func(t *testing.T) {
+		var srvCalled bool
+
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srvCalled = true
+
+			assertCall(t, r, command, ActionUnshare, map[string]string{
+				"subaccount": subaccountId,
+				"id":         instanceId,
+			})
+		}))
+		defer srv.Close()
+
+		res, err := uut.Services.Instance.Unshare(context.TODO(), subaccountId, instanceId)
+
 		if assert.True(t, srvCalled) && assert.NoError(t, err) {
 			assert.Equal(t, 200, res.StatusCode)
 		}
@@ -2,6 +2,7 @@ package btpcli
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
 )
@@ -38,6 +39,21 @@ func (f servicesOfferingFacade) List(ctx context.Context, subaccountId string, f
 	return doExecute[[]servicemanager.ServiceOfferingResponseObject](f.cliClient, ctx, NewListRequest(f.getCommand(), params))
 }
 
+// ListBySubaccounts fetches the service offerings of all given subaccounts with at most maxConcurrency requests in
+// flight. The result is keyed by the subaccount ID. If requests fail, the error of the first failing subaccount in the
+// order of subaccountIds is returned.
+func (f servicesOfferingFacade) ListBySubaccounts(ctx context.Context, subaccountIds []string, environment string, maxConcurrency int) (map[string][]servicemanager.ServiceOfferingResponseObject, error) {
+	offerings, failedSubaccountId, err := executeConcurrently(subaccountIds, maxConcurrency, func(subaccountId string) ([]servicemanager.ServiceOfferingResponseObject, error) {
+		offerings, _, err := f.List(ctx, subaccountId, "", "", environment)
+		return offerings, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the service offerings of subaccount %s: %w", failedSubaccountId, err)
+	}
+
+	return offerings, nil
+}
+
 func (f servicesOfferingFacade) GetById(ctx context.Context, subaccountId string, offeringId string) (servicemanager.ServiceOfferingResponseObject, CommandResponse, error) {
 	return doExecute[servicemanager.ServiceOfferingResponseObject](f.cliClient, ctx, NewGetRequest(f.getCommand(), map[string]string{
 		"subaccount": subaccountId,
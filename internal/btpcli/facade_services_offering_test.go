@@ -2,6 +2,8 @@ package btpcli
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -92,6 +94,50 @@ func TestServicesOfferingFacade_List(t *testing.T) {
 	})
 }
 
+func TestServicesOfferingFacade_ListBySubaccounts(t *testing.T) {
+	command := "services/offering"
+
+	subaccountIds := []string{"6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"}
+
+	t.Run("fetches the service offerings of all subaccounts", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+				assert.Equal(t, fmt.Sprintf("/command/%s/%s", cliTargetProtocolVersion, command), r.URL.Path)
+				assert.Equal(t, string(ActionList), r.URL.RawQuery)
+				assert.Equal(t, "kubernetes", payload.ParamValues["environment"])
+
+				fmt.Fprintf(w, `[{"name": "offering-%s"}]`, payload.ParamValues["subaccount"])
+			}
+		}))
+		defer srv.Close()
+
+		res, err := uut.Services.Offering.ListBySubaccounts(context.TODO(), subaccountIds, "kubernetes", 2)
+
+		if assert.NoError(t, err) && assert.Len(t, res, 2) {
+			for _, subaccountId := range subaccountIds {
+				if assert.Len(t, res[subaccountId], 1) {
+					assert.Equal(t, "offering-"+subaccountId, res[subaccountId][0].Name)
+				}
+			}
+		}
+	})
+	t.Run("reports the failing subaccount", func(t *testing.T) {
+		uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderCLIBackendStatus, "403")
+			fmt.Fprintf(w, `{"error": "access denied"}`)
+		}))
+		defer srv.Close()
+
+		res, err := uut.Services.Offering.ListBySubaccounts(context.TODO(), subaccountIds, "", 2)
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "failed to list the service offerings of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f: access denied")
+	})
+}
+
 func TestServicesOfferingFacade_GetById(t *testing.T) {
 	command := "services/offering"
 
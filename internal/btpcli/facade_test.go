@@ -1,11 +1,14 @@
 package btpcli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +24,7 @@ func prepareClientFacadeForTest(handleFn http.HandlerFunc) (*ClientFacade, *http
 
 	apiClient := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
 	apiClient.session = &Session{GlobalAccountSubdomain: "795b53bb-a3f0-4769-adf0-26173282a975"}
+	apiClient.ValidateResponseSchema = true
 	return NewClientFacade(apiClient), srv
 }
 
@@ -39,3 +43,93 @@ func assertCall(t *testing.T, r *http.Request, expectedCommand string, expectedA
 		assert.Equal(t, expectedParams, payload.ParamValues)
 	}
 }
+
+func TestClientFacade_LoginWithSessionCache(t *testing.T) {
+	loginReq := NewLoginRequestWithCustomIDP("my-idp", "my-subdomain", "john.doe@int.test", "my-pass")
+
+	newLoginTestServer := func(validToken string, logins *int) *httptest.Server {
+		return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				*logins++
+				w.Header().Set(HeaderCLIReplacementRefreshToken, "fresh-token")
+				fmt.Fprint(w, `{"refreshToken": "fresh-token", "user": "john.doe@int.test", "mail": "john.doe@int.test", "issuer": "accounts.sap.com"}`)
+				return
+			}
+
+			if r.Header.Get(HeaderCLIRefreshToken) != validToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.Header().Set(HeaderCLIBackendStatus, "200")
+			w.Header().Set(HeaderCLIReplacementRefreshToken, "replaced-token")
+			fmt.Fprint(w, "{}")
+		}))
+	}
+
+	newClientFacade := func(srv *httptest.Server) *ClientFacade {
+		srvUrl, _ := url.Parse(srv.URL)
+		return NewClientFacade(NewV2ClientWithHttpClient(srv.Client(), srvUrl))
+	}
+
+	t.Run("happy path - valid cached session is reused", func(t *testing.T) {
+		logins := 0
+		srv := newLoginTestServer("cached-token", &logins)
+		defer srv.Close()
+
+		cache := NewSessionCache(filepath.Join(t.TempDir(), "session.json"))
+		assert.NoError(t, cache.store(sessionCacheKey(srv.URL, loginReq), &Session{GlobalAccountSubdomain: "my-subdomain", IdentityProvider: "my-idp", RefreshToken: "cached-token", LoggedInUser: &v2LoggedInUser{Username: "john.doe@int.test"}}))
+
+		uut := newClientFacade(srv)
+		res, err := uut.LoginWithSessionCache(context.TODO(), cache, loginReq)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, logins)
+			assert.Equal(t, "john.doe@int.test", res.Username)
+			assert.Equal(t, "john.doe@int.test", uut.GetLoggedInUser().Username)
+
+			// the replaced refresh token is persisted for the next run
+			cached, ok := cache.load(sessionCacheKey(srv.URL, loginReq))
+			if assert.True(t, ok) {
+				assert.Equal(t, "replaced-token", cached.RefreshToken)
+			}
+		}
+	})
+	t.Run("happy path - invalid cached session leads to a full login", func(t *testing.T) {
+		logins := 0
+		srv := newLoginTestServer("replaced-token", &logins)
+		defer srv.Close()
+
+		cache := NewSessionCache(filepath.Join(t.TempDir(), "session.json"))
+		assert.NoError(t, cache.store(sessionCacheKey(srv.URL, loginReq), &Session{GlobalAccountSubdomain: "my-subdomain", IdentityProvider: "my-idp", RefreshToken: "revoked-token", LoggedInUser: &v2LoggedInUser{}}))
+
+		uut := newClientFacade(srv)
+		_, err := uut.LoginWithSessionCache(context.TODO(), cache, loginReq)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, logins)
+
+			cached, ok := cache.load(sessionCacheKey(srv.URL, loginReq))
+			if assert.True(t, ok) {
+				assert.Equal(t, "fresh-token", cached.RefreshToken)
+			}
+		}
+	})
+	t.Run("happy path - missing cache leads to a full login", func(t *testing.T) {
+		logins := 0
+		srv := newLoginTestServer("fresh-token", &logins)
+		defer srv.Close()
+
+		cache := NewSessionCache(filepath.Join(t.TempDir(), "session.json"))
+
+		uut := newClientFacade(srv)
+		_, err := uut.LoginWithSessionCache(context.TODO(), cache, loginReq)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, logins)
+
+			_, ok := cache.load(sessionCacheKey(srv.URL, loginReq))
+			assert.True(t, ok)
+		}
+	})
+}
@@ -2,8 +2,7 @@ package btpcli
 
 import (
 	"context"
-	"encoding/json"
-	"io"
+	"sync"
 )
 
 // firstElementOrDefault returns the first element of a slice or if not available the given defaultValue
@@ -21,6 +20,16 @@ func nthElementOrDefault[T any](slice []T, n int, defaultValue T) T {
 }
 
 func doExecute[T interface{}](cliClient *v2Client, ctx context.Context, req *CommandRequest, options ...CommandOptions) (T, CommandResponse, error) {
+	return doExecuteAndDecode[T](cliClient, ctx, req, false, options...)
+}
+
+// doExecuteWithSchemaValidation works like doExecute, but rejects responses with fields which are not part of T if the
+// client validates response schemas. Facades opt in once their types cover the responses of the CLI server completely.
+func doExecuteWithSchemaValidation[T interface{}](cliClient *v2Client, ctx context.Context, req *CommandRequest, options ...CommandOptions) (T, CommandResponse, error) {
+	return doExecuteAndDecode[T](cliClient, ctx, req, cliClient.ValidateResponseSchema, options...)
+}
+
+func doExecuteAndDecode[T interface{}](cliClient *v2Client, ctx context.Context, req *CommandRequest, disallowUnknownFields bool, options ...CommandOptions) (T, CommandResponse, error) {
 	var obj T
 
 	res, err := cliClient.Execute(ctx, req, options...)
@@ -30,9 +39,50 @@ func doExecute[T interface{}](cliClient *v2Client, ctx context.Context, req *Com
 	}
 
 	defer res.Body.Close()
-	if err = json.NewDecoder(res.Body).Decode(&obj); err == nil || err == io.EOF {
-		return obj, res, nil
-	} else {
-		return obj, res, err
+	obj, err = decodeResponse[T](req, res.Body, disallowUnknownFields)
+
+	return obj, res, err
+}
+
+// executeConcurrently calls execute for each of the given IDs with at most maxConcurrency calls in flight. The results
+// are keyed by the ID. If calls fail, the first failing ID in the order of ids is returned along with its error, so
+// that the outcome does not depend on scheduling.
+func executeConcurrently[T any](ids []string, maxConcurrency int, execute func(id string) (T, error)) (map[string]T, string, error) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	type result struct {
+		value T
+		err   error
 	}
+
+	results := make([]result, len(ids))
+	slots := make(chan struct{}, maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+
+		go func(i int, id string) {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i].value, results[i].err = execute(id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	values := make(map[string]T, len(ids))
+	for i, id := range ids {
+		if results[i].err != nil {
+			return nil, id, results[i].err
+		}
+
+		values[id] = results[i].value
+	}
+
+	return values, "", nil
 }
@@ -1,7 +1,10 @@
 package btpcli
 
 import (
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -38,3 +41,45 @@ func TestNthElementOrDefault(t *testing.T) {
 		assert.Equal(t, 2, val)
 	})
 }
+
+func TestExecuteConcurrently(t *testing.T) {
+	t.Parallel()
+	ids := []string{"a", "b", "c", "d", "e"}
+
+	t.Run("executes all calls with bounded concurrency", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+
+		values, failedId, err := executeConcurrently(ids, 2, func(id string) (string, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+
+			for {
+				observed := atomic.LoadInt32(&maxInFlight)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			return "value-" + id, nil
+		})
+
+		if assert.NoError(t, err) {
+			assert.Empty(t, failedId)
+			assert.Equal(t, map[string]string{"a": "value-a", "b": "value-b", "c": "value-c", "d": "value-d", "e": "value-e"}, values)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+		}
+	})
+	t.Run("returns the first failing id in the given order", func(t *testing.T) {
+		values, failedId, err := executeConcurrently(ids, 0, func(id string) (string, error) {
+			if id == "b" || id == "d" {
+				return "", fmt.Errorf("failed %s", id)
+			}
+			return id, nil
+		})
+
+		assert.Nil(t, values)
+		assert.Equal(t, "b", failedId)
+		assert.EqualError(t, err, "failed b")
+	})
+}
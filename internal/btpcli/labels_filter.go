@@ -0,0 +1,71 @@
+package btpcli
+
+import "strings"
+
+// commandsWithLabelsFilter lists the commands for which the CLI server filters the response by labels itself.
+var commandsWithLabelsFilter = map[string]bool{
+	"accounts/subaccount": true,
+}
+
+// applyLabelsFilter adds the labels filter to the command parameters, if the CLI server supports it for the command.
+// It reports whether the filter has been sent to the server, otherwise the caller must filter the response itself.
+func applyLabelsFilter(command string, params map[string]string, labelsFilter string) bool {
+	if len(labelsFilter) == 0 {
+		return true
+	}
+
+	if !commandsWithLabelsFilter[command] {
+		return false
+	}
+
+	params["labelsFilter"] = labelsFilter
+
+	return true
+}
+
+// filterByLabels returns the values whose labels meet all conditions of the labels filter.
+func filterByLabels[T any](values []T, labelsOf func(T) map[string][]string, labelsFilter string) []T {
+	filtered := []T{}
+
+	for _, value := range values {
+		if matchesLabelsFilter(labelsOf(value), labelsFilter) {
+			filtered = append(filtered, value)
+		}
+	}
+
+	return filtered
+}
+
+// matchesLabelsFilter checks if the labels meet all conditions of the filter. The filter consists of conditions
+// separated by `;`, each either a label name or a label name with a value (`name=value`). An empty filter matches everything.
+func matchesLabelsFilter(labels map[string][]string, labelsFilter string) bool {
+	for _, condition := range strings.Split(labelsFilter, ";") {
+		condition = strings.TrimSpace(condition)
+		if len(condition) == 0 {
+			continue
+		}
+
+		name, value, withValue := strings.Cut(condition, "=")
+
+		values, exists := labels[strings.TrimSpace(name)]
+		if !exists {
+			return false
+		}
+
+		if withValue && !containsString(values, strings.TrimSpace(value)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package btpcli
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLabelsFilter(t *testing.T) {
+	t.Run("filter is sent to the server if supported", func(t *testing.T) {
+		params := map[string]string{}
+
+		assert.True(t, applyLabelsFilter("accounts/subaccount", params, "env=dev"))
+		assert.Equal(t, map[string]string{"labelsFilter": "env=dev"}, params)
+	})
+	t.Run("filter is left to the caller if not supported", func(t *testing.T) {
+		params := map[string]string{}
+
+		assert.False(t, applyLabelsFilter("accounts/global-account", params, "env=dev"))
+		assert.Empty(t, params)
+	})
+	t.Run("empty filter needs no filtering", func(t *testing.T) {
+		params := map[string]string{}
+
+		assert.True(t, applyLabelsFilter("accounts/global-account", params, ""))
+		assert.Empty(t, params)
+	})
+}
+
+func TestMatchesLabelsFilter(t *testing.T) {
+	labels := map[string][]string{
+		"env":  {"dev", "test"},
+		"team": {},
+	}
+
+	tests := []struct {
+		filter   string
+		expected bool
+	}{
+		{filter: "", expected: true},
+		{filter: "env", expected: true},
+		{filter: "env=dev", expected: true},
+		{filter: "env=test; team", expected: true},
+		{filter: "env=prod", expected: false},
+		{filter: "env=dev;cost-center", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("filter '%s'", test.filter), func(t *testing.T) {
+			assert.Equal(t, test.expected, matchesLabelsFilter(labels, test.filter))
+		})
+	}
+}
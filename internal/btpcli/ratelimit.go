@@ -0,0 +1,69 @@
+package btpcli
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	HeaderRateLimitLimit     string = "X-Ratelimit-Limit"
+	HeaderRateLimitRemaining string = "X-Ratelimit-Remaining"
+	HeaderRateLimitReset     string = "X-Ratelimit-Reset"
+)
+
+// RateLimit holds the rate limit of the CLI server, as reported with the latest response.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the number of seconds until the current window ends. Zero, if not reported.
+	Reset int
+}
+
+// rateLimitFrom parses the rate limit headers of the response. It returns nil, if the server doesn't report its rate
+// limit, or reports it in a format that can't be parsed.
+func rateLimitFrom(header http.Header) *RateLimit {
+	limit, err := strconv.Atoi(header.Get(HeaderRateLimitLimit))
+	if err != nil {
+		return nil
+	}
+
+	remaining, err := strconv.Atoi(header.Get(HeaderRateLimitRemaining))
+	if err != nil {
+		return nil
+	}
+
+	// the reset is optional
+	reset, _ := strconv.Atoi(header.Get(HeaderRateLimitReset))
+
+	return &RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+}
+
+// observeRateLimit keeps the rate limit reported with the response, if any. Responses without rate limit headers don't
+// reset the rate limit observed before.
+func (v2 *v2Client) observeRateLimit(res *http.Response) {
+	rateLimit := rateLimitFrom(res.Header)
+	if rateLimit == nil {
+		return
+	}
+
+	v2.rateLimitMutex.Lock()
+	defer v2.rateLimitMutex.Unlock()
+
+	v2.rateLimit = rateLimit
+}
+
+// GetRateLimit returns the rate limit reported with the latest response of the CLI server, or nil if the server hasn't
+// reported any so far.
+func (v2 *v2Client) GetRateLimit() *RateLimit {
+	v2.rateLimitMutex.Lock()
+	defer v2.rateLimitMutex.Unlock()
+
+	if v2.rateLimit == nil {
+		return nil
+	}
+
+	rateLimit := *v2.rateLimit
+	return &rateLimit
+}
@@ -0,0 +1,80 @@
+package btpcli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitFrom(t *testing.T) {
+	t.Parallel()
+	t.Run("all headers reported", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(HeaderRateLimitLimit, "100")
+		header.Set(HeaderRateLimitRemaining, "42")
+		header.Set(HeaderRateLimitReset, "30")
+
+		assert.Equal(t, &RateLimit{Limit: 100, Remaining: 42, Reset: 30}, rateLimitFrom(header))
+	})
+	t.Run("reset not reported", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(HeaderRateLimitLimit, "100")
+		header.Set(HeaderRateLimitRemaining, "42")
+
+		assert.Equal(t, &RateLimit{Limit: 100, Remaining: 42}, rateLimitFrom(header))
+	})
+	t.Run("no rate limit reported", func(t *testing.T) {
+		assert.Nil(t, rateLimitFrom(http.Header{}))
+	})
+	t.Run("invalid rate limit reported", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(HeaderRateLimitLimit, "100")
+		header.Set(HeaderRateLimitRemaining, "many")
+
+		assert.Nil(t, rateLimitFrom(header))
+	})
+}
+
+func TestV2Client_GetRateLimit(t *testing.T) {
+	t.Run("no request sent so far", func(t *testing.T) {
+		uut := NewV2Client(nil)
+		assert.Nil(t, uut.GetRateLimit())
+	})
+	t.Run("latest rate limit is kept", func(t *testing.T) {
+		remaining := []string{"42", "", "41"}
+		requests := 0
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(remaining[requests]) > 0 {
+				w.Header().Set(HeaderRateLimitLimit, "100")
+				w.Header().Set(HeaderRateLimitRemaining, remaining[requests])
+				w.Header().Set(HeaderRateLimitReset, "30")
+			}
+			requests++
+
+			w.Header().Set(HeaderCLIBackendStatus, "200")
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		uut := NewV2ClientWithHttpClient(srv.Client(), srvUrl)
+
+		_, err := uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{}))
+		assert.NoError(t, err)
+		assert.Equal(t, &RateLimit{Limit: 100, Remaining: 42, Reset: 30}, uut.GetRateLimit())
+
+		_, err = uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{}))
+		assert.NoError(t, err)
+		assert.Equal(t, &RateLimit{Limit: 100, Remaining: 42, Reset: 30}, uut.GetRateLimit(), "responses without rate limit keep the one observed before")
+
+		_, err = uut.Execute(context.TODO(), NewGetRequest("subaccount/role", map[string]string{}))
+		assert.NoError(t, err)
+		assert.Equal(t, &RateLimit{Limit: 100, Remaining: 41, Reset: 30}, uut.GetRateLimit())
+	})
+}
@@ -0,0 +1,115 @@
+package btpcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ResponseDecodingError is returned if the response of the CLI server can't be decoded into the expected type.
+type ResponseDecodingError struct {
+	Command string
+	Action  Action
+	// Field is the path of the offending field, if known
+	Field string
+	// Offset is the number of bytes of the response which have been read before the error occurred, if known
+	Offset int64
+	Err    error
+}
+
+func (e *ResponseDecodingError) Error() string {
+	var details []string
+
+	if len(e.Field) > 0 {
+		details = append(details, fmt.Sprintf("field '%s'", e.Field))
+	}
+
+	if e.Offset > 0 {
+		details = append(details, fmt.Sprintf("offset %d", e.Offset))
+	}
+
+	msg := fmt.Sprintf("unable to decode the response of command '%s' with action '%s'", e.Command, e.Action)
+	if len(details) > 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(details, ", "))
+	}
+
+	return fmt.Sprintf("%s: %s", msg, e.Err)
+}
+
+func (e *ResponseDecodingError) Unwrap() error {
+	return e.Err
+}
+
+// ErrMalformedResponse is wrapped by a ResponseDecodingError if the response is not a single valid JSON value.
+var ErrMalformedResponse = errors.New("the response is not valid JSON")
+
+// ErrUnexpectedFieldType is wrapped by a ResponseDecodingError if a field of the response doesn't match its type.
+var ErrUnexpectedFieldType = errors.New("the response contains a field of unexpected type")
+
+// ErrUnknownResponseField is wrapped by a ResponseDecodingError if the response contains a field which isn't part of
+// the expected type, and the client validates the response schema.
+var ErrUnknownResponseField = errors.New("the response contains an unknown field")
+
+// decodeResponse decodes the JSON response of the given command. An empty response results in the zero value of T. If
+// disallowUnknownFields is set, fields which are not part of T and data after the JSON value are rejected as well.
+func decodeResponse[T any](cmdReq *CommandRequest, body io.Reader, disallowUnknownFields bool) (T, error) {
+	var obj T
+
+	decoder := json.NewDecoder(body)
+	if disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(&obj); err == io.EOF {
+		return obj, nil
+	} else if err != nil {
+		return obj, newResponseDecodingError(cmdReq, err)
+	}
+
+	if disallowUnknownFields {
+		if offset := decoder.InputOffset(); !isAtEOF(decoder) {
+			decodingErr := newResponseDecodingError(cmdReq, fmt.Errorf("%w: unexpected data after the JSON value", ErrMalformedResponse))
+			decodingErr.Offset = offset
+
+			return obj, decodingErr
+		}
+	}
+
+	return obj, nil
+}
+
+func isAtEOF(decoder *json.Decoder) bool {
+	_, err := decoder.Token()
+	return err == io.EOF
+}
+
+func newResponseDecodingError(cmdReq *CommandRequest, err error) *ResponseDecodingError {
+	decodingErr := &ResponseDecodingError{
+		Command: cmdReq.Command,
+		Action:  cmdReq.Action,
+		Err:     err,
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		decodingErr.Offset = syntaxErr.Offset
+		decodingErr.Err = fmt.Errorf("%w: %s", ErrMalformedResponse, syntaxErr)
+	case errors.As(err, &typeErr):
+		decodingErr.Field = typeErr.Field
+		decodingErr.Offset = typeErr.Offset
+		decodingErr.Err = fmt.Errorf("%w: expected %s, got %s", ErrUnexpectedFieldType, typeErr.Type, typeErr.Value)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		decodingErr.Err = fmt.Errorf("%w: unexpected end of the response", ErrMalformedResponse)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// the encoding/json package doesn't provide a dedicated error type for unknown fields
+		decodingErr.Field = strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		decodingErr.Err = ErrUnknownResponseField
+	}
+
+	return decodingErr
+}
@@ -0,0 +1,70 @@
+package btpcli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	t.Parallel()
+
+	type response struct {
+		Name  string `json:"name"`
+		Ready bool   `json:"ready"`
+	}
+
+	cmdReq := NewGetRequest("services/instance", map[string]string{})
+
+	t.Run("decodes the response", func(t *testing.T) {
+		res, err := decodeResponse[response](cmdReq, strings.NewReader(`{"name": "my-instance", "ready": true}`), true)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, response{Name: "my-instance", Ready: true}, res)
+		}
+	})
+	t.Run("returns the zero value for empty responses", func(t *testing.T) {
+		res, err := decodeResponse[response](cmdReq, strings.NewReader(""), true)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, response{}, res)
+		}
+	})
+	t.Run("ignores unknown fields, if not disallowed", func(t *testing.T) {
+		res, err := decodeResponse[response](cmdReq, strings.NewReader(`{"name": "my-instance", "unknown": 1}`), false)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, response{Name: "my-instance"}, res)
+		}
+	})
+	t.Run("error path - unknown field", func(t *testing.T) {
+		_, err := decodeResponse[response](cmdReq, strings.NewReader(`{"name": "my-instance", "unknown": 1}`), true)
+
+		assert.ErrorIs(t, err, ErrUnknownResponseField)
+		assert.EqualError(t, err, "unable to decode the response of command 'services/instance' with action 'get' (field 'unknown'): the response contains an unknown field")
+	})
+	t.Run("error path - invalid JSON", func(t *testing.T) {
+		_, err := decodeResponse[response](cmdReq, strings.NewReader(`{"name": my-instance}`), false)
+
+		assert.ErrorIs(t, err, ErrMalformedResponse)
+		assert.EqualError(t, err, "unable to decode the response of command 'services/instance' with action 'get' (offset 10): the response is not valid JSON: invalid character 'm' looking for beginning of value")
+	})
+	t.Run("error path - unexpected field type", func(t *testing.T) {
+		_, err := decodeResponse[response](cmdReq, strings.NewReader(`{"name": 1}`), false)
+
+		var decodingErr *ResponseDecodingError
+		if assert.ErrorAs(t, err, &decodingErr) {
+			assert.Equal(t, "services/instance", decodingErr.Command)
+			assert.Equal(t, ActionGet, decodingErr.Action)
+			assert.Equal(t, "name", decodingErr.Field)
+			assert.ErrorIs(t, err, ErrUnexpectedFieldType)
+		}
+	})
+	t.Run("error path - data after the JSON value", func(t *testing.T) {
+		_, err := decodeResponse[response](cmdReq, strings.NewReader(`{"name": "my-instance"} {}`), true)
+
+		assert.ErrorIs(t, err, ErrMalformedResponse)
+		assert.EqualError(t, err, "unable to decode the response of command 'services/instance' with action 'get' (offset 23): the response is not valid JSON: unexpected data after the JSON value")
+	})
+}
@@ -0,0 +1,178 @@
+package btpcli
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried by default.
+	DefaultMaxRetries = 3
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
+// RetryOptions configure how requests to the CLI server are retried.
+type RetryOptions struct {
+	// MaxRetries is the number of times a failed request is retried. If zero, requests aren't retried.
+	MaxRetries int
+	// RequestTimeout bounds each attempt of a request. If zero, attempts are only bound by the context.
+	RequestTimeout time.Duration
+}
+
+// WithRetries returns a copy of the given client, which retries requests that failed because of network errors or
+// because the CLI server responded with 429 or 5xx. The client itself is left untouched.
+//
+// Requests which change resources are only retried if the CLI server can't have processed them, i.e. if the connection
+// couldn't be established or if the server responded with 429 or 503. Reading requests are retried in any of the cases.
+func WithRetries(client *http.Client, opts RetryOptions) *http.Client {
+	transport := client.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	clientWithRetries := *client
+	clientWithRetries.Transport = &retryTransport{
+		transport: transport,
+		opts:      opts,
+		backoff:   exponentialBackoffWithJitter,
+	}
+
+	return &clientWithRetries
+}
+
+// retryTransport implements the http.RoundTripper interface and retries failed requests with backoff.
+type retryTransport struct {
+	transport http.RoundTripper
+	opts      RetryOptions
+	backoff   func(attempt int) time.Duration
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := isIdempotentRequest(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, cancel, err := rt.prepareAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := rt.transport.RoundTrip(attemptReq)
+
+		if attempt >= rt.opts.MaxRetries || req.Context().Err() != nil || !isRetryable(res, err, idempotent) || (req.Body != nil && req.GetBody == nil) {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+
+			res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+			return res, nil
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		cancel()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(rt.backoff(attempt)):
+		}
+	}
+}
+
+// prepareAttempt clones the request for the given attempt with a fresh body and the request timeout applied.
+func (rt *retryTransport) prepareAttempt(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+
+	if rt.opts.RequestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, rt.opts.RequestTimeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	return attemptReq, cancel, nil
+}
+
+// isIdempotentRequest reports whether the request can safely be sent more than once. Commands are idempotent if they
+// only read resources, whereas logging in just creates another session.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	if strings.Contains(req.URL.Path, "/command/") {
+		return isReadingAction(Action(req.URL.RawQuery))
+	}
+
+	return strings.Contains(req.URL.Path, "/login/")
+}
+
+// isRetryable reports whether the outcome of an attempt warrants another attempt.
+func isRetryable(res *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		// an untrusted certificate of the server doesn't change from one attempt to the next
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return false
+		}
+
+		// the request hasn't been sent if the connection couldn't be established
+		var opErr *net.OpError
+		return idempotent || (errors.As(err, &opErr) && opErr.Op == "dial")
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case res.StatusCode >= 500:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// exponentialBackoffWithJitter returns the delay before the next attempt, which doubles with each attempt up to
+// retryMaxDelay. The delay is randomized by up to half, so that clients failing at the same time don't retry in sync.
+func exponentialBackoffWithJitter(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		if d := retryBaseDelay << attempt; d < retryMaxDelay {
+			delay = d
+		}
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// cancelOnCloseBody releases the context of an attempt once the response body has been consumed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
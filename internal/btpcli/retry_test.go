@@ -0,0 +1,218 @@
+package btpcli
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetries(t *testing.T) {
+	newClient := func(srv *httptest.Server, opts RetryOptions) *http.Client {
+		client := WithRetries(srv.Client(), opts)
+		client.Transport.(*retryTransport).backoff = func(int) time.Duration { return 0 }
+		return client
+	}
+
+	newServer := func(statusCodes ...int) (*httptest.Server, *int32) {
+		var attempts int32
+
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempt := int(atomic.AddInt32(&attempts, 1))
+
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `{"paramValues":{}}`, string(body), "the body must be resent with each attempt")
+
+			if attempt <= len(statusCodes) {
+				w.WriteHeader(statusCodes[attempt-1])
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})), &attempts
+	}
+
+	post := func(client *http.Client, url string) (*http.Response, error) {
+		return client.Post(url, "application/json", bytes.NewBufferString(`{"paramValues":{}}`))
+	}
+
+	tests := []struct {
+		description    string
+		action         Action
+		statusCodes    []int
+		expectStatus   int
+		expectAttempts int32
+	}{
+		{
+			description:    "happy path - reading command is retried on 5xx",
+			action:         ActionGet,
+			statusCodes:    []int{http.StatusBadGateway, http.StatusInternalServerError},
+			expectStatus:   http.StatusOK,
+			expectAttempts: 3,
+		},
+		{
+			description:    "happy path - changing command is retried on 429 and 503",
+			action:         ActionCreate,
+			statusCodes:    []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+			expectStatus:   http.StatusOK,
+			expectAttempts: 3,
+		},
+		{
+			description:    "happy path - changing command isn't retried on 502, as it may have been processed",
+			action:         ActionCreate,
+			statusCodes:    []int{http.StatusBadGateway},
+			expectStatus:   http.StatusBadGateway,
+			expectAttempts: 1,
+		},
+		{
+			description:    "happy path - client errors aren't retried",
+			action:         ActionGet,
+			statusCodes:    []int{http.StatusNotFound},
+			expectStatus:   http.StatusNotFound,
+			expectAttempts: 1,
+		},
+		{
+			description:    "error path - retries are exhausted",
+			action:         ActionList,
+			statusCodes:    []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectStatus:   http.StatusBadGateway,
+			expectAttempts: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			srv, attempts := newServer(test.statusCodes...)
+			defer srv.Close()
+
+			res, err := post(newClient(srv, RetryOptions{MaxRetries: 3}), srv.URL+"/command/v2.38.0/accounts/subaccount?"+string(test.action))
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expectStatus, res.StatusCode)
+				res.Body.Close()
+			}
+			assert.Equal(t, test.expectAttempts, atomic.LoadInt32(attempts))
+		})
+	}
+
+	t.Run("happy path - no retries if disabled", func(t *testing.T) {
+		srv, attempts := newServer(http.StatusServiceUnavailable)
+		defer srv.Close()
+
+		res, err := post(newClient(srv, RetryOptions{}), srv.URL+"/command/v2.38.0/accounts/subaccount?get")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+			res.Body.Close()
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("happy path - attempt exceeding the request timeout is retried", func(t *testing.T) {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(200 * time.Millisecond):
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		res, err := post(newClient(srv, RetryOptions{MaxRetries: 3, RequestTimeout: 50 * time.Millisecond}), srv.URL+"/command/v2.38.0/accounts/subaccount?get")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			res.Body.Close()
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("error path - changing command exceeding the request timeout isn't retried", func(t *testing.T) {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+		}))
+		defer srv.Close()
+
+		_, err := post(newClient(srv, RetryOptions{MaxRetries: 3, RequestTimeout: 50 * time.Millisecond}), srv.URL+"/command/v2.38.0/accounts/subaccount?delete")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("error path - retries stop once the context is cancelled", func(t *testing.T) {
+		srv, attempts := newServer(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+		defer srv.Close()
+
+		client := WithRetries(srv.Client(), RetryOptions{MaxRetries: 3})
+		client.Transport.(*retryTransport).backoff = func(int) time.Duration { return time.Minute }
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/command/v2.38.0/accounts/subaccount?get", bytes.NewBufferString(`{"paramValues":{}}`))
+		_, err := client.Do(req)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("error path - untrusted server certificate isn't retried", func(t *testing.T) {
+		var connections int32
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+		srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&connections, 1)
+			}
+		}
+		srv.StartTLS()
+		defer srv.Close()
+
+		client := WithRetries(&http.Client{}, RetryOptions{MaxRetries: 3})
+		client.Transport.(*retryTransport).backoff = func(int) time.Duration { return 0 }
+
+		_, err := post(client, srv.URL+"/command/v2.38.0/accounts/subaccount?get")
+
+		var certErr *tls.CertificateVerificationError
+		assert.ErrorAs(t, err, &certErr)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+	})
+
+	t.Run("happy path - client is left untouched", func(t *testing.T) {
+		client := &http.Client{}
+
+		clientWithRetries := WithRetries(client, RetryOptions{MaxRetries: 3})
+
+		assert.Nil(t, client.Transport)
+		assert.IsType(t, &retryTransport{}, clientWithRetries.Transport)
+	})
+}
+
+func TestExponentialBackoffWithJitter(t *testing.T) {
+	for attempt, expectedDelay := range []time.Duration{retryBaseDelay, 2 * retryBaseDelay, 4 * retryBaseDelay} {
+		delay := exponentialBackoffWithJitter(attempt)
+
+		assert.GreaterOrEqual(t, delay, expectedDelay/2)
+		assert.LessOrEqual(t, delay, expectedDelay)
+	}
+
+	assert.LessOrEqual(t, exponentialBackoffWithJitter(100), retryMaxDelay)
+}
@@ -0,0 +1,34 @@
+package btpcli
+
+// commandScope is the level of the account model (global account, directory or subaccount) a command is executed on.
+// The BTP CLI server expects the scope as a dedicated parameter, so the facades derive it from the IDs handed over by
+// the resources instead of adding the parameter themselves.
+type commandScope struct {
+	param string
+	id    string
+}
+
+func globalAccountScope(cliClient *v2Client) commandScope {
+	return commandScope{param: "globalAccount", id: cliClient.GetGlobalAccountSubdomain()}
+}
+
+func directoryScope(directoryId string) commandScope {
+	return commandScope{param: "directory", id: directoryId}
+}
+
+func subaccountScope(subaccountId string) commandScope {
+	return commandScope{param: "subaccount", id: subaccountId}
+}
+
+// params returns a copy of the given command parameters including the parameter which selects the scope.
+func (s commandScope) params(params map[string]string) map[string]string {
+	scoped := make(map[string]string, len(params)+1)
+
+	for key, value := range params {
+		scoped[key] = value
+	}
+
+	scoped[s.param] = s.id
+
+	return scoped
+}
@@ -0,0 +1,138 @@
+package btpcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandScope(t *testing.T) {
+	t.Parallel()
+	t.Run("global account scope uses the subdomain of the session", func(t *testing.T) {
+		client := &v2Client{session: &Session{GlobalAccountSubdomain: "my-globalaccount"}}
+
+		assert.Equal(t, map[string]string{
+			"globalAccount": "my-globalaccount",
+		}, globalAccountScope(client).params(nil))
+	})
+	t.Run("directory scope", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"directory":          "b2fde2a8-0e73-4a4f-8a4d-a3a1e1b5c889",
+			"roleCollectionName": "my-role-collection",
+		}, directoryScope("b2fde2a8-0e73-4a4f-8a4d-a3a1e1b5c889").params(map[string]string{
+			"roleCollectionName": "my-role-collection",
+		}))
+	})
+	t.Run("subaccount scope", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"subaccount":         "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f",
+			"roleCollectionName": "my-role-collection",
+		}, subaccountScope("6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f").params(map[string]string{
+			"roleCollectionName": "my-role-collection",
+		}))
+	})
+	t.Run("scope overrides a conflicting parameter and leaves the input untouched", func(t *testing.T) {
+		params := map[string]string{
+			"subaccount": "should-be-overridden",
+		}
+
+		assert.Equal(t, map[string]string{
+			"subaccount": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f",
+		}, subaccountScope("6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f").params(params))
+		assert.Equal(t, "should-be-overridden", params["subaccount"])
+	})
+}
+
+func TestCommandScope_Facades(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description           string
+		callFunctionUnderTest func(ctx context.Context, uut *ClientFacade) (CommandResponse, error)
+		srvExpectPath         string
+		srvExpectBody         string
+	}{
+		{
+			description: "role collection by global account",
+			callFunctionUnderTest: func(ctx context.Context, uut *ClientFacade) (CommandResponse, error) {
+				_, res, err := uut.Security.RoleCollection.GetByGlobalAccount(ctx, "my-role-collection")
+				return res, err
+			},
+			srvExpectPath: "security/role-collection",
+			srvExpectBody: `{"paramValues":{"globalAccount":"795b53bb-a3f0-4769-adf0-26173282a975","roleCollectionName":"my-role-collection"}}`,
+		},
+		{
+			description: "role collection by directory",
+			callFunctionUnderTest: func(ctx context.Context, uut *ClientFacade) (CommandResponse, error) {
+				_, res, err := uut.Security.RoleCollection.GetByDirectory(ctx, "b2fde2a8-0e73-4a4f-8a4d-a3a1e1b5c889", "my-role-collection")
+				return res, err
+			},
+			srvExpectPath: "security/role-collection",
+			srvExpectBody: `{"paramValues":{"directory":"b2fde2a8-0e73-4a4f-8a4d-a3a1e1b5c889","roleCollectionName":"my-role-collection"}}`,
+		},
+		{
+			description: "role collection assignment by subaccount",
+			callFunctionUnderTest: func(ctx context.Context, uut *ClientFacade) (CommandResponse, error) {
+				_, res, err := uut.Security.RoleCollection.AssignUserBySubaccount(ctx, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "my-role-collection", "john.doe@int.test", "sap.default")
+				return res, err
+			},
+			srvExpectPath: "security/role-collection",
+			srvExpectBody: `{"paramValues":{"createUserIfMissing":"true","origin":"sap.default","roleCollectionName":"my-role-collection","subaccount":"6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f","userName":"john.doe@int.test"}}`,
+		},
+		{
+			description: "user by directory",
+			callFunctionUnderTest: func(ctx context.Context, uut *ClientFacade) (CommandResponse, error) {
+				_, res, err := uut.Security.User.GetByDirectory(ctx, "b2fde2a8-0e73-4a4f-8a4d-a3a1e1b5c889", "john.doe@int.test", "sap.default")
+				return res, err
+			},
+			srvExpectPath: "security/user",
+			srvExpectBody: `{"paramValues":{"directory":"b2fde2a8-0e73-4a4f-8a4d-a3a1e1b5c889","origin":"sap.default","userName":"john.doe@int.test"}}`,
+		},
+		{
+			description: "trust by global account",
+			callFunctionUnderTest: func(ctx context.Context, uut *ClientFacade) (CommandResponse, error) {
+				_, res, err := uut.Security.Trust.GetByGlobalAccount(ctx, "sap.custom")
+				return res, err
+			},
+			srvExpectPath: "security/trust",
+			srvExpectBody: `{"paramValues":{"globalAccount":"795b53bb-a3f0-4769-adf0-26173282a975","origin":"sap.custom"}}`,
+		},
+		{
+			description: "settings by subaccount",
+			callFunctionUnderTest: func(ctx context.Context, uut *ClientFacade) (CommandResponse, error) {
+				_, res, err := uut.Security.Settings.GetBySubaccount(ctx, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f")
+				return res, err
+			},
+			srvExpectPath: "security/settings",
+			srvExpectBody: `{"paramValues":{"subaccount":"6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var srvCalled bool
+
+			uut, srv := prepareClientFacadeForTest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				srvCalled = true
+
+				assert.Equal(t, fmt.Sprintf("/command/%s/%s", cliTargetProtocolVersion, test.srvExpectPath), r.URL.Path)
+
+				if b, err := io.ReadAll(r.Body); assert.NoError(t, err) {
+					assert.Equal(t, test.srvExpectBody, strings.TrimSpace(string(b)))
+				}
+			}))
+			defer srv.Close()
+
+			res, err := test.callFunctionUnderTest(context.TODO(), uut)
+
+			if assert.True(t, srvCalled) && assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ type v2LoggedInUser struct {
 	Username string
 	Email    string
 	Issuer   string
+	Groups   []string
 }
 
 type Session struct {
@@ -0,0 +1,120 @@
+package btpcli
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// sessionCacheLifetime is the time after which a cached session is considered expired, if it hasn't been used since.
+const sessionCacheLifetime = 12 * time.Hour
+
+// NewSessionCache returns a cache which persists sessions in the file at the given path, so that they can be reused
+// across runs instead of logging in again.
+func NewSessionCache(path string) *SessionCache {
+	return &SessionCache{
+		path: path,
+		now:  time.Now,
+	}
+}
+
+type SessionCache struct {
+	path string
+	now  func() time.Time
+}
+
+type cachedSession struct {
+	GlobalAccountSubdomain string         `json:"globalAccountSubdomain"`
+	IdentityProvider       string         `json:"identityProvider"`
+	RefreshToken           string         `json:"refreshToken"`
+	LoggedInUser           v2LoggedInUser `json:"loggedInUser"`
+	ExpiresAt              time.Time      `json:"expiresAt"`
+}
+
+// sessionCacheKey identifies the session of a user in the cache, so that sessions of different CLI servers, global
+// accounts, identity providers or users don't collide.
+func sessionCacheKey(serverURL string, loginReq *LoginRequest) string {
+	username := loginReq.Username
+	if len(loginReq.ClientId) > 0 {
+		username = loginReq.ClientId
+	}
+
+	return strings.Join([]string{serverURL, loginReq.GlobalAccountSubdomain, loginReq.IdentityProvider, username}, "|")
+}
+
+func (c *SessionCache) read() (map[string]cachedSession, error) {
+	sessions := map[string]cachedSession{}
+
+	content, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sessions, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(content, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// load returns the cached session for the given key, unless it has expired.
+func (c *SessionCache) load(key string) (*Session, bool) {
+	sessions, err := c.read()
+	if err != nil {
+		return nil, false
+	}
+
+	cached, ok := sessions[key]
+	if !ok || len(cached.RefreshToken) == 0 || c.now().After(cached.ExpiresAt) {
+		return nil, false
+	}
+
+	loggedInUser := cached.LoggedInUser
+
+	return &Session{
+		GlobalAccountSubdomain: cached.GlobalAccountSubdomain,
+		IdentityProvider:       cached.IdentityProvider,
+		RefreshToken:           cached.RefreshToken,
+		LoggedInUser:           &loggedInUser,
+	}, true
+}
+
+// store persists the session for the given key. The file is only readable by the current user, as it contains the
+// refresh token of the session.
+func (c *SessionCache) store(key string, session *Session) error {
+	sessions, err := c.read()
+	if err != nil {
+		// a corrupt cache is simply replaced
+		sessions = map[string]cachedSession{}
+	}
+
+	cached := cachedSession{
+		GlobalAccountSubdomain: session.GlobalAccountSubdomain,
+		IdentityProvider:       session.IdentityProvider,
+		RefreshToken:           session.RefreshToken,
+		ExpiresAt:              c.now().Add(sessionCacheLifetime),
+	}
+
+	if session.LoggedInUser != nil {
+		cached.LoggedInUser = *session.LoggedInUser
+	}
+
+	sessions[key] = cached
+
+	content, err := json.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(c.path, content, 0600); err != nil {
+		return err
+	}
+
+	// the permissions given above only apply if the file is newly created
+	return os.Chmod(c.path, 0600)
+}
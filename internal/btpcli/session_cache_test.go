@@ -0,0 +1,99 @@
+package btpcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCache(t *testing.T) {
+	session := &Session{
+		GlobalAccountSubdomain: "my-subdomain",
+		IdentityProvider:       "my-idp",
+		RefreshToken:           "my-token",
+		LoggedInUser:           &v2LoggedInUser{Username: "john.doe@int.test", Email: "john.doe@int.test", Issuer: "accounts.sap.com"},
+	}
+
+	t.Run("stored session can be loaded again", func(t *testing.T) {
+		uut := NewSessionCache(filepath.Join(t.TempDir(), "session.json"))
+
+		if assert.NoError(t, uut.store("my-key", session)) {
+			loaded, ok := uut.load("my-key")
+
+			if assert.True(t, ok) {
+				assert.Equal(t, session.GlobalAccountSubdomain, loaded.GlobalAccountSubdomain)
+				assert.Equal(t, session.IdentityProvider, loaded.IdentityProvider)
+				assert.Equal(t, session.RefreshToken, loaded.RefreshToken)
+				assert.Equal(t, *session.LoggedInUser, *loaded.LoggedInUser)
+			}
+		}
+	})
+	t.Run("file is only accessible by the current user", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		assert.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+
+		uut := NewSessionCache(path)
+
+		if assert.NoError(t, uut.store("my-key", session)) {
+			info, err := os.Stat(path)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			}
+		}
+	})
+	t.Run("sessions of different keys don't collide", func(t *testing.T) {
+		uut := NewSessionCache(filepath.Join(t.TempDir(), "session.json"))
+
+		other := &Session{GlobalAccountSubdomain: "other-subdomain", RefreshToken: "other-token", LoggedInUser: &v2LoggedInUser{}}
+
+		assert.NoError(t, uut.store("my-key", session))
+		assert.NoError(t, uut.store("other-key", other))
+
+		loaded, ok := uut.load("my-key")
+		if assert.True(t, ok) {
+			assert.Equal(t, "my-token", loaded.RefreshToken)
+		}
+
+		loaded, ok = uut.load("other-key")
+		if assert.True(t, ok) {
+			assert.Equal(t, "other-token", loaded.RefreshToken)
+		}
+
+		_, ok = uut.load("unknown-key")
+		assert.False(t, ok)
+	})
+	t.Run("expired session isn't loaded", func(t *testing.T) {
+		uut := NewSessionCache(filepath.Join(t.TempDir(), "session.json"))
+		assert.NoError(t, uut.store("my-key", session))
+
+		uut.now = func() time.Time { return time.Now().Add(sessionCacheLifetime + time.Minute) }
+
+		_, ok := uut.load("my-key")
+		assert.False(t, ok)
+	})
+	t.Run("missing or corrupt file isn't loaded", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		uut := NewSessionCache(path)
+
+		_, ok := uut.load("my-key")
+		assert.False(t, ok)
+
+		assert.NoError(t, os.WriteFile(path, []byte("this is not json"), 0600))
+
+		_, ok = uut.load("my-key")
+		assert.False(t, ok)
+	})
+}
+
+func TestSessionCacheKey(t *testing.T) {
+	const serverURL = "https://cli.btp.cloud.sap"
+
+	assert.Equal(t, "https://cli.btp.cloud.sap|my-subdomain|my-idp|john.doe@int.test", sessionCacheKey(serverURL, NewLoginRequestWithCustomIDP("my-idp", "my-subdomain", "john.doe@int.test", "my-pass")))
+	assert.Equal(t, "https://cli.btp.cloud.sap|my-subdomain||sb-client-id", sessionCacheKey(serverURL, NewLoginRequestWithClientCredentials("my-subdomain", "sb-client-id", "my-secret", "https://my-tenant.local/oauth/token")))
+	assert.NotEqual(t, sessionCacheKey(serverURL, NewLoginRequestWithCustomIDP("my-idp", "my-subdomain", "john.doe@int.test", "my-pass")), sessionCacheKey(serverURL, NewLoginRequestWithCustomIDP("other-idp", "my-subdomain", "john.doe@int.test", "my-pass")))
+	assert.NotEqual(t, sessionCacheKey(serverURL, NewLoginRequestWithCustomIDP("my-idp", "my-subdomain", "john.doe@int.test", "my-pass")), sessionCacheKey("https://canary.cli.btp.int.sap", NewLoginRequestWithCustomIDP("my-idp", "my-subdomain", "john.doe@int.test", "my-pass")))
+}
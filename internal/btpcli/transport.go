@@ -1,9 +1,17 @@
 package btpcli
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"net/http"
+	"net/url"
 )
 
+// ErrCustomTransport is returned if the transport of a http client can't be configured, e.g. to add a client certificate
+// or a proxy, because it isn't a *http.Transport.
+var ErrCustomTransport = errors.New("the TLS or proxy settings can't be applied to a custom transport")
+
 // injectBTPCLITransport wraps the transport of the given client with the btpcliTransport
 func injectBTPCLITransport(client *http.Client) *http.Client {
 	parentTransport := http.DefaultTransport
@@ -40,3 +48,100 @@ func (bt *btpcliTransport) RoundTrip(req *http.Request) (*http.Response, error)
 
 	return bt.transport.RoundTrip(req)
 }
+
+// WithClientCertificate returns a copy of the given client which presents the certificate on the TLS handshake. The
+// client itself is left untouched, so that e.g. a shared default client doesn't present the certificate as well.
+func WithClientCertificate(client *http.Client, certificate tls.Certificate) (*http.Client, error) {
+	httpTransport, err := cloneHTTPTransport(client)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsClientConfig(httpTransport).Certificates = []tls.Certificate{certificate}
+
+	clientWithCertificate := *client
+	clientWithCertificate.Transport = httpTransport
+
+	return &clientWithCertificate, nil
+}
+
+// WithRootCAs returns a copy of the given client which verifies the certificate of the server against the given
+// certificate pool instead of the ones trusted by the system. The client itself is left untouched.
+func WithRootCAs(client *http.Client, rootCAs *x509.CertPool) (*http.Client, error) {
+	httpTransport, err := cloneHTTPTransport(client)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsClientConfig(httpTransport).RootCAs = rootCAs
+
+	clientWithRootCAs := *client
+	clientWithRootCAs.Transport = httpTransport
+
+	return &clientWithRootCAs, nil
+}
+
+// WithInsecureSkipVerify returns a copy of the given client which accepts any certificate presented by the server.
+// This makes the connection susceptible to man-in-the-middle attacks and must only be used for testing. The client
+// itself is left untouched.
+func WithInsecureSkipVerify(client *http.Client) (*http.Client, error) {
+	httpTransport, err := cloneHTTPTransport(client)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsClientConfig(httpTransport).InsecureSkipVerify = true
+
+	clientWithoutVerification := *client
+	clientWithoutVerification.Transport = httpTransport
+
+	return &clientWithoutVerification, nil
+}
+
+// WithProxy returns a copy of the given client which sends all requests through the proxy, regardless of the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. Credentials embedded in the proxy URL are used for basic
+// authentication with the proxy. The client itself is left untouched.
+func WithProxy(client *http.Client, proxyURL *url.URL) (*http.Client, error) {
+	httpTransport, err := cloneHTTPTransport(client)
+	if err != nil {
+		return nil, err
+	}
+
+	httpTransport.Proxy = http.ProxyURL(proxyURL)
+
+	clientWithProxy := *client
+	clientWithProxy.Transport = httpTransport
+
+	return &clientWithProxy, nil
+}
+
+// cloneHTTPTransport returns a copy of the *http.Transport of the given client, which can be changed without affecting
+// the client.
+func cloneHTTPTransport(client *http.Client) (*http.Transport, error) {
+	transport := client.Transport
+
+	// unwrap the transport in case the btpcliTransport has already been injected
+	if bt, ok := transport.(*btpcliTransport); ok {
+		transport = bt.transport
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	httpTransport, ok := transport.(*http.Transport)
+	if !ok {
+		return nil, ErrCustomTransport
+	}
+
+	return httpTransport.Clone(), nil
+}
+
+// tlsClientConfig returns the TLS configuration of the transport, which is created if there is none yet.
+func tlsClientConfig(httpTransport *http.Transport) *tls.Config {
+	if httpTransport.TLSClientConfig == nil {
+		httpTransport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return httpTransport.TLSClientConfig
+}
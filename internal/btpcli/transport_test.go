@@ -1,10 +1,20 @@
 package btpcli
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -105,3 +115,185 @@ func TestBTPCLITransport(t *testing.T) {
 		})
 	}
 }
+
+func TestWithClientCertificate(t *testing.T) {
+	t.Run("happy path - certificate is presented on the TLS handshake", func(t *testing.T) {
+		certificate := generateTestCertificate(t)
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if assert.Len(t, r.TLS.PeerCertificates, 1) {
+				assert.Equal(t, "terraform-provider-btp", r.TLS.PeerCertificates[0].Subject.CommonName)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		srv.StartTLS()
+		defer srv.Close()
+
+		client := srv.Client()
+		uut, err := WithClientCertificate(injectBTPCLITransport(client), certificate)
+
+		if assert.NoError(t, err) {
+			res, err := uut.Get(srv.URL)
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+			}
+		}
+
+		// the original client must not present the certificate
+		_, err = client.Get(srv.URL)
+		assert.Error(t, err)
+	})
+	t.Run("error path - custom transport", func(t *testing.T) {
+		_, err := WithClientCertificate(&http.Client{Transport: &btpcliTransport{transport: &btpcliTransport{}}}, generateTestCertificate(t))
+
+		assert.ErrorIs(t, err, ErrCustomTransport)
+	})
+}
+
+func TestWithRootCAs(t *testing.T) {
+	t.Run("happy path - server certificate is verified against the given CAs", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(srv.Certificate())
+
+		client := &http.Client{}
+		uut, err := WithRootCAs(injectBTPCLITransport(client), rootCAs)
+
+		if assert.NoError(t, err) {
+			res, err := uut.Get(srv.URL)
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+			}
+		}
+
+		// the original client must only trust the CAs of the system
+		_, err = client.Get(srv.URL)
+		assert.Error(t, err)
+	})
+	t.Run("happy path - client certificate and CAs can be combined", func(t *testing.T) {
+		rootCAs := x509.NewCertPool()
+
+		withRootCAs, err := WithRootCAs(&http.Client{}, rootCAs)
+		assert.NoError(t, err)
+
+		uut, err := WithClientCertificate(withRootCAs, generateTestCertificate(t))
+		if assert.NoError(t, err) {
+			tlsConfig := uut.Transport.(*http.Transport).TLSClientConfig
+			assert.Len(t, tlsConfig.Certificates, 1)
+			assert.Same(t, rootCAs, tlsConfig.RootCAs)
+		}
+
+		// the client with the CAs must not present the certificate
+		assert.Empty(t, withRootCAs.Transport.(*http.Transport).TLSClientConfig.Certificates)
+	})
+	t.Run("error path - custom transport", func(t *testing.T) {
+		_, err := WithRootCAs(&http.Client{Transport: &btpcliTransport{transport: &btpcliTransport{}}}, x509.NewCertPool())
+
+		assert.ErrorIs(t, err, ErrCustomTransport)
+	})
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	t.Run("happy path - any server certificate is accepted", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		client := &http.Client{}
+		uut, err := WithInsecureSkipVerify(injectBTPCLITransport(client))
+
+		if assert.NoError(t, err) {
+			res, err := uut.Get(srv.URL)
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+			}
+		}
+
+		// the original client must still verify the certificate
+		_, err = client.Get(srv.URL)
+		assert.Error(t, err)
+	})
+	t.Run("error path - custom transport", func(t *testing.T) {
+		_, err := WithInsecureSkipVerify(&http.Client{Transport: &btpcliTransport{transport: &btpcliTransport{}}})
+
+		assert.ErrorIs(t, err, ErrCustomTransport)
+	})
+}
+
+func TestWithProxy(t *testing.T) {
+	t.Run("happy path - requests are sent through the proxy with basic auth", func(t *testing.T) {
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// proxied requests carry the absolute URL of the target
+			assert.Equal(t, "http://cpcli.example.com/login/v2.38.0", r.URL.String())
+			assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("proxy-user:proxy-pass")), r.Header.Get("Proxy-Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer proxy.Close()
+
+		proxyURL, _ := url.Parse(proxy.URL)
+		proxyURL.User = url.UserPassword("proxy-user", "proxy-pass")
+
+		client := &http.Client{}
+		uut, err := WithProxy(injectBTPCLITransport(client), proxyURL)
+
+		if assert.NoError(t, err) {
+			res, err := uut.Get("http://cpcli.example.com/login/v2.38.0")
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+			}
+		}
+
+		// the original client must keep the default transport, which only uses proxies from the environment
+		assert.Same(t, http.DefaultTransport, client.Transport.(*btpcliTransport).transport)
+	})
+	t.Run("happy path - client certificate and proxy can be combined", func(t *testing.T) {
+		proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+
+		withProxy, err := WithProxy(&http.Client{}, proxyURL)
+		assert.NoError(t, err)
+
+		uut, err := WithClientCertificate(withProxy, generateTestCertificate(t))
+		if assert.NoError(t, err) {
+			transport := uut.Transport.(*http.Transport)
+			assert.Len(t, transport.TLSClientConfig.Certificates, 1)
+
+			proxy, _ := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "cpcli.example.com"}})
+			assert.Equal(t, proxyURL, proxy)
+		}
+	})
+	t.Run("error path - custom transport", func(t *testing.T) {
+		proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+
+		_, err := WithProxy(&http.Client{Transport: &btpcliTransport{transport: &btpcliTransport{}}}, proxyURL)
+
+		assert.ErrorIs(t, err, ErrCustomTransport)
+	})
+}
+
+func generateTestCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-btp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -15,6 +15,8 @@ type DataCenterResponseObject struct {
 	Domain string `json:"domain,omitempty"`
 	// The environment that the data center supports. For example: Kubernetes, Cloud Foundry.
 	Environment string `json:"environment,omitempty"`
+	// The geographic locations from where the data center can be accessed. Valid values: * <b>STANDARD:</b> The data center can be accessed from any geographic location. * <b>EU_ACCESS:</b> The data center can be accessed only within locations in the EU.
+	GeoAccess string `json:"geoAccess,omitempty"`
 	// The infrastructure provider for the data center. Valid values: * <b>AWS:</b> Amazon Web Services. * <b>GCP:</b> Google Cloud Platform. * <b>AZURE:</b> Microsoft Azure. * <b>SAP:</b> SAP BTP (Neo). * <b>ALI:</b> Alibaba Cloud. * <b>IBM:</b> IBM Cloud.
 	IaasProvider string `json:"iaasProvider,omitempty"`
 	// Technical name of the data center. Must be unique within the cloud deployment.
@@ -11,6 +11,8 @@ package cis
 type SubaccountResponseObject struct {
 	// Whether the subaccount can use beta services and applications.
 	BetaEnabled bool `json:"betaEnabled"`
+	// The beta features enabled for the subaccount.
+	BetaFeatures []string `json:"betaFeatures,omitempty"`
 	// Details of the user that created the subaccount.
 	CreatedBy string `json:"createdBy,omitempty"`
 	// The date the subaccount was created. Dates and times are in UTC format.
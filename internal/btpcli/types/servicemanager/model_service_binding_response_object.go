@@ -36,4 +36,8 @@ type ServiceBindingResponseObject struct {
 	// The last time the binding was updated.<br/> In ISO 8601 format.
 	UpdatedAt time.Time            `json:"updated_at,omitempty"`
 	Labels    ServiceManagerLabels `json:"labels,omitempty"`
+	// The last time the credentials of the binding were rotated. Only set if the binding has been rotated.<br/> In ISO 8601 format.
+	LastRotatedAt time.Time `json:"last_rotated_at,omitempty"`
+	// The times the credentials of the binding were rotated. Only provided by CLI servers supporting the rotation history.
+	RotationHistory []time.Time `json:"rotation_history,omitempty"`
 }
@@ -35,8 +35,8 @@ type ServiceInstanceResponseObject struct {
 	Context map[string]string `json:"context,omitempty"`
 	// The maintenance info for the resource.
 	MaintenanceInfo map[string]string `json:"maintenance_info,omitempty"`
-	// Wheher the resource can be used.
-	Usable bool `json:"usable,omitempty"`
+	// Wheher the resource can be used. Nil if the service plan doesn't report the usability.
+	Usable *bool `json:"usable,omitempty"`
 	// The time the resource was created. <br/>In ISO 8601 format:</br> YYYY-MM-DDThh:mm:ssTZD
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// The last time the resource was updated. <br/> In ISO 8601 format.
@@ -19,4 +19,8 @@ type ServiceOfferingMetadata struct {
 	ImageUrl string `json:"imageUrl,omitempty"`
 	// The support URL for the service offering.
 	SupportUrl string `json:"supportUrl,omitempty"`
+	// Whether the service offering is deprecated.
+	Deprecated bool `json:"deprecated,omitempty"`
+	// The date of the deprecation of the service offering, as provided by the service broker.
+	DeprecationDate string `json:"deprecationDate,omitempty"`
 }
@@ -17,4 +17,8 @@ type ServicePlanMetadata struct {
 	SupportedMinOSBVersion json.Number `json:"supportedMinOSBVersion,omitempty"`
 	// The latest supported OSB version.
 	SupportedMaxOSBVersion json.Number `json:"supportedMaxOSBVersion,omitempty"`
+	// Whether the service plan is deprecated.
+	Deprecated bool `json:"deprecated,omitempty"`
+	// The date of the deprecation of the service plan, as provided by the service broker.
+	DeprecationDate string `json:"deprecationDate,omitempty"`
 }
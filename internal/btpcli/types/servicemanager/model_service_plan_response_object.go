@@ -28,8 +28,12 @@ type ServicePlanResponseObject struct {
 	// Whether the service plan is free.
 	Free bool `json:"free,omitempty"`
 	// Whether the service plan is bindable.
-	Bindable bool                 `json:"bindable,omitempty"`
+	Bindable bool `json:"bindable,omitempty"`
+	// Whether only one service instance of the service plan can be created per subaccount.
+	Unique   bool                 `json:"unique,omitempty"`
 	Metadata *ServicePlanMetadata `json:"metadata,omitempty"`
+	// The schemas of the parameters accepted by the service plan.
+	Schemas *ServicePlanSchemas `json:"schemas,omitempty"`
 	// The ID of the service offering.
 	ServiceOfferingId string `json:"service_offering_id,omitempty"`
 	// The time the service plan was created.<br> In ISO 8601 format:</br> YYYY-MM-DDThh:mm:ssTZD
@@ -10,4 +10,7 @@ const (
 	StateInProgress string = "in progress"
 	StateFailed     string = "failed"
 	StateSucceeded  string = "succeeded"
+	// StateNotUsable is no state of the API, but denotes a service instance whose last operation succeeded, but which
+	// can't be used yet.
+	StateNotUsable string = "not usable"
 )
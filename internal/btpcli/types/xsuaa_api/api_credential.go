@@ -0,0 +1,18 @@
+package xsuaa_api
+
+type ApiCredential struct {
+	// The name of the API credential.
+	Name string `json:"name,omitempty"`
+	// The client ID of the API credential.
+	ClientId string `json:"clientid,omitempty"`
+	// The client secret of the API credential. Only returned on creation, and only if no certificate is used.
+	ClientSecret string `json:"clientsecret,omitempty"`
+	// The certificate the client authenticates with, if any.
+	Certificate string `json:"certificate,omitempty"`
+	// The URL of the token endpoint to request access tokens from.
+	TokenUrl string `json:"tokenurl,omitempty"`
+	// The URL of the API the credential grants access to.
+	ApiUrl string `json:"apiurl,omitempty"`
+	// Whether the API credential only grants read access.
+	ReadOnly bool `json:"read-only,omitempty"`
+}
@@ -0,0 +1,23 @@
+package xsuaa_settings
+
+type TenantSettingsResp struct {
+	// The origin key of the identity provider that is used by default for the login screen.
+	DefaultIdp string `json:"defaultIdp,omitempty"`
+	// The domains which are allowed to embed the login screen of the tenant in an iframe.
+	IframeDomains string `json:"iframeDomains,omitempty"`
+	// The policy of the tokens which are issued by the tenant.
+	TokenPolicySettings TokenPolicySettings `json:"tokenPolicySettings,omitempty"`
+	// Whether users with the same email address are treated as the same user.
+	TreatUsersWithSameEmailAsSameUser bool `json:"treatUsersWithSameEmailAsSameUser,omitempty"`
+	// The custom domains which are allowed for the email addresses of users.
+	CustomEmailDomains []string `json:"customEmailDomains,omitempty"`
+}
+
+type TokenPolicySettings struct {
+	// The validity of access tokens in seconds. -1 means the default of the tenant is used.
+	AccessTokenValidity int32 `json:"accessTokenValidity,omitempty"`
+	// The validity of refresh tokens in seconds. -1 means the default of the tenant is used.
+	RefreshTokenValidity int32 `json:"refreshTokenValidity,omitempty"`
+	// Whether only one refresh token is valid at a time.
+	RefreshTokenUnique bool `json:"refreshTokenUnique,omitempty"`
+}
@@ -16,4 +16,6 @@ type TrustConfigurationResponseObject struct {
 	ReadOnly bool `json:"readOnly,omitempty"`
 	// Name of the identity provider
 	IdentityProvider string `json:"identityProvider,omitempty"`
+	// The SAML metadata document of the identity provider. Only returned if requested explicitly.
+	Metadata string `json:"metadata,omitempty"`
 }
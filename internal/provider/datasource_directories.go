@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func newDirectoriesDataSource() datasource.DataSource {
+	return &directoriesDataSource{}
+}
+
+type directoriesDataSourceConfig struct {
+	/* INPUT */
+	LabelsFilter types.String `tfsdk:"labels_filter"`
+	/* OUTPUT */
+	Id     types.String    `tfsdk:"id"`
+	Values []directoryType `tfsdk:"values"`
+}
+
+type directoriesDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *directoriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_directories", req.ProviderTypeName)
+}
+
+func (ds *directoriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *directoriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets all the directories in a global account, including the directories nested in other directories.
+
+__Tip:__
+You must be assigned to the admin or viewer role of the global account.`,
+		Attributes: map[string]schema.Attribute{
+			"labels_filter": schema.StringAttribute{
+				MarkdownDescription: "Filters the directories based on their labels. The filter consists of one or more conditions separated by `;`, which must all be met. A condition is either a label name (e.g. `my-label`) or a label name with a value (e.g. `my-label=my-value`).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the global account.",
+				Computed:            true,
+			},
+			"values": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the directory.",
+							Computed:            true,
+						},
+						"created_by": schema.StringAttribute{
+							MarkdownDescription: "The details of the user that created the directory.",
+							Computed:            true,
+						},
+						"created_date": schema.StringAttribute{
+							MarkdownDescription: "The date and time when the resource was created in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the directory.",
+							Computed:            true,
+						},
+						"features": schema.SetAttribute{
+							ElementType: types.StringType,
+							MarkdownDescription: "The features that are enabled for the directory. Possible values are: \n" +
+								getFormattedValueAsTableRow("value", "description") +
+								getFormattedValueAsTableRow("---", "---") +
+								getFormattedValueAsTableRow("`DEFAULT` ", "All directories have the following basic feature enabled:"+
+									"<br> 1. Group and filter subaccounts for reports and filters "+
+									"<br> 2. Monitor usage and costs on a directory level (costs only available for contracts that use the consumption-based commercial model)"+
+									"<br> 3. Set custom properties and tags to the directory for identification and reporting purposes.") +
+								getFormattedValueAsTableRow("`ENTITLEMENTS`", "Allows the assignment of a quota for services and applications to the directory from the global account quota for distribution to the subaccounts under this directory.") +
+								getFormattedValueAsTableRow("`AUTHORIZATIONS`", "Allows the assignment of users as administrators or viewers of this directory. You must apply this feature in combination with the `ENTITLEMENTS` feature."),
+							Computed: true,
+						},
+						"labels": schema.MapAttribute{
+							ElementType: types.SetType{
+								ElemType: types.StringType,
+							},
+							MarkdownDescription: "The set of words or phrases assigned to the directory.",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the directory.",
+							Computed:            true,
+						},
+						"parent_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the directory's parent entity. Typically this is the global account.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The current state of the directory. Possible values are: \n" +
+								getFormattedValueAsTableRow("state", "description") +
+								getFormattedValueAsTableRow("---", "---") +
+								getFormattedValueAsTableRow("`OK`", "The CRUD operation or series of operations completed successfully.") +
+								getFormattedValueAsTableRow("`STARTED`", "CRUD operation on an entity has started.") +
+								getFormattedValueAsTableRow("`CANCELLED`", "The operation or processing was canceled by the operator.") +
+								getFormattedValueAsTableRow("`PROCESSING`", "A series of operations related to the entity is in progress.") +
+								getFormattedValueAsTableRow("`PROCESSING_FAILED`", "The processing operations failed.") +
+								getFormattedValueAsTableRow("`CREATING`", "Creating entity operation is in progress.") +
+								getFormattedValueAsTableRow("`CREATION_FAILED`", "The creation operation failed, and the entity was not created or was created but cannot be used.") +
+								getFormattedValueAsTableRow("`UPDATING`", "Updating entity operation is in progress.") +
+								getFormattedValueAsTableRow("`UPDATE_FAILED`", "The update operation failed, and the entity was not updated.") +
+								getFormattedValueAsTableRow("`DELETING`", "Deleting entity operation is in progress.") +
+								getFormattedValueAsTableRow("`DELETION_FAILED`", "The delete operation failed, and the entity was not deleted.") +
+								getFormattedValueAsTableRow("`MOVING`", "Moving entity operation is in progress.") +
+								getFormattedValueAsTableRow("`MOVE_FAILED`", "Entity could not be moved to a different location.") +
+								getFormattedValueAsTableRow("`PENDING REVIEW`", "The processing operation has been stopped for reviewing and can be restarted by the operator.") +
+								getFormattedValueAsTableRow("`MIGRATING`", "Migrating entity from Neo to Cloud Foundry."),
+							Computed: true,
+						},
+						"subdomain": schema.StringAttribute{
+							MarkdownDescription: "This applies only to directories that have the user authorization management feature enabled. The subdomain is part of the path used to access the authorization tenant of the directory.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The directories contained in the global account.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *directoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data directoriesDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := ds.cli.Accounts.Directory.List(ctx, data.LabelsFilter.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Directories", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Values = []directoryType{}
+
+	for _, directoryRes := range cliRes {
+		directory, diags := directoryValueFrom(ctx, directoryRes)
+		resp.Diagnostics.Append(diags...)
+
+		data.Values = append(data.Values, directory)
+	}
+
+	data.Id = types.StringValue(ds.cli.GetGlobalAccountSubdomain())
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func newDirectoriesTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{
+	"guid": "03760ecf-9d89-4189-a92a-1c7efed09298",
+	"displayName": "a-global-account",
+	"children": [
+		{
+			"guid": "05368777-4934-41e8-9f3c-6ec5f4d564b9",
+			"displayName": "directory-dev",
+			"parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298",
+			"entityState": "OK",
+			"labels": {"env": ["dev"]},
+			"children": [
+				{
+					"guid": "5357bda0-8651-4eab-a69d-12d282bc3247",
+					"displayName": "directory-dev-team",
+					"parentGUID": "05368777-4934-41e8-9f3c-6ec5f4d564b9",
+					"entityState": "OK",
+					"labels": {"env": ["dev"], "team": ["a-team"]}
+				}
+			]
+		},
+		{
+			"guid": "8cc1b5c2-0f7e-4cdb-9f8c-7e6b1d6c11a2",
+			"displayName": "directory-prod",
+			"parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298",
+			"entityState": "OK",
+			"labels": {"env": ["prod"]}
+		}
+	]
+}`)
+	}))
+}
+
+func TestDataSourceDirectories(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - all directories", func(t *testing.T) {
+		srv := newDirectoriesTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectories("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.#", "3"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.0.name", "directory-dev"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.1.name", "directory-dev-team"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.1.parent_id", "05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.2.name", "directory-prod"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - filtered by labels", func(t *testing.T) {
+		srv := newDirectoriesTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectoriesWithLabelsFilter("uut", "env=dev"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.0.name", "directory-dev"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.1.name", "directory-dev-team"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectoriesWithLabelsFilter("uut", "env=dev;team"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_directories.uut", "values.0.id", "5357bda0-8651-4eab-a69d-12d282bc3247"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectories("uut"),
+					ExpectError: regexp.MustCompile(`Received response with unexpected status \[Status: 404; Correlation ID:\s+[a-f0-9\-]+\]`),
+				},
+			},
+		})
+	})
+}
+
+func hclDatasourceDirectories(resourceName string) string {
+	template := `data "btp_directories" "%s" {}`
+
+	return fmt.Sprintf(template, resourceName)
+}
+
+func hclDatasourceDirectoriesWithLabelsFilter(resourceName string, labelsFilter string) string {
+	template := `data "btp_directories" "%s" { labels_filter = "%s" }`
+
+	return fmt.Sprintf(template, resourceName, labelsFilter)
+}
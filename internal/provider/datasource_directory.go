@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
@@ -17,6 +18,22 @@ func newDirectoryDataSource() datasource.DataSource {
 	return &directoryDataSource{}
 }
 
+type directoryDataSourceType struct {
+	ID                   types.String `tfsdk:"id"`
+	CreatedBy            types.String `tfsdk:"created_by"`
+	CreatedDate          types.String `tfsdk:"created_date"`
+	Description          types.String `tfsdk:"description"`
+	Features             types.Set    `tfsdk:"features"`
+	FetchSubaccountCount types.Bool   `tfsdk:"fetch_subaccount_count"`
+	Labels               types.Map    `tfsdk:"labels"`
+	LastModified         types.String `tfsdk:"last_modified"`
+	Name                 types.String `tfsdk:"name"`
+	ParentID             types.String `tfsdk:"parent_id"`
+	State                types.String `tfsdk:"state"`
+	SubaccountCount      types.Int64  `tfsdk:"subaccount_count"`
+	Subdomain            types.String `tfsdk:"subdomain"`
+}
+
 type directoryDataSource struct {
 	cli *btpcli.ClientFacade
 }
@@ -75,6 +92,10 @@ __Further documentation:__
 					getFormattedValueAsTableRow("`AUTHORIZATIONS`", "Allows the assignment of users as administrators or viewers of this directory. You must apply this feature in combination with the `ENTITLEMENTS` feature."),
 				Computed: true,
 			},
+			"fetch_subaccount_count": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, the subaccounts of the directory are counted as well. As this requires an additional request, they are not counted by default.",
+				Optional:            true,
+			},
 			"labels": schema.MapAttribute{
 				ElementType: types.SetType{
 					ElemType: types.StringType,
@@ -115,6 +136,10 @@ __Further documentation:__
 					getFormattedValueAsTableRow("`MIGRATING`", "Migrating entity from Neo to Cloud Foundry."),
 				Computed: true,
 			},
+			"subaccount_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of subaccounts located directly in the directory. Subaccounts of nested directories are not counted. Only available if `fetch_subaccount_count` is set to `true`.",
+				Computed:            true,
+			},
 			"subdomain": schema.StringAttribute{
 				MarkdownDescription: "This applies only to directories that have the user authorization management feature enabled. The subdomain is part of the path used to access the authorization tenant of the directory.",
 				Computed:            true,
@@ -124,7 +149,7 @@ __Further documentation:__
 }
 
 func (ds *directoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data directoryType
+	var data directoryDataSourceType
 
 	diags := req.Config.Get(ctx, &data)
 
@@ -139,9 +164,45 @@ func (ds *directoryDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	data, diags = directoryValueFrom(ctx, cliRes)
+	directory, diags := directoryValueFrom(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
 
+	data.ID = directory.ID
+	data.CreatedBy = directory.CreatedBy
+	data.CreatedDate = directory.CreatedDate
+	data.Description = directory.Description
+	data.Features = directory.Features
+	data.Labels = directory.Labels
+	data.LastModified = directory.LastModified
+	data.Name = directory.Name
+	data.ParentID = directory.ParentID
+	data.State = directory.State
+	data.Subdomain = directory.Subdomain
+	data.SubaccountCount = types.Int64Null()
+
+	if data.FetchSubaccountCount.ValueBool() {
+		subaccounts, _, err := ds.cli.Accounts.Subaccount.List(ctx, "")
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Directory", fmt.Sprintf("%s", err))
+			return
+		}
+
+		data.SubaccountCount = types.Int64Value(countSubaccountsInDirectory(subaccounts.Value, directory.ID.ValueString()))
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// countSubaccountsInDirectory returns the number of subaccounts whose parent is the given directory.
+func countSubaccountsInDirectory(subaccounts []cis.SubaccountResponseObject, directoryId string) int64 {
+	var count int64
+
+	for _, subaccount := range subaccounts {
+		if subaccount.ParentGUID == directoryId {
+			count++
+		}
+	}
+
+	return count
+}
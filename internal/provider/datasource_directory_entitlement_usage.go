@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+// directoryEntitlementUsageMaxConcurrency limits the number of parallel requests sent to the CLI server.
+const directoryEntitlementUsageMaxConcurrency = 5
+
+func newDirectoryEntitlementUsageDataSource() datasource.DataSource {
+	return &directoryEntitlementUsageDataSource{}
+}
+
+type directoryEntitlementUsageValue struct {
+	ServiceName    types.String  `tfsdk:"service_name"`
+	PlanName       types.String  `tfsdk:"plan_name"`
+	QuotaAssigned  types.Float64 `tfsdk:"quota_assigned"`
+	QuotaRemaining types.Float64 `tfsdk:"quota_remaining"`
+	Unlimited      types.Bool    `tfsdk:"unlimited"`
+	SubaccountIds  types.Set     `tfsdk:"subaccount_ids"`
+}
+
+type directoryEntitlementUsageDataSourceConfig struct {
+	/* INPUT */
+	DirectoryId types.String `tfsdk:"directory_id"`
+	/* OUTPUT */
+	Id     types.String                     `tfsdk:"id"`
+	Values []directoryEntitlementUsageValue `tfsdk:"values"`
+}
+
+type directoryEntitlementUsageDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *directoryEntitlementUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_directory_entitlement_usage", req.ProviderTypeName)
+}
+
+func (ds *directoryEntitlementUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *directoryEntitlementUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the usage of the entitlements by the subaccounts of a directory, including the subaccounts in its subdirectories. The quota assigned to the subaccounts is summed up per service plan.
+
+__Tip:__
+You must be assigned to the admin or viewer role of the global account, directory, or of each subaccount in the directory.`,
+		Attributes: map[string]schema.Attribute{
+			"directory_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the directory.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the directory.",
+				Computed:            true,
+			},
+			"values": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the entitled service.",
+							Computed:            true,
+						},
+						"plan_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the entitled service plan.",
+							Computed:            true,
+						},
+						"quota_assigned": schema.Float64Attribute{
+							MarkdownDescription: "The quota of the service plan assigned to the subaccounts in total.",
+							Computed:            true,
+						},
+						"quota_remaining": schema.Float64Attribute{
+							MarkdownDescription: "The quota of the service plan which is not assigned yet, summed up over the entities the subaccounts get their quota from, i.e. the directories managing entitlements or the global account.",
+							Computed:            true,
+						},
+						"unlimited": schema.BoolAttribute{
+							MarkdownDescription: "Shows whether an unlimited amount of the service plan is assigned to any of the subaccounts.",
+							Computed:            true,
+						},
+						"subaccount_ids": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The IDs of the subaccounts to which the service plan is assigned.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The usage of the entitlements, ordered by the names of the service and the plan.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *directoryEntitlementUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data directoryEntitlementUsageDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	directory, _, err := ds.cli.Accounts.Directory.GetWithHierarchy(ctx, data.DirectoryId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Entitlement Usage (Directory)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	_, subaccounts := flattenGlobalaccountHierarchy(directory.Children, directory.Subaccounts)
+
+	var subaccountIds []string
+	for _, subaccount := range subaccounts {
+		subaccountIds = append(subaccountIds, subaccount.Guid)
+	}
+
+	// the requests are sent in a stable order, so that errors are reported deterministically
+	sort.Strings(subaccountIds)
+
+	entitlements, err := ds.cli.Accounts.Entitlement.ListBySubaccounts(ctx, subaccountIds, directoryEntitlementUsageMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Entitlement Usage (Directory)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = data.DirectoryId
+	data.Values, diags = directoryEntitlementUsageValuesFrom(ctx, subaccountIds, entitlements)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// directoryEntitlementUsageValuesFrom sums up the assignments of the service plans to the given subaccounts. The
+// remaining quota is reported once per parent entity, as all subaccounts of a parent draw from the same quota.
+func directoryEntitlementUsageValuesFrom(ctx context.Context, subaccountIds []string, entitlementsBySubaccount map[string]cis_entitlements.EntitledAndAssignedServicesResponseObject) ([]directoryEntitlementUsageValue, diag.Diagnostics) {
+	type planKey struct {
+		serviceName string
+		planName    string
+	}
+
+	type planUsage struct {
+		assigned      float64
+		unlimited     bool
+		remaining     map[string]float64
+		subaccountIds []string
+	}
+
+	usages := map[planKey]*planUsage{}
+
+	for _, subaccountId := range subaccountIds {
+		for _, service := range entitlementsBySubaccount[subaccountId].AssignedServices {
+			for _, plan := range service.ServicePlans {
+				for _, assignment := range plan.AssignmentInfo {
+					if assignment.EntityType != "SUBACCOUNT" || assignment.EntityId != subaccountId {
+						continue
+					}
+
+					key := planKey{serviceName: service.Name, planName: plan.Name}
+
+					usage, ok := usages[key]
+					if !ok {
+						usage = &planUsage{remaining: map[string]float64{}}
+						usages[key] = usage
+					}
+
+					usage.assigned += assignment.Amount
+					usage.unlimited = usage.unlimited || assignment.UnlimitedAmountAssigned
+					usage.remaining[assignment.ParentId] = assignment.ParentRemainingAmount
+					usage.subaccountIds = append(usage.subaccountIds, subaccountId)
+				}
+			}
+		}
+	}
+
+	keys := make([]planKey, 0, len(usages))
+	for key := range usages {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].serviceName != keys[j].serviceName {
+			return keys[i].serviceName < keys[j].serviceName
+		}
+		return keys[i].planName < keys[j].planName
+	})
+
+	var diags diag.Diagnostics
+	values := []directoryEntitlementUsageValue{}
+
+	for _, key := range keys {
+		usage := usages[key]
+
+		var remaining float64
+		for _, parentRemaining := range usage.remaining {
+			remaining += parentRemaining
+		}
+
+		value := directoryEntitlementUsageValue{
+			ServiceName:    types.StringValue(key.serviceName),
+			PlanName:       types.StringValue(key.planName),
+			QuotaAssigned:  types.Float64Value(usage.assigned),
+			QuotaRemaining: types.Float64Value(remaining),
+			Unlimited:      types.BoolValue(usage.unlimited),
+		}
+
+		var setDiags diag.Diagnostics
+		value.SubaccountIds, setDiags = types.SetValueFrom(ctx, types.StringType, usage.subaccountIds)
+		diags.Append(setDiags...)
+
+		values = append(values, value)
+	}
+
+	return values, diags
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
+)
+
+func TestDataSourceDirectoryEntitlementUsage(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := newDirectoryEntitlementUsageTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectoryEntitlementUsage("uut", "05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "id", "05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.0.service_name", "alert-notification"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.0.plan_name", "standard"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.0.quota_assigned", "1"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.0.subaccount_ids.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.1.service_name", "hana-cloud"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.1.plan_name", "hana"),
+						// the subaccount in the subdirectory counts as well
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.1.quota_assigned", "5"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.1.quota_remaining", "3"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.1.unlimited", "false"),
+						resource.TestCheckResourceAttr("data.btp_directory_entitlement_usage.uut", "values.1.subaccount_ids.#", "2"),
+						resource.TestCheckTypeSetElemAttr("data.btp_directory_entitlement_usage.uut", "values.1.subaccount_ids.*", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+						resource.TestCheckTypeSetElemAttr("data.btp_directory_entitlement_usage.uut", "values.1.subaccount_ids.*", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - directory_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceDirectoryEntitlementUsage("uut", "this-is-not-a-uuid"),
+					ExpectError: regexp.MustCompile(`Attribute directory_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+func TestDirectoryEntitlementUsageValuesFrom(t *testing.T) {
+	assignment := func(subaccountId string, amount float64, parentId string, parentRemaining float64) cis_entitlements.AssignedServicePlanSubaccountDto {
+		return cis_entitlements.AssignedServicePlanSubaccountDto{
+			EntityType:            "SUBACCOUNT",
+			EntityId:              subaccountId,
+			Amount:                amount,
+			ParentId:              parentId,
+			ParentRemainingAmount: parentRemaining,
+		}
+	}
+
+	entitlements := func(assignments ...cis_entitlements.AssignedServicePlanSubaccountDto) cis_entitlements.EntitledAndAssignedServicesResponseObject {
+		return cis_entitlements.EntitledAndAssignedServicesResponseObject{
+			AssignedServices: []cis_entitlements.AssignedServiceResponseObject{
+				{
+					Name: "hana-cloud",
+					ServicePlans: []cis_entitlements.AssignedServicePlanResponseObject{
+						{Name: "hana", AssignmentInfo: assignments},
+					},
+				},
+			},
+		}
+	}
+
+	values, diags := directoryEntitlementUsageValuesFrom(context.TODO(), []string{"subaccount-1", "subaccount-2", "subaccount-3"}, map[string]cis_entitlements.EntitledAndAssignedServicesResponseObject{
+		"subaccount-1": entitlements(assignment("subaccount-1", 2, "directory-1", 4)),
+		// assignments to other entities are ignored
+		"subaccount-2": entitlements(assignment("subaccount-2", 1, "directory-1", 4), assignment("subaccount-4", 8, "directory-1", 4)),
+		"subaccount-3": entitlements(assignment("subaccount-3", 3, "directory-2", 1)),
+	})
+
+	if assert.False(t, diags.HasError()) && assert.Len(t, values, 1) {
+		assert.Equal(t, 6.0, values[0].QuotaAssigned.ValueFloat64())
+		// the remaining quota of each parent is counted once
+		assert.Equal(t, 5.0, values[0].QuotaRemaining.ValueFloat64())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("subaccount-1"), types.StringValue("subaccount-2"), types.StringValue("subaccount-3")}), values[0].SubaccountIds)
+	}
+
+	values, diags = directoryEntitlementUsageValuesFrom(context.TODO(), nil, nil)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, values)
+}
+
+// newDirectoryEntitlementUsageTestServer returns a CLI server for a directory with one subaccount and a subdirectory,
+// which contains another subaccount. Both subaccounts have quota of hana-cloud assigned, which they draw from the
+// directory.
+func newDirectoryEntitlementUsageTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/directory") && r.URL.RawQuery == "get":
+			fmt.Fprintf(w, `{
+				"guid": "05368777-4934-41e8-9f3c-6ec5f4d564b9",
+				"subaccounts": [{"guid": "ef23ace8-6ade-4d78-9c1f-8df729548bbf"}],
+				"children": [{"guid": "03760ecf-9d89-4189-a92a-1c7efed09298", "subaccounts": [{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}]}]
+			}`)
+		case strings.HasSuffix(r.URL.Path, "/accounts/entitlement") && r.URL.RawQuery == "list":
+			subaccountId := payload.ParamValues["subaccountFilter"]
+
+			amount := 2
+			alertNotification := ""
+			if subaccountId == "ef23ace8-6ade-4d78-9c1f-8df729548bbf" {
+				amount = 3
+				alertNotification = fmt.Sprintf(`, {"name": "alert-notification", "servicePlans": [{"name": "standard", "assignmentInfo": [{"entityType": "SUBACCOUNT", "entityId": "%s", "amount": 1, "parentId": "05368777-4934-41e8-9f3c-6ec5f4d564b9"}]}]}`, subaccountId)
+			}
+
+			fmt.Fprintf(w, `{"assignedServices": [{"name": "hana-cloud", "servicePlans": [{"name": "hana", "assignmentInfo": [{"entityType": "SUBACCOUNT", "entityId": "%s", "amount": %d, "parentId": "05368777-4934-41e8-9f3c-6ec5f4d564b9", "parentRemainingAmount": 3}]}]}%s]}`, subaccountId, amount, alertNotification)
+		default:
+			t.Errorf("unexpected request: %s?%s", r.URL.Path, r.URL.RawQuery)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func hclDatasourceDirectoryEntitlementUsage(resourceName string, directoryId string) string {
+	template := `
+data "btp_directory_entitlement_usage" "%s" {
+    directory_id = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, directoryId)
+}
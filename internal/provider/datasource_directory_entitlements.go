@@ -92,6 +92,14 @@ To view all the resources that a directory and its subdirectories and subaccount
 							MarkdownDescription: "The quota, which is not used.",
 							Computed:            true,
 						},
+						"auto_assign": schema.BoolAttribute{
+							MarkdownDescription: "Shows whether the plan is automatically assigned to subaccounts which are created in the directory of the entitlement.",
+							Computed:            true,
+						},
+						"auto_distribute_amount": schema.Int64Attribute{
+							MarkdownDescription: "The quota which is automatically assigned to subaccounts which are created in the directory of the entitlement.",
+							Computed:            true,
+						},
 						"category": schema.StringAttribute{
 							MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
 								getFormattedValueAsTableRow("value", "description") +
@@ -134,14 +142,16 @@ func (ds *directoryEntitlementsDataSource) Read(ctx context.Context, req datasou
 	for _, service := range cliRes.EntitledServices {
 		for _, servicePlan := range service.ServicePlans {
 			values[fmt.Sprintf("%s:%s", service.Name, servicePlan.Name)] = entitledService{
-				ServiceName:        types.StringValue(service.Name),
-				ServiceDisplayName: types.StringValue(service.DisplayName),
-				PlanName:           types.StringValue(servicePlan.Name),
-				PlanDisplayName:    types.StringValue(servicePlan.DisplayName),
-				PlanDescription:    types.StringValue(servicePlan.Description),
-				QuotaAssigned:      types.Float64Value(servicePlan.Amount),
-				QuotaRemaining:     types.Float64Value(servicePlan.RemainingAmount),
-				Category:           types.StringValue(servicePlan.Category),
+				ServiceName:          types.StringValue(service.Name),
+				ServiceDisplayName:   types.StringValue(service.DisplayName),
+				PlanName:             types.StringValue(servicePlan.Name),
+				PlanDisplayName:      types.StringValue(servicePlan.DisplayName),
+				PlanDescription:      types.StringValue(servicePlan.Description),
+				QuotaAssigned:        types.Float64Value(servicePlan.Amount),
+				QuotaRemaining:       types.Float64Value(servicePlan.RemainingAmount),
+				Category:             types.StringValue(servicePlan.Category),
+				AutoAssign:           types.BoolValue(servicePlan.AutoAssign),
+				AutoDistributeAmount: types.Int64Value(int64(servicePlan.AutoDistributeAmount)),
 			}
 		}
 	}
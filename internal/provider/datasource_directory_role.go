@@ -18,6 +18,21 @@ func newDirectoryRoleDataSource() datasource.DataSource {
 	return &directoryRoleDataSource{}
 }
 
+type directoryRoleDataSourceType struct {
+	/* INPUT */
+	DirectoryId          types.String `tfsdk:"directory_id"`
+	Id                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	RoleTemplateAppId    types.String `tfsdk:"app_id"`
+	RoleTemplateName     types.String `tfsdk:"role_template_name"`
+	FetchRoleCollections types.Bool   `tfsdk:"fetch_role_collections"`
+	/* OUTPUT */
+	Description     types.String         `tfsdk:"description"`
+	IsReadOnly      types.Bool           `tfsdk:"read_only"`
+	RoleCollections types.List           `tfsdk:"role_collections"`
+	Scopes          []directoryRoleScope `tfsdk:"scopes"`
+}
+
 type directoryRoleDataSource struct {
 	cli *btpcli.ClientFacade
 }
@@ -71,6 +86,15 @@ func (ds *directoryRoleDataSource) Schema(_ context.Context, _ datasource.Schema
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"fetch_role_collections": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, the role collections that contain the role are fetched as well. As this requires an additional request, they are not fetched by default.",
+				Optional:            true,
+			},
+			"role_collections": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the role collections that contain the role, sorted by name. Only available if `fetch_role_collections` is set to `true`.",
+				Computed:            true,
+			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "The description of the role.",
 				Computed:            true,
@@ -116,7 +140,7 @@ func (ds *directoryRoleDataSource) Schema(_ context.Context, _ datasource.Schema
 }
 
 func (ds *directoryRoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data directoryRoleType
+	var data directoryRoleDataSourceType
 
 	diags := req.Config.Get(ctx, &data)
 
@@ -131,12 +155,33 @@ func (ds *directoryRoleDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	state, diags := directoryRoleFromValue(ctx, cliRes)
-	state.DirectoryId = data.DirectoryId
-	state.Id = data.DirectoryId
-
+	role, diags := directoryRoleFromValue(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
 
+	state := directoryRoleDataSourceType{
+		DirectoryId:          data.DirectoryId,
+		Id:                   data.DirectoryId,
+		Name:                 role.Name,
+		RoleTemplateAppId:    role.RoleTemplateAppId,
+		RoleTemplateName:     role.RoleTemplateName,
+		FetchRoleCollections: data.FetchRoleCollections,
+		Description:          role.Description,
+		IsReadOnly:           role.IsReadOnly,
+		RoleCollections:      types.ListNull(types.StringType),
+		Scopes:               role.Scopes,
+	}
+
+	if data.FetchRoleCollections.ValueBool() {
+		roleCollections, _, err := ds.cli.Security.RoleCollection.ListByDirectory(ctx, data.DirectoryId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Role (Directory)", fmt.Sprintf("%s", err))
+			return
+		}
+
+		state.RoleCollections, diags = roleCollectionsContainingRole(ctx, roleCollections, data.Name.ValueString(), data.RoleTemplateAppId.ValueString(), data.RoleTemplateName.ValueString())
+		resp.Diagnostics.Append(diags...)
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -51,6 +51,25 @@ func TestDataSourceDirectoryRole(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - with role collections", func(t *testing.T) {
+		srv := newRoleCollectionsTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectoryRoleWithRoleCollections("uut", "05368777-4934-41e8-9f3c-6ec5f4d564b9", "Subaccount Viewer", "Subaccount_Viewer", "cis-local!b2"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_directory_role.uut", "role_collections.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_directory_role.uut", "role_collections.0", "Auditors"),
+						resource.TestCheckResourceAttr("data.btp_directory_role.uut", "role_collections.1", "Subaccount Viewer"),
+					),
+				},
+			},
+		})
+	})
 	t.Run("error path - directory_id, name, role_template_name and app_id are mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -145,3 +164,16 @@ data "btp_directory_role" "%s" {
 
 	return fmt.Sprintf(template, resourceName, directoryId, name, roleTemplateName, appId)
 }
+
+func hclDatasourceDirectoryRoleWithRoleCollections(resourceName string, directoryId string, name string, roleTemplateName string, appId string) string {
+	template := `
+data "btp_directory_role" "%s" {
+    directory_id           = "%s"
+    name                   = "%s"
+    role_template_name     = "%s"
+    app_id                 = "%s"
+    fetch_role_collections = true
+}`
+
+	return fmt.Sprintf(template, resourceName, directoryId, name, roleTemplateName, appId)
+}
@@ -34,6 +34,7 @@ func TestDataSourceDirectory(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_directory.uut", "parent_id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
 						resource.TestCheckResourceAttr("data.btp_directory.uut", "state", "OK"),
 						resource.TestCheckResourceAttr("data.btp_directory.uut", "subdomain", ""),
+						resource.TestCheckNoResourceAttr("data.btp_directory.uut", "subaccount_count"),
 					),
 				},
 				{ // security enabled directory
@@ -54,6 +55,44 @@ func TestDataSourceDirectory(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - subaccount count", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/accounts/directory"):
+				fmt.Fprint(w, `{"guid": "5357bda0-8651-4eab-a69d-12d282bc3247", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "displayName": "integration-test-dir-static", "entityState": "OK"}`)
+			case strings.HasSuffix(r.URL.Path, "/accounts/subaccount"):
+				fmt.Fprint(w, `{"value": [
+					{"guid": "77395ee8-6909-4d8b-a9c4-0ad0b9ad4a75", "parentGUID": "5357bda0-8651-4eab-a69d-12d282bc3247"},
+					{"guid": "b9955d3c-5a5d-4d5a-a5a4-3a8f1b4126c6", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298"},
+					{"guid": "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "parentGUID": "5357bda0-8651-4eab-a69d-12d282bc3247"}
+				]}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceDirectoryWithSubaccountCount("uut", "5357bda0-8651-4eab-a69d-12d282bc3247"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_directory.uut", "name", "integration-test-dir-static"),
+						resource.TestCheckResourceAttr("data.btp_directory.uut", "subaccount_count", "2"),
+					),
+				},
+			},
+		})
+	})
 	t.Run("error path - id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -104,3 +143,11 @@ func TestDataSourceDirectory(t *testing.T) {
 func hclDatasourceDirectory(resourceName string, id string) string {
 	return fmt.Sprintf(`data "btp_directory" "%s" { id = "%s" }`, resourceName, id)
 }
+
+func hclDatasourceDirectoryWithSubaccountCount(resourceName string, id string) string {
+	return fmt.Sprintf(`
+data "btp_directory" "%s" {
+    id                     = "%s"
+    fetch_subaccount_count = true
+}`, resourceName, id)
+}
@@ -61,7 +61,7 @@ func (ds *directoryUserDataSource) Schema(_ context.Context, _ datasource.Schema
 				},
 			},
 			"origin": schema.StringAttribute{
-				MarkdownDescription: "The identity provider that hosts the user. The default value is 'ldap'",
+				MarkdownDescription: "The identity provider that hosts the user. The default is the `default_origin` of the provider, or `ldap` if not set.",
 				Computed:            true,
 				Optional:            true,
 				Validators: []validator.String{
@@ -119,7 +119,7 @@ func (ds *directoryUserDataSource) Read(ctx context.Context, req datasource.Read
 	}
 
 	if data.Origin.IsNull() {
-		data.Origin = types.StringValue("ldap")
+		data.Origin = defaultOrigin(ds.cli)
 	}
 
 	cliRes, _, err := ds.cli.Security.User.GetByDirectory(ctx, data.DirectoryId.ValueString(), data.UserName.ValueString(), data.Origin.ValueString())
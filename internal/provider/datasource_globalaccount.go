@@ -34,6 +34,7 @@ type globalaccountDataSourceConfig struct {
 	LastModified     types.String `tfsdk:"last_modified"`
 	State            types.String `tfsdk:"state"`
 	Origin           types.String `tfsdk:"origin"`
+	ParentId         types.String `tfsdk:"parent_id"`
 	RenewalDate      types.String `tfsdk:"renewal_date"`
 	ServiceId        types.String `tfsdk:"service_id"`
 	Subdomain        types.String `tfsdk:"subdomain"`
@@ -145,12 +146,16 @@ __Further documentation:__
 					getFormattedValueAsTableRow("`REGION_SETUP`", "Created automatically as part of the region setup."),
 				Computed: true,
 			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "The GUID of the global account's parent entity, if the global account is part of a hierarchy of global accounts.",
+				Computed:            true,
+			},
 			"service_id": schema.StringAttribute{
 				MarkdownDescription: "For internal accounts, the service for which the global account was created.",
 				Computed:            true,
 			},
 			"subdomain": schema.StringAttribute{
-				MarkdownDescription: "The subdomain is part of the path used to access the authorization tenant of the global account.",
+				MarkdownDescription: "The subdomain is part of the path used to access the authorization tenant of the global account. It is the technical identifier used in the `globalaccount` attribute of the provider configuration.",
 				Computed:            true,
 			},
 			"usage": schema.StringAttribute{
@@ -239,6 +244,7 @@ func (ds *globalaccountDataSource) Read(ctx context.Context, req datasource.Read
 	data.LastModified = timeToValue(cliRes.ModifiedDate.Time())
 	data.State = types.StringValue(cliRes.EntityState)
 	data.Origin = types.StringValue(cliRes.Origin)
+	data.ParentId = stringNullIfEmpty(cliRes.ParentGUID)
 	data.RenewalDate = timeToValue(cliRes.RenewalDate.Time())
 
 	data.ServiceId = stringNullIfEmpty(cliRes.ServiceId)
@@ -17,26 +17,30 @@ func newGlobalaccountEntitlementsDataSource() datasource.DataSource {
 }
 
 type entitledService struct {
-	ServiceName        types.String  `tfsdk:"service_name"`
-	ServiceDisplayName types.String  `tfsdk:"service_display_name"`
-	PlanName           types.String  `tfsdk:"plan_name"`
-	PlanDisplayName    types.String  `tfsdk:"plan_display_name"`
-	PlanDescription    types.String  `tfsdk:"plan_description"`
-	QuotaAssigned      types.Float64 `tfsdk:"quota_assigned"`
-	QuotaRemaining     types.Float64 `tfsdk:"quota_remaining"`
-	Category           types.String  `tfsdk:"category"`
+	ServiceName          types.String  `tfsdk:"service_name"`
+	ServiceDisplayName   types.String  `tfsdk:"service_display_name"`
+	PlanName             types.String  `tfsdk:"plan_name"`
+	PlanDisplayName      types.String  `tfsdk:"plan_display_name"`
+	PlanDescription      types.String  `tfsdk:"plan_description"`
+	QuotaAssigned        types.Float64 `tfsdk:"quota_assigned"`
+	QuotaRemaining       types.Float64 `tfsdk:"quota_remaining"`
+	Category             types.String  `tfsdk:"category"`
+	AutoAssign           types.Bool    `tfsdk:"auto_assign"`
+	AutoDistributeAmount types.Int64   `tfsdk:"auto_distribute_amount"`
 }
 
 func entitledServiceType() map[string]attr.Type {
 	return map[string]attr.Type{
-		"service_name":         types.StringType,
-		"service_display_name": types.StringType,
-		"plan_name":            types.StringType,
-		"plan_display_name":    types.StringType,
-		"plan_description":     types.StringType,
-		"quota_assigned":       types.Float64Type,
-		"quota_remaining":      types.Float64Type,
-		"category":             types.StringType,
+		"service_name":           types.StringType,
+		"service_display_name":   types.StringType,
+		"plan_name":              types.StringType,
+		"plan_display_name":      types.StringType,
+		"plan_description":       types.StringType,
+		"quota_assigned":         types.Float64Type,
+		"quota_remaining":        types.Float64Type,
+		"category":               types.StringType,
+		"auto_assign":            types.BoolType,
+		"auto_distribute_amount": types.Int64Type,
 	}
 }
 
@@ -107,6 +111,14 @@ To view all the resources a global account:
 							MarkdownDescription: "The quota, which is not used.",
 							Computed:            true,
 						},
+						"auto_assign": schema.BoolAttribute{
+							MarkdownDescription: "Shows whether the plan is automatically assigned to subaccounts which are created in the directory of the entitlement.",
+							Computed:            true,
+						},
+						"auto_distribute_amount": schema.Int64Attribute{
+							MarkdownDescription: "The quota which is automatically assigned to subaccounts which are created in the directory of the entitlement.",
+							Computed:            true,
+						},
 						"category": schema.StringAttribute{
 							MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
 								getFormattedValueAsTableRow("value", "description") +
@@ -149,14 +161,16 @@ func (ds *globalaccountEntitlementsDataSource) Read(ctx context.Context, req dat
 	for _, service := range cliRes.EntitledServices {
 		for _, servicePlan := range service.ServicePlans {
 			values[fmt.Sprintf("%s:%s", service.Name, servicePlan.Name)] = entitledService{
-				ServiceName:        types.StringValue(service.Name),
-				ServiceDisplayName: types.StringValue(service.DisplayName),
-				PlanName:           types.StringValue(servicePlan.Name),
-				PlanDisplayName:    types.StringValue(servicePlan.DisplayName),
-				PlanDescription:    types.StringValue(servicePlan.Description),
-				QuotaAssigned:      types.Float64Value(servicePlan.Amount),
-				QuotaRemaining:     types.Float64Value(servicePlan.RemainingAmount),
-				Category:           types.StringValue(servicePlan.Category),
+				ServiceName:          types.StringValue(service.Name),
+				ServiceDisplayName:   types.StringValue(service.DisplayName),
+				PlanName:             types.StringValue(servicePlan.Name),
+				PlanDisplayName:      types.StringValue(servicePlan.DisplayName),
+				PlanDescription:      types.StringValue(servicePlan.Description),
+				QuotaAssigned:        types.Float64Value(servicePlan.Amount),
+				QuotaRemaining:       types.Float64Value(servicePlan.RemainingAmount),
+				Category:             types.StringValue(servicePlan.Category),
+				AutoAssign:           types.BoolValue(servicePlan.AutoAssign),
+				AutoDistributeAmount: types.Int64Value(int64(servicePlan.AutoDistributeAmount)),
 			}
 		}
 	}
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/saas_manager_service"
+)
+
+// globalaccountInventoryMaxConcurrency limits the number of parallel requests sent to the CLI server.
+const globalaccountInventoryMaxConcurrency = 5
+
+func newGlobalaccountInventoryDataSource() datasource.DataSource {
+	return &globalaccountInventoryDataSource{}
+}
+
+type globalaccountInventoryDataSourceConfig struct {
+	/* OUTPUT */
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Subdomain   types.String `tfsdk:"subdomain"`
+	Directories types.List   `tfsdk:"directories"`
+	Subaccounts types.List   `tfsdk:"subaccounts"`
+}
+
+type globalaccountInventoryDirectory struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	ParentId     types.String `tfsdk:"parent_id"`
+	Features     types.Set    `tfsdk:"features"`
+	Entitlements types.Map    `tfsdk:"entitlements"`
+}
+
+type globalaccountInventorySubaccount struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Subdomain     types.String `tfsdk:"subdomain"`
+	Region        types.String `tfsdk:"region"`
+	ParentId      types.String `tfsdk:"parent_id"`
+	State         types.String `tfsdk:"state"`
+	Entitlements  types.Map    `tfsdk:"entitlements"`
+	Subscriptions types.List   `tfsdk:"subscriptions"`
+}
+
+type globalaccountInventorySubscription struct {
+	AppName  types.String `tfsdk:"app_name"`
+	PlanName types.String `tfsdk:"plan_name"`
+	State    types.String `tfsdk:"state"`
+}
+
+var globalaccountInventorySubscriptionType = map[string]attr.Type{
+	"app_name":  types.StringType,
+	"plan_name": types.StringType,
+	"state":     types.StringType,
+}
+
+var globalaccountInventoryDirectoryType = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"parent_id":    types.StringType,
+	"features":     types.SetType{ElemType: types.StringType},
+	"entitlements": types.MapType{ElemType: types.ObjectType{AttrTypes: entitledServiceType()}},
+}
+
+var globalaccountInventorySubaccountType = map[string]attr.Type{
+	"id":            types.StringType,
+	"name":          types.StringType,
+	"subdomain":     types.StringType,
+	"region":        types.StringType,
+	"parent_id":     types.StringType,
+	"state":         types.StringType,
+	"entitlements":  types.MapType{ElemType: types.ObjectType{AttrTypes: entitledServiceType()}},
+	"subscriptions": types.ListType{ElemType: types.ObjectType{AttrTypes: globalaccountInventorySubscriptionType}},
+}
+
+type globalaccountInventoryDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *globalaccountInventoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_globalaccount_inventory", req.ProviderTypeName)
+}
+
+func (ds *globalaccountInventoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *globalaccountInventoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets an inventory of a global account: all its directories and subaccounts together with their entitlements and the subscriptions of the subaccounts.
+
+The directories and subaccounts are listed depth-first, the tree is given by their ` + "`parent_id`" + `.
+
+To get the inventory of a global account:
+* You must be assigned to the global account admin or global account viewer role.
+* You must be assigned to the subaccount admin or subaccount viewer role of each subaccount.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the global account.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the global account.",
+				Computed:            true,
+			},
+			"subdomain": schema.StringAttribute{
+				MarkdownDescription: "The subdomain of the global account.",
+				Computed:            true,
+			},
+			"directories": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the directory.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the directory.",
+							Computed:            true,
+						},
+						"parent_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the directory's parent entity. Typically this is the global account.",
+							Computed:            true,
+						},
+						"features": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The features that are enabled for the directory.",
+							Computed:            true,
+						},
+						"entitlements": schema.MapNestedAttribute{
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: entitledServiceAttributes(),
+							},
+							MarkdownDescription: "The entitlements of the directory, keyed by `<service_name>:<plan_name>`. Only set if the directory manages entitlements.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The directories of the global account, including all subdirectories.",
+				Computed:            true,
+			},
+			"subaccounts": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the subaccount.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the subaccount.",
+							Computed:            true,
+						},
+						"subdomain": schema.StringAttribute{
+							MarkdownDescription: "The subdomain that becomes part of the path used to access the authorization tenant of the subaccount.",
+							Computed:            true,
+						},
+						"region": schema.StringAttribute{
+							MarkdownDescription: "The region in which the subaccount was created.",
+							Computed:            true,
+						},
+						"parent_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the subaccount's parent entity, either the global account or a directory.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The current state of the subaccount.",
+							Computed:            true,
+						},
+						"entitlements": schema.MapNestedAttribute{
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: entitledServiceAttributes(),
+							},
+							MarkdownDescription: "The entitlements of the subaccount, keyed by `<service_name>:<plan_name>`.",
+							Computed:            true,
+						},
+						"subscriptions": schema.ListNestedAttribute{
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"app_name": schema.StringAttribute{
+										MarkdownDescription: "The unique registration name of the subscribed application.",
+										Computed:            true,
+									},
+									"plan_name": schema.StringAttribute{
+										MarkdownDescription: "The plan name of the subscribed application.",
+										Computed:            true,
+									},
+									"state": schema.StringAttribute{
+										MarkdownDescription: "The subscription state of the application.",
+										Computed:            true,
+									},
+								},
+							},
+							MarkdownDescription: "The applications the subaccount is subscribed to.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The subaccounts of the global account, including the ones in directories.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *globalaccountInventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data globalaccountInventoryDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	globalAccount, _, err := ds.cli.Accounts.GlobalAccount.GetWithHierarchy(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Inventory (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	directories, subaccounts := flattenGlobalaccountHierarchy(globalAccount.Children, globalAccount.Subaccounts)
+
+	var subaccountIds, entitlementDirectoryIds []string
+	for _, subaccount := range subaccounts {
+		subaccountIds = append(subaccountIds, subaccount.Guid)
+	}
+	for _, directory := range directories {
+		if directoryManagesEntitlements(directory) {
+			entitlementDirectoryIds = append(entitlementDirectoryIds, directory.Guid)
+		}
+	}
+
+	subaccountEntitlements, err := ds.cli.Accounts.Entitlement.ListBySubaccounts(ctx, subaccountIds, globalaccountInventoryMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Inventory (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	directoryEntitlements, err := ds.cli.Accounts.Entitlement.ListByDirectories(ctx, entitlementDirectoryIds, globalaccountInventoryMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Inventory (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	subscriptions, err := ds.cli.Accounts.Subscription.ListBySubaccounts(ctx, subaccountIds, globalaccountInventoryMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Inventory (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = types.StringValue(globalAccount.Guid)
+	data.Name = types.StringValue(globalAccount.DisplayName)
+	data.Subdomain = types.StringValue(globalAccount.Subdomain)
+
+	directoryValues := []globalaccountInventoryDirectory{}
+	for _, directory := range directories {
+		var value globalaccountInventoryDirectory
+		value, diags = globalaccountInventoryDirectoryFrom(ctx, directory, directoryEntitlements)
+		resp.Diagnostics.Append(diags...)
+
+		directoryValues = append(directoryValues, value)
+	}
+
+	subaccountValues := []globalaccountInventorySubaccount{}
+	for _, subaccount := range subaccounts {
+		var value globalaccountInventorySubaccount
+		value, diags = globalaccountInventorySubaccountFrom(ctx, subaccount, subaccountEntitlements[subaccount.Guid], subscriptions[subaccount.Guid])
+		resp.Diagnostics.Append(diags...)
+
+		subaccountValues = append(subaccountValues, value)
+	}
+
+	data.Directories, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: globalaccountInventoryDirectoryType}, directoryValues)
+	resp.Diagnostics.Append(diags...)
+
+	data.Subaccounts, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: globalaccountInventorySubaccountType}, subaccountValues)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenGlobalaccountHierarchy lists the given directories, their subdirectories and all subaccounts depth-first.
+func flattenGlobalaccountHierarchy(children []cis.DirectoryResponseObject, subaccounts []cis.SubaccountResponseObject) ([]cis.DirectoryResponseObject, []cis.SubaccountResponseObject) {
+	allDirectories := []cis.DirectoryResponseObject{}
+	allSubaccounts := append([]cis.SubaccountResponseObject{}, subaccounts...)
+
+	for _, child := range children {
+		allDirectories = append(allDirectories, child)
+
+		childDirectories, childSubaccounts := flattenGlobalaccountHierarchy(child.Children, child.Subaccounts)
+		allDirectories = append(allDirectories, childDirectories...)
+		allSubaccounts = append(allSubaccounts, childSubaccounts...)
+	}
+
+	return allDirectories, allSubaccounts
+}
+
+func globalaccountInventoryDirectoryFrom(ctx context.Context, directory cis.DirectoryResponseObject, entitlements map[string]cis_entitlements.EntitledAndAssignedServicesResponseObject) (value globalaccountInventoryDirectory, diags diag.Diagnostics) {
+	value = globalaccountInventoryDirectory{
+		Id:       types.StringValue(directory.Guid),
+		Name:     types.StringValue(directory.DisplayName),
+		ParentId: types.StringValue(directory.ParentGUID),
+	}
+
+	value.Features, diags = types.SetValueFrom(ctx, types.StringType, directory.DirectoryFeatures)
+	if diags.HasError() {
+		return
+	}
+
+	directoryEntitlements, ok := entitlements[directory.Guid]
+	if !ok {
+		value.Entitlements = types.MapNull(types.ObjectType{AttrTypes: entitledServiceType()})
+		return
+	}
+
+	value.Entitlements, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: entitledServiceType()}, subaccountEntitledServicesFrom(directoryEntitlements))
+	return
+}
+
+func globalaccountInventorySubaccountFrom(ctx context.Context, subaccount cis.SubaccountResponseObject, entitlements cis_entitlements.EntitledAndAssignedServicesResponseObject, applications []saas_manager_service.EntitledApplicationsResponseObject) (value globalaccountInventorySubaccount, diags diag.Diagnostics) {
+	value = globalaccountInventorySubaccount{
+		Id:        types.StringValue(subaccount.Guid),
+		Name:      types.StringValue(subaccount.DisplayName),
+		Subdomain: types.StringValue(subaccount.Subdomain),
+		Region:    types.StringValue(subaccount.Region),
+		ParentId:  types.StringValue(subaccount.ParentGUID),
+		State:     types.StringValue(subaccount.State),
+	}
+
+	value.Entitlements, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: entitledServiceType()}, subaccountEntitledServicesFrom(entitlements))
+	if diags.HasError() {
+		return
+	}
+
+	subscriptions := []globalaccountInventorySubscription{}
+	for _, application := range applications {
+		if application.State == saas_manager_service.StateNotSubscribed {
+			continue
+		}
+
+		subscriptions = append(subscriptions, globalaccountInventorySubscription{
+			AppName:  types.StringValue(application.AppName),
+			PlanName: types.StringValue(application.PlanName),
+			State:    types.StringValue(application.State),
+		})
+	}
+
+	value.Subscriptions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: globalaccountInventorySubscriptionType}, subscriptions)
+	return
+}
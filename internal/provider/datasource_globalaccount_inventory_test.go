@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceGlobalaccountInventory(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := newGlobalaccountInventoryTestServer("")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + `data "btp_globalaccount_inventory" "uut" {}`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "name", "terraform-integration-canary"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.0.id", "05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.0.parent_id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.0.entitlements.%", "1"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.0.entitlements.directory-05368777-4934-41e8-9f3c-6ec5f4d564b9:free.category", "APPLICATION"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.1.id", "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "directories.1.parent_id", "05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+						resource.TestCheckNoResourceAttr("data.btp_globalaccount_inventory.uut", "directories.1.entitlements"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.0.id", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.0.parent_id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.0.entitlements.subaccount-6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f:free.category", "APPLICATION"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.0.subscriptions.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.0.subscriptions.0.app_name", "app-6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.0.subscriptions.0.state", "SUBSCRIBED"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.1.id", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.1.parent_id", "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.1.region", "eu12"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_inventory.uut", "subaccounts.1.subscriptions.#", "1"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := newGlobalaccountInventoryTestServer("ef23ace8-6ade-4d78-9c1f-8df729548bbf")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + `data "btp_globalaccount_inventory" "uut" {}`,
+					ExpectError: regexp.MustCompile(`subscriptions of subaccount ef23ace8-6ade-4d78-9c1f-8df729548bbf`),
+				},
+			},
+		})
+	})
+}
+
+// newGlobalaccountInventoryTestServer simulates a global account with a directory managing entitlements, a
+// subdirectory and a subaccount on each level. The subscriptions of failingSubaccountId can't be listed.
+func newGlobalaccountInventoryTestServer(failingSubaccountId string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/global-account") && r.URL.RawQuery == "get":
+			fmt.Fprintf(w, `{
+	"guid": "03760ecf-9d89-4189-a92a-1c7efed09298", "displayName": "terraform-integration-canary", "subdomain": "terraform-integration-canary",
+	"subaccounts": [{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "integration-test-acc-static", "subdomain": "integration-test-acc-static", "region": "eu10", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK"}],
+	"children": [{
+		"guid": "05368777-4934-41e8-9f3c-6ec5f4d564b9", "displayName": "integration-test-dir-entitlements", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "directoryFeatures": ["DEFAULT", "ENTITLEMENTS"],
+		"children": [{
+			"guid": "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d", "displayName": "integration-test-dir-se-static", "parentGUID": "05368777-4934-41e8-9f3c-6ec5f4d564b9", "directoryFeatures": ["DEFAULT"],
+			"subaccounts": [{"guid": "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "displayName": "integration-test-services-static", "subdomain": "integration-test-services-static", "region": "eu12", "parentGUID": "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d", "state": "OK"}]
+		}]
+	}]
+}`)
+		case strings.HasSuffix(r.URL.Path, "/accounts/entitlement") && r.URL.RawQuery == "list":
+			if directoryId, ok := payload.ParamValues["directory"]; ok {
+				fmt.Fprintf(w, `{"entitledServices": [{"name": "directory-%s", "servicePlans": [{"name": "free", "category": "APPLICATION"}]}]}`, directoryId)
+				return
+			}
+
+			fmt.Fprintf(w, `{"entitledServices": [{"name": "subaccount-%s", "servicePlans": [{"name": "free", "category": "APPLICATION"}]}]}`, payload.ParamValues["subaccountFilter"])
+		case strings.HasSuffix(r.URL.Path, "/accounts/subscription") && r.URL.RawQuery == "list":
+			subaccountId := payload.ParamValues["subaccount"]
+			if subaccountId == failingSubaccountId {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			fmt.Fprintf(w, `{"applications": [{"appName": "app-%s", "planName": "default", "state": "SUBSCRIBED"}, {"appName": "other-app", "planName": "default", "state": "NOT_SUBSCRIBED"}]}`, subaccountId)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
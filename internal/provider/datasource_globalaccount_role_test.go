@@ -35,6 +35,25 @@ func TestDataSourceGlobalaccountRole(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - with role collections", func(t *testing.T) {
+		srv := newRoleCollectionsTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceGlobalaccountRoleWithRoleCollections("uut", "Subaccount Viewer", "Subaccount_Viewer", "cis-local!b2"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_globalaccount_role.uut", "role_collections.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_role.uut", "role_collections.0", "Auditors"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_role.uut", "role_collections.1", "Subaccount Viewer"),
+					),
+				},
+			},
+		})
+	})
 	t.Run("error path - name, role_template_name and app_id are mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -116,3 +135,15 @@ data "btp_globalaccount_role" "%s" {
 
 	return fmt.Sprintf(template, resourceName, name, roleTemplateName, appId)
 }
+
+func hclDatasourceGlobalaccountRoleWithRoleCollections(resourceName string, name string, roleTemplateName string, appId string) string {
+	template := `
+data "btp_globalaccount_role" "%s" {
+    name                   = "%s"
+    role_template_name     = "%s"
+    app_id                 = "%s"
+    fetch_role_collections = true
+}`
+
+	return fmt.Sprintf(template, resourceName, name, roleTemplateName, appId)
+}
@@ -33,6 +33,35 @@ func TestDataSourceGlobalAccount(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "usage", "Testing"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "commercial_model", "Subscription"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "consumption_based", "true"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "subdomain", "terraformintcanary"),
+						resource.TestCheckNoResourceAttr("data.btp_globalaccount.uut", "parent_id"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - global account with parent", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"guid": "03760ecf-9d89-4189-a92a-1c7efed09298", "displayName": "a-global-account", "subdomain": "a-global-account-subdomain", "parentGUID": "5357bda0-8651-4eab-a69d-12d282bc3247"}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceGlobalAccount("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "subdomain", "a-global-account-subdomain"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount.uut", "parent_id", "5357bda0-8651-4eab-a69d-12d282bc3247"),
 					),
 				},
 			},
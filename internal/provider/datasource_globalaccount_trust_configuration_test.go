@@ -29,6 +29,7 @@ func TestDataSourceGlobalaccountTrustConfiguration(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "identity_provider", ""),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "name", "sap.default"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "protocol", "OpenID Connect"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "read_only", "false"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "status", "active"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "type", "Application"),
@@ -53,6 +54,7 @@ func TestDataSourceGlobalaccountTrustConfiguration(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "identity_provider", "terraformint.accounts400.ondemand.com"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "name", "terraformint-platform"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "protocol", "OpenID Connect"),
+						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "read_only", "false"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "status", "active"),
 						resource.TestCheckResourceAttr("data.btp_globalaccount_trust_configuration.uut", "type", "Platform"),
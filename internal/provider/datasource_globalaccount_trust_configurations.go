@@ -82,6 +82,14 @@ __Further documentation:__
 							MarkdownDescription: "The protocol used to establish trust with the identity provider.",
 							Computed:            true,
 						},
+						"protocol_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the protocol used to establish trust with the identity provider. Possible values are: \n " +
+								getFormattedValueAsTableRow("value", "description") +
+								getFormattedValueAsTableRow("---", "---") +
+								getFormattedValueAsTableRow("`saml2`", "The trust is established with SAML 2.0.") +
+								getFormattedValueAsTableRow("`openIdConnect`", "The trust is established with OpenID Connect."),
+							Computed: true,
+						},
 						"status": schema.StringAttribute{
 							MarkdownDescription: "Shows whether the identity provider is currently active or not.",
 							Computed:            true,
@@ -52,7 +52,7 @@ func (ds *globalaccountUserDataSource) Schema(_ context.Context, _ datasource.Sc
 		MarkdownDescription: `Shows registered users in a global account. Users belong to one of the identity providers (IdPs) of the global account.`,
 		Attributes: map[string]schema.Attribute{
 			"origin": schema.StringAttribute{
-				MarkdownDescription: "The identity provider that hosts the user. The default value is 'ldap'",
+				MarkdownDescription: "The identity provider that hosts the user. The default is the `default_origin` of the provider, or `ldap` if not set.",
 				Computed:            true,
 				Optional:            true,
 				Validators: []validator.String{
@@ -110,7 +110,7 @@ func (ds *globalaccountUserDataSource) Read(ctx context.Context, req datasource.
 	}
 
 	if data.Origin.IsNull() {
-		data.Origin = types.StringValue("ldap")
+		data.Origin = defaultOrigin(ds.cli)
 	}
 
 	cliRes, _, err := ds.cli.Security.User.GetByGlobalAccount(ctx, data.UserName.ValueString(), data.Origin.ValueString())
@@ -54,7 +54,7 @@ __Further documentation:__
 				Computed:            true,
 			},
 			"origin": schema.StringAttribute{
-				MarkdownDescription: "The identity provider that hosts the user. The default value is 'ldap'.",
+				MarkdownDescription: "The identity provider that hosts the user. The default is the `default_origin` of the provider, or `ldap` if not set.",
 				Computed:            true,
 				Optional:            true,
 				Validators: []validator.String{
@@ -81,7 +81,7 @@ func (ds *globalaccountUsersDataSource) Read(ctx context.Context, req datasource
 	}
 
 	if data.Origin.IsNull() {
-		data.Origin = types.StringValue("ldap")
+		data.Origin = defaultOrigin(ds.cli)
 	}
 
 	cliRes, _, err := ds.cli.Security.User.ListByGlobalAccount(ctx, data.Origin.ValueString())
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+const defaultIdentityProvider = "sap.default"
+
+func newProviderConfigDataSource(betaFeaturesEnabled bool) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &providerConfigDataSource{betaFeaturesEnabled: betaFeaturesEnabled}
+	}
+}
+
+type providerConfigDataSourceConfig struct {
+	/* OUTPUT */
+	Id                  types.String `tfsdk:"id"`
+	CLIServerURL        types.String `tfsdk:"cli_server_url"`
+	GlobalAccount       types.String `tfsdk:"globalaccount"`
+	IdentityProvider    types.String `tfsdk:"idp"`
+	BetaFeaturesEnabled types.Bool   `tfsdk:"beta_features_enabled"`
+}
+
+type providerConfigDataSource struct {
+	cli                 *btpcli.ClientFacade
+	betaFeaturesEnabled bool
+}
+
+func (ds *providerConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_provider_config", req.ProviderTypeName)
+}
+
+func (ds *providerConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *providerConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the effective configuration of the provider, i.e. the values resolved from the provider block, the environment variables, and the defaults. This helps to debug setups that configure the provider in different ways.
+
+__Note:__
+Secrets like the password or the client secret are never exposed.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The subdomain of the global account.",
+				Computed:            true,
+			},
+			"cli_server_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the BTP CLI server the provider is connected to.",
+				Computed:            true,
+			},
+			"globalaccount": schema.StringAttribute{
+				MarkdownDescription: "The subdomain of the global account the provider is logged on to.",
+				Computed:            true,
+			},
+			"idp": schema.StringAttribute{
+				MarkdownDescription: "The identity provider used for authentication, after `idps` and the `BTP_IDP` environment variable have been taken into account.",
+				Computed:            true,
+			},
+			"beta_features_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether the beta resources and data sources of the provider are available.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *providerConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data providerConfigDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idp := ds.cli.GetIdentityProvider()
+	if len(idp) == 0 {
+		idp = defaultIdentityProvider
+	}
+
+	data.Id = types.StringValue(ds.cli.GetGlobalAccountSubdomain())
+	data.CLIServerURL = types.StringValue(ds.cli.GetServerURL())
+	data.GlobalAccount = types.StringValue(ds.cli.GetGlobalAccountSubdomain())
+	data.IdentityProvider = types.StringValue(idp)
+	data.BetaFeaturesEnabled = types.BoolValue(ds.betaFeaturesEnabled)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
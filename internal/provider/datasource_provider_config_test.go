@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceProviderConfig(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - default idp", func(t *testing.T) {
+		srv := newProviderConfigTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceProviderConfig("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "id", "terraformintcanary"),
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "cli_server_url", srv.URL),
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "globalaccount", "terraformintcanary"),
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "idp", "sap.default"),
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "beta_features_enabled", "true"),
+						resource.TestCheckNoResourceAttr("data.btp_provider_config.uut", "password"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - idp resolved from idps", func(t *testing.T) {
+		srv := newProviderConfigTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    idp            = "default-idp"
+    idps           = {
+        terraformintcanary = "canary-idp"
+    }
+}
+`, srv.URL) + hclDatasourceProviderConfig("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "globalaccount", "terraformintcanary"),
+						resource.TestCheckResourceAttr("data.btp_provider_config.uut", "idp", "canary-idp"),
+					),
+				},
+			},
+		})
+	})
+}
+
+// newProviderConfigTestServer returns a CLI server which only accepts logins.
+func newProviderConfigTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func hclDatasourceProviderConfig(resourceName string) string {
+	return fmt.Sprintf(`data "btp_provider_config" "%s" {}`, resourceName)
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
 )
 
 var regionType attr.Type = types.ObjectType{
@@ -43,7 +46,8 @@ type regionDataSourceConfig struct {
 }
 
 type regionsDataSourceConfig struct {
-	Id types.String `tfsdk:"id"`
+	Id    types.String `tfsdk:"id"`
+	Scope types.String `tfsdk:"scope"`
 	/* OUTPUT */
 	Values types.List `tfsdk:"values"`
 }
@@ -76,6 +80,17 @@ You must be assigned to the global account admin or viewer role.`,
 				MarkdownDescription: "The ID of the global account.",
 				Computed:            true,
 			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The scope of the returned regions. Possible values are: \n" +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`all`", "All regions of the region catalog (default).") +
+					getFormattedValueAsTableRow("`globalaccount`", "Only the regions in which the global account is entitled to create subaccounts, i.e. the regions in which at least one of its entitlements is available."),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("all", "globalaccount"),
+				},
+			},
 			"values": schema.ListNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -147,9 +162,25 @@ func (ds *regionsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		resp.Diagnostics.AddError("API Error Reading Resource Regions", fmt.Sprintf("%s", err))
 		return
 	}
+	var entitledRegions map[string]bool
+
+	if data.Scope.ValueString() == "globalaccount" {
+		entitlements, _, err := ds.cli.Accounts.Entitlement.ListByGlobalAccount(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Regions", fmt.Sprintf("%s", err))
+			return
+		}
+
+		entitledRegions = regionsOfEntitlements(entitlements)
+	}
+
 	regions := []regionDataSourceConfig{}
 
 	for _, regionConf := range cliRes.Datacenters {
+		if entitledRegions != nil && !entitledRegions[regionConf.Name] {
+			continue
+		}
+
 		r := regionDataSourceConfig{
 			ID:                     types.StringValue(regionConf.Name),
 			Name:                   types.StringValue(regionConf.DisplayName),
@@ -173,3 +204,18 @@ func (ds *regionsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// regionsOfEntitlements returns the technical names of the data centers in which at least one of the entitled service plans is available.
+func regionsOfEntitlements(entitlements cis_entitlements.EntitledAndAssignedServicesResponseObject) map[string]bool {
+	regions := map[string]bool{}
+
+	for _, service := range entitlements.EntitledServices {
+		for _, plan := range service.ServicePlans {
+			for _, dataCenter := range plan.DataCenters {
+				regions[dataCenter.Name] = true
+			}
+		}
+	}
+
+	return regions
+}
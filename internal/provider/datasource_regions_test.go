@@ -30,6 +30,59 @@ func TestDataSourceRegions(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - scoped to the global account", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/accounts/available-region"):
+				fmt.Fprint(w, `{"datacenters": [{"name": "cf-eu10", "region": "eu10"}, {"name": "cf-us10", "region": "us10"}, {"name": "cf-ap21", "region": "ap21"}]}`)
+			case strings.HasSuffix(r.URL.Path, "/accounts/entitlement"):
+				fmt.Fprint(w, `{"entitledServices": [{"name": "cloudfoundry", "servicePlans": [{"name": "standard", "dataCenters": [{"name": "cf-eu10"}]}]}, {"name": "hana-cloud", "servicePlans": [{"name": "hana", "dataCenters": [{"name": "cf-eu10"}, {"name": "cf-ap21"}]}]}]}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceRegionsWithScope("uut", "globalaccount"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_regions.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_regions.uut", "values.0.id", "cf-eu10"),
+						resource.TestCheckResourceAttr("data.btp_regions.uut", "values.1.id", "cf-ap21"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceRegionsWithScope("uut", "all"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_regions.uut", "values.#", "3"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - invalid scope", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceRegionsWithScope("uut", "subaccount"),
+					ExpectError: regexp.MustCompile(`Attribute scope value must be one of`),
+				},
+			},
+		})
+	})
 	t.Run("error path - cli server returns error", func(t *testing.T) {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if strings.HasPrefix(r.URL.Path, "/login/") {
@@ -57,3 +110,10 @@ func hclDatasourceRegions(resourceName string) string {
 	template := `data "btp_regions" "%s" {}`
 	return fmt.Sprintf(template, resourceName)
 }
+
+func hclDatasourceRegionsWithScope(resourceName string, scope string) string {
+	template := `data "btp_regions" "%s" {
+    scope = "%s"
+}`
+	return fmt.Sprintf(template, resourceName, scope)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func newServerInfoDataSource() datasource.DataSource {
+	return &serverInfoDataSource{}
+}
+
+type serverInfoDataSourceConfig struct {
+	/* OUTPUT */
+	Id                 types.String `tfsdk:"id"`
+	CLIServerURL       types.String `tfsdk:"cli_server_url"`
+	RateLimitLimit     types.Int64  `tfsdk:"rate_limit_limit"`
+	RateLimitRemaining types.Int64  `tfsdk:"rate_limit_remaining"`
+	RateLimitReset     types.Int64  `tfsdk:"rate_limit_reset"`
+}
+
+type serverInfoDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *serverInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_server_info", req.ProviderTypeName)
+}
+
+func (ds *serverInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *serverInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets information about the BTP CLI server the provider is connected to, like the rate limit the server reported with its latest response. This helps to tune the parallelism of Terraform.
+
+__Note:__
+The rate limit attributes are empty, if the server doesn't report a rate limit.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The URL of the BTP CLI server.",
+				Computed:            true,
+			},
+			"cli_server_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the BTP CLI server the provider is connected to.",
+				Computed:            true,
+			},
+			"rate_limit_limit": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests the server allows in the current window.",
+				Computed:            true,
+			},
+			"rate_limit_remaining": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests left in the current window.",
+				Computed:            true,
+			},
+			"rate_limit_reset": schema.Int64Attribute{
+				MarkdownDescription: "The number of seconds until the current window ends.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *serverInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data serverInfoDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(ds.cli.GetServerURL())
+	data.CLIServerURL = types.StringValue(ds.cli.GetServerURL())
+	data.RateLimitLimit = types.Int64Null()
+	data.RateLimitRemaining = types.Int64Null()
+	data.RateLimitReset = types.Int64Null()
+
+	if rateLimit := ds.cli.GetRateLimit(); rateLimit != nil {
+		data.RateLimitLimit = types.Int64Value(int64(rateLimit.Limit))
+		data.RateLimitRemaining = types.Int64Value(int64(rateLimit.Remaining))
+		data.RateLimitReset = types.Int64Value(int64(rateLimit.Reset))
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
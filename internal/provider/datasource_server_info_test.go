@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceServerInfo(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - rate limit reported", func(t *testing.T) {
+		srv := newServerInfoTestServer(true)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceServerInfo("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_server_info.uut", "id", srv.URL),
+						resource.TestCheckResourceAttr("data.btp_server_info.uut", "cli_server_url", srv.URL),
+						resource.TestCheckResourceAttr("data.btp_server_info.uut", "rate_limit_limit", "100"),
+						resource.TestCheckResourceAttr("data.btp_server_info.uut", "rate_limit_remaining", "42"),
+						resource.TestCheckResourceAttr("data.btp_server_info.uut", "rate_limit_reset", "30"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - no rate limit reported", func(t *testing.T) {
+		srv := newServerInfoTestServer(false)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceServerInfo("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_server_info.uut", "cli_server_url", srv.URL),
+						resource.TestCheckNoResourceAttr("data.btp_server_info.uut", "rate_limit_limit"),
+						resource.TestCheckNoResourceAttr("data.btp_server_info.uut", "rate_limit_remaining"),
+						resource.TestCheckNoResourceAttr("data.btp_server_info.uut", "rate_limit_reset"),
+					),
+				},
+			},
+		})
+	})
+}
+
+// newServerInfoTestServer returns a CLI server which only accepts logins and optionally reports its rate limit.
+func newServerInfoTestServer(reportRateLimit bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reportRateLimit {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "42")
+			w.Header().Set("X-RateLimit-Reset", "30")
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func hclDatasourceServerInfo(resourceName string) string {
+	return fmt.Sprintf(`data "btp_server_info" "%s" {}`, resourceName)
+}
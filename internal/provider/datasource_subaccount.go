@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
@@ -47,6 +48,11 @@ You must be assigned to the admin or viewer role of the global account, director
 					uuidvalidator.ValidUUID(),
 				},
 			},
+			"ancestry": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the directories the subaccount is located in, ordered from its parent directory up to the top-level directory below the global account. The list is empty if the subaccount is located directly in the global account.",
+				Computed:            true,
+			},
 			"beta_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Shows whether the subaccount can use beta services and applications.",
 				Computed:            true,
@@ -138,7 +144,7 @@ You must be assigned to the admin or viewer role of the global account, director
 }
 
 func (ds *subaccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data subaccountType
+	var data subaccountDataSourceType
 
 	diags := req.Config.Get(ctx, &data)
 
@@ -153,9 +159,42 @@ func (ds *subaccountDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	data, diags = subaccountValueFrom(ctx, cliRes)
+	ancestry, err := ds.ancestry(ctx, cliRes)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Subaccount", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data, diags = subaccountDataSourceValueFrom(ctx, cliRes)
+	resp.Diagnostics.Append(diags...)
+
+	data.Ancestry, diags = types.ListValueFrom(ctx, types.StringType, ancestry)
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// ancestry walks up the directory hierarchy from the parent of the subaccount until the global account is reached.
+func (ds *subaccountDataSource) ancestry(ctx context.Context, subaccount cis.SubaccountResponseObject) ([]string, error) {
+	ancestry := []string{}
+	visited := map[string]bool{}
+
+	for parentId := subaccount.ParentGUID; parentId != "" && parentId != subaccount.GlobalAccountGUID; {
+		if visited[parentId] {
+			return nil, fmt.Errorf("the directory hierarchy of subaccount %s contains a cycle at directory %s", subaccount.Guid, parentId)
+		}
+		visited[parentId] = true
+
+		ancestry = append(ancestry, parentId)
+
+		directory, _, err := ds.cli.Accounts.Directory.Get(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+
+		parentId = directory.ParentGUID
+	}
+
+	return ancestry, nil
+}
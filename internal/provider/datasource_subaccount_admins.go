@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+const subaccountAdminRoleCollectionName = "Subaccount Administrator"
+
+func newSubaccountAdminsDataSource() datasource.DataSource {
+	return &subaccountAdminsDataSource{}
+}
+
+type subaccountAdminType struct {
+	/* OUTPUT */
+	UserName types.String `tfsdk:"user_name"`
+	Origin   types.String `tfsdk:"origin"`
+	Email    types.String `tfsdk:"email"`
+}
+
+type subaccountAdminsDataSourceConfig struct {
+	/* INPUT */
+	SubaccountId       types.String `tfsdk:"subaccount_id"`
+	RoleCollectionName types.String `tfsdk:"role_collection_name"`
+	Id                 types.String `tfsdk:"id"`
+	/* OUTPUT */
+	Values []subaccountAdminType `tfsdk:"values"`
+}
+
+type subaccountAdminsDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *subaccountAdminsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_admins", req.ProviderTypeName)
+}
+
+func (ds *subaccountAdminsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *subaccountAdminsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets all users of a subaccount that are assigned to the subaccount administrator role collection, across all identity providers that are trusted by the subaccount.
+
+__Tip:__
+You must be assigned to the admin or viewer role of the subaccount.`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+			},
+			"role_collection_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the role collection that grants administrator access to the subaccount. The default value is `" + subaccountAdminRoleCollectionName + "`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+			},
+			"values": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_name": schema.StringAttribute{
+							MarkdownDescription: "The username of the user.",
+							Computed:            true,
+						},
+						"origin": schema.StringAttribute{
+							MarkdownDescription: "The identity provider that hosts the user.",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The e-mail address of the user.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The administrators of the subaccount, sorted by origin and username.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *subaccountAdminsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data subaccountAdminsDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.RoleCollectionName.IsNull() {
+		data.RoleCollectionName = types.StringValue(subaccountAdminRoleCollectionName)
+	}
+
+	subaccountId := data.SubaccountId.ValueString()
+
+	roleCollection, _, err := ds.cli.Security.RoleCollection.GetBySubaccount(ctx, subaccountId, data.RoleCollectionName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Admins (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Values = []subaccountAdminType{}
+
+	for _, user := range roleCollection.UserReferences {
+		data.Values = append(data.Values, subaccountAdminType{
+			UserName: types.StringValue(user.Username),
+			Origin:   types.StringValue(user.Origin),
+			Email:    types.StringValue(user.Email),
+		})
+	}
+
+	sort.Slice(data.Values, func(i, j int) bool {
+		if data.Values[i].Origin.ValueString() != data.Values[j].Origin.ValueString() {
+			return data.Values[i].Origin.ValueString() < data.Values[j].Origin.ValueString()
+		}
+
+		return data.Values[i].UserName.ValueString() < data.Values[j].UserName.ValueString()
+	})
+
+	data.Id = data.SubaccountId
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
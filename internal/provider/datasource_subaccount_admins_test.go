@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func newSubaccountAdminsTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode request body: %s", err)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/role-collection") && r.URL.RawQuery == "get" && payload.ParamValues["roleCollectionName"] == "Subaccount Administrator":
+			fmt.Fprintf(w, `{"name": "Subaccount Administrator", "userReferences": [{"username": "zoe.doe@test.com", "email": "zoe.doe@test.com", "origin": "my-ias"}, {"username": "john.doe@test.com", "email": "john.doe@test.com", "origin": "ldap"}]}`)
+		default:
+			w.Header().Set("X-Cpcli-Backend-Status", "404")
+			fmt.Fprintf(w, `{"error": "not found"}`)
+		}
+	}))
+}
+
+func TestDataSourceSubaccountAdmins(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := newSubaccountAdminsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountAdmins("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_admins.uut", "role_collection_name", "Subaccount Administrator"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_admins.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_admins.uut", "values.0.user_name", "john.doe@test.com"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_admins.uut", "values.0.origin", "ldap"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_admins.uut", "values.1.user_name", "zoe.doe@test.com"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_admins.uut", "values.1.origin", "my-ias"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceSubaccountAdmins("uut", "this-is-not-a-uuid"),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountAdmins("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					ExpectError: regexp.MustCompile(`Received response with unexpected status \[Status: 404; Correlation ID:\s+[a-f0-9\-]+\]`),
+				},
+			},
+		})
+	})
+}
+
+func hclDatasourceSubaccountAdmins(resourceName string, subaccountId string) string {
+	template := `data "btp_subaccount_admins" "%s" { subaccount_id = "%s" }`
+
+	return fmt.Sprintf(template, resourceName, subaccountId)
+}
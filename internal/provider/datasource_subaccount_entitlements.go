@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
@@ -61,49 +62,7 @@ To get all entitlements and quota assigned to a specific subaccount:
 			},
 			"values": schema.MapNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"service_name": schema.StringAttribute{
-							MarkdownDescription: "The name of the entitled service.",
-							Computed:            true,
-						},
-						"service_display_name": schema.StringAttribute{
-							MarkdownDescription: "The display name of the entitled service.",
-							Computed:            true,
-						},
-						"plan_name": schema.StringAttribute{
-							MarkdownDescription: "The name of the entitled service plan.",
-							Computed:            true,
-						},
-						"plan_display_name": schema.StringAttribute{
-							MarkdownDescription: "The display name of the entitled service plan.",
-							Computed:            true,
-						},
-						"plan_description": schema.StringAttribute{
-							MarkdownDescription: "The description of the entitled service plan.",
-							Computed:            true,
-						},
-						"quota_assigned": schema.Float64Attribute{
-							MarkdownDescription: "The overall quota assigned.",
-							Computed:            true,
-						},
-						"quota_remaining": schema.Float64Attribute{
-							MarkdownDescription: "The quota, which is not used.",
-							Computed:            true,
-						},
-						"category": schema.StringAttribute{
-							MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
-								getFormattedValueAsTableRow("value", "description") +
-								getFormattedValueAsTableRow("---", "---") +
-								getFormattedValueAsTableRow("`PLATFORM`", " A service required for using a specific platform; for example, Application Runtime is required for the Cloud Foundry platform.") +
-								getFormattedValueAsTableRow("`SERVICE`", "A commercial or technical service. that has a numeric quota (amount) when entitled or assigned to a resource. When assigning entitlements of this type, use the 'amount' option.") +
-								getFormattedValueAsTableRow("`ELASTIC_SERVICE`", "A commercial or technical service that has no numeric quota (amount) when entitled or assigned to a resource. Generally this type of service can be as many times as needed when enabled, but may in some cases be restricted by the service owner.") +
-								getFormattedValueAsTableRow("`ELASTIC_LIMITED`", "An elastic service that can be enabled for only one subaccount per global account.") +
-								getFormattedValueAsTableRow("`APPLICATION`", "A multitenant application to which consumers can subscribe. As opposed to applications defined as a 'QUOTA_BASED_APPLICATION', these applications do not have a numeric quota and are simply enabled or disabled as entitlements per subaccount.") +
-								getFormattedValueAsTableRow("`QUOTA_BASED_APPLICATION`", "A multitenant application to which consumers can subscribe. As opposed to applications defined as 'APPLICATION', these applications have an numeric quota that limits consumer usage of the subscribed application per subaccount.") +
-								getFormattedValueAsTableRow("`ENVIRONMENT`", " An environment service; for example, Cloud Foundry."),
-							Computed: true,
-						},
-					},
+					Attributes: entitledServiceAttributes(),
 				},
 				Computed: true,
 			},
@@ -111,6 +70,60 @@ To get all entitlements and quota assigned to a specific subaccount:
 	}
 }
 
+func entitledServiceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"service_name": schema.StringAttribute{
+			MarkdownDescription: "The name of the entitled service.",
+			Computed:            true,
+		},
+		"service_display_name": schema.StringAttribute{
+			MarkdownDescription: "The display name of the entitled service.",
+			Computed:            true,
+		},
+		"plan_name": schema.StringAttribute{
+			MarkdownDescription: "The name of the entitled service plan.",
+			Computed:            true,
+		},
+		"plan_display_name": schema.StringAttribute{
+			MarkdownDescription: "The display name of the entitled service plan.",
+			Computed:            true,
+		},
+		"plan_description": schema.StringAttribute{
+			MarkdownDescription: "The description of the entitled service plan.",
+			Computed:            true,
+		},
+		"quota_assigned": schema.Float64Attribute{
+			MarkdownDescription: "The overall quota assigned.",
+			Computed:            true,
+		},
+		"quota_remaining": schema.Float64Attribute{
+			MarkdownDescription: "The quota, which is not used.",
+			Computed:            true,
+		},
+		"auto_assign": schema.BoolAttribute{
+			MarkdownDescription: "Shows whether the plan is automatically assigned to subaccounts which are created in the directory of the entitlement.",
+			Computed:            true,
+		},
+		"auto_distribute_amount": schema.Int64Attribute{
+			MarkdownDescription: "The quota which is automatically assigned to subaccounts which are created in the directory of the entitlement.",
+			Computed:            true,
+		},
+		"category": schema.StringAttribute{
+			MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
+				getFormattedValueAsTableRow("value", "description") +
+				getFormattedValueAsTableRow("---", "---") +
+				getFormattedValueAsTableRow("`PLATFORM`", " A service required for using a specific platform; for example, Application Runtime is required for the Cloud Foundry platform.") +
+				getFormattedValueAsTableRow("`SERVICE`", "A commercial or technical service. that has a numeric quota (amount) when entitled or assigned to a resource. When assigning entitlements of this type, use the 'amount' option.") +
+				getFormattedValueAsTableRow("`ELASTIC_SERVICE`", "A commercial or technical service that has no numeric quota (amount) when entitled or assigned to a resource. Generally this type of service can be as many times as needed when enabled, but may in some cases be restricted by the service owner.") +
+				getFormattedValueAsTableRow("`ELASTIC_LIMITED`", "An elastic service that can be enabled for only one subaccount per global account.") +
+				getFormattedValueAsTableRow("`APPLICATION`", "A multitenant application to which consumers can subscribe. As opposed to applications defined as a 'QUOTA_BASED_APPLICATION', these applications do not have a numeric quota and are simply enabled or disabled as entitlements per subaccount.") +
+				getFormattedValueAsTableRow("`QUOTA_BASED_APPLICATION`", "A multitenant application to which consumers can subscribe. As opposed to applications defined as 'APPLICATION', these applications have an numeric quota that limits consumer usage of the subscribed application per subaccount.") +
+				getFormattedValueAsTableRow("`ENVIRONMENT`", " An environment service; for example, Cloud Foundry."),
+			Computed: true,
+		},
+	}
+}
+
 func (ds *subaccountEntitlementsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data subaccountEntitlementsDataSourceConfig
 
@@ -127,27 +140,33 @@ func (ds *subaccountEntitlementsDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 
+	data.Id = data.SubaccountId
+	data.Values, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: entitledServiceType()}, subaccountEntitledServicesFrom(cliRes))
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func subaccountEntitledServicesFrom(value cis_entitlements.EntitledAndAssignedServicesResponseObject) map[string]entitledService {
 	values := map[string]entitledService{}
 
-	for _, service := range cliRes.EntitledServices {
+	for _, service := range value.EntitledServices {
 		for _, servicePlan := range service.ServicePlans {
 			values[fmt.Sprintf("%s:%s", service.Name, servicePlan.Name)] = entitledService{
-				ServiceName:        types.StringValue(service.Name),
-				ServiceDisplayName: types.StringValue(service.DisplayName),
-				PlanName:           types.StringValue(servicePlan.Name),
-				PlanDisplayName:    types.StringValue(servicePlan.DisplayName),
-				PlanDescription:    types.StringValue(servicePlan.Description),
-				QuotaAssigned:      types.Float64Value(servicePlan.Amount),
-				QuotaRemaining:     types.Float64Value(servicePlan.RemainingAmount),
-				Category:           types.StringValue(servicePlan.Category),
+				ServiceName:          types.StringValue(service.Name),
+				ServiceDisplayName:   types.StringValue(service.DisplayName),
+				PlanName:             types.StringValue(servicePlan.Name),
+				PlanDisplayName:      types.StringValue(servicePlan.DisplayName),
+				PlanDescription:      types.StringValue(servicePlan.Description),
+				QuotaAssigned:        types.Float64Value(servicePlan.Amount),
+				QuotaRemaining:       types.Float64Value(servicePlan.RemainingAmount),
+				Category:             types.StringValue(servicePlan.Category),
+				AutoAssign:           types.BoolValue(servicePlan.AutoAssign),
+				AutoDistributeAmount: types.Int64Value(int64(servicePlan.AutoDistributeAmount)),
 			}
 		}
 	}
 
-	data.Id = data.SubaccountId
-	data.Values, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: entitledServiceType()}, values)
-	resp.Diagnostics.Append(diags...)
-
-	diags = resp.State.Set(ctx, &data)
-	resp.Diagnostics.Append(diags...)
+	return values
 }
@@ -100,6 +100,12 @@ You must be assigned to the subaccount admin or viewer role.`,
 				MarkdownDescription: "An identifier that represents the last operation. This ID is returned by the environment brokers.",
 				Computed:            true,
 			},
+			"outputs": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The outputs of the environment broker, e.g. the API endpoint of a Cloud Foundry org or the kubeconfig URL of a Kyma runtime.",
+				Computed:            true,
+				Sensitive:           true,
+			},
 			"parameters": schema.StringAttribute{
 				MarkdownDescription: "The configuration parameters for the environment instance.",
 				Computed:            true,
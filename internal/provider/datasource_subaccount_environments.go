@@ -3,13 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/provisioning"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
@@ -32,12 +35,35 @@ type subaccountEnvironment struct {
 	UpdateSchema       types.String `tfsdk:"schema_update"`
 }
 
+type subaccountEnvironmentPlan struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Updateable  types.Bool   `tfsdk:"updateable"`
+	Landscapes  types.List   `tfsdk:"landscapes"`
+}
+
+type subaccountEnvironmentLandscape struct {
+	Label             types.String `tfsdk:"label"`
+	TechnicalKey      types.String `tfsdk:"technical_key"`
+	AvailabilityLevel types.String `tfsdk:"availability_level"`
+}
+
+type subaccountEnvironmentCatalogEntry struct {
+	EnvironmentType    types.String                     `tfsdk:"environment_type"`
+	ServiceName        types.String                     `tfsdk:"service_name"`
+	ServiceDisplayName types.String                     `tfsdk:"service_display_name"`
+	ServiceDescription types.String                     `tfsdk:"service_description"`
+	AvailablePlans     []subaccountEnvironmentPlan      `tfsdk:"available_plans"`
+	Landscapes         []subaccountEnvironmentLandscape `tfsdk:"landscapes"`
+}
+
 type subaccountEnvironmentsDataSourceConfig struct {
 	/* INPUT */
 	SubaccountId types.String `tfsdk:"subaccount_id"`
 	Id           types.String `tfsdk:"id"`
 	/* OUTPUT */
-	Values []subaccountEnvironment `tfsdk:"values"`
+	Values  []subaccountEnvironment             `tfsdk:"values"`
+	Catalog []subaccountEnvironmentCatalogEntry `tfsdk:"catalog"`
 }
 
 type subaccountEnvironmentsDataSource struct {
@@ -132,6 +158,75 @@ You must be assigned to the subaccount admin or viewer role.`,
 				},
 				Computed: true,
 			},
+			"catalog": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"environment_type": schema.StringAttribute{
+							MarkdownDescription: "The type of environment that is available (for example: cloudfoundry).",
+							Computed:            true,
+						},
+						"service_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the service offered in the catalog of the corresponding environment broker (for example: cloudfoundry).",
+							Computed:            true,
+						},
+						"service_display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the service.",
+							Computed:            true,
+						},
+						"service_description": schema.StringAttribute{
+							MarkdownDescription: "The short description of the service.",
+							Computed:            true,
+						},
+						"available_plans": schema.ListNestedAttribute{
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "The name of the service plan.",
+										Computed:            true,
+									},
+									"description": schema.StringAttribute{
+										MarkdownDescription: "The description of the service plan.",
+										Computed:            true,
+									},
+									"updateable": schema.BoolAttribute{
+										MarkdownDescription: "Specifies if the consumer can change the plan of an existing instance of the environment.",
+										Computed:            true,
+									},
+									"landscapes": schema.ListAttribute{
+										ElementType:         types.StringType,
+										MarkdownDescription: "The labels of the landscapes in which the service plan is available.",
+										Computed:            true,
+									},
+								},
+							},
+							MarkdownDescription: "The service plans of the environment, sorted by name.",
+							Computed:            true,
+						},
+						"landscapes": schema.ListNestedAttribute{
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"label": schema.StringAttribute{
+										MarkdownDescription: "The landscape label of the environment broker.",
+										Computed:            true,
+									},
+									"technical_key": schema.StringAttribute{
+										MarkdownDescription: "The technical key of the corresponding environment broker.",
+										Computed:            true,
+									},
+									"availability_level": schema.StringAttribute{
+										MarkdownDescription: "The availability level of the environment broker.",
+										Computed:            true,
+									},
+								},
+							},
+							MarkdownDescription: "The landscapes in which the environment is available, sorted by label.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The available environments grouped by environment type and service, with their service plans and landscapes. In contrast to `values`, which contains an entry per combination of service plan and landscape, each environment is listed only once.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -171,8 +266,121 @@ func (ds *subaccountEnvironmentsDataSource) Read(ctx context.Context, req dataso
 		})
 	}
 
+	data.Catalog, diags = subaccountEnvironmentCatalogFrom(ctx, cliRes.AvailableEnvironments)
+	resp.Diagnostics.Append(diags...)
+
 	data.Id = data.SubaccountId
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// subaccountEnvironmentCatalogFrom groups the available environments, which are returned per combination of service plan and
+// landscape, by environment type and service.
+func subaccountEnvironmentCatalogFrom(ctx context.Context, availableEnvironments []provisioning.AvailableEnvironmentResponseObject) ([]subaccountEnvironmentCatalogEntry, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	type catalogKey struct {
+		environmentType string
+		serviceName     string
+	}
+
+	keys := []catalogKey{}
+	environmentsByKey := map[catalogKey][]provisioning.AvailableEnvironmentResponseObject{}
+
+	for _, availableEnvironment := range availableEnvironments {
+		key := catalogKey{environmentType: availableEnvironment.EnvironmentType, serviceName: availableEnvironment.ServiceName}
+
+		if _, ok := environmentsByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		environmentsByKey[key] = append(environmentsByKey[key], availableEnvironment)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].environmentType != keys[j].environmentType {
+			return keys[i].environmentType < keys[j].environmentType
+		}
+
+		return keys[i].serviceName < keys[j].serviceName
+	})
+
+	catalog := []subaccountEnvironmentCatalogEntry{}
+
+	for _, key := range keys {
+		environments := environmentsByKey[key]
+
+		entry := subaccountEnvironmentCatalogEntry{
+			EnvironmentType:    types.StringValue(key.environmentType),
+			ServiceName:        types.StringValue(key.serviceName),
+			ServiceDisplayName: types.StringValue(environments[0].ServiceDisplayName),
+			ServiceDescription: types.StringValue(environments[0].ServiceDescription),
+			AvailablePlans:     []subaccountEnvironmentPlan{},
+			Landscapes:         []subaccountEnvironmentLandscape{},
+		}
+
+		planNames := []string{}
+		plansByName := map[string]provisioning.AvailableEnvironmentResponseObject{}
+		planLandscapes := map[string]map[string]bool{}
+		landscapesByLabel := map[string]provisioning.AvailableEnvironmentResponseObject{}
+
+		for _, environment := range environments {
+			if _, ok := plansByName[environment.PlanName]; !ok {
+				planNames = append(planNames, environment.PlanName)
+				plansByName[environment.PlanName] = environment
+			}
+
+			if planLandscapes[environment.PlanName] == nil {
+				planLandscapes[environment.PlanName] = map[string]bool{}
+			}
+			planLandscapes[environment.PlanName][environment.LandscapeLabel] = true
+
+			if _, ok := landscapesByLabel[environment.LandscapeLabel]; !ok {
+				landscapesByLabel[environment.LandscapeLabel] = environment
+			}
+		}
+
+		sort.Strings(planNames)
+
+		for _, planName := range planNames {
+			plan := plansByName[planName]
+
+			landscapes := []string{}
+			for label := range planLandscapes[planName] {
+				landscapes = append(landscapes, label)
+			}
+			sort.Strings(landscapes)
+
+			landscapesValue, diags := types.ListValueFrom(ctx, types.StringType, landscapes)
+			diagnostics.Append(diags...)
+
+			entry.AvailablePlans = append(entry.AvailablePlans, subaccountEnvironmentPlan{
+				Name:        types.StringValue(plan.PlanName),
+				Description: types.StringValue(plan.Description),
+				Updateable:  types.BoolValue(plan.PlanUpdatable),
+				Landscapes:  landscapesValue,
+			})
+		}
+
+		labels := make([]string, 0, len(landscapesByLabel))
+		for label := range landscapesByLabel {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			landscape := landscapesByLabel[label]
+
+			entry.Landscapes = append(entry.Landscapes, subaccountEnvironmentLandscape{
+				Label:             types.StringValue(landscape.LandscapeLabel),
+				TechnicalKey:      types.StringValue(landscape.TechnicalKey),
+				AvailabilityLevel: types.StringValue(landscape.AvailabilityLevel),
+			})
+		}
+
+		catalog = append(catalog, entry)
+	}
+
+	return catalog, diagnostics
+}
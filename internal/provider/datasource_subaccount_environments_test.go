@@ -26,6 +26,59 @@ func TestDataSourceSubaccountEnvironments(t *testing.T) {
 					Check: resource.ComposeAggregateTestCheckFunc(
 						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "subaccount_id", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.environment_type", "cloudfoundry"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.available_plans.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.available_plans.0.name", "standard"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.available_plans.0.landscapes.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.landscapes.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.landscapes.0.label", "cf-eu12"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.landscapes.1.label", "cf-eu12-001"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - catalog grouped by environment", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"availableEnvironments": [
+				{"environmentType": "kyma", "serviceName": "kymaruntime", "serviceDisplayName": "Kyma Runtime", "planName": "azure", "description": "Kyma on Azure", "planUpdatable": false, "landscapeLabel": "kyma-eu12", "technicalKey": "kyma-broker", "availabilityLevel": "SUBACCOUNT"},
+				{"environmentType": "cloudfoundry", "serviceName": "cloudfoundry", "serviceDisplayName": "Cloud Foundry Runtime", "planName": "standard", "description": "Cloud Foundry", "planUpdatable": false, "landscapeLabel": "cf-eu12-001", "technicalKey": "cf-eu12-001", "availabilityLevel": "SUBACCOUNT"},
+				{"environmentType": "kyma", "serviceName": "kymaruntime", "serviceDisplayName": "Kyma Runtime", "planName": "aws", "description": "Kyma on AWS", "planUpdatable": true, "landscapeLabel": "kyma-eu12", "technicalKey": "kyma-broker", "availabilityLevel": "SUBACCOUNT"},
+				{"environmentType": "cloudfoundry", "serviceName": "cloudfoundry", "serviceDisplayName": "Cloud Foundry Runtime", "planName": "standard", "description": "Cloud Foundry", "planUpdatable": false, "landscapeLabel": "cf-eu12", "technicalKey": "cf-eu12", "availabilityLevel": "SUBACCOUNT"}
+			]}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountEnvironments("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "values.#", "4"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.environment_type", "cloudfoundry"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.available_plans.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.available_plans.0.landscapes.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.available_plans.0.landscapes.0", "cf-eu12"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.landscapes.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.0.landscapes.1.technical_key", "cf-eu12-001"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.environment_type", "kyma"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.service_display_name", "Kyma Runtime"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.available_plans.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.available_plans.0.name", "aws"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.available_plans.0.updateable", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.available_plans.1.name", "azure"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.landscapes.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_environments.uut", "catalog.1.landscapes.0.label", "kyma-eu12"),
 					),
 				},
 			},
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+func newSubaccountGroupRoleCollectionsDataSource() datasource.DataSource {
+	return &subaccountGroupRoleCollectionsDataSource{}
+}
+
+type subaccountGroupRoleCollectionsDataSourceConfig struct {
+	/* INPUT */
+	SubaccountId types.String `tfsdk:"subaccount_id"`
+	GroupName    types.String `tfsdk:"group_name"`
+	Origin       types.String `tfsdk:"origin"`
+	/* OUTPUT */
+	Id              types.String `tfsdk:"id"`
+	RoleCollections types.Set    `tfsdk:"role_collections"`
+}
+
+type subaccountGroupRoleCollectionsDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *subaccountGroupRoleCollectionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_group_role_collections", req.ProviderTypeName)
+}
+
+func (ds *subaccountGroupRoleCollectionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *subaccountGroupRoleCollectionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the role collections to which a group of an identity provider (IdP) is mapped in a subaccount.`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"origin": schema.StringAttribute{
+				MarkdownDescription: "The identity provider that hosts the group. The default is the `default_origin` of the provider, or `ldap` if not set.",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				DeprecationMessage:  "Use the `subaccount_id` attribute instead",
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+			},
+			"role_collections": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The set of role collections, to which the group is mapped.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *subaccountGroupRoleCollectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data subaccountGroupRoleCollectionsDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Origin.IsNull() {
+		data.Origin = defaultOrigin(ds.cli)
+	}
+
+	cliRes, _, err := ds.cli.Security.RoleCollection.ListBySubaccount(ctx, data.SubaccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Group Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = data.SubaccountId
+	data.RoleCollections, diags = roleCollectionsAssignedToGroup(ctx, cliRes, data.GroupName.ValueString(), data.Origin.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceSubaccountGroupRoleCollections(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := newSubaccountGroupRoleCollectionsTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountGroupRoleCollections("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "auditors", "my-ias"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_group_role_collections.uut", "origin", "my-ias"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_group_role_collections.uut", "role_collections.#", "2"),
+						resource.TestCheckTypeSetElemAttr("data.btp_subaccount_group_role_collections.uut", "role_collections.*", "Auditors"),
+						resource.TestCheckTypeSetElemAttr("data.btp_subaccount_group_role_collections.uut", "role_collections.*", "Subaccount Viewer"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - default origin", func(t *testing.T) {
+		srv := newSubaccountGroupRoleCollectionsTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountGroupRoleCollections("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "auditors", ""),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_group_role_collections.uut", "origin", "ldap"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_group_role_collections.uut", "role_collections.#", "0"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceSubaccountGroupRoleCollections("uut", "this-is-not-a-uuid", "auditors", ""),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+	t.Run("error path - group_name mandatory", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + `data "btp_subaccount_group_role_collections" "uut" { subaccount_id = "ef23ace8-6ade-4d78-9c1f-8df729548bbf" }`,
+					ExpectError: regexp.MustCompile(`The argument "group_name" is required, but no definition was found`),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountGroupRoleCollectionsTestServer simulates a CLI server with role collections to which the group
+// `auditors` of the identity provider `my-ias` is mapped.
+func newSubaccountGroupRoleCollectionsTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		if !strings.HasSuffix(r.URL.Path, "/security/role-collection") || r.URL.RawQuery != "list" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprint(w, `[
+			{"name": "Subaccount Viewer", "samlAttrAssignment": [{"roleCollectionName": "Subaccount Viewer", "attributeName": "Groups", "attributeValue": "auditors", "comparisonOperator": "equals", "samlEntityId": "my-ias"}]},
+			{"name": "Auditors", "samlAttrAssignment": [{"roleCollectionName": "Auditors", "attributeName": "Groups", "attributeValue": "auditors", "comparisonOperator": "equals", "samlEntityId": "my-ias"}]},
+			{"name": "Developers", "samlAttrAssignment": [{"roleCollectionName": "Developers", "attributeName": "Groups", "attributeValue": "developers", "comparisonOperator": "equals", "samlEntityId": "my-ias"}]},
+			{"name": "Subaccount Administrator"}
+		]`)
+	}))
+}
+
+func hclDatasourceSubaccountGroupRoleCollections(resourceName string, subaccountId string, groupName string, origin string) string {
+	if origin == "" {
+		return fmt.Sprintf(`
+data "btp_subaccount_group_role_collections" "%s" {
+    subaccount_id = "%s"
+    group_name    = "%s"
+}`, resourceName, subaccountId, groupName)
+	}
+
+	return fmt.Sprintf(`
+data "btp_subaccount_group_role_collections" "%s" {
+    subaccount_id = "%s"
+    group_name    = "%s"
+    origin        = "%s"
+}`, resourceName, subaccountId, groupName, origin)
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+func newSubaccountQuotaDataSource() datasource.DataSource {
+	return &subaccountQuotaDataSource{}
+}
+
+type subaccountQuotaType struct {
+	ServiceName types.String  `tfsdk:"service_name"`
+	PlanName    types.String  `tfsdk:"plan_name"`
+	Category    types.String  `tfsdk:"category"`
+	Unlimited   types.Bool    `tfsdk:"unlimited"`
+	Entitled    types.Float64 `tfsdk:"entitled"`
+	Used        types.Float64 `tfsdk:"used"`
+	Remaining   types.Float64 `tfsdk:"remaining"`
+}
+
+type subaccountQuotaDataSourceConfig struct {
+	/* INPUT */
+	SubaccountId types.String `tfsdk:"subaccount_id"`
+	Id           types.String `tfsdk:"id"`
+	/* OUTPUT */
+	Values map[string]subaccountQuotaType `tfsdk:"values"`
+}
+
+type subaccountQuotaDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *subaccountQuotaDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_quota", req.ProviderTypeName)
+}
+
+func (ds *subaccountQuotaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *subaccountQuotaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the quota of a subaccount per entitled service plan, i.e. the amount the subaccount is entitled to, the amount it already uses, and the amount it can still consume.
+
+__Tip:__
+You must be assigned to either the subaccount admin or subaccount viewer role.`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+			},
+			"values": schema.MapNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the entitled service.",
+							Computed:            true,
+						},
+						"plan_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the entitled service plan.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The category of the entitled service plan, e.g. `SERVICE`, `ELASTIC_SERVICE` or `APPLICATION`.",
+							Computed:            true,
+						},
+						"unlimited": schema.BoolAttribute{
+							MarkdownDescription: "Shows whether the subaccount is entitled to an unlimited quota of the service plan. If so, `entitled`, `used` and `remaining` are not set.",
+							Computed:            true,
+						},
+						"entitled": schema.Float64Attribute{
+							MarkdownDescription: "The quota the subaccount is entitled to.",
+							Computed:            true,
+						},
+						"used": schema.Float64Attribute{
+							MarkdownDescription: "The quota the subaccount already uses.",
+							Computed:            true,
+						},
+						"remaining": schema.Float64Attribute{
+							MarkdownDescription: "The quota the subaccount can still consume.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The quota per entitled service plan. The key is composed of the service name and the plan name separated by a colon, e.g. `alert-notification:standard`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *subaccountQuotaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data subaccountQuotaDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := ds.cli.Accounts.Entitlement.ListBySubaccount(ctx, data.SubaccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Quota (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = data.SubaccountId
+	data.Values = subaccountQuotaFrom(data.SubaccountId.ValueString(), cliRes)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// subaccountQuotaFrom determines the quota per entitled service plan. A plan is considered unlimited, if it is either
+// entitled with unlimited usage, or if an unlimited amount of it is assigned to the subaccount.
+func subaccountQuotaFrom(subaccountId string, value cis_entitlements.EntitledAndAssignedServicesResponseObject) map[string]subaccountQuotaType {
+	unlimitedAssignments := map[string]bool{}
+
+	for _, service := range value.AssignedServices {
+		for _, servicePlan := range service.ServicePlans {
+			for _, assignment := range servicePlan.AssignmentInfo {
+				if assignment.EntityId == subaccountId && assignment.UnlimitedAmountAssigned {
+					unlimitedAssignments[fmt.Sprintf("%s:%s", service.Name, servicePlan.Name)] = true
+				}
+			}
+		}
+	}
+
+	values := map[string]subaccountQuotaType{}
+
+	for _, service := range value.EntitledServices {
+		for _, servicePlan := range service.ServicePlans {
+			key := fmt.Sprintf("%s:%s", service.Name, servicePlan.Name)
+
+			quota := subaccountQuotaType{
+				ServiceName: types.StringValue(service.Name),
+				PlanName:    types.StringValue(servicePlan.Name),
+				Category:    types.StringValue(servicePlan.Category),
+				Unlimited:   types.BoolValue(servicePlan.Unlimited || unlimitedAssignments[key]),
+				Entitled:    types.Float64Null(),
+				Used:        types.Float64Null(),
+				Remaining:   types.Float64Null(),
+			}
+
+			if !quota.Unlimited.ValueBool() {
+				quota.Entitled = types.Float64Value(servicePlan.Amount)
+				quota.Remaining = types.Float64Value(servicePlan.RemainingAmount)
+				quota.Used = types.Float64Value(servicePlan.Amount - servicePlan.RemainingAmount)
+			}
+
+			values[key] = quota
+		}
+	}
+
+	return values
+}
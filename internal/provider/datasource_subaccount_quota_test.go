@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceSubaccountQuota(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{
+				"entitledServices": [
+					{"name": "hana-cloud", "servicePlans": [{"name": "hana", "category": "SERVICE", "amount": 4, "remainingAmount": 1}]},
+					{"name": "alert-notification", "servicePlans": [{"name": "standard", "category": "ELASTIC_SERVICE", "amount": 1, "remainingAmount": 1, "unlimited": true}]},
+					{"name": "destination", "servicePlans": [{"name": "lite", "category": "SERVICE", "amount": 2, "remainingAmount": 2}]}
+				],
+				"assignedServices": [
+					{"name": "destination", "servicePlans": [{"name": "lite", "assignmentInfo": [{"entityId": "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "entityType": "SUBACCOUNT", "unlimitedAmountAssigned": true}]}]}
+				]
+			}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountQuota("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.%", "3"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.hana-cloud:hana.category", "SERVICE"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.hana-cloud:hana.unlimited", "false"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.hana-cloud:hana.entitled", "4"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.hana-cloud:hana.used", "3"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.hana-cloud:hana.remaining", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.alert-notification:standard.unlimited", "true"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_quota.uut", "values.alert-notification:standard.entitled"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_quota.uut", "values.alert-notification:standard.used"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_quota.uut", "values.alert-notification:standard.remaining"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_quota.uut", "values.destination:lite.unlimited", "true"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_quota.uut", "values.destination:lite.remaining"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceSubaccountQuota("uut", "this-is-not-a-uuid"),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+	t.Run("error path - subaccount_id mandatory", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + `data "btp_subaccount_quota" "uut" {}`,
+					ExpectError: regexp.MustCompile(`The argument "subaccount_id" is required, but no definition was found`),
+				},
+			},
+		})
+	})
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountQuota("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					ExpectError: regexp.MustCompile(`Received response with unexpected status \[Status: 404; Correlation ID:\s+[a-f0-9\-]+\]`),
+				},
+			},
+		})
+	})
+}
+
+func hclDatasourceSubaccountQuota(resourceName string, subaccountId string) string {
+	template := `
+data "btp_subaccount_quota" "%s" {
+    subaccount_id = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId)
+}
@@ -29,15 +29,17 @@ type subaccountRoleScope struct {
 
 type subaccountRoleDataSourceConfig struct {
 	/* INPUT */
-	SubaccountId      types.String `tfsdk:"subaccount_id"`
-	Id                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	RoleTemplateAppId types.String `tfsdk:"app_id"`
-	RoleTemplateName  types.String `tfsdk:"role_template_name"`
+	SubaccountId         types.String `tfsdk:"subaccount_id"`
+	Id                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	RoleTemplateAppId    types.String `tfsdk:"app_id"`
+	RoleTemplateName     types.String `tfsdk:"role_template_name"`
+	FetchRoleCollections types.Bool   `tfsdk:"fetch_role_collections"`
 	/* OUTPUT */
-	Description types.String          `tfsdk:"description"`
-	IsReadOnly  types.Bool            `tfsdk:"read_only"`
-	Scopes      []subaccountRoleScope `tfsdk:"scopes"`
+	Description     types.String          `tfsdk:"description"`
+	IsReadOnly      types.Bool            `tfsdk:"read_only"`
+	RoleCollections types.List            `tfsdk:"role_collections"`
+	Scopes          []subaccountRoleScope `tfsdk:"scopes"`
 }
 
 type subaccountRoleDataSource struct {
@@ -93,6 +95,15 @@ func (ds *subaccountRoleDataSource) Schema(_ context.Context, _ datasource.Schem
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"fetch_role_collections": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, the role collections that contain the role are fetched as well. As this requires an additional request, they are not fetched by default.",
+				Optional:            true,
+			},
+			"role_collections": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the role collections that contain the role, sorted by name. Only available if `fetch_role_collections` is set to `true`.",
+				Computed:            true,
+			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "The description of the role.",
 				Computed:            true,
@@ -179,6 +190,19 @@ func (ds *subaccountRoleDataSource) Read(ctx context.Context, req datasource.Rea
 		data.Scopes = append(data.Scopes, scopeVal)
 	}
 
+	data.RoleCollections = types.ListNull(types.StringType)
+
+	if data.FetchRoleCollections.ValueBool() {
+		roleCollections, _, err := ds.cli.Security.RoleCollection.ListBySubaccount(ctx, data.SubaccountId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Role (Subaccount)", fmt.Sprintf("%s", err))
+			return
+		}
+
+		data.RoleCollections, diags = roleCollectionsContainingRole(ctx, roleCollections, data.Name.ValueString(), data.RoleTemplateAppId.ValueString(), data.RoleTemplateName.ValueString())
+		resp.Diagnostics.Append(diags...)
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
@@ -24,12 +25,17 @@ type subaccountRoleCollectionRoleType struct {
 	RoleTemplateAppId types.String `tfsdk:"role_template_app_id"`
 	Description       types.String `tfsdk:"description"`
 	Name              types.String `tfsdk:"name"`
+	AppName           types.String `tfsdk:"app_name"`
+	AppDescription    types.String `tfsdk:"app_description"`
+	IsReadOnly        types.Bool   `tfsdk:"read_only"`
+	Scopes            types.Set    `tfsdk:"scopes"`
 }
 
 type subaccountRoleCollectionDataSourceConfig struct {
 	/* INPUT */
-	SubaccountId types.String `tfsdk:"subaccount_id"`
-	Id           types.String `tfsdk:"id"`
+	SubaccountId     types.String `tfsdk:"subaccount_id"`
+	Id               types.String `tfsdk:"id"`
+	FetchRoleDetails types.Bool   `tfsdk:"fetch_role_details"`
 	/* OUTPUT */
 	Name        types.String                       `tfsdk:"name"`
 	IsReadOnly  types.Bool                         `tfsdk:"read_only"`
@@ -76,6 +82,10 @@ func (ds *subaccountRoleCollectionDataSource) Schema(_ context.Context, _ dataso
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"fetch_role_details": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, the details of the referenced roles (`app_name`, `app_description`, `read_only` and `scopes`) are fetched as well. As this requires an additional request, they are not fetched by default.",
+				Optional:            true,
+			},
 			"read_only": schema.BoolAttribute{
 				MarkdownDescription: "Shows whether the role collection is read-only.",
 				Computed:            true,
@@ -103,6 +113,23 @@ func (ds *subaccountRoleCollectionDataSource) Schema(_ context.Context, _ dataso
 							MarkdownDescription: "The name of the referenced role.",
 							Computed:            true,
 						},
+						"app_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the application that provides the referenced role. Only available if `fetch_role_details` is set to `true`.",
+							Computed:            true,
+						},
+						"app_description": schema.StringAttribute{
+							MarkdownDescription: "The description of the application that provides the referenced role. Only available if `fetch_role_details` is set to `true`.",
+							Computed:            true,
+						},
+						"read_only": schema.BoolAttribute{
+							MarkdownDescription: "Shows whether the referenced role can be modified or not. Only available if `fetch_role_details` is set to `true`.",
+							Computed:            true,
+						},
+						"scopes": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The names of the scopes granted by the referenced role. Only available if `fetch_role_details` is set to `true`.",
+							Computed:            true,
+						},
 					},
 				},
 				Computed: true,
@@ -132,16 +159,55 @@ func (ds *subaccountRoleCollectionDataSource) Read(ctx context.Context, req data
 	data.Description = types.StringValue(rolecollection.Description)
 	data.IsReadOnly = types.BoolValue(rolecollection.IsReadOnly)
 
+	// the details of all roles are fetched with a single request instead of one request per referenced role
+	roleDetails := map[string]xsuaa_authz.Role{}
+	if data.FetchRoleDetails.ValueBool() {
+		roles, _, err := ds.cli.Security.Role.ListBySubaccount(ctx, data.SubaccountId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Role Collection (Subaccount)", fmt.Sprintf("%s", err))
+			return
+		}
+
+		for _, role := range roles {
+			roleDetails[roleKey(role.Name, role.RoleTemplateAppId, role.RoleTemplateName)] = role
+		}
+	}
+
 	data.Roles = []subaccountRoleCollectionRoleType{}
 	for _, ref := range rolecollection.RoleReferences {
-		data.Roles = append(data.Roles, subaccountRoleCollectionRoleType{
+		role := subaccountRoleCollectionRoleType{
 			RoleTemplateName:  types.StringValue(ref.RoleTemplateName),
 			RoleTemplateAppId: types.StringValue(ref.RoleTemplateAppId),
 			Description:       types.StringValue(ref.Description),
 			Name:              types.StringValue(ref.Name),
-		})
+			AppName:           types.StringNull(),
+			AppDescription:    types.StringNull(),
+			IsReadOnly:        types.BoolNull(),
+			Scopes:            types.SetNull(types.StringType),
+		}
+
+		if details, ok := roleDetails[roleKey(ref.Name, ref.RoleTemplateAppId, ref.RoleTemplateName)]; ok {
+			role.AppName = types.StringValue(details.AppName)
+			role.AppDescription = types.StringValue(details.AppDescription)
+			role.IsReadOnly = types.BoolValue(details.IsReadOnly)
+
+			scopes := []string{}
+			for _, scope := range details.Scopes {
+				scopes = append(scopes, scope.Name)
+			}
+
+			role.Scopes, diags = types.SetValueFrom(ctx, types.StringType, scopes)
+			resp.Diagnostics.Append(diags...)
+		}
+
+		data.Roles = append(data.Roles, role)
 	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// roleKey identifies a role, as the role name is only unique per role template and application.
+func roleKey(roleName string, roleTemplateAppId string, roleTemplateName string) string {
+	return fmt.Sprintf("%s/%s/%s", roleTemplateAppId, roleTemplateName, roleName)
+}
@@ -34,6 +34,46 @@ func TestDataSourceSubaccountRoleCollection(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - with role details", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			if strings.HasSuffix(r.URL.Path, "/security/role-collection") {
+				fmt.Fprintf(w, `{"name": "Subaccount Viewer", "description": "Read-only access to the subaccount", "isReadOnly": true, "roleReferences": [{"roleTemplateAppId": "cis-local!b2", "roleTemplateName": "Subaccount_Viewer", "name": "Subaccount Viewer"}]}`)
+				return
+			}
+
+			fmt.Fprintf(w, `[{"name": "Subaccount Viewer", "roleTemplateAppId": "cis-local!b2", "roleTemplateName": "Subaccount_Viewer", "description": "Role for subaccount members with read-only authorizations", "appName": "cis-local", "appDescription": "Core Commercialization Services", "isReadOnly": true, "scopes": [{"name": "cis-local!b2.subaccount.read"}, {"name": "cis-local!b2.job.read"}]}, {"name": "Subaccount Admin", "roleTemplateAppId": "cis-local!b2", "roleTemplateName": "Subaccount_Admin", "isReadOnly": true}]`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountRoleCollectionWithRoleDetails("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Subaccount Viewer"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_role_collection.uut", "roles.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs("data.btp_subaccount_role_collection.uut", "roles.*", map[string]string{
+							"name":                 "Subaccount Viewer",
+							"role_template_name":   "Subaccount_Viewer",
+							"role_template_app_id": "cis-local!b2",
+							"app_name":             "cis-local",
+							"app_description":      "Core Commercialization Services",
+							"read_only":            "true",
+							"scopes.#":             "2",
+						}),
+					),
+				},
+			},
+		})
+	})
 	t.Run("error path - subaccount_id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -101,3 +141,13 @@ data "btp_subaccount_role_collection" "%s" {
 }`
 	return fmt.Sprintf(template, resourceName, id, name)
 }
+
+func hclDatasourceSubaccountRoleCollectionWithRoleDetails(resourceName string, id string, name string) string {
+	template := `
+data "btp_subaccount_role_collection" "%s" {
+    subaccount_id      = "%s"
+    name               = "%s"
+    fetch_role_details = true
+}`
+	return fmt.Sprintf(template, resourceName, id, name)
+}
@@ -36,6 +36,25 @@ func TestDataSourceSubaccountRole(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - with role collections", func(t *testing.T) {
+		srv := newRoleCollectionsTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountRoleWithRoleCollections("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Subaccount Viewer", "Subaccount_Viewer", "cis-local!b2"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_role.uut", "role_collections.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_role.uut", "role_collections.0", "Auditors"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_role.uut", "role_collections.1", "Subaccount Viewer"),
+					),
+				},
+			},
+		})
+	})
 	t.Run("error path - subaccount_id, name, role_template_name and app_id are mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -130,3 +149,39 @@ data "btp_subaccount_role" "%s" {
 
 	return fmt.Sprintf(template, resourceName, subaccountId, name, roleTemplateName, appId)
 }
+
+func hclDatasourceSubaccountRoleWithRoleCollections(resourceName string, subaccountId string, name string, roleTemplateName string, appId string) string {
+	template := `
+data "btp_subaccount_role" "%s" {
+    subaccount_id          = "%s"
+    name                   = "%s"
+    role_template_name     = "%s"
+    app_id                 = "%s"
+    fetch_role_collections = true
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, name, roleTemplateName, appId)
+}
+
+// newRoleCollectionsTestServer simulates a CLI server with a role that is contained in two of three role collections.
+func newRoleCollectionsTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if strings.HasSuffix(r.URL.Path, "/security/role-collection") {
+			fmt.Fprintf(w, `[
+				{"name": "Subaccount Viewer", "roleReferences": [{"name": "Subaccount Viewer", "roleTemplateAppId": "cis-local!b2", "roleTemplateName": "Subaccount_Viewer"}]},
+				{"name": "Other Viewer", "roleReferences": [{"name": "Subaccount Viewer", "roleTemplateAppId": "other!b3", "roleTemplateName": "Subaccount_Viewer"}]},
+				{"name": "Auditors", "roleReferences": [{"name": "Auditor", "roleTemplateAppId": "auditlog!b1", "roleTemplateName": "Auditor"}, {"name": "Subaccount Viewer", "roleTemplateAppId": "cis-local!b2", "roleTemplateName": "Subaccount_Viewer"}]}
+			]`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"name": "Subaccount Viewer", "roleTemplateAppId": "cis-local!b2", "roleTemplateName": "Subaccount_Viewer", "description": "Subaccount viewer", "isReadOnly": true, "scopes": []}`)
+	}))
+}
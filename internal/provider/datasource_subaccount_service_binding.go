@@ -72,6 +72,10 @@ func (ds *subaccountServiceBindingDataSource) Schema(_ context.Context, _ dataso
 				MarkdownDescription: "The ID of the service instance associated with the binding.",
 				Computed:            true,
 			},
+			"service_instance_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the service instance associated with the binding.",
+				Computed:            true,
+			},
 			"context": schema.MapAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "Contextual data for the resource.",
@@ -87,6 +91,36 @@ func (ds *subaccountServiceBindingDataSource) Schema(_ context.Context, _ dataso
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"credentials_format": schema.StringAttribute{
+				MarkdownDescription: "The format in which the credentials are provided as `formatted_credentials`. Possible values are: \n" +
+					getFormattedValueAsTableRow("format", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`json`", "The credentials as JSON object") +
+					getFormattedValueAsTableRow("`base64`", "The base64-encoded JSON object") +
+					getFormattedValueAsTableRow("`dotenv`", "One `KEY=value` line per credential, nested objects are flattened by joining their keys with an underscore") +
+					getFormattedValueAsTableRow("`yaml`", "One string value per top-level credential, ready to be used as `stringData` of a Kubernetes secret"),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(credentialsFormats...),
+				},
+			},
+			"formatted_credentials": schema.StringAttribute{
+				MarkdownDescription: "The credentials to access the binding in the format given by `credentials_format`. Only available if `credentials_format` is set.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"parse_destination_credentials": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the credentials are parsed as the ones of a destination-like service binding and its authentication settings are exposed as `authentication_type` and `principal_propagation`.",
+				Optional:            true,
+			},
+			"authentication_type": schema.StringAttribute{
+				MarkdownDescription: "The authentication type given in the credentials, e.g. `PrincipalPropagation` or `OAuth2SAMLBearerAssertion`. Only available if `parse_destination_credentials` is `true` and the credentials contain it.",
+				Computed:            true,
+			},
+			"principal_propagation": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether the identity of the business user is propagated instead of using a technical user. Taken from the credentials if given explicitly, otherwise derived from the `authentication_type`. Only available if `parse_destination_credentials` is `true`.",
+				Computed:            true,
+			},
 			"parameters": schema.StringAttribute{
 				MarkdownDescription: "The parameters of the service binding as a valid JSON object.",
 				Computed:            true,
@@ -108,6 +142,15 @@ func (ds *subaccountServiceBindingDataSource) Schema(_ context.Context, _ dataso
 				MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
 				Computed:            true,
 			},
+			"last_rotated": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the credentials of the binding were last rotated in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format. Not set if the binding hasn't been rotated or the information isn't available.",
+				Computed:            true,
+			},
+			"rotation_history": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The dates and times when the credentials of the binding were rotated in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format. Not set if the BTP CLI server doesn't provide the rotation history.",
+				Computed:            true,
+			},
 			"labels": schema.MapAttribute{
 				ElementType: types.SetType{
 					ElemType: types.StringType,
@@ -120,7 +163,7 @@ func (ds *subaccountServiceBindingDataSource) Schema(_ context.Context, _ dataso
 }
 
 func (ds *subaccountServiceBindingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data subaccountServiceBindingType
+	var data subaccountServiceBindingDataSourceType
 
 	diags := req.Config.Get(ctx, &data)
 
@@ -145,10 +188,46 @@ func (ds *subaccountServiceBindingDataSource) Read(ctx context.Context, req data
 		return
 	}
 
-	data, diags = subaccountServiceBindingValueFrom(ctx, cliRes)
+	credentialsFormat := data.CredentialsFormat
+	parseDestinationCredentials := data.ParseDestinationCredentials
+
+	data, diags = subaccountServiceBindingDataSourceValueFrom(ctx, cliRes)
 	data.Parameters = types.StringNull() // the API doesn't return parameters for already created instances
 	resp.Diagnostics.Append(diags...)
 
+	if !credentialsFormat.IsNull() {
+		formattedCredentials, err := formatCredentials(data.Credentials.ValueString(), credentialsFormat.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("credentials_format"), "Credentials Cannot Be Formatted", fmt.Sprintf("%s", err))
+			return
+		}
+
+		data.CredentialsFormat = credentialsFormat
+		data.FormattedCredentials = types.StringValue(formattedCredentials)
+	}
+
+	if parseDestinationCredentials.ValueBool() {
+		authenticationType, principalPropagation, err := destinationAuthenticationFrom(data.Credentials.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("parse_destination_credentials"), "Credentials Cannot Be Parsed", fmt.Sprintf("%s", err))
+			return
+		}
+
+		data.AuthenticationType = stringNullIfEmpty(authenticationType)
+		data.PrincipalPropagation = types.BoolValue(principalPropagation)
+	}
+	data.ParseDestinationCredentials = parseDestinationCredentials
+
+	if data.ServiceInstanceName.IsNull() && len(cliRes.ServiceInstanceId) > 0 {
+		instanceRes, _, err := ds.cli.Services.Instance.GetById(ctx, cliRes.SubaccountId, cliRes.ServiceInstanceId)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Service Binding (Subaccount)", fmt.Sprintf("%s", err))
+			return
+		}
+
+		data.ServiceInstanceName = types.StringValue(instanceRes.Name)
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
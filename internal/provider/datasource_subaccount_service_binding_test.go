@@ -2,7 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -26,6 +29,7 @@ func TestDataSourceSubaccountServiceBinding(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "id", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "name", "test-service-binding-iban"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "ready", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "service_instance_name", "tf-testacc-iban-sample"),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_binding.uut", "created_date", regexpValidRFC3999Format),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_binding.uut", "last_modified", regexpValidRFC3999Format),
 					),
@@ -50,6 +54,7 @@ func TestDataSourceSubaccountServiceBinding(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "id", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "name", "test-service-binding-iban"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "ready", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "service_instance_name", "tf-testacc-iban-sample"),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_binding.uut", "created_date", regexpValidRFC3999Format),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_binding.uut", "last_modified", regexpValidRFC3999Format),
 					),
@@ -58,6 +63,175 @@ func TestDataSourceSubaccountServiceBinding(t *testing.T) {
 		})
 
 	})
+	t.Run("happy path - service instance name resolved from the instance ID", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			if strings.HasSuffix(r.URL.Path, "/services/instance") {
+				fmt.Fprintf(w, `{"id": "b6a7d7da-41f4-4dfc-a883-fde826c2e9f9", "name": "my-instance", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5"}`)
+				return
+			}
+
+			fmt.Fprintf(w, `{"id": "b02e4b22-906b-40c5-9c5e-dbb6a9068444", "name": "my-binding", "service_instance_id": "b6a7d7da-41f4-4dfc-a883-fde826c2e9f9", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "context": {"platform": "kubernetes"}, "last_operation": {"state": "succeeded"}}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceBindingbyId("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "service_instance_id", "b6a7d7da-41f4-4dfc-a883-fde826c2e9f9"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "service_instance_name", "my-instance"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - rotation metadata", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "b02e4b22-906b-40c5-9c5e-dbb6a9068444", "name": "my-binding", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "context": {"instance_name": "my-instance"}, "last_operation": {"state": "succeeded"}, "rotation_history": ["2023-05-02T08:00:00Z", "2023-08-01T09:30:00Z"]}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceBindingbyId("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "last_rotated", "2023-08-01T09:30:00Z"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "rotation_history.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "rotation_history.0", "2023-05-02T08:00:00Z"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "rotation_history.1", "2023-08-01T09:30:00Z"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - rotation metadata unavailable", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "b02e4b22-906b-40c5-9c5e-dbb6a9068444", "name": "my-binding", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "context": {"instance_name": "my-instance"}, "last_operation": {"state": "succeeded"}}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceBindingbyId("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_binding.uut", "last_rotated"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_binding.uut", "rotation_history"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - credentials in different formats", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "b02e4b22-906b-40c5-9c5e-dbb6a9068444", "name": "my-binding", "service_instance_id": "b6a7d7da-41f4-4dfc-a883-fde826c2e9f9", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "context": {"instance_name": "my-instance"}, "credentials": {"clientid": "sb-my-app", "uaa": {"url": "https://my.auth.com"}}, "last_operation": {"state": "succeeded"}}`)
+		}))
+		defer srv.Close()
+
+		formats := map[string]string{
+			"json":   `{"clientid": "sb-my-app", "uaa": {"url": "https://my.auth.com"}}`,
+			"base64": "eyJjbGllbnRpZCI6ICJzYi1teS1hcHAiLCAidWFhIjogeyJ1cmwiOiAiaHR0cHM6Ly9teS5hdXRoLmNvbSJ9fQ==",
+			"dotenv": "CLIENTID=\"sb-my-app\"\nUAA_URL=\"https://my.auth.com\"\n",
+			"yaml":   "clientid: sb-my-app\nuaa: '{\"url\":\"https://my.auth.com\"}'\n",
+		}
+
+		steps := []resource.TestStep{}
+		for _, format := range credentialsFormats {
+			steps = append(steps, resource.TestStep{
+				Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceBindingWithCredentialsFormat("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444", format),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "credentials_format", format),
+					resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "formatted_credentials", formats[format]),
+				),
+			})
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps:                    steps,
+		})
+	})
+	t.Run("happy path - authentication settings of destination credentials", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "b02e4b22-906b-40c5-9c5e-dbb6a9068444", "name": "my-binding", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "context": {"instance_name": "my-destination"}, "credentials": {"uri": "https://destination-configuration.cfapps.eu10.hana.ondemand.com", "Authentication": "PrincipalPropagation", "ProxyType": "OnPremise"}, "last_operation": {"state": "succeeded"}}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceBindingWithDestinationCredentials("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "parse_destination_credentials", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "authentication_type", "PrincipalPropagation"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_binding.uut", "principal_propagation", "true"),
+					),
+				},
+				{
+					// the credentials are only parsed if requested
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceBindingbyId("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_binding.uut", "authentication_type"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_binding.uut", "principal_propagation"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - invalid credentials format", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceSubaccountServiceBindingWithCredentialsFormat("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b02e4b22-906b-40c5-9c5e-dbb6a9068444", "xml"),
+					ExpectError: regexp.MustCompile(`Attribute credentials_format value must be one of`),
+				},
+			},
+		})
+	})
 	t.Run("error path - subaccount_id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -113,6 +287,24 @@ func hclDatasourceSubaccountServiceBindingbyName(resourceName string, subaccount
 	return fmt.Sprintf(template, resourceName, subaccountId, bindingName)
 }
 
+func hclDatasourceSubaccountServiceBindingWithCredentialsFormat(resourceName string, subaccountId string, bindingId string, credentialsFormat string) string {
+	template := `data "btp_subaccount_service_binding" "%s" {
+	subaccount_id      = "%s"
+	id                 = "%s"
+	credentials_format = "%s"
+}`
+	return fmt.Sprintf(template, resourceName, subaccountId, bindingId, credentialsFormat)
+}
+
+func hclDatasourceSubaccountServiceBindingWithDestinationCredentials(resourceName string, subaccountId string, bindingId string) string {
+	template := `data "btp_subaccount_service_binding" "%s" {
+	subaccount_id                 = "%s"
+	id                            = "%s"
+	parse_destination_credentials = true
+}`
+	return fmt.Sprintf(template, resourceName, subaccountId, bindingId)
+}
+
 func hclDatasourceSubaccountServiceBindingNoSubaccount(resourceName string, bindingName string) string {
 	template := `data "btp_subaccount_service_binding" "%s" {
 	name          = "%s"
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
@@ -69,13 +70,21 @@ func (ds *subaccountServiceInstanceDataSource) Schema(_ context.Context, _ datas
 				Computed:            true,
 			},
 			"ready": schema.BoolAttribute{
-				MarkdownDescription: "",
+				MarkdownDescription: "Shows whether the service instance has been provisioned.",
 				Computed:            true,
 			},
 			"serviceplan_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the service plan.",
 				Computed:            true,
 			},
+			"service_plan_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the service plan. Null if the service plan can't be resolved, e.g. because it isn't visible in the subaccount anymore.",
+				Computed:            true,
+			},
+			"offering_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the service offering of the service plan. Null if the service offering can't be resolved.",
+				Computed:            true,
+			},
 			"platform_id": schema.StringAttribute{
 				MarkdownDescription: "The platform ID.",
 				Computed:            true,
@@ -94,7 +103,7 @@ func (ds *subaccountServiceInstanceDataSource) Schema(_ context.Context, _ datas
 				Computed:            true,
 			},
 			"usable": schema.BoolAttribute{
-				MarkdownDescription: "Shows whether the resource can be used.",
+				MarkdownDescription: "Shows whether the service instance can be used, e.g. to create bindings. Some service plans report an instance as `ready` before it is usable. Null if the service plan doesn't report the usability.",
 				Computed:            true,
 			},
 			"state": schema.StringAttribute{
@@ -146,11 +155,32 @@ func (ds *subaccountServiceInstanceDataSource) Read(ctx context.Context, req dat
 		return
 	}
 
+	subaccountId := data.SubaccountId.ValueString()
+
 	data, diags = subaccountServiceInstanceValueFrom(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
 
 	data.Parameters = types.StringNull() // TODO can be set once --show-parameters is works
+	data.ServicePlanName, data.OfferingName = ds.servicePlanAndOfferingNames(ctx, subaccountId, cliRes.ServicePlanId)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// servicePlanAndOfferingNames resolves the names of the service plan and its offering. The lookup is best effort, names
+// which can't be resolved are null.
+func (ds *subaccountServiceInstanceDataSource) servicePlanAndOfferingNames(ctx context.Context, subaccountId string, servicePlanId string) (types.String, types.String) {
+	servicePlan, _, err := ds.cli.Services.Plan.GetById(ctx, subaccountId, servicePlanId)
+	if err != nil {
+		tflog.Debug(ctx, "unable to look up the service plan of the service instance", map[string]interface{}{"serviceplan_id": servicePlanId, "error": err.Error()})
+		return types.StringNull(), types.StringNull()
+	}
+
+	serviceOffering, _, err := ds.cli.Services.Offering.GetById(ctx, subaccountId, servicePlan.ServiceOfferingId)
+	if err != nil {
+		tflog.Debug(ctx, "unable to look up the service offering of the service instance", map[string]interface{}{"service_offering_id": servicePlan.ServiceOfferingId, "error": err.Error()})
+		return stringNullIfEmpty(servicePlan.Name), types.StringNull()
+	}
+
+	return stringNullIfEmpty(servicePlan.Name), stringNullIfEmpty(serviceOffering.Name)
+}
@@ -1,8 +1,13 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -62,6 +67,105 @@ func TestDataSourceSubaccountServiceInstance(t *testing.T) {
 		})
 	})
 
+	t.Run("happy path - ready and usable are reported separately", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			switch r.URL.RawQuery {
+			case "get":
+				var body struct {
+					ParamValues map[string]string `json:"paramValues"`
+				}
+
+				content, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(content, &body); err != nil {
+					t.Errorf("unexpected request body: %s", content)
+				}
+
+				switch body.ParamValues["id"] {
+				case "df532d07-57a7-415e-a261-23a398ef068a":
+					fmt.Fprint(w, `{"id": "df532d07-57a7-415e-a261-23a398ef068a", "name": "not-usable", "ready": true, "usable": false, "last_operation": {"state": "succeeded"}}`)
+				default:
+					fmt.Fprint(w, `{"id": "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd", "name": "usability-not-reported", "ready": true, "last_operation": {"state": "succeeded"}}`)
+				}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) +
+						hclDatasourceSubaccountServiceInstanceById("not_usable", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "df532d07-57a7-415e-a261-23a398ef068a") +
+						hclDatasourceSubaccountServiceInstanceById("not_reported", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_instance.not_usable", "ready", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_instance.not_usable", "usable", "false"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_instance.not_reported", "ready", "true"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_instance.not_reported", "usable"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - names of service plan and offering", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/services/instance") && body.ParamValues["id"] == "df532d07-57a7-415e-a261-23a398ef068a":
+				fmt.Fprint(w, `{"id": "df532d07-57a7-415e-a261-23a398ef068a", "name": "tf-testacc-alertnotification-instance", "service_plan_id": "f0aac855-474d-4016-9529-61c062efbc7c", "ready": true, "last_operation": {"state": "succeeded"}}`)
+			case strings.HasSuffix(r.URL.Path, "/services/plan") && body.ParamValues["id"] == "f0aac855-474d-4016-9529-61c062efbc7c":
+				fmt.Fprint(w, `{"id": "f0aac855-474d-4016-9529-61c062efbc7c", "name": "standard", "service_offering_id": "b5a2a0b6-4ae5-4a8a-9a7a-3f0d4a4b5c6d"}`)
+			case strings.HasSuffix(r.URL.Path, "/services/offering") && body.ParamValues["id"] == "b5a2a0b6-4ae5-4a8a-9a7a-3f0d4a4b5c6d":
+				fmt.Fprint(w, `{"id": "b5a2a0b6-4ae5-4a8a-9a7a-3f0d4a4b5c6d", "name": "alert-notification"}`)
+			default:
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprint(w, `{"error": "not found"}`)
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountServiceInstanceById("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "df532d07-57a7-415e-a261-23a398ef068a"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_instance.uut", "serviceplan_id", "f0aac855-474d-4016-9529-61c062efbc7c"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_instance.uut", "service_plan_name", "standard"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_instance.uut", "offering_name", "alert-notification"),
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - specify ID and name", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
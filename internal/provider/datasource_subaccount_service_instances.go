@@ -175,7 +175,7 @@ func (ds *subaccountServiceInstancesDataSource) Read(ctx context.Context, req da
 			Ready:         types.BoolValue(serviceInstance.Ready),
 			ServicePlanId: types.StringValue(serviceInstance.ServicePlanId),
 			PlatformId:    types.StringValue(serviceInstance.PlatformId),
-			Usable:        types.BoolValue(serviceInstance.Usable),
+			Usable:        types.BoolPointerValue(serviceInstance.Usable),
 			CreatedDate:   timeToValue(serviceInstance.CreatedAt),
 			LastModified:  timeToValue(serviceInstance.UpdatedAt),
 		}
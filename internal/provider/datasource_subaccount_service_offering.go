@@ -39,6 +39,8 @@ type subaccountServiceOfferingDataSourceConfig struct {
 	CatalogName          types.String `tfsdk:"catalog_name"`
 	CreatedDate          types.String `tfsdk:"created_date"`
 	LastModified         types.String `tfsdk:"last_modified"`
+	Deprecated           types.Bool   `tfsdk:"deprecated"`
+	DeprecationDate      types.String `tfsdk:"deprecation_date"`
 }
 
 type subaccountServiceOfferingDataSource struct {
@@ -138,6 +140,14 @@ func (ds *subaccountServiceOfferingDataSource) Schema(_ context.Context, _ datas
 				MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
 				Computed:            true,
 			},
+			"deprecated": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether the service offering is deprecated and should no longer be used for new service instances.",
+				Computed:            true,
+			},
+			"deprecation_date": schema.StringAttribute{
+				MarkdownDescription: "The date of the deprecation of the service offering as provided by the service broker, if any.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -182,6 +192,7 @@ func (ds *subaccountServiceOfferingDataSource) Read(ctx context.Context, req dat
 	data.CatalogName = types.StringValue(cliRes.CatalogName)
 	data.CreatedDate = timeToValue(cliRes.CreatedAt)
 	data.LastModified = timeToValue(cliRes.UpdatedAt)
+	data.Deprecated, data.DeprecationDate = serviceOfferingDeprecation(cliRes)
 
 	data.Tags, diags = types.SetValueFrom(ctx, types.StringType, cliRes.Tags)
 	resp.Diagnostics.Append(diags...)
@@ -189,3 +200,12 @@ func (ds *subaccountServiceOfferingDataSource) Read(ctx context.Context, req dat
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// serviceOfferingDeprecation returns whether the service offering is deprecated and the date of the deprecation, which is null if unknown.
+func serviceOfferingDeprecation(offering servicemanager.ServiceOfferingResponseObject) (types.Bool, types.String) {
+	if offering.Metadata == nil {
+		return types.BoolValue(false), types.StringNull()
+	}
+
+	return types.BoolValue(offering.Metadata.Deprecated), stringNullIfEmpty(offering.Metadata.DeprecationDate)
+}
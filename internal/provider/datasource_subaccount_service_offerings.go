@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -40,6 +42,7 @@ type subaccountServiceOfferingsDataSourceConfig struct {
 	SubaccountId types.String `tfsdk:"subaccount_id"`
 	Id           types.String `tfsdk:"id"`
 	Environment  types.String `tfsdk:"environment"`
+	PlatformId   types.String `tfsdk:"platform_id"`
 	FieldsFilter types.String `tfsdk:"fields_filter"`
 	LabelsFilter types.String `tfsdk:"labels_filter"`
 	/* OUTPUT */
@@ -85,6 +88,16 @@ func (ds *subaccountServiceOfferingsDataSource) Schema(_ context.Context, _ data
 					getFormattedValueAsTableRow("`cloudfoundry`", "Cloud Foundry") +
 					getFormattedValueAsTableRow("`kubernetes`", "Kubernetes"),
 				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("platform_id")),
+				},
+			},
+			"platform_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of a platform registered in the subaccount. Lists only the services that can be consumed on the type of this platform, e.g. a Kubernetes cluster.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"fields_filter": schema.StringAttribute{
 				MarkdownDescription: "Filters the response based on the field query. For example, use \"name eq 'my service offering name'\".",
@@ -186,6 +199,16 @@ func (ds *subaccountServiceOfferingsDataSource) Read(ctx context.Context, req da
 	if !data.Environment.IsNull() {
 		environment = data.Environment.ValueString()
 	}
+	if !data.PlatformId.IsNull() {
+		// the offerings are scoped by the environment the platform belongs to
+		platform, _, err := ds.cli.Services.Platform.GetById(ctx, data.SubaccountId.ValueString(), data.PlatformId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Service Offerings (Subaccount)", fmt.Sprintf("%s", err))
+			return
+		}
+
+		environment = platform.Type_
+	}
 
 	cliRes, _, err := ds.cli.Services.Offering.List(ctx, data.SubaccountId.ValueString(), fieldsFilter, labelsFilter, environment)
 	if err != nil {
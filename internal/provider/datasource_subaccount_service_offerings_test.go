@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDataSourceSubaccountServiceOfferings(t *testing.T) {
@@ -67,6 +72,63 @@ func TestDataSourceSubaccountServiceOfferings(t *testing.T) {
 		})
 	})
 
+	t.Run("happy path - service offerings for subaccount and platform", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			if strings.HasSuffix(r.URL.Path, "/services/platform") {
+				assert.Equal(t, "1a8d3a5c-7b8f-4b31-9e5a-0b0d6e9a7d12", body.ParamValues["id"])
+				fmt.Fprintf(w, `{"id": "1a8d3a5c-7b8f-4b31-9e5a-0b0d6e9a7d12", "name": "my-cluster", "type": "kubernetes"}`)
+				return
+			}
+
+			assert.Equal(t, "kubernetes", body.ParamValues["environment"])
+			fmt.Fprintf(w, `[{"id": "b5b7c7f6-6f4c-4437-9c2f-8d6a2b5e2b9a", "name": "xsuaa"}, {"id": "c8a3a0a4-5b1e-4bb8-8b0a-7d7c6d2c3e1f", "name": "destination"}]`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountOfferingsBySubaccountAndPlatform("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "1a8d3a5c-7b8f-4b31-9e5a-0b0d6e9a7d12"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_offerings.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_offerings.uut", "values.0.name", "xsuaa"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_offerings.uut", "values.1.name", "destination"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - environment and platform_id are mutually exclusive", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + `
+data "btp_subaccount_service_offerings" "uut" {
+    subaccount_id = "59cd458e-e66e-4b60-b6d8-8f219379f9a5"
+    environment   = "cloudfoundry"
+    platform_id   = "1a8d3a5c-7b8f-4b31-9e5a-0b0d6e9a7d12"
+}`,
+					ExpectError: regexp.MustCompile(`Attribute "platform_id" cannot be specified when "environment" is specified`),
+				},
+			},
+		})
+	})
 	t.Run("error path - subaccount_id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -122,3 +184,13 @@ data "btp_subaccount_service_offerings" "%s" {
 
 	return fmt.Sprintf(template, resourceName, subaccountId)
 }
+
+func hclDatasourceSubaccountOfferingsBySubaccountAndPlatform(resourceName string, subaccountId string, platformId string) string {
+	template := `
+data "btp_subaccount_service_offerings" "%s" {
+     subaccount_id = "%s"
+     platform_id   = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, platformId)
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -27,15 +28,20 @@ type subaccountServicePlanDataSourceConfig struct {
 	Name         types.String `tfsdk:"name"`
 	OfferingName types.String `tfsdk:"offering_name"`
 	/* OUTPUT */
-	Ready             types.Bool   `tfsdk:"ready"`
-	Description       types.String `tfsdk:"description"`
-	CatalogId         types.String `tfsdk:"catalog_id"`
-	CatalogName       types.String `tfsdk:"catalog_name"`
-	Free              types.Bool   `tfsdk:"free"`
-	Bindable          types.Bool   `tfsdk:"bindable"`
-	ServiceOfferingId types.String `tfsdk:"serviceoffering_id"`
-	CreatedDate       types.String `tfsdk:"created_date"`
-	LastModified      types.String `tfsdk:"last_modified"`
+	Ready                types.Bool   `tfsdk:"ready"`
+	Description          types.String `tfsdk:"description"`
+	CatalogId            types.String `tfsdk:"catalog_id"`
+	CatalogName          types.String `tfsdk:"catalog_name"`
+	Free                 types.Bool   `tfsdk:"free"`
+	Bindable             types.Bool   `tfsdk:"bindable"`
+	Unique               types.Bool   `tfsdk:"unique"`
+	ServiceOfferingId    types.String `tfsdk:"serviceoffering_id"`
+	CreatedDate          types.String `tfsdk:"created_date"`
+	LastModified         types.String `tfsdk:"last_modified"`
+	CreateInstanceSchema types.String `tfsdk:"create_instance_schema"`
+	CreateBindingSchema  types.String `tfsdk:"create_binding_schema"`
+	Deprecated           types.Bool   `tfsdk:"deprecated"`
+	DeprecationDate      types.String `tfsdk:"deprecation_date"`
 }
 
 type subaccountServicePlanDataSource struct {
@@ -116,6 +122,10 @@ func (ds *subaccountServicePlanDataSource) Schema(_ context.Context, _ datasourc
 				MarkdownDescription: "Shows whether the service plan is bindable.",
 				Computed:            true,
 			},
+			"unique": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether only one service instance of the service plan can be created per subaccount.",
+				Computed:            true,
+			},
 			"serviceoffering_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the service offering.",
 				Computed:            true,
@@ -128,6 +138,22 @@ func (ds *subaccountServicePlanDataSource) Schema(_ context.Context, _ datasourc
 				MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
 				Computed:            true,
 			},
+			"create_instance_schema": schema.StringAttribute{
+				MarkdownDescription: "The JSON schema of the parameters accepted when creating a service instance of the plan, if the plan provides one.",
+				Computed:            true,
+			},
+			"create_binding_schema": schema.StringAttribute{
+				MarkdownDescription: "The JSON schema of the parameters accepted when creating a service binding of the plan, if the plan provides one.",
+				Computed:            true,
+			},
+			"deprecated": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether the service plan is deprecated and should no longer be used for new service instances.",
+				Computed:            true,
+			},
+			"deprecation_date": schema.StringAttribute{
+				MarkdownDescription: "The date of the deprecation of the service plan as provided by the service broker, if any.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -166,10 +192,58 @@ func (ds *subaccountServicePlanDataSource) Read(ctx context.Context, req datasou
 	data.CatalogName = types.StringValue(cliRes.CatalogName)
 	data.Free = types.BoolValue(cliRes.Free)
 	data.Bindable = types.BoolValue(cliRes.Bindable)
+	data.Unique = types.BoolValue(cliRes.Unique)
 	data.ServiceOfferingId = types.StringValue(cliRes.ServiceOfferingId)
 	data.CreatedDate = timeToValue(cliRes.CreatedAt)
 	data.LastModified = timeToValue(cliRes.UpdatedAt)
+	data.CreateInstanceSchema = servicePlanCreateInstanceSchema(cliRes)
+	data.CreateBindingSchema = servicePlanCreateBindingSchema(cliRes)
+	data.Deprecated, data.DeprecationDate = servicePlanDeprecation(cliRes)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// servicePlanCreateInstanceSchema returns the JSON schema of the parameters for creating a service instance, or null if the plan has none.
+func servicePlanCreateInstanceSchema(plan servicemanager.ServicePlanResponseObject) types.String {
+	if plan.Schemas == nil || plan.Schemas.ServiceInstance == nil || plan.Schemas.ServiceInstance.Create == nil {
+		return types.StringNull()
+	}
+
+	return jsonSchemaToValue(plan.Schemas.ServiceInstance.Create.Parameters)
+}
+
+// servicePlanUpdateInstanceSchema returns the JSON schema of the parameters for updating a service instance, or null if the plan has none.
+func servicePlanUpdateInstanceSchema(plan servicemanager.ServicePlanResponseObject) types.String {
+	if plan.Schemas == nil || plan.Schemas.ServiceInstance == nil || plan.Schemas.ServiceInstance.Update == nil {
+		return types.StringNull()
+	}
+
+	return jsonSchemaToValue(plan.Schemas.ServiceInstance.Update.Parameters)
+}
+
+// servicePlanCreateBindingSchema returns the JSON schema of the parameters for creating a service binding, or null if the plan has none.
+func servicePlanCreateBindingSchema(plan servicemanager.ServicePlanResponseObject) types.String {
+	if plan.Schemas == nil || plan.Schemas.ServiceBinding == nil || plan.Schemas.ServiceBinding.Create == nil {
+		return types.StringNull()
+	}
+
+	return jsonSchemaToValue(plan.Schemas.ServiceBinding.Create.Parameters)
+}
+
+// servicePlanDeprecation returns whether the service plan is deprecated and the date of the deprecation, which is null if unknown.
+func servicePlanDeprecation(plan servicemanager.ServicePlanResponseObject) (types.Bool, types.String) {
+	if plan.Metadata == nil {
+		return types.BoolValue(false), types.StringNull()
+	}
+
+	return types.BoolValue(plan.Metadata.Deprecated), stringNullIfEmpty(plan.Metadata.DeprecationDate)
+}
+
+func jsonSchemaToValue(schema json.RawMessage) types.String {
+	if len(schema) == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(schema))
+}
@@ -2,7 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -27,8 +30,11 @@ func TestDataSourceSubaccountServicePlan(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "ready", "true"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "catalog_name", "lite"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "free", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "unique", "false"),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_plan.uut", "created_date", regexpValidRFC3999Format),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_plan.uut", "last_modified", regexpValidRFC3999Format),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_plan.uut", "create_instance_schema"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_plan.uut", "create_binding_schema"),
 					),
 				},
 			},
@@ -53,6 +59,66 @@ func TestDataSourceSubaccountServicePlan(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "free", "true"),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_plan.uut", "created_date", regexpValidRFC3999Format),
 						resource.TestMatchResourceAttr("data.btp_subaccount_service_plan.uut", "last_modified", regexpValidRFC3999Format),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_plan.uut", "create_instance_schema"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_plan.uut", "create_binding_schema"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - service plan with schemas", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprint(w, servicePlanWithSchemasResponse)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountPlanById("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "b50d1b0b-2059-4f21-a014-2ea87752eb48"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "name", "application"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "create_instance_schema", servicePlanCreateInstanceSchemaJSON),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "create_binding_schema", `{"type":"object","properties":{"credential-type":{"type":"string","enum":["binding-secret","x509"]}}}`),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "deprecated", "false"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_service_plan.uut", "deprecation_date"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - deprecated service plan", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprint(w, servicePlanDeprecatedResponse)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountPlanById("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "name", "lite"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "deprecated", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_plan.uut", "deprecation_date", "2023-12-31"),
 					),
 				},
 			},
@@ -87,6 +153,13 @@ func TestDataSourceSubaccountServicePlan(t *testing.T) {
 
 }
 
+// servicePlanCreateInstanceSchemaJSON is the schema of the parameters for creating an instance of the plan returned by servicePlanWithSchemasResponse.
+const servicePlanCreateInstanceSchemaJSON = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object","properties":{"xsappname":{"type":"string","maxLength":100}},"required":["xsappname"],"additionalProperties":false}`
+
+const servicePlanWithSchemasResponse = `{"id":"b50d1b0b-2059-4f21-a014-2ea87752eb48","ready":true,"name":"application","catalog_name":"application","free":true,"bindable":true,"service_offering_id":"7ad5d1a5-5b6f-4e6e-bb49-38a6b34d2a27","schemas":{"service_instance":{"create":{"parameters":` + servicePlanCreateInstanceSchemaJSON + `}},"service_binding":{"create":{"parameters":{"type":"object","properties":{"credential-type":{"type":"string","enum":["binding-secret","x509"]}}}}}}}`
+
+const servicePlanDeprecatedResponse = `{"id":"02fed361-89c1-4560-82c3-0deaf93ac75b","ready":true,"name":"lite","catalog_name":"lite","free":true,"bindable":true,"service_offering_id":"7ad5d1a5-5b6f-4e6e-bb49-38a6b34d2a27","metadata":{"deprecated":true,"deprecationDate":"2023-12-31"}}`
+
 func hclDatasourceSubaccountPlanById(resourceName string, subaccountId string, planId string) string {
 	template := `
 data "btp_subaccount_service_plan" "%s" { 
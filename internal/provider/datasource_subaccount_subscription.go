@@ -62,6 +62,38 @@ You must be assigned to the subaccount admin or viewer role.`,
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"fetch_available_plans": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, all plans of the multitenant application the subaccount is entitled to are fetched as well. As this requires an additional request, they are not fetched by default.",
+				Optional:            true,
+			},
+			"available_plans": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"plan_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the plan.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The technical name of the category to which the multitenant application is grouped for this plan.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the plan.",
+							Computed:            true,
+						},
+						"quota": schema.Int64Attribute{
+							MarkdownDescription: "The total amount the subaccount is entitled to consume.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The subscription state of the subaccount regarding the plan.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The plans of the multitenant application the subaccount is entitled to, sorted by plan name. Only available if `fetch_available_plans` is set to `true`.",
+				Computed:            true,
+			},
 			"parameters": schema.StringAttribute{
 				MarkdownDescription: "The parameters of the subscription as a valid JSON object.",
 				Computed:            true,
@@ -127,6 +159,10 @@ You must be assigned to the subaccount admin or viewer role.`,
 				MarkdownDescription: "The subscription state of the subaccount regarding the multitenant application.",
 				Computed:            true,
 			},
+			"recoverable": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether a failed subscription is likely to succeed when retried, e.g. because the app provider timed out or was temporarily unavailable. Always `false` if the subscription didn't fail.",
+				Computed:            true,
+			},
 			"subscribed_subaccount_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the subaccount which is subscribed to the multitenant application.",
 				Computed:            true,
@@ -167,7 +203,7 @@ You must be assigned to the subaccount admin or viewer role.`,
 }
 
 func (ds *subaccountSubscriptionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data subaccountSubscriptionType
+	var data subaccountSubscriptionDataSourceType
 
 	diags := req.Config.Get(ctx, &data)
 
@@ -182,9 +218,24 @@ func (ds *subaccountSubscriptionDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 
-	data, diags = subaccountSubscriptionValueFrom(ctx, cliRes)
+	subaccountId, fetchAvailablePlans := data.SubaccountId.ValueString(), data.FetchAvailablePlans
+
+	data, diags = subaccountSubscriptionDataSourceValueFrom(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
 
+	data.FetchAvailablePlans = fetchAvailablePlans
+
+	if fetchAvailablePlans.ValueBool() {
+		entitledApps, _, err := ds.cli.Accounts.Subscription.List(ctx, subaccountId)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Reading Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
+			return
+		}
+
+		data.AvailablePlans, diags = subaccountSubscriptionAvailablePlansFrom(ctx, data.AppName.ValueString(), entitledApps)
+		resp.Diagnostics.Append(diags...)
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
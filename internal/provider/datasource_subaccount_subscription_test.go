@@ -2,7 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -35,6 +38,54 @@ func TestDataSourceSubaccountSubscription(t *testing.T) {
 		})
 
 	})
+	t.Run("happy path - with available plans", func(t *testing.T) {
+		srv := newSubscriptionAvailablePlansTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountSubscriptionWithAvailablePlans("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.0.plan_name", "free"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.0.category", "Foundation / Cross Services"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.0.state", "SUBSCRIBED"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.1.plan_name", "standard"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.1.category", "Security"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.1.quota", "3"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "available_plans.1.state", "NOT_SUBSCRIBED"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - recoverable and non-recoverable failures", func(t *testing.T) {
+		for appErrorStatus, recoverable := range map[int]string{
+			http.StatusGatewayTimeout: "true",
+			http.StatusBadRequest:     "false",
+		} {
+			srv := newFailedSubscriptionTestServer(appErrorStatus)
+
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: getProviders(srv.Client()),
+				Steps: []resource.TestStep{
+					{
+						Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountSubscriptionByIdAndPlan("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "state", "SUBSCRIBE_FAILED"),
+							resource.TestCheckResourceAttr("data.btp_subaccount_subscription.uut", "recoverable", recoverable),
+						),
+					},
+				},
+			})
+
+			srv.Close()
+		}
+	})
 	t.Run("error path - subaccount_id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -82,6 +133,16 @@ func hclDatasourceSubaccountSubscriptionByIdAndPlan(resourceName string, subacco
 	return fmt.Sprintf(template, resourceName, subaccountId, appName, planName)
 }
 
+func hclDatasourceSubaccountSubscriptionWithAvailablePlans(resourceName string, subaccountId string, appName string, planName string) string {
+	template := `data "btp_subaccount_subscription" "%s" {
+	subaccount_id         = "%s"
+	app_name              = "%s"
+	plan_name             = "%s"
+	fetch_available_plans = true
+}`
+	return fmt.Sprintf(template, resourceName, subaccountId, appName, planName)
+}
+
 func hclDatasourceSubaccountSubscriptionNoSubaccountId(resourceName string, appName string, planName string) string {
 	template := `data "btp_subaccount_subscription" "%s" {
 	app_name      = "%s"
@@ -97,3 +158,38 @@ func hclDatasourceSubaccountSubscriptionNoAppName(resourceName string, subaccoun
 }`
 	return fmt.Sprintf(template, resourceName, subaccountId, planName)
 }
+
+func newSubscriptionAvailablePlansTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if r.URL.RawQuery == "list" {
+			fmt.Fprintf(w, `{"applications": [
+				{"appName": "auditlog-viewer", "planName": "standard", "category": "Security", "quota": 3, "state": "NOT_SUBSCRIBED"},
+				{"appName": "content-agent-ui", "planName": "free", "category": "Foundation / Cross Services", "quota": 1, "state": "SUBSCRIBED"},
+				{"appName": "auditlog-viewer", "planName": "free", "category": "Foundation / Cross Services", "quota": 1, "state": "SUBSCRIBED"}
+			]}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"appName": "auditlog-viewer", "planName": "free", "category": "Foundation / Cross Services", "quota": 1, "state": "SUBSCRIBED", "subscribedSubaccountId": "59cd458e-e66e-4b60-b6d8-8f219379f9a5"}`)
+	}))
+}
+
+// newFailedSubscriptionTestServer simulates a failed subscription, for which the app provider responded with the given status.
+func newFailedSubscriptionTestServer(appErrorStatus int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"appName": "auditlog-viewer", "planName": "free", "state": "SUBSCRIBE_FAILED", "subscriptionError": {"errorMessage": "The app provider rejected the subscription", "appError": "{\"status\": %d}"}, "subscribedSubaccountId": "59cd458e-e66e-4b60-b6d8-8f219379f9a5"}`, appErrorStatus)
+	}))
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -37,11 +38,55 @@ func TestDataSourceSubaccount(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount.test", "state", "OK"),
 						resource.TestCheckResourceAttr("data.btp_subaccount.test", "subdomain", "integration-test-acc-static-b8xxozer"),
 						resource.TestCheckResourceAttr("data.btp_subaccount.test", "usage", "NOT_USED_FOR_PRODUCTION"),
+						resource.TestCheckResourceAttr("data.btp_subaccount.test", "ancestry.#", "0"),
 					),
 				},
 			},
 		})
 	})
+	t.Run("happy path - ancestry of a nested subaccount", func(t *testing.T) {
+		srv := newSubaccountAncestryTestServer(t, map[string]string{
+			"ef23ace8-6ade-4d78-9c1f-8df729548bbf": "5357bda0-8651-4eab-a69d-12d282bc3247",
+			"5357bda0-8651-4eab-a69d-12d282bc3247": "1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b",
+			"1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b": "03760ecf-9d89-4189-a92a-1c7efed09298",
+		})
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccount("test", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount.test", "parent_id", "5357bda0-8651-4eab-a69d-12d282bc3247"),
+						resource.TestCheckResourceAttr("data.btp_subaccount.test", "ancestry.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccount.test", "ancestry.0", "5357bda0-8651-4eab-a69d-12d282bc3247"),
+						resource.TestCheckResourceAttr("data.btp_subaccount.test", "ancestry.1", "1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - cycle in the directory hierarchy", func(t *testing.T) {
+		srv := newSubaccountAncestryTestServer(t, map[string]string{
+			"ef23ace8-6ade-4d78-9c1f-8df729548bbf": "5357bda0-8651-4eab-a69d-12d282bc3247",
+			"5357bda0-8651-4eab-a69d-12d282bc3247": "1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b",
+			"1bf2a5e3-1f4d-4b6e-9a08-4d6b8620dc6b": "5357bda0-8651-4eab-a69d-12d282bc3247",
+		})
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccount("test", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					ExpectError: regexp.MustCompile(`contains a cycle at directory 5357bda0-8651-4eab-a69d-12d282bc3247`),
+				},
+			},
+		})
+	})
 	t.Run("error path - subaccount doesn't exist", func(t *testing.T) {
 		rec := setupVCR(t, "fixtures/datasource_subaccount.err_subaccount_doesnt_exist")
 		defer stopQuietly(rec)
@@ -104,6 +149,31 @@ func TestDataSourceSubaccount(t *testing.T) {
 	})
 }
 
+// newSubaccountAncestryTestServer returns a CLI server serving the subaccount and directories with the given parents.
+func newSubaccountAncestryTestServer(t *testing.T, parents map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode request body: %s", err)
+		}
+
+		id := payload.ParamValues["subaccount"]
+		if strings.HasSuffix(r.URL.Path, "/accounts/directory") {
+			id = payload.ParamValues["directoryID"]
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"guid": "%s", "globalAccountGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "parentGUID": "%s"}`, id, parents[id])
+	}))
+}
+
 func hclDatasourceSubaccount(resourceName string, id string) string {
 	return fmt.Sprintf(`data "btp_subaccount" "%s" { id = "%s" }`, resourceName, id)
 }
@@ -57,6 +57,10 @@ __Further documentation:__
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"fetch_metadata": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, the SAML metadata document of the identity provider is fetched as well. As the document can be large, it is not fetched by default.",
+				Optional:            true,
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the trust configuration.",
 				Computed:            true,
@@ -81,6 +85,14 @@ __Further documentation:__
 				MarkdownDescription: "The protocol used to establish trust with the identity provider.",
 				Computed:            true,
 			},
+			"protocol_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the protocol used to establish trust with the identity provider. Possible values are: \n " +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`saml2`", "The trust is established with SAML 2.0.") +
+					getFormattedValueAsTableRow("`openIdConnect`", "The trust is established with OpenID Connect."),
+				Computed: true,
+			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "Shows whether the identity provider is currently active or not.",
 				Computed:            true,
@@ -89,6 +101,10 @@ __Further documentation:__
 				MarkdownDescription: "Shows whether the trust configuration can be modified.",
 				Computed:            true,
 			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "The SAML metadata document which is stored for the identity provider. Only available if `fetch_metadata` is set to `true`.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -103,7 +119,7 @@ func (ds *subaccountTrustConfigurationDataSource) Read(ctx context.Context, req
 		return
 	}
 
-	cliRes, _, err := ds.cli.Security.Trust.GetBySubaccount(ctx, data.SubaccountId.ValueString(), data.Origin.ValueString())
+	cliRes, err := getSubaccountTrustConfiguration(ctx, ds.cli, data.SubaccountId.ValueString(), data.Origin.ValueString(), data.FetchMetadata.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Reading Resource Trust Configuration (Subaccount)", fmt.Sprintf("%s", err))
 		return
@@ -111,6 +127,7 @@ func (ds *subaccountTrustConfigurationDataSource) Read(ctx context.Context, req
 
 	state, diags := subaccountTrustConfigurationFromValue(ctx, cliRes)
 	state.SubaccountId = data.SubaccountId
+	state.FetchMetadata = data.FetchMetadata
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &state)
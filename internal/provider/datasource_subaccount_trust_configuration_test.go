@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -29,6 +30,7 @@ func TestDataSourceSubaccountTrustConfiguration(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "identity_provider", ""),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "name", "sap.default"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "protocol", "OpenID Connect"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "read_only", "false"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "status", "active"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "type", "Application"),
@@ -53,6 +55,7 @@ func TestDataSourceSubaccountTrustConfiguration(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "identity_provider", "terraformint.accounts400.ondemand.com"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "name", "terraformint-platform"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "protocol", "OpenID Connect"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "read_only", "true"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "status", "active"),
 						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "type", "Platform"),
@@ -109,6 +112,46 @@ func TestDataSourceSubaccountTrustConfiguration(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - with metadata", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			if strings.Contains(string(body), `"includeMetadata":"true"`) {
+				fmt.Fprintf(w, `{"originKey": "terraformint-platform", "name": "terraformint-platform", "metadata": "<md:EntityDescriptor entityID=\"terraformint.accounts400.ondemand.com\"/>"}`)
+			} else {
+				fmt.Fprintf(w, `{"originKey": "terraformint-platform", "name": "terraformint-platform"}`)
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountTrustConfigurationWithMetadata("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "terraformint-platform"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "id", "terraformint-platform"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "fetch_metadata", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "metadata", `<md:EntityDescriptor entityID="terraformint.accounts400.ondemand.com"/>`),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountTrustConfiguration("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "terraformint-platform"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_trust_configuration.uut", "id", "terraformint-platform"),
+						resource.TestCheckNoResourceAttr("data.btp_subaccount_trust_configuration.uut", "metadata"),
+					),
+				},
+			},
+		})
+	})
 }
 
 func hclDatasourceSubaccountTrustConfiguration(resourceName string, subaccountId string, origin string) string {
@@ -119,3 +162,13 @@ data "btp_subaccount_trust_configuration" "%s" {
 }`
 	return fmt.Sprintf(template, resourceName, subaccountId, origin)
 }
+
+func hclDatasourceSubaccountTrustConfigurationWithMetadata(resourceName string, subaccountId string, origin string) string {
+	template := `
+data "btp_subaccount_trust_configuration" "%s" {
+    subaccount_id  = "%s"
+	origin         = "%s"
+	fetch_metadata = true
+}`
+	return fmt.Sprintf(template, resourceName, subaccountId, origin)
+}
@@ -61,7 +61,7 @@ func (ds *subaccountUserDataSource) Schema(_ context.Context, _ datasource.Schem
 				},
 			},
 			"origin": schema.StringAttribute{
-				MarkdownDescription: "The identity provider that hosts the user. The default value is 'ldap'",
+				MarkdownDescription: "The identity provider that hosts the user. The default is the `default_origin` of the provider, or `ldap` if not set.",
 				Computed:            true,
 				Optional:            true,
 				Validators: []validator.String{
@@ -119,7 +119,7 @@ func (ds *subaccountUserDataSource) Read(ctx context.Context, req datasource.Rea
 	}
 
 	if data.Origin.IsNull() {
-		data.Origin = types.StringValue("ldap")
+		data.Origin = defaultOrigin(ds.cli)
 	}
 
 	cliRes, _, err := ds.cli.Security.User.GetBySubaccount(ctx, data.SubaccountId.ValueString(), data.UserName.ValueString(), data.Origin.ValueString())
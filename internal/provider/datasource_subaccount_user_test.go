@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -38,6 +39,43 @@ func TestDataSourceSubaccountUser(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - default origin of the provider", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+
+			if payload.ParamValues["origin"] != "my-ias" {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "user not found"}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "de350a51-fa8f-4bdf-bd75-79179b846911", "username": "jenny.doe@test.com", "origin": "my-ias"}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithDefaultOrigin(srv.URL, "my-ias") + hclDatasourceSubaccountUser("uut", "5381d6a4-d67f-45b1-93a0-624876f74d03", "jenny.doe@test.com"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccount_user.uut", "origin", "my-ias"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_user.uut", "id", "de350a51-fa8f-4bdf-bd75-79179b846911"),
+					),
+				},
+			},
+		})
+	})
 	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -119,3 +157,25 @@ data "btp_subaccount_user" "%s" {
 
 	return fmt.Sprintf(template, resourceName, subaccountId, userName, origin)
 }
+
+func hclDatasourceSubaccountUser(resourceName string, subaccountId string, userName string) string {
+	template := `
+data "btp_subaccount_user" "%s" {
+	subaccount_id = "%s"
+	user_name     = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, userName)
+}
+
+func hclProviderWithDefaultOrigin(cliServerURL string, defaultOrigin string) string {
+	return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    default_origin = "%s"
+}
+    `, cliServerURL, defaultOrigin)
+}
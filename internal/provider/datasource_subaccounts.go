@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
 var subaccountObjType = types.ObjectType{
@@ -47,6 +48,8 @@ func newSubaccountsDataSource() datasource.DataSource {
 type subaccountsType struct {
 	Id           types.String `tfsdk:"id"`
 	LabelsFilter types.String `tfsdk:"labels_filter"`
+	Region       types.String `tfsdk:"region"`
+	ParentId     types.String `tfsdk:"parent_id"`
 	Values       types.List   `tfsdk:"values"`
 }
 
@@ -68,7 +71,7 @@ func (ds *subaccountsDataSource) Configure(_ context.Context, req datasource.Con
 
 func (ds *subaccountsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: `Gets all the subaccounts in a global account, including the subaccounts in directories.
+		MarkdownDescription: `Gets all the subaccounts in a global account, including the subaccounts in directories. If several filters are set, only the subaccounts which match all of them are returned.
 
 __Tip:__
 You must be assigned to the admin or viewer role of the global account, directory.`,
@@ -80,6 +83,20 @@ You must be assigned to the admin or viewer role of the global account, director
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Filters the subaccounts by the region in which they were created.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "Filters the subaccounts by the ID of their parent entity, i.e. the directory or global account in which they are located directly.",
+				Optional:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+			},
 			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
 				DeprecationMessage:  "Use the `btp_globalaccount` datasource instead",
 				MarkdownDescription: "The ID of the global account.",
@@ -211,6 +228,14 @@ func (ds *subaccountsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	subaccountConfigs := []subaccountType{}
 
 	for _, subaccountRes := range cliRes.Value {
+		if !data.Region.IsNull() && subaccountRes.Region != data.Region.ValueString() {
+			continue
+		}
+
+		if !data.ParentId.IsNull() && subaccountRes.ParentGUID != data.ParentId.ValueString() {
+			continue
+		}
+
 		c := subaccountType{
 			ID:           types.StringValue(subaccountRes.Guid),
 			BetaEnabled:  types.BoolValue(subaccountRes.BetaEnabled),
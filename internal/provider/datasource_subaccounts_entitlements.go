@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+// subaccountsEntitlementsMaxConcurrency limits the number of parallel requests sent to the CLI server.
+const subaccountsEntitlementsMaxConcurrency = 5
+
+func newSubaccountsEntitlementsDataSource() datasource.DataSource {
+	return &subaccountsEntitlementsDataSource{}
+}
+
+type subaccountsEntitlementsDataSourceConfig struct {
+	/* INPUT */
+	SubaccountIds types.Set `tfsdk:"subaccount_ids"`
+	/* OUTPUT */
+	Id     types.String `tfsdk:"id"`
+	Values types.Map    `tfsdk:"values"`
+}
+
+type subaccountEntitlementsValue struct {
+	Entitlements types.Map `tfsdk:"entitlements"`
+}
+
+type subaccountsEntitlementsDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *subaccountsEntitlementsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccounts_entitlements", req.ProviderTypeName)
+}
+
+func (ds *subaccountsEntitlementsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *subaccountsEntitlementsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets all the entitlements and quota assignments for a list of subaccounts.
+
+To get all entitlements and quota assigned to the subaccounts:
+* You must be assigned to either the subaccount admin or subaccount viewer role of each subaccount.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the global account.",
+				Computed:            true,
+			},
+			"subaccount_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the subaccounts.",
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(uuidvalidator.ValidUUID()),
+				},
+			},
+			"values": schema.MapNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entitlements": schema.MapNestedAttribute{
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: entitledServiceAttributes(),
+							},
+							MarkdownDescription: "The entitlements of the subaccount, keyed by `<service_name>:<plan_name>`.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The entitlements keyed by the ID of the subaccount.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *subaccountsEntitlementsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data subaccountsEntitlementsDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var subaccountIds []string
+	diags = data.SubaccountIds.ElementsAs(ctx, &subaccountIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the requests are sent in a stable order, so that errors are reported deterministically
+	sort.Strings(subaccountIds)
+
+	cliRes, err := ds.cli.Accounts.Entitlement.ListBySubaccounts(ctx, subaccountIds, subaccountsEntitlementsMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Entitlements (Subaccounts)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	values := map[string]subaccountEntitlementsValue{}
+	for _, subaccountId := range subaccountIds {
+		var value subaccountEntitlementsValue
+
+		value.Entitlements, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: entitledServiceType()}, subaccountEntitledServicesFrom(cliRes[subaccountId]))
+		resp.Diagnostics.Append(diags...)
+
+		values[subaccountId] = value
+	}
+
+	data.Id = types.StringValue(ds.cli.GetGlobalAccountSubdomain())
+	data.Values, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: map[string]attr.Type{
+		"entitlements": types.MapType{ElemType: types.ObjectType{AttrTypes: entitledServiceType()}},
+	}}, values)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceSubaccountsEntitlements(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"entitledServices": [{"name": "alert-notification", "servicePlans": [{"name": "standard", "amount": 1, "category": "SERVICE"}]}, {"name": "subaccount-%s", "servicePlans": [{"name": "free", "category": "APPLICATION"}]}]}`, payload.ParamValues["subaccountFilter"])
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountsEntitlements("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccounts_entitlements.uut", "values.%", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_entitlements.uut", "values.ef23ace8-6ade-4d78-9c1f-8df729548bbf.entitlements.%", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_entitlements.uut", "values.ef23ace8-6ade-4d78-9c1f-8df729548bbf.entitlements.alert-notification:standard.quota_assigned", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_entitlements.uut", "values.ef23ace8-6ade-4d78-9c1f-8df729548bbf.entitlements.subaccount-ef23ace8-6ade-4d78-9c1f-8df729548bbf:free.category", "APPLICATION"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_entitlements.uut", "values.6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f.entitlements.subaccount-6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f:free.category", "APPLICATION"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - subaccount_ids not valid UUIDs", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclDatasourceSubaccountsEntitlements("uut", "this-is-not-a-uuid"),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - subaccount_ids mandatory", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + `data "btp_subaccounts_entitlements" "uut" {}`,
+					ExpectError: regexp.MustCompile(`The argument "subaccount_ids" is required, but no definition was found.`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+
+			if payload.ParamValues["subaccountFilter"] == "ef23ace8-6ade-4d78-9c1f-8df729548bbf" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclDatasourceSubaccountsEntitlements("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+					ExpectError: regexp.MustCompile(`entitlements of subaccount ef23ace8-6ade-4d78-9c1f-8df729548bbf`),
+				},
+			},
+		})
+	})
+}
+
+func hclDatasourceSubaccountsEntitlements(resourceName string, subaccountIds ...string) string {
+	template := `data "btp_subaccounts_entitlements" "%s" { subaccount_ids = ["%s"] }`
+
+	return fmt.Sprintf(template, resourceName, strings.Join(subaccountIds, `", "`))
+}
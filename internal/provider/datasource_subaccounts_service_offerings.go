@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
+)
+
+// subaccountsServiceOfferingsMaxConcurrency limits the number of parallel requests sent to the CLI server.
+const subaccountsServiceOfferingsMaxConcurrency = 5
+
+func newSubaccountsServiceOfferingsDataSource() datasource.DataSource {
+	return &subaccountsServiceOfferingsDataSource{}
+}
+
+type subaccountsServiceOfferingValue struct {
+	Name          types.String `tfsdk:"name"`
+	CatalogId     types.String `tfsdk:"catalog_id"`
+	Description   types.String `tfsdk:"description"`
+	SubaccountIds types.Set    `tfsdk:"subaccount_ids"`
+}
+
+type subaccountsServiceOfferingsDataSourceConfig struct {
+	/* INPUT */
+	Environment types.String `tfsdk:"environment"`
+	/* OUTPUT */
+	Id     types.String                      `tfsdk:"id"`
+	Values []subaccountsServiceOfferingValue `tfsdk:"values"`
+}
+
+type subaccountsServiceOfferingsDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *subaccountsServiceOfferingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccounts_service_offerings", req.ProviderTypeName)
+}
+
+func (ds *subaccountsServiceOfferingsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *subaccountsServiceOfferingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists the service offerings available in the subaccounts of the global account, along with the subaccounts each service offering is available in. This helps to discover which services can be consumed when planning new landscapes.
+
+__Tip:__
+You must be assigned to the admin or viewer role of the global account, and to the admin or viewer role of each subaccount.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The subdomain of the global account.",
+				Computed:            true,
+			},
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Lists services to be consumed in a Cloud Foundry or Kubernetes-native way. Valid values are: \n " +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`cloudfoundry`", "Cloud Foundry") +
+					getFormattedValueAsTableRow("`kubernetes`", "Kubernetes"),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"values": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the service offering.",
+							Computed:            true,
+						},
+						"catalog_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the service offering as provided by the catalog.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the service offering.",
+							Computed:            true,
+						},
+						"subaccount_ids": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The IDs of the subaccounts in which the service offering is available.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The service offerings, ordered by their name.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *subaccountsServiceOfferingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data subaccountsServiceOfferingsDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subaccounts, _, err := ds.cli.Accounts.Subaccount.List(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Subaccounts", fmt.Sprintf("%s", err))
+		return
+	}
+
+	var subaccountIds []string
+	for _, subaccount := range subaccounts.Value {
+		subaccountIds = append(subaccountIds, subaccount.Guid)
+	}
+
+	// the requests are sent in a stable order, so that errors are reported deterministically
+	sort.Strings(subaccountIds)
+
+	cliRes, err := ds.cli.Services.Offering.ListBySubaccounts(ctx, subaccountIds, data.Environment.ValueString(), subaccountsServiceOfferingsMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Service Offerings (Subaccounts)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = types.StringValue(ds.cli.GetGlobalAccountSubdomain())
+	data.Values, diags = subaccountsServiceOfferingValuesFrom(ctx, subaccountIds, cliRes)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// subaccountsServiceOfferingValuesFrom merges the service offerings of the subaccounts. Service offerings with the same
+// name and catalog ID are considered the same, independent of the subaccount specific ID they have been registered with.
+func subaccountsServiceOfferingValuesFrom(ctx context.Context, subaccountIds []string, offeringsBySubaccount map[string][]servicemanager.ServiceOfferingResponseObject) ([]subaccountsServiceOfferingValue, diag.Diagnostics) {
+	type offeringKey struct {
+		name      string
+		catalogId string
+	}
+
+	offerings := map[offeringKey]servicemanager.ServiceOfferingResponseObject{}
+	offeringSubaccountIds := map[offeringKey][]string{}
+
+	for _, subaccountId := range subaccountIds {
+		for _, offering := range offeringsBySubaccount[subaccountId] {
+			key := offeringKey{name: offering.Name, catalogId: offering.CatalogId}
+
+			if _, ok := offerings[key]; !ok {
+				offerings[key] = offering
+			}
+			offeringSubaccountIds[key] = append(offeringSubaccountIds[key], subaccountId)
+		}
+	}
+
+	keys := make([]offeringKey, 0, len(offerings))
+	for key := range offerings {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].catalogId < keys[j].catalogId
+	})
+
+	var diags diag.Diagnostics
+	values := []subaccountsServiceOfferingValue{}
+
+	for _, key := range keys {
+		value := subaccountsServiceOfferingValue{
+			Name:        types.StringValue(key.name),
+			CatalogId:   types.StringValue(key.catalogId),
+			Description: types.StringValue(offerings[key].Description),
+		}
+
+		var setDiags diag.Diagnostics
+		value.SubaccountIds, setDiags = types.SetValueFrom(ctx, types.StringType, offeringSubaccountIds[key])
+		diags.Append(setDiags...)
+
+		values = append(values, value)
+	}
+
+	return values, diags
+}
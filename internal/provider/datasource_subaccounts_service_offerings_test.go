@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
+)
+
+func TestDataSourceSubaccountsServiceOfferings(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := newSubaccountsServiceOfferingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + `data "btp_subaccounts_service_offerings" "uut" {}`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "id", "terraformintcanary"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.0.name", "alert-notification"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.0.catalog_id", "alert-notification-catalog-id"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.0.description", "Alert Notification"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.0.subaccount_ids.#", "2"),
+						resource.TestCheckTypeSetElemAttr("data.btp_subaccounts_service_offerings.uut", "values.0.subaccount_ids.*", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+						resource.TestCheckTypeSetElemAttr("data.btp_subaccounts_service_offerings.uut", "values.0.subaccount_ids.*", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.1.name", "destination"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.1.subaccount_ids.#", "1"),
+						resource.TestCheckTypeSetElemAttr("data.btp_subaccounts_service_offerings.uut", "values.1.subaccount_ids.*", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - environment filter", func(t *testing.T) {
+		srv := newSubaccountsServiceOfferingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + `data "btp_subaccounts_service_offerings" "uut" { environment = "kubernetes" }`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.0.name", "alert-notification"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts_service_offerings.uut", "values.0.subaccount_ids.#", "2"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			if strings.HasSuffix(r.URL.Path, "/accounts/subaccount") {
+				w.Header().Set("X-Cpcli-Backend-Status", "200")
+				fmt.Fprintf(w, `{"value": [{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}]}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "403")
+			fmt.Fprintf(w, `{"error": "access denied"}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + `data "btp_subaccounts_service_offerings" "uut" {}`,
+					ExpectError: regexp.MustCompile(`failed to list the service offerings of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f`),
+				},
+			},
+		})
+	})
+}
+
+func TestSubaccountsServiceOfferingValuesFrom(t *testing.T) {
+	values, diags := subaccountsServiceOfferingValuesFrom(context.TODO(), []string{"subaccount-1", "subaccount-2"}, map[string][]servicemanager.ServiceOfferingResponseObject{
+		"subaccount-1": {
+			{Id: "1", Name: "xsuaa", CatalogId: "xsuaa-catalog-id", Description: "first"},
+			{Id: "2", Name: "destination", CatalogId: "destination-catalog-id"},
+		},
+		"subaccount-2": {
+			{Id: "3", Name: "xsuaa", CatalogId: "xsuaa-catalog-id", Description: "second"},
+			{Id: "4", Name: "xsuaa", CatalogId: "another-catalog-id"},
+		},
+	})
+
+	if assert.False(t, diags.HasError()) && assert.Len(t, values, 3) {
+		assert.Equal(t, "destination", values[0].Name.ValueString())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("subaccount-1")}), values[0].SubaccountIds)
+
+		assert.Equal(t, "another-catalog-id", values[1].CatalogId.ValueString())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("subaccount-2")}), values[1].SubaccountIds)
+
+		// the description is taken from the first subaccount which offers the service
+		assert.Equal(t, "xsuaa-catalog-id", values[2].CatalogId.ValueString())
+		assert.Equal(t, "first", values[2].Description.ValueString())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("subaccount-1"), types.StringValue("subaccount-2")}), values[2].SubaccountIds)
+	}
+
+	values, diags = subaccountsServiceOfferingValuesFrom(context.TODO(), nil, nil)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, values)
+}
+
+// newSubaccountsServiceOfferingsTestServer returns a CLI server for a global account with two subaccounts. Both offer
+// the service alert-notification in all environments, but only one of them offers the service destination, which
+// isn't available for Kubernetes.
+func newSubaccountsServiceOfferingsTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/subaccount") && r.URL.RawQuery == "list":
+			fmt.Fprintf(w, `{"value": [{"guid": "ef23ace8-6ade-4d78-9c1f-8df729548bbf"}, {"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/services/offering") && r.URL.RawQuery == "list":
+			offerings := []string{
+				fmt.Sprintf(`{"id": "offering-%s", "name": "alert-notification", "catalog_id": "alert-notification-catalog-id", "description": "Alert Notification"}`, payload.ParamValues["subaccount"]),
+			}
+
+			if payload.ParamValues["subaccount"] == "ef23ace8-6ade-4d78-9c1f-8df729548bbf" && payload.ParamValues["environment"] != "kubernetes" {
+				offerings = append(offerings, `{"id": "destination-id", "name": "destination", "catalog_id": "destination-catalog-id", "description": "Destination"}`)
+			}
+
+			fmt.Fprintf(w, "[%s]", strings.Join(offerings, ", "))
+		default:
+			t.Errorf("unexpected request: %s?%s", r.URL.Path, r.URL.RawQuery)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
@@ -2,6 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -26,7 +30,91 @@ func TestDataSourceSubaccounts(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - filtered by region and parent", func(t *testing.T) {
+		srv := newSubaccountsTestServer(t)
+		defer srv.Close()
 
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + `data "btp_subaccounts" "uut" { region = "eu10" }`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccounts.uut", "values.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts.uut", "values.0.id", "ef23ace8-6ade-4d78-9c1f-8df729548bbf"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts.uut", "values.1.id", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + `data "btp_subaccounts" "uut" {
+  region    = "eu10"
+  parent_id = "05368777-4934-41e8-9f3c-6ec5f4d564b9"
+}`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccounts.uut", "values.#", "1"),
+						resource.TestCheckResourceAttr("data.btp_subaccounts.uut", "values.0.id", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - no subaccount matches the filters", func(t *testing.T) {
+		srv := newSubaccountsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + `data "btp_subaccounts" "uut" {
+  region    = "us10"
+  parent_id = "05368777-4934-41e8-9f3c-6ec5f4d564b9"
+}`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_subaccounts.uut", "values.#", "0"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("error path - parent_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + `data "btp_subaccounts" "uut" { parent_id = "this-is-not-a-uuid" }`,
+					ExpectError: regexp.MustCompile(`Attribute parent_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountsTestServer returns a CLI server for a global account with two subaccounts in eu10, of which one is
+// located in a directory, and one subaccount in us10.
+func newSubaccountsTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		if !strings.HasSuffix(r.URL.Path, "/accounts/subaccount") || r.URL.RawQuery != "list" {
+			t.Errorf("unexpected request: %s?%s", r.URL.Path, r.URL.RawQuery)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"value": [
+			{"guid": "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "region": "eu10", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298"},
+			{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "region": "eu10", "parentGUID": "05368777-4934-41e8-9f3c-6ec5f4d564b9"},
+			{"guid": "77395f6a-a601-4c9e-8cd0-c1fcefc7f60f", "region": "us10", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298"}
+		]}`)
+	}))
 }
 
 func hclDatasourceSubaccounts(resourceName string) string {
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
+)
+
+// userEffectivePermissionsMaxConcurrency limits the number of parallel requests sent to the CLI server.
+const userEffectivePermissionsMaxConcurrency = 5
+
+const (
+	userEffectivePermissionScopeGlobalaccount = "globalaccount"
+	userEffectivePermissionScopeDirectory     = "directory"
+	userEffectivePermissionScopeSubaccount    = "subaccount"
+)
+
+func newUserEffectivePermissionsDataSource() datasource.DataSource {
+	return &userEffectivePermissionsDataSource{}
+}
+
+type userEffectivePermissionsDataSourceConfig struct {
+	/* INPUT */
+	UserName types.String `tfsdk:"user_name"`
+	Origin   types.String `tfsdk:"origin"`
+	/* OUTPUT */
+	Id              types.String `tfsdk:"id"`
+	RoleCollections types.List   `tfsdk:"role_collections"`
+}
+
+type userEffectivePermission struct {
+	Scope          types.String `tfsdk:"scope"`
+	ScopeId        types.String `tfsdk:"scope_id"`
+	ScopeName      types.String `tfsdk:"scope_name"`
+	RoleCollection types.String `tfsdk:"role_collection"`
+}
+
+var userEffectivePermissionType = map[string]attr.Type{
+	"scope":           types.StringType,
+	"scope_id":        types.StringType,
+	"scope_name":      types.StringType,
+	"role_collection": types.StringType,
+}
+
+type userEffectivePermissionsDataSource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (ds *userEffectivePermissionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_user_effective_permissions", req.ProviderTypeName)
+}
+
+func (ds *userEffectivePermissionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (ds *userEffectivePermissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists the role collections assigned to a user in the global account, in all directories which manage authorizations, and in all subaccounts.
+
+__Tip:__
+You must be assigned to the global account admin or viewer role, and to the admin or viewer role of the directories and subaccounts.`,
+		Attributes: map[string]schema.Attribute{
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "The username of the user.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 256),
+				},
+			},
+			"origin": schema.StringAttribute{
+				MarkdownDescription: "The identity provider that hosts the user. The default is the `default_origin` of the provider, or `ldap` if not set.",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the global account.",
+				Computed:            true,
+			},
+			"role_collections": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scope": schema.StringAttribute{
+							MarkdownDescription: "The kind of account the role collection is assigned in. Possible values are: " +
+								"\n\t - `globalaccount`" +
+								"\n\t - `directory`" +
+								"\n\t - `subaccount`",
+							Computed: true,
+						},
+						"scope_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the global account, directory or subaccount.",
+							Computed:            true,
+						},
+						"scope_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the global account, directory or subaccount.",
+							Computed:            true,
+						},
+						"role_collection": schema.StringAttribute{
+							MarkdownDescription: "The name of the role collection.",
+							Computed:            true,
+						},
+					},
+				},
+				MarkdownDescription: "The role collections assigned to the user, starting with the global account, followed by the directories and the subaccounts.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (ds *userEffectivePermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data userEffectivePermissionsDataSourceConfig
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Origin.IsNull() {
+		data.Origin = defaultOrigin(ds.cli)
+	}
+
+	userName, origin := data.UserName.ValueString(), data.Origin.ValueString()
+
+	globalAccount, _, err := ds.cli.Accounts.GlobalAccount.GetWithHierarchy(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Effective Permissions (User)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	directories, subaccounts := flattenGlobalaccountHierarchy(globalAccount.Children, globalAccount.Subaccounts)
+
+	var subaccountIds, authorizationDirectoryIds []string
+	for _, subaccount := range subaccounts {
+		subaccountIds = append(subaccountIds, subaccount.Guid)
+	}
+	for _, directory := range directories {
+		if directoryManagesAuthorizations(directory) {
+			authorizationDirectoryIds = append(authorizationDirectoryIds, directory.Guid)
+		}
+	}
+
+	globalAccountUser, comRes, err := ds.cli.Security.User.GetByGlobalAccount(ctx, userName, origin)
+	if err != nil && comRes.StatusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error Reading Resource Effective Permissions (User)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	directoryUsers, err := ds.cli.Security.User.GetByDirectories(ctx, authorizationDirectoryIds, userName, origin, userEffectivePermissionsMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Effective Permissions (User)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	subaccountUsers, err := ds.cli.Security.User.GetBySubaccounts(ctx, subaccountIds, userName, origin, userEffectivePermissionsMaxConcurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Effective Permissions (User)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = types.StringValue(globalAccount.Guid)
+
+	permissions := userEffectivePermissionsFrom(userEffectivePermissionScopeGlobalaccount, globalAccount.Guid, globalAccount.DisplayName, globalAccountUser)
+
+	for _, directory := range directories {
+		if user, ok := directoryUsers[directory.Guid]; ok {
+			permissions = append(permissions, userEffectivePermissionsFrom(userEffectivePermissionScopeDirectory, directory.Guid, directory.DisplayName, user)...)
+		}
+	}
+
+	for _, subaccount := range subaccounts {
+		if user, ok := subaccountUsers[subaccount.Guid]; ok {
+			permissions = append(permissions, userEffectivePermissionsFrom(userEffectivePermissionScopeSubaccount, subaccount.Guid, subaccount.DisplayName, user)...)
+		}
+	}
+
+	data.RoleCollections, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: userEffectivePermissionType}, permissions)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func directoryManagesAuthorizations(directory cis.DirectoryResponseObject) bool {
+	for _, feature := range directory.DirectoryFeatures {
+		if feature == "AUTHORIZATIONS" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func userEffectivePermissionsFrom(scope string, scopeId string, scopeName string, user xsuaa_authz.UserReference) []userEffectivePermission {
+	roleCollections := append([]string{}, user.RoleCollections...)
+	sort.Strings(roleCollections)
+
+	permissions := []userEffectivePermission{}
+	for _, roleCollection := range roleCollections {
+		permissions = append(permissions, userEffectivePermission{
+			Scope:          types.StringValue(scope),
+			ScopeId:        types.StringValue(scopeId),
+			ScopeName:      types.StringValue(scopeName),
+			RoleCollection: types.StringValue(roleCollection),
+		})
+	}
+
+	return permissions
+}
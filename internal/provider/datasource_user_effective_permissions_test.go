@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataSourceUserEffectivePermissions(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path", func(t *testing.T) {
+		srv := newUserEffectivePermissionsTestServer("")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceUserEffectivePermissions("uut", "jenny.doe@test.com"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "origin", "ldap"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.#", "4"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.0.scope", "globalaccount"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.0.scope_id", "03760ecf-9d89-4189-a92a-1c7efed09298"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.0.scope_name", "terraform-integration-canary"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.0.role_collection", "Global Account Viewer"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.1.scope", "directory"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.1.scope_id", "05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.1.role_collection", "Directory Viewer"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.2.scope", "subaccount"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.2.scope_id", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.2.scope_name", "integration-test-acc-static"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.2.role_collection", "Subaccount Administrator"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.3.scope", "subaccount"),
+						resource.TestCheckResourceAttr("data.btp_user_effective_permissions.uut", "role_collections.3.role_collection", "Subaccount Viewer"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli server returns error", func(t *testing.T) {
+		srv := newUserEffectivePermissionsTestServer("6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclDatasourceUserEffectivePermissions("uut", "jenny.doe@test.com"),
+					ExpectError: regexp.MustCompile(`user of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - user_name must not be empty", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclDatasourceUserEffectivePermissions("uut", ""),
+					ExpectError: regexp.MustCompile(`Attribute user_name string length must be between 1 and 256, got: 0`),
+				},
+			},
+		})
+	})
+}
+
+func hclDatasourceUserEffectivePermissions(resourceName string, userName string) string {
+	return fmt.Sprintf(`data "btp_user_effective_permissions" "%s" { user_name = "%s" }`, resourceName, userName)
+}
+
+// newUserEffectivePermissionsTestServer simulates a global account with a directory managing authorizations, a
+// subdirectory which doesn't, and a subaccount on each level. The user is unknown to the subaccount in the
+// subdirectory. Reading the user of failingSubaccountId fails.
+func newUserEffectivePermissionsTestServer(failingSubaccountId string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/global-account") && r.URL.RawQuery == "get":
+			fmt.Fprintf(w, `{
+	"guid": "03760ecf-9d89-4189-a92a-1c7efed09298", "displayName": "terraform-integration-canary", "subdomain": "terraform-integration-canary",
+	"subaccounts": [{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "integration-test-acc-static", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298"}],
+	"children": [{
+		"guid": "05368777-4934-41e8-9f3c-6ec5f4d564b9", "displayName": "integration-test-dir-se-static", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "directoryFeatures": ["DEFAULT", "AUTHORIZATIONS"],
+		"children": [{
+			"guid": "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d", "displayName": "integration-test-dir-static", "parentGUID": "05368777-4934-41e8-9f3c-6ec5f4d564b9", "directoryFeatures": ["DEFAULT"],
+			"subaccounts": [{"guid": "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "displayName": "integration-test-services-static", "parentGUID": "f6c7137d-c5a0-48c2-b2a4-fd64e6b35d3d"}]
+		}]
+	}]
+}`)
+		case strings.HasSuffix(r.URL.Path, "/security/user") && r.URL.RawQuery == "get":
+			switch {
+			case payload.ParamValues["subaccount"] == failingSubaccountId && failingSubaccountId != "":
+				w.Header().Set("X-Cpcli-Backend-Status", "403")
+				fmt.Fprintf(w, `{"error": "access denied"}`)
+			case payload.ParamValues["subaccount"] == "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f":
+				fmt.Fprintf(w, `{"username": "%s", "roleCollections": ["Subaccount Viewer", "Subaccount Administrator"]}`, payload.ParamValues["userName"])
+			case payload.ParamValues["directory"] == "05368777-4934-41e8-9f3c-6ec5f4d564b9":
+				fmt.Fprintf(w, `{"username": "%s", "roleCollections": ["Directory Viewer"]}`, payload.ParamValues["userName"])
+			case payload.ParamValues["globalAccount"] != "":
+				fmt.Fprintf(w, `{"username": "%s", "roleCollections": ["Global Account Viewer"]}`, payload.ParamValues["userName"])
+			default:
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "user not found"}`)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
@@ -19,6 +19,7 @@ type whoamiDataSourceConfig struct {
 	ID     types.String `tfsdk:"id"`
 	Email  types.String `tfsdk:"email"`
 	Issuer types.String `tfsdk:"issuer"`
+	Groups types.List   `tfsdk:"groups"`
 }
 
 type whoamiDataSource struct {
@@ -53,6 +54,11 @@ func (gen *whoamiDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				MarkdownDescription: "The name of the token issuer.",
 				Computed:            true,
 			},
+			"groups": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The groups of the logged-in user as passed on by the identity provider. The list is empty if the identity provider doesn't pass on any groups.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -77,6 +83,14 @@ func (gen *whoamiDataSource) Read(ctx context.Context, req datasource.ReadReques
 	data.Email = types.StringValue(user.Email)
 	data.Issuer = types.StringValue(user.Issuer)
 
+	groups := user.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+
+	data.Groups, diags = types.ListValueFrom(ctx, types.StringType, groups)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
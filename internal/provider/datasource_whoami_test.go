@@ -2,6 +2,9 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -23,6 +26,34 @@ func TestDataSourceWhoami(t *testing.T) {
 						resource.TestCheckResourceAttr("data.btp_whoami.uut", "id", "john.doe@int.test"),
 						resource.TestCheckResourceAttr("data.btp_whoami.uut", "email", "john.doe@int.test"),
 						resource.TestCheckResourceAttr("data.btp_whoami.uut", "issuer", "accounts.sap.com"),
+						resource.TestCheckResourceAttr("data.btp_whoami.uut", "groups.#", "0"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("happy path - groups passed on by the identity provider", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, `{"issuer": "customidp.accounts.ondemand.com", "user": "john.doe@int.test", "mail": "john.doe@int.test", "refreshToken": "abc", "groups": ["admins", "developers"]}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclDatasourceWhoami("uut"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.btp_whoami.uut", "id", "john.doe@int.test"),
+						resource.TestCheckResourceAttr("data.btp_whoami.uut", "groups.#", "2"),
+						resource.TestCheckResourceAttr("data.btp_whoami.uut", "groups.0", "admins"),
+						resource.TestCheckResourceAttr("data.btp_whoami.uut", "groups.1", "developers"),
 					),
 				},
 			},
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	credentialsFormatJSON   = "json"
+	credentialsFormatBase64 = "base64"
+	credentialsFormatDotenv = "dotenv"
+	credentialsFormatYAML   = "yaml"
+)
+
+var credentialsFormats = []string{credentialsFormatJSON, credentialsFormatBase64, credentialsFormatDotenv, credentialsFormatYAML}
+
+var regexpInvalidDotenvKeyChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// principalPropagationAuthenticationTypes are the authentication types of destinations which forward the identity of
+// the business user instead of using a technical user.
+var principalPropagationAuthenticationTypes = []string{"PrincipalPropagation", "OAuth2SAMLBearerAssertion", "OAuth2JWTBearer", "OAuth2UserTokenExchange", "SAPAssertionSSO"}
+
+// formatCredentials transforms the JSON credentials of a service binding into the given format:
+//   - json: the credentials as returned by the API
+//   - base64: the base64-encoded JSON credentials
+//   - dotenv: one KEY=value line per credential, nested objects are flattened by joining their keys with an underscore
+//   - yaml: one string value per top-level credential, ready to be used as `stringData` of a Kubernetes secret
+func formatCredentials(credentials string, format string) (string, error) {
+	switch format {
+	case credentialsFormatJSON:
+		return credentials, nil
+	case credentialsFormatBase64:
+		return base64.StdEncoding.EncodeToString([]byte(credentials)), nil
+	}
+
+	var values map[string]interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(credentials))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&values); err != nil {
+		return "", fmt.Errorf("the credentials are not a valid JSON object: %w", err)
+	}
+
+	switch format {
+	case credentialsFormatDotenv:
+		return credentialsAsDotenv(values)
+	case credentialsFormatYAML:
+		return credentialsAsYAML(values)
+	default:
+		return "", fmt.Errorf("unsupported credentials format '%s'", format)
+	}
+}
+
+// destinationAuthenticationFrom determines the authentication settings from the JSON credentials of a destination-like
+// service binding. The authentication type is taken from `Authentication` or `authenticationType`, regardless of the case
+// of the key. Whether the identity of the user is propagated is taken from `principalPropagation`, if given, and derived
+// from the authentication type otherwise. The authentication type is empty if the credentials don't contain it.
+func destinationAuthenticationFrom(credentials string) (authenticationType string, principalPropagation bool, err error) {
+	var values map[string]interface{}
+
+	if err = json.Unmarshal([]byte(credentials), &values); err != nil {
+		return "", false, fmt.Errorf("the credentials are not a valid JSON object: %w", err)
+	}
+
+	var explicitPrincipalPropagation *bool
+
+	for key, value := range values {
+		switch strings.ToLower(strings.ReplaceAll(key, "_", "")) {
+		case "authentication", "authenticationtype":
+			if typ, ok := value.(string); ok {
+				authenticationType = typ
+			}
+		case "principalpropagation":
+			if flag, ok := value.(bool); ok {
+				explicitPrincipalPropagation = &flag
+			}
+		}
+	}
+
+	if explicitPrincipalPropagation != nil {
+		return authenticationType, *explicitPrincipalPropagation, nil
+	}
+
+	for _, typ := range principalPropagationAuthenticationTypes {
+		if strings.EqualFold(typ, authenticationType) {
+			return authenticationType, true, nil
+		}
+	}
+
+	return authenticationType, false, nil
+}
+
+func credentialsAsDotenv(values map[string]interface{}) (string, error) {
+	lines := []string{}
+
+	var flatten func(prefix string, values map[string]interface{}) error
+	flatten = func(prefix string, values map[string]interface{}) error {
+		for key, value := range values {
+			key = regexpInvalidDotenvKeyChars.ReplaceAllString(strings.ToUpper(prefix+key), "_")
+
+			if nested, isObject := value.(map[string]interface{}); isObject {
+				if err := flatten(key+"_", nested); err != nil {
+					return err
+				}
+				continue
+			}
+
+			encoded, err := compactJSON(value)
+			if err != nil {
+				return err
+			}
+
+			if _, isArray := value.([]interface{}); isArray {
+				// arrays contain quotes and commas, hence they are quoted as a whole
+				encoded, _ = compactJSON(encoded)
+			}
+
+			lines = append(lines, fmt.Sprintf("%s=%s", key, encoded))
+		}
+
+		return nil
+	}
+
+	if err := flatten("", values); err != nil {
+		return "", err
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func credentialsAsYAML(values map[string]interface{}) (string, error) {
+	secretData := map[string]string{}
+
+	for key, value := range values {
+		if v, isString := value.(string); isString {
+			secretData[key] = v
+			continue
+		}
+
+		encoded, err := compactJSON(value)
+		if err != nil {
+			return "", err
+		}
+
+		secretData[key] = encoded
+	}
+
+	out, err := yaml.Marshal(secretData)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func compactJSON(value interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatCredentials(t *testing.T) {
+	credentials := `{"clientid": "sb-my-app!t1", "clientsecret": "pa$$word", "url": "https://my.auth.com?a=1&b=2", "port": 443, "tags": ["xsuaa", "auth"], "uaa": {"zone-id": "b1f0a5c9", "trusted": true}}`
+
+	tests := []struct {
+		format  string
+		expects string
+	}{
+		{
+			format:  "json",
+			expects: credentials,
+		},
+		{
+			format:  "base64",
+			expects: "eyJjbGllbnRpZCI6ICJzYi1teS1hcHAhdDEiLCAiY2xpZW50c2VjcmV0IjogInBhJCR3b3JkIiwgInVybCI6ICJodHRwczovL215LmF1dGguY29tP2E9MSZiPTIiLCAicG9ydCI6IDQ0MywgInRhZ3MiOiBbInhzdWFhIiwgImF1dGgiXSwgInVhYSI6IHsiem9uZS1pZCI6ICJiMWYwYTVjOSIsICJ0cnVzdGVkIjogdHJ1ZX19",
+		},
+		{
+			format: "dotenv",
+			expects: `CLIENTID="sb-my-app!t1"
+CLIENTSECRET="pa$$word"
+PORT=443
+TAGS="[\"xsuaa\",\"auth\"]"
+UAA_TRUSTED=true
+UAA_ZONE_ID="b1f0a5c9"
+URL="https://my.auth.com?a=1&b=2"
+`,
+		},
+		{
+			format: "yaml",
+			expects: `clientid: sb-my-app!t1
+clientsecret: pa$$word
+port: "443"
+tags: '["xsuaa","auth"]'
+uaa: '{"trusted":true,"zone-id":"b1f0a5c9"}'
+url: https://my.auth.com?a=1&b=2
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run("happy path - "+test.format, func(t *testing.T) {
+			formatted, err := formatCredentials(credentials, test.format)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expects, formatted)
+		})
+	}
+
+	t.Run("happy path - no credentials", func(t *testing.T) {
+		formatted, err := formatCredentials(`{}`, "dotenv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", formatted)
+	})
+
+	t.Run("error path - credentials are not a JSON object", func(t *testing.T) {
+		_, err := formatCredentials(`["sb-my-app!t1"]`, "yaml")
+
+		assert.ErrorContains(t, err, "the credentials are not a valid JSON object")
+	})
+
+	t.Run("error path - unsupported format", func(t *testing.T) {
+		_, err := formatCredentials(credentials, "xml")
+
+		assert.EqualError(t, err, "unsupported credentials format 'xml'")
+	})
+}
+
+func TestDestinationAuthenticationFrom(t *testing.T) {
+	tests := []struct {
+		description                  string
+		credentials                  string
+		expectedAuthenticationType   string
+		expectedPrincipalPropagation bool
+	}{
+		{
+			description:                  "happy path - principal propagation",
+			credentials:                  `{"Name": "my-backend", "ProxyType": "OnPremise", "Authentication": "PrincipalPropagation"}`,
+			expectedAuthenticationType:   "PrincipalPropagation",
+			expectedPrincipalPropagation: true,
+		},
+		{
+			description:                  "happy path - user propagation with SAML bearer assertion",
+			credentials:                  `{"authentication_type": "OAuth2SAMLBearerAssertion"}`,
+			expectedAuthenticationType:   "OAuth2SAMLBearerAssertion",
+			expectedPrincipalPropagation: true,
+		},
+		{
+			description:                  "happy path - technical user",
+			credentials:                  `{"authenticationType": "BasicAuthentication", "User": "technical-user"}`,
+			expectedAuthenticationType:   "BasicAuthentication",
+			expectedPrincipalPropagation: false,
+		},
+		{
+			description:                  "happy path - explicit flag takes precedence",
+			credentials:                  `{"authentication": "OAuth2ClientCredentials", "principalPropagation": true}`,
+			expectedAuthenticationType:   "OAuth2ClientCredentials",
+			expectedPrincipalPropagation: true,
+		},
+		{
+			description:                  "happy path - no authentication settings",
+			credentials:                  `{"clientid": "sb-my-app", "uri": "https://destination-configuration.cfapps.eu10.hana.ondemand.com"}`,
+			expectedAuthenticationType:   "",
+			expectedPrincipalPropagation: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			authenticationType, principalPropagation, err := destinationAuthenticationFrom(test.credentials)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedAuthenticationType, authenticationType)
+			assert.Equal(t, test.expectedPrincipalPropagation, principalPropagation)
+		})
+	}
+
+	t.Run("error path - credentials aren't a JSON object", func(t *testing.T) {
+		_, _, err := destinationAuthenticationFrom(`not json`)
+
+		assert.ErrorContains(t, err, "the credentials are not a valid JSON object")
+	})
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const jsonSchemaResourceURL = "https://terraform-provider-btp.local/parameters.schema.json"
+
+// validateJSONSchema validates the JSON document against the given JSON schema and returns the violations found. A
+// schema without `$schema` is treated as draft 4, which service brokers use to describe their parameters. References
+// are resolved within the schema only, as the schema of a service plan must not make the provider load other documents.
+func validateJSONSchema(schema string, document string) ([]string, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft4
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("the external reference %s is not supported", url)
+	}
+
+	if err := compiler.AddResource(jsonSchemaResourceURL, strings.NewReader(schema)); err != nil {
+		return nil, fmt.Errorf("the schema is not valid JSON: %w", err)
+	}
+
+	compiledSchema, err := compiler.Compile(jsonSchemaResourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("the schema is not valid: %w", err)
+	}
+
+	var documentValue interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&documentValue); err != nil {
+		return nil, fmt.Errorf("the document is not valid JSON: %w", err)
+	}
+
+	violations := []string{}
+
+	var validationErr *jsonschema.ValidationError
+	if err := compiledSchema.Validate(documentValue); errors.As(err, &validationErr) {
+		collectJSONSchemaViolations(validationErr, &violations)
+	} else if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(violations)
+
+	return violations, nil
+}
+
+// collectJSONSchemaViolations collects the innermost causes of the validation error, which name the actual violations.
+func collectJSONSchemaViolations(err *jsonschema.ValidationError, violations *[]string) {
+	if len(err.Causes) == 0 {
+		location := strings.TrimPrefix(err.InstanceLocation, "/")
+		if len(location) == 0 {
+			location = "(root)"
+		}
+
+		*violations = append(*violations, fmt.Sprintf("%s: %s", location, err.Message))
+		return
+	}
+
+	for _, cause := range err.Causes {
+		collectJSONSchemaViolations(cause, violations)
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {
+			"xsappname": {"type": "string", "minLength": 1, "maxLength": 10, "pattern": "^[a-z-]+$"},
+			"tenant-mode": {"type": "string", "enum": ["dedicated", "shared"]},
+			"instances": {"type": "integer", "minimum": 1, "maximum": 5},
+			"scopes": {"type": "array", "items": {"type": "string"}, "minItems": 1, "uniqueItems": true},
+			"oauth2-configuration": {
+				"type": "object",
+				"properties": {"token-validity": {"type": "number", "minimum": 60, "exclusiveMinimum": true}},
+				"additionalProperties": false
+			}
+		},
+		"required": ["xsappname"],
+		"additionalProperties": false
+	}`
+
+	tests := []struct {
+		description string
+		document    string
+		expects     []string
+	}{
+		{
+			description: "happy path - valid document",
+			document:    `{"xsappname": "my-app", "tenant-mode": "shared", "instances": 2, "scopes": ["read", "write"], "oauth2-configuration": {"token-validity": 3600}}`,
+			expects:     []string{},
+		},
+		{
+			description: "error path - required property missing",
+			document:    `{"tenant-mode": "shared"}`,
+			expects:     []string{"(root): missing properties: 'xsappname'"},
+		},
+		{
+			description: "error path - additional property",
+			document:    `{"xsappname": "my-app", "unknown": true}`,
+			expects:     []string{"(root): additionalProperties 'unknown' not allowed"},
+		},
+		{
+			description: "error path - invalid type",
+			document:    `{"xsappname": "my-app", "instances": 1.5}`,
+			expects:     []string{"instances: expected integer, but got number"},
+		},
+		{
+			description: "error path - document is not an object",
+			document:    `["my-app"]`,
+			expects:     []string{"(root): expected object, but got array"},
+		},
+		{
+			description: "error path - string constraints",
+			document:    `{"xsappname": "My_Application"}`,
+			expects:     []string{"xsappname: does not match pattern '^[a-z-]+$'", "xsappname: length must be <= 10, but got 14"},
+		},
+		{
+			description: "error path - value not in enum",
+			document:    `{"xsappname": "my-app", "tenant-mode": "external"}`,
+			expects:     []string{`tenant-mode: value must be one of "dedicated", "shared"`},
+		},
+		{
+			description: "error path - number constraints",
+			document:    `{"xsappname": "my-app", "instances": 6, "oauth2-configuration": {"token-validity": 60}}`,
+			expects:     []string{"instances: must be <= 5 but found 6", "oauth2-configuration/token-validity: must be > 60 but found 60"},
+		},
+		{
+			description: "error path - array constraints",
+			document:    `{"xsappname": "my-app", "scopes": ["read", 1, "read"]}`,
+			expects:     []string{"scopes/1: expected string, but got number", "scopes: items at index 0 and 2 are equal"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			violations, err := validateJSONSchema(schema, test.document)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expects, violations)
+		})
+	}
+
+	t.Run("error path - references, formats and combinators", func(t *testing.T) {
+		schema := `{
+			"definitions": {"email": {"type": "string", "format": "email"}},
+			"properties": {
+				"owner": {"$ref": "#/definitions/email"},
+				"instances": {"oneOf": [{"type": "string"}, {"type": "integer"}]},
+				"limits": {"allOf": [{"type": "object"}, {"required": ["memory"]}]}
+			}
+		}`
+
+		violations, err := validateJSONSchema(schema, `{"owner": "jenny.doe", "instances": true, "limits": {}}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"instances: expected integer, but got boolean", "instances: expected string, but got boolean", "limits: missing properties: 'memory'", "owner: 'jenny.doe' is not valid 'email'"}, violations)
+	})
+
+	t.Run("error path - large integers keep their precision", func(t *testing.T) {
+		violations, err := validateJSONSchema(`{"properties": {"id": {"maximum": 9007199254740992}}}`, `{"id": 9007199254740993}`)
+
+		assert.NoError(t, err)
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("error path - external references are not loaded", func(t *testing.T) {
+		_, err := validateJSONSchema(`{"properties": {"xsappname": {"$ref": "https://example.com/xsappname.json"}}}`, `{}`)
+
+		assert.ErrorContains(t, err, "the external reference https://example.com/xsappname.json is not supported")
+	})
+
+	t.Run("error path - schema is not valid JSON", func(t *testing.T) {
+		_, err := validateJSONSchema(`{"type": `, `{}`)
+
+		assert.ErrorContains(t, err, "the schema is not valid JSON")
+	})
+
+	t.Run("error path - document is not valid JSON", func(t *testing.T) {
+		_, err := validateJSONSchema(`{}`, `{"xsappname": `)
+
+		assert.ErrorContains(t, err, "the document is not valid JSON")
+	})
+}
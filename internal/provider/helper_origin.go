@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+// normalizeOrigin removes surrounding whitespace from the origin before it is sent to the API. The case is kept, as
+// the origin key is passed on as configured.
+func normalizeOrigin(origin string) string {
+	return strings.TrimSpace(origin)
+}
+
+// originsEqual reports whether both origins only differ in whitespace or case, so that such a change of the
+// configuration doesn't cause a diff.
+func originsEqual(a types.String, b types.String) bool {
+	return strings.EqualFold(normalizeOrigin(a.ValueString()), normalizeOrigin(b.ValueString()))
+}
+
+// originOrPrior returns the prior origin, if it only differs in whitespace or case from the given one. This keeps the
+// origin as it has been configured instead of the one returned by the API.
+func originOrPrior(origin string, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && originsEqual(prior, types.StringValue(origin)) {
+		return prior
+	}
+
+	return types.StringValue(origin)
+}
+
+// defaultOrigin returns the origin configured as default of the provider, or the origin of the default identity
+// provider if there is none.
+func defaultOrigin(cli *btpcli.ClientFacade) types.String {
+	if cli != nil && len(cli.DefaultOrigin) > 0 {
+		return types.StringValue(cli.DefaultOrigin)
+	}
+
+	return types.StringValue(defaultUserOrigin)
+}
+
+// originRequiresReplace is like stringplanmodifier.RequiresReplace, but ignores changes of the origin that only
+// differ in whitespace or case.
+func originRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !originsEqual(req.PlanValue, req.StateValue)
+		},
+		"If the value of this attribute denotes a different identity provider, Terraform will destroy and recreate the resource.",
+		"If the value of this attribute denotes a different identity provider, Terraform will destroy and recreate the resource.",
+	)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func TestNormalizeOrigin(t *testing.T) {
+	assert.Equal(t, "ldap", normalizeOrigin("ldap"))
+	assert.Equal(t, "LDAP", normalizeOrigin("  LDAP\t"))
+	assert.Equal(t, "My-Tenant-Platform", normalizeOrigin("My-Tenant-Platform "))
+	assert.Equal(t, "", normalizeOrigin(" "))
+}
+
+func TestOriginOrPrior(t *testing.T) {
+	assert.Equal(t, types.StringValue("LDAP "), originOrPrior("ldap", types.StringValue("LDAP ")))
+	assert.Equal(t, types.StringValue("ldap"), originOrPrior("ldap", types.StringValue("my-ias")))
+	assert.Equal(t, types.StringValue("ldap"), originOrPrior("ldap", types.StringNull()))
+	assert.Equal(t, types.StringValue("ldap"), originOrPrior("ldap", types.StringUnknown()))
+}
+
+func TestDefaultOrigin(t *testing.T) {
+	assert.Equal(t, types.StringValue("ldap"), defaultOrigin(nil))
+	assert.Equal(t, types.StringValue("ldap"), defaultOrigin(&btpcli.ClientFacade{}))
+	assert.Equal(t, types.StringValue("my-ias"), defaultOrigin(&btpcli.ClientFacade{DefaultOrigin: "my-ias"}))
+}
+
+func TestOriginRequiresReplace(t *testing.T) {
+	tests := []struct {
+		description    string
+		state          types.String
+		plan           types.String
+		expectsReplace bool
+	}{
+		{
+			description:    "same origin",
+			state:          types.StringValue("ldap"),
+			plan:           types.StringValue("ldap"),
+			expectsReplace: false,
+		},
+		{
+			description:    "origin differs in case and whitespace only",
+			state:          types.StringValue("ldap"),
+			plan:           types.StringValue(" LDAP "),
+			expectsReplace: false,
+		},
+		{
+			description:    "different origin",
+			state:          types.StringValue("ldap"),
+			plan:           types.StringValue("my-ias"),
+			expectsReplace: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"origin": tftypes.String}}
+
+			req := planmodifier.StringRequest{
+				Path:       path.Root("origin"),
+				StateValue: test.state,
+				PlanValue:  test.plan,
+				State: tfsdk.State{
+					Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{"origin": tftypes.NewValue(tftypes.String, test.state.ValueString())}),
+				},
+				Plan: tfsdk.Plan{
+					Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{"origin": tftypes.NewValue(tftypes.String, test.plan.ValueString())}),
+				},
+			}
+			resp := &planmodifier.StringResponse{PlanValue: test.plan}
+
+			originRequiresReplace().PlanModifyString(context.TODO(), req, resp)
+
+			assert.Equal(t, test.expectsReplace, resp.RequiresReplace)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readParametersFile reads the JSON parameters stored in the given file.
+func readParametersFile(filename string) (string, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the parameters file: %w", err)
+	}
+
+	if !json.Valid(content) {
+		return "", fmt.Errorf("the parameters file %s doesn't contain valid JSON", filename)
+	}
+
+	return string(content), nil
+}
+
+// mergeParameters deep merges the JSON objects base and overrides. Nested objects are merged key by key, all other
+// values of overrides (including arrays and null) replace the ones of base.
+func mergeParameters(base string, overrides string) (string, error) {
+	var baseValues, overrideValues map[string]interface{}
+
+	if err := decodeJSONObject(base, &baseValues); err != nil {
+		return "", fmt.Errorf("the base parameters are not a valid JSON object: %w", err)
+	}
+
+	if err := decodeJSONObject(overrides, &overrideValues); err != nil {
+		return "", fmt.Errorf("the inline parameters are not a valid JSON object: %w", err)
+	}
+
+	return compactJSON(deepMergeValues(baseValues, overrideValues))
+}
+
+// decodeJSONObject decodes numbers as json.Number, so that integers beyond 2^53 are passed on without loss of precision.
+func decodeJSONObject(value string, target *map[string]interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(target); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unexpected data after the JSON object")
+	}
+
+	return nil
+}
+
+func deepMergeValues(base map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range overrides {
+		nestedOverrides, isObject := value.(map[string]interface{})
+		nestedBase, isBaseObject := merged[key].(map[string]interface{})
+
+		if isObject && isBaseObject {
+			merged[key] = deepMergeValues(nestedBase, nestedOverrides)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
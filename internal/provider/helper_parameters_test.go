@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeParameters(t *testing.T) {
+	t.Run("happy path - inline parameters take precedence", func(t *testing.T) {
+		merged, err := mergeParameters(`{"xsappname": "base-app", "tenant-mode": "dedicated"}`, `{"xsappname": "my-app"}`)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"xsappname": "my-app", "tenant-mode": "dedicated"}`, merged)
+	})
+
+	t.Run("happy path - nested objects are merged", func(t *testing.T) {
+		base := `{"oauth2-configuration": {"redirect-uris": ["https://base.example.com/**"], "token-validity": 900, "credential-types": {"primary": "binding-secret"}}, "xsappname": "my-app"}`
+		overrides := `{"oauth2-configuration": {"token-validity": 3600, "credential-types": {"secondary": "x509"}}}`
+
+		merged, err := mergeParameters(base, overrides)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"oauth2-configuration": {"redirect-uris": ["https://base.example.com/**"], "token-validity": 3600, "credential-types": {"primary": "binding-secret", "secondary": "x509"}}, "xsappname": "my-app"}`, merged)
+	})
+
+	t.Run("happy path - arrays and scalars replace objects", func(t *testing.T) {
+		merged, err := mergeParameters(`{"scopes": ["read", "write"], "role-templates": {"name": "Viewer"}, "foreign-scope-references": ["uaa.user"]}`, `{"scopes": ["read"], "role-templates": null, "foreign-scope-references": {"name": "uaa.user"}}`)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"scopes": ["read"], "role-templates": null, "foreign-scope-references": {"name": "uaa.user"}}`, merged)
+	})
+
+	t.Run("happy path - base parameters are not modified", func(t *testing.T) {
+		base := `{"oauth2-configuration": {"token-validity": 900}}`
+
+		_, err := mergeParameters(base, `{"oauth2-configuration": {"token-validity": 3600}}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"oauth2-configuration": {"token-validity": 900}}`, base)
+	})
+
+	t.Run("happy path - large numbers keep their precision", func(t *testing.T) {
+		merged, err := mergeParameters(`{"instance-id": 9007199254740993, "ratio": 0.1}`, `{"owner-id": 12345678901234567890}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"instance-id":9007199254740993,"owner-id":12345678901234567890,"ratio":0.1}`, merged)
+	})
+
+	t.Run("error path - base parameters not a JSON object", func(t *testing.T) {
+		_, err := mergeParameters(`["my-app"]`, `{}`)
+
+		assert.ErrorContains(t, err, "the base parameters are not a valid JSON object")
+	})
+
+	t.Run("error path - inline parameters not a JSON object", func(t *testing.T) {
+		_, err := mergeParameters(`{}`, `"my-app"`)
+
+		assert.ErrorContains(t, err, "the inline parameters are not a valid JSON object")
+	})
+
+	t.Run("error path - inline parameters followed by further data", func(t *testing.T) {
+		_, err := mergeParameters(`{}`, `{"xsappname": "my-app"} {}`)
+
+		assert.ErrorContains(t, err, "the inline parameters are not a valid JSON object")
+	})
+}
+
+func TestReadParametersFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("happy path", func(t *testing.T) {
+		filename := filepath.Join(dir, "parameters.json")
+		assert.NoError(t, os.WriteFile(filename, []byte(`{"xsappname": "my-app"}`), 0600))
+
+		parameters, err := readParametersFile(filename)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"xsappname": "my-app"}`, parameters)
+	})
+
+	t.Run("error path - file does not exist", func(t *testing.T) {
+		_, err := readParametersFile(filepath.Join(dir, "missing.json"))
+
+		assert.ErrorContains(t, err, "unable to read the parameters file")
+	})
+
+	t.Run("error path - no valid JSON", func(t *testing.T) {
+		filename := filepath.Join(dir, "invalid.json")
+		assert.NoError(t, os.WriteFile(filename, []byte(`xsappname: my-app`), 0600))
+
+		_, err := readParametersFile(filename)
+
+		assert.ErrorContains(t, err, "doesn't contain valid JSON")
+	})
+}
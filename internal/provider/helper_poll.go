@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+const (
+	defaultPollInterval    = 5 * time.Second
+	defaultMaxPollInterval = 10 * time.Second
+)
+
+// pollInterval returns the base interval in which the state of long-running operations is polled, i.e. the
+// `poll_interval` of the provider configuration or the default if none is configured.
+func pollInterval(cli *btpcli.ClientFacade) time.Duration {
+	if cli == nil || cli.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+
+	return cli.PollInterval
+}
+
+// maxPollInterval returns the interval to which the polling of long-running operations backs off at most, i.e. the
+// `max_poll_interval` of the provider configuration or the default if none is configured. It never undercuts the
+// base interval.
+func maxPollInterval(cli *btpcli.ClientFacade) time.Duration {
+	maxInterval := defaultMaxPollInterval
+	if cli != nil && cli.MaxPollInterval > 0 {
+		maxInterval = cli.MaxPollInterval
+	}
+
+	if interval := pollInterval(cli); interval > maxInterval {
+		return interval
+	}
+
+	return maxInterval
+}
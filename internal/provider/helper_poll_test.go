@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func TestPollInterval(t *testing.T) {
+	client := btpcli.NewClientFacade(btpcli.NewV2Client(nil))
+
+	assert.Equal(t, defaultPollInterval, pollInterval(client))
+
+	client.PollInterval = 30 * time.Second
+	assert.Equal(t, 30*time.Second, pollInterval(client))
+}
+
+func TestMaxPollInterval(t *testing.T) {
+	client := btpcli.NewClientFacade(btpcli.NewV2Client(nil))
+
+	assert.Equal(t, defaultMaxPollInterval, maxPollInterval(client))
+
+	client.MaxPollInterval = 2 * time.Minute
+	assert.Equal(t, 2*time.Minute, maxPollInterval(client))
+
+	client.PollInterval = 5 * time.Minute
+	assert.Equal(t, 5*time.Minute, maxPollInterval(client))
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+)
+
+// retryOnForbidden repeats a CLI call as long as the backend rejects it with status 403 (Forbidden), but at most for the given timeout.
+// This covers the eventual consistency of the authorization caches, e.g. when assigning a role collection that has just been created.
+// Any other error is returned immediately.
+func retryOnForbidden(ctx context.Context, timeout time.Duration, call func() (btpcli.CommandResponse, error)) error {
+	return retryWhile(ctx, timeout, func(res btpcli.CommandResponse, _ error) bool {
+		return res.StatusCode == http.StatusForbidden
+	}, call)
+}
+
+// retryWhileBusy repeats a CLI call as long as the backend rejects it, because another operation on the same service instance
+// is still in progress, but at most for the given timeout. This happens e.g. when binding an instance which has just been
+// created or updated. Any other error is returned immediately.
+func retryWhileBusy(ctx context.Context, timeout time.Duration, call func() (btpcli.CommandResponse, error)) error {
+	return retryWhile(ctx, timeout, func(_ btpcli.CommandResponse, err error) bool {
+		return errors.Is(err, btpcli.ErrOperationInProgress)
+	}, call)
+}
+
+// retryWhile repeats a CLI call as long as its outcome is retryable, but at most for the given timeout.
+// Once the timeout is exceeded, the error of the last call is returned.
+func retryWhile(ctx context.Context, timeout time.Duration, retryable func(res btpcli.CommandResponse, err error) bool, call func() (btpcli.CommandResponse, error)) error {
+	var lastErr error
+
+	retryConf := &tfutils.StateChangeConf{
+		Pending: []string{"RETRY"},
+		Target:  []string{"DONE"},
+		Refresh: func() (interface{}, string, error) {
+			res, err := call()
+
+			if retryable(res, err) {
+				lastErr = err
+				return res, "RETRY", nil
+			}
+
+			if err != nil {
+				return res, "", err
+			}
+
+			return res, "DONE", nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := retryConf.WaitForStateContext(ctx)
+
+	var timeoutErr *tfutils.TimeoutError
+	if errors.As(err, &timeoutErr) && lastErr != nil {
+		return lastErr
+	}
+
+	return err
+}
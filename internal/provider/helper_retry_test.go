@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func TestRetryOnForbidden(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - succeeds after forbidden", func(t *testing.T) {
+		calls := 0
+
+		err := retryOnForbidden(context.TODO(), 1*time.Minute, func() (btpcli.CommandResponse, error) {
+			calls++
+
+			if calls == 1 {
+				return btpcli.CommandResponse{StatusCode: http.StatusForbidden}, errors.New("access denied")
+			}
+
+			return btpcli.CommandResponse{StatusCode: http.StatusOK}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("error path - other errors are not retried", func(t *testing.T) {
+		calls := 0
+
+		err := retryOnForbidden(context.TODO(), 1*time.Minute, func() (btpcli.CommandResponse, error) {
+			calls++
+
+			return btpcli.CommandResponse{StatusCode: http.StatusNotFound}, errors.New("role collection not found")
+		})
+
+		assert.EqualError(t, err, "role collection not found")
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("error path - returns the last error once the timeout is exceeded", func(t *testing.T) {
+		err := retryOnForbidden(context.TODO(), 3*time.Second, func() (btpcli.CommandResponse, error) {
+			return btpcli.CommandResponse{StatusCode: http.StatusForbidden}, errors.New("access denied")
+		})
+
+		assert.EqualError(t, err, "access denied")
+	})
+}
+
+func TestRetryWhileBusy(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - succeeds once the instance isn't busy anymore", func(t *testing.T) {
+		calls := 0
+
+		err := retryWhileBusy(context.TODO(), 1*time.Minute, func() (btpcli.CommandResponse, error) {
+			calls++
+
+			if calls == 1 {
+				return btpcli.CommandResponse{StatusCode: http.StatusUnprocessableEntity}, fmt.Errorf("%w Another concurrent operation in progress for this resource", btpcli.ErrOperationInProgress)
+			}
+
+			return btpcli.CommandResponse{StatusCode: http.StatusCreated}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("error path - other unprocessable entities are not retried", func(t *testing.T) {
+		calls := 0
+
+		err := retryWhileBusy(context.TODO(), 1*time.Minute, func() (btpcli.CommandResponse, error) {
+			calls++
+
+			return btpcli.CommandResponse{StatusCode: http.StatusUnprocessableEntity}, errors.New("The service plan doesn't support bindings")
+		})
+
+		assert.EqualError(t, err, "The service plan doesn't support bindings")
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("error path - forbidden is not retried", func(t *testing.T) {
+		calls := 0
+
+		err := retryWhileBusy(context.TODO(), 1*time.Minute, func() (btpcli.CommandResponse, error) {
+			calls++
+
+			return btpcli.CommandResponse{StatusCode: http.StatusForbidden}, errors.New("access denied")
+		})
+
+		assert.EqualError(t, err, "access denied")
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
+)
+
+// roleCollectionsContainingRole returns the sorted names of the role collections that reference the given role.
+func roleCollectionsContainingRole(ctx context.Context, roleCollections []xsuaa_authz.RoleCollection, roleName string, roleTemplateAppId string, roleTemplateName string) (types.List, diag.Diagnostics) {
+	names := []string{}
+
+	for _, roleCollection := range roleCollections {
+		for _, role := range roleCollection.RoleReferences {
+			if roleKey(role.Name, role.RoleTemplateAppId, role.RoleTemplateName) == roleKey(roleName, roleTemplateAppId, roleTemplateName) {
+				names = append(names, roleCollection.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return types.ListValueFrom(ctx, types.StringType, names)
+}
+
+// roleCollectionsAssignedToGroup returns the sorted names of the role collections to which the group of the given
+// identity provider is mapped. Group mappings are attribute assignments on the `Groups` attribute.
+func roleCollectionsAssignedToGroup(ctx context.Context, roleCollections []xsuaa_authz.RoleCollection, groupName string, origin string) (types.Set, diag.Diagnostics) {
+	names := []string{}
+
+	for _, roleCollection := range roleCollections {
+		if roleCollectionHasGroup(roleCollection, groupName, origin) {
+			names = append(names, roleCollection.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return types.SetValueFrom(ctx, types.StringType, names)
+}
+
+// roleCollectionHasAssignment reports whether the user or, if no user name is given, the group of the identity
+// provider with the given origin is assigned to the role collection.
+func roleCollectionHasAssignment(roleCollection xsuaa_authz.RoleCollection, userName string, groupName string, origin string) bool {
+	if len(userName) == 0 {
+		return roleCollectionHasGroup(roleCollection, groupName, origin)
+	}
+
+	for _, user := range roleCollection.UserReferences {
+		if strings.EqualFold(user.Username, userName) && normalizeOrigin(user.Origin) == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roleCollectionHasGroup reports whether the group of the given identity provider is mapped to the role collection.
+// Group mappings are attribute assignments on the `Groups` attribute.
+func roleCollectionHasGroup(roleCollection xsuaa_authz.RoleCollection, groupName string, origin string) bool {
+	for _, assignment := range roleCollection.SamlAttrAssignment {
+		attributeName, attributeValue := assignment.AttributeName, assignment.AttributeValue
+		if attributeName == "" {
+			// fall back to the deprecated parameters
+			attributeName, attributeValue = assignment.SamlAttrName, assignment.SamlAttributeValue
+		}
+
+		if attributeName == "Groups" && attributeValue == groupName && assignment.SamlEntityId == origin {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
+)
+
+func TestRoleCollectionsContainingRole(t *testing.T) {
+	roleCollections := []xsuaa_authz.RoleCollection{
+		{
+			Name: "Subaccount Viewer",
+			RoleReferences: []xsuaa_authz.RoleReference{
+				{Name: "Subaccount Viewer", RoleTemplateAppId: "cis-local!b2", RoleTemplateName: "Subaccount_Viewer"},
+			},
+		},
+		{
+			Name: "Auditors",
+			RoleReferences: []xsuaa_authz.RoleReference{
+				{Name: "Auditor", RoleTemplateAppId: "auditlog!b1", RoleTemplateName: "Auditor"},
+				{Name: "Subaccount Viewer", RoleTemplateAppId: "cis-local!b2", RoleTemplateName: "Subaccount_Viewer"},
+			},
+		},
+		{
+			Name: "Other Viewer",
+			RoleReferences: []xsuaa_authz.RoleReference{
+				{Name: "Subaccount Viewer", RoleTemplateAppId: "other!b3", RoleTemplateName: "Subaccount_Viewer"},
+			},
+		},
+		{
+			Name: "Empty",
+		},
+	}
+
+	t.Run("happy path - collections referencing the role", func(t *testing.T) {
+		res, diags := roleCollectionsContainingRole(context.TODO(), roleCollections, "Subaccount Viewer", "cis-local!b2", "Subaccount_Viewer")
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("Auditors"),
+			types.StringValue("Subaccount Viewer"),
+		}), res)
+	})
+
+	t.Run("happy path - role not referenced", func(t *testing.T) {
+		res, diags := roleCollectionsContainingRole(context.TODO(), roleCollections, "Auditor", "other!b3", "Auditor")
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}), res)
+	})
+}
+
+func TestRoleCollectionsAssignedToGroup(t *testing.T) {
+	roleCollections := []xsuaa_authz.RoleCollection{
+		{
+			Name: "Subaccount Viewer",
+			SamlAttrAssignment: []xsuaa_authz.SamlAttrAssignment{
+				{AttributeName: "Groups", AttributeValue: "auditors", ComparisonOperator: "equals", SamlEntityId: "my-ias"},
+			},
+		},
+		{
+			Name: "Auditors",
+			SamlAttrAssignment: []xsuaa_authz.SamlAttrAssignment{
+				{AttributeName: "Groups", AttributeValue: "developers", ComparisonOperator: "equals", SamlEntityId: "my-ias"},
+				{SamlAttrName: "Groups", SamlAttributeValue: "auditors", SamlEntityId: "my-ias"},
+			},
+		},
+		{
+			Name: "Other IdP",
+			SamlAttrAssignment: []xsuaa_authz.SamlAttrAssignment{
+				{AttributeName: "Groups", AttributeValue: "auditors", ComparisonOperator: "equals", SamlEntityId: "other-ias"},
+			},
+		},
+		{
+			Name: "Other Attribute",
+			SamlAttrAssignment: []xsuaa_authz.SamlAttrAssignment{
+				{AttributeName: "department", AttributeValue: "auditors", ComparisonOperator: "equals", SamlEntityId: "my-ias"},
+			},
+		},
+		{
+			Name: "Empty",
+		},
+	}
+
+	t.Run("happy path - collections mapped to the group", func(t *testing.T) {
+		res, diags := roleCollectionsAssignedToGroup(context.TODO(), roleCollections, "auditors", "my-ias")
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("Auditors"),
+			types.StringValue("Subaccount Viewer"),
+		}), res)
+	})
+
+	t.Run("happy path - group not mapped", func(t *testing.T) {
+		res, diags := roleCollectionsAssignedToGroup(context.TODO(), roleCollections, "auditors", "ldap")
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{}), res)
+	})
+}
+
+func TestRoleCollectionHasAssignment(t *testing.T) {
+	roleCollection := xsuaa_authz.RoleCollection{
+		Name: "Destination Administrator",
+		UserReferences: []xsuaa_authz.UserReference{
+			{Username: "Jenny.Doe@test.com", Origin: "ldap"},
+		},
+		SamlAttrAssignment: []xsuaa_authz.SamlAttrAssignment{
+			{AttributeName: "Groups", AttributeValue: "auditors", ComparisonOperator: "equals", SamlEntityId: "my-ias"},
+		},
+	}
+
+	t.Run("happy path - user assigned", func(t *testing.T) {
+		assert.True(t, roleCollectionHasAssignment(roleCollection, "jenny.doe@test.com", "", "ldap"))
+	})
+
+	t.Run("happy path - user assigned with other origin", func(t *testing.T) {
+		assert.False(t, roleCollectionHasAssignment(roleCollection, "jenny.doe@test.com", "", "my-ias"))
+	})
+
+	t.Run("happy path - group assigned", func(t *testing.T) {
+		assert.True(t, roleCollectionHasAssignment(roleCollection, "", "auditors", "my-ias"))
+	})
+
+	t.Run("happy path - group not assigned", func(t *testing.T) {
+		assert.False(t, roleCollectionHasAssignment(roleCollection, "", "developers", "my-ias"))
+	})
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/saas_manager_service"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+)
+
+// subscribeAndWait subscribes the subaccount to the application and polls the subscription until it is completed, but
+// at most for the given timeout. If wait is set, the CLI server is asked to wait for the subscription before responding.
+// The subscription is returned if it has succeeded or failed. It is nil, if no final state has been reached, e.g. because
+// the timeout expired, so that the caller has to look up the state of the subscription itself. Whether the subscription
+// has been requested at all tells if there might be something to clean up.
+func subscribeAndWait(ctx context.Context, cli *btpcli.ClientFacade, subaccountId string, appName string, planName string, parameters string, labels map[string][]string, timeout time.Duration, wait bool) (subscription *saas_manager_service.EntitledApplicationsResponseObject, requested bool, err error) {
+	if _, _, err := cli.Accounts.Subaccount.Subscribe(ctx, subaccountId, appName, planName, parameters, labels, wait); err != nil {
+		return nil, false, err
+	}
+
+	var lastRes *saas_manager_service.EntitledApplicationsResponseObject
+
+	subscribeStateConf := &tfutils.StateChangeConf{
+		Pending: []string{saas_manager_service.StateInProcess},
+		Target:  []string{saas_manager_service.StateSubscribed},
+		Refresh: func() (interface{}, string, error) {
+			subRes, _, err := cli.Accounts.Subscription.Get(ctx, subaccountId, appName, planName)
+
+			if err != nil {
+				return subRes, "", err
+			}
+
+			lastRes = &subRes
+
+			// No error returned even is subscription failed
+			if subRes.State == saas_manager_service.StateSubscribeFailed {
+				return subRes, subRes.State, subscriptionOperationError("subscription", subRes)
+			}
+
+			return subRes, subRes.State, nil
+		},
+		Timeout:    timeout,
+		Delay:      subscriptionPollingDelay(wait, pollInterval(cli)),
+		MinTimeout: pollInterval(cli),
+		MaxTimeout: maxPollInterval(cli),
+	}
+
+	_, err = subscribeStateConf.WaitForStateContext(ctx)
+	if err != nil && (lastRes == nil || lastRes.State != saas_manager_service.StateSubscribeFailed) {
+		return nil, true, err
+	}
+
+	return lastRes, true, err
+}
+
+// unsubscribeAndWait unsubscribes the subaccount from the application and polls the subscription until it is removed,
+// but at most for the given timeout. If wait is set, the CLI server is asked to wait for the unsubscription before
+// responding. A subaccount that doesn't exist anymore, e.g. because it has been deleted outside of Terraform, has no
+// subscriptions left, hence this isn't treated as an error.
+func unsubscribeAndWait(ctx context.Context, cli *btpcli.ClientFacade, subaccountId string, appName string, planName string, timeout time.Duration, wait bool) error {
+	_, comRes, err := cli.Accounts.Subaccount.Unsubscribe(ctx, subaccountId, appName, wait)
+	if comRes.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	unsubscribeStateConf := &tfutils.StateChangeConf{
+		Pending: []string{saas_manager_service.StateInProcess, saas_manager_service.StateSubscribed, saas_manager_service.StateSubscribeFailed},
+		Target:  []string{saas_manager_service.StateNotSubscribed},
+		Refresh: func() (interface{}, string, error) {
+			subRes, comRes, err := cli.Accounts.Subscription.Get(ctx, subaccountId, appName, planName)
+
+			if comRes.StatusCode == http.StatusNotFound {
+				return subRes, saas_manager_service.StateNotSubscribed, nil
+			}
+
+			if err != nil {
+				return subRes, subRes.State, err
+			}
+
+			// No error returned even is unsubscribe failed
+			if subRes.State == saas_manager_service.StateUnsubscribeFailed {
+				return subRes, subRes.State, subscriptionOperationError("unsubscription", subRes)
+			}
+
+			return subRes, subRes.State, nil
+		},
+		Timeout:    timeout,
+		Delay:      subscriptionPollingDelay(wait, pollInterval(cli)),
+		MinTimeout: pollInterval(cli),
+		MaxTimeout: maxPollInterval(cli),
+	}
+
+	_, err = unsubscribeStateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// subscriptionOperationError describes why a subscription or unsubscription has failed.
+func subscriptionOperationError(operation string, value saas_manager_service.EntitledApplicationsResponseObject) error {
+	if value.SubscriptionError != nil && len(value.SubscriptionError.ErrorMessage) > 0 {
+		return fmt.Errorf("%s failed: %s", operation, value.SubscriptionError.ErrorMessage)
+	}
+
+	return fmt.Errorf("undefined API error during %s", operation)
+}
+
+// subscriptionPollingDelay returns the delay before the state of the subscription is polled for the first time. If
+// the CLI server has already waited for the operation, the final state is expected to be available right away.
+func subscriptionPollingDelay(waited bool, interval time.Duration) time.Duration {
+	if waited {
+		return 0
+	}
+
+	return interval
+}
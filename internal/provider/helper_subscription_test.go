@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func TestSubscribeAndWait(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - subscribed", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusOK, "SUBSCRIBED", http.StatusOK)
+		defer srv.Close()
+
+		subscription, requested, err := subscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", "", nil, time.Minute, false)
+
+		assert.NoError(t, err)
+		assert.True(t, requested)
+		if assert.NotNil(t, subscription) {
+			assert.Equal(t, "SUBSCRIBED", subscription.State)
+		}
+	})
+	t.Run("error path - subscription failed", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusOK, "SUBSCRIBE_FAILED", http.StatusOK)
+		defer srv.Close()
+
+		subscription, requested, err := subscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", "", nil, time.Minute, false)
+
+		assert.EqualError(t, err, "subscription failed: the app provider rejected the subscription")
+		assert.True(t, requested)
+		if assert.NotNil(t, subscription) {
+			assert.Equal(t, "SUBSCRIBE_FAILED", subscription.State)
+		}
+	})
+	t.Run("error path - subscription rejected", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusBadRequest, "NOT_SUBSCRIBED", http.StatusOK)
+		defer srv.Close()
+
+		subscription, requested, err := subscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", "", nil, time.Minute, false)
+
+		assert.ErrorContains(t, err, "Bad Request")
+		assert.False(t, requested)
+		assert.Nil(t, subscription)
+	})
+}
+
+func TestUnsubscribeAndWait(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - unsubscribed", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusOK, "NOT_SUBSCRIBED", http.StatusOK)
+		defer srv.Close()
+
+		assert.NoError(t, unsubscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", time.Minute, false))
+	})
+	t.Run("happy path - subaccount deleted outside of Terraform", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusNotFound, "NOT_SUBSCRIBED", http.StatusOK)
+		defer srv.Close()
+
+		assert.NoError(t, unsubscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", time.Minute, false))
+	})
+	t.Run("happy path - subaccount deleted while unsubscribing", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusOK, "SUBSCRIBED", http.StatusNotFound)
+		defer srv.Close()
+
+		assert.NoError(t, unsubscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", time.Minute, false))
+	})
+	t.Run("error path - unsubscription failed", func(t *testing.T) {
+		cli, srv := newSubscriptionHelperTestClient(t, http.StatusOK, "UNSUBSCRIBE_FAILED", http.StatusOK)
+		defer srv.Close()
+
+		assert.EqualError(t, unsubscribeAndWait(context.TODO(), cli, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "auditlog-viewer", "free", time.Minute, false), "unsubscription failed: the app provider rejected the subscription")
+	})
+}
+
+// newSubscriptionHelperTestClient returns a client for a CLI server, which responds to subscribe and unsubscribe requests
+// with the given status and reports the subscription in the given state, or fails to look it up with the given status.
+func newSubscriptionHelperTestClient(t *testing.T, operationStatus int, state string, getStatus int) (*btpcli.ClientFacade, *httptest.Server) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/accounts/subaccount") {
+			w.Header().Set("X-Cpcli-Backend-Status", fmt.Sprint(operationStatus))
+			if operationStatus != http.StatusOK {
+				fmt.Fprintf(w, `{"error": "%s"}`, http.StatusText(operationStatus))
+				return
+			}
+
+			fmt.Fprint(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", fmt.Sprint(getStatus))
+		if getStatus != http.StatusOK {
+			fmt.Fprintf(w, `{"error": "%s"}`, http.StatusText(getStatus))
+			return
+		}
+
+		fmt.Fprintf(w, `{"appName": "auditlog-viewer", "planName": "free", "state": "%s", "subscriptionError": {"errorMessage": "the app provider rejected the subscription"}}`, state)
+	}))
+
+	srvUrl, err := url.Parse(srv.URL)
+	assert.NoError(t, err)
+
+	cli := btpcli.NewClientFacade(btpcli.NewV2ClientWithHttpClient(srv.Client(), srvUrl))
+	cli.PollInterval = 10 * time.Millisecond
+
+	return cli, srv
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/SAP/terraform-provider-btp/internal/validation/durationvalidator"
+)
+
+const defaultOperationTimeout = 10 * time.Minute
+
+// resourceTimeoutsAttributes describes the `timeouts` of a resource whose operations are polled until they are completed.
+// As the timeouts are the deadlines of the polling, only positive durations are accepted.
+func resourceTimeoutsAttributes(ctx context.Context, resourceName string) schema.SingleNestedAttribute {
+	attributes := timeouts.Attributes(ctx, timeouts.Opts{
+		Create:            true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: fmt.Sprintf("The maximum duration of the creation of the %s, given as a duration like `30s`, `10m` or `1h`. The default value is `10m`.", resourceName),
+		UpdateDescription: fmt.Sprintf("The maximum duration of an update of the %s, given as a duration like `30s`, `10m` or `1h`. The default value is `10m`.", resourceName),
+		DeleteDescription: fmt.Sprintf("The maximum duration of the deletion of the %s, given as a duration like `30s`, `10m` or `1h`. The default value is `10m`.", resourceName),
+	}).(schema.SingleNestedAttribute)
+
+	attributes.MarkdownDescription = fmt.Sprintf("The maximum durations Terraform waits for the %s to reach its target state. If a timeout expires, the last observed state is reported.", resourceName)
+
+	for name, attribute := range attributes.Attributes {
+		timeout := attribute.(schema.StringAttribute)
+		timeout.Validators = append(timeout.Validators, durationvalidator.PositiveDuration())
+		attributes.Attributes[name] = timeout
+	}
+
+	return attributes
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceTimeoutsAttributes(t *testing.T) {
+	t.Parallel()
+
+	attributes := resourceTimeoutsAttributes(context.TODO(), "subaccount")
+
+	assert.Len(t, attributes.Attributes, 3)
+
+	for name, attribute := range attributes.Attributes {
+		validate := func(value string) bool {
+			resp := &validator.StringResponse{}
+			for _, v := range attribute.(schema.StringAttribute).Validators {
+				v.ValidateString(context.TODO(), validator.StringRequest{Path: path.Root("timeouts").AtName(name), ConfigValue: types.StringValue(value)}, resp)
+			}
+			return !resp.Diagnostics.HasError()
+		}
+
+		assert.True(t, validate("30s"), name)
+		assert.True(t, validate("1h"), name)
+		assert.False(t, validate("0s"), name)
+		assert.False(t, validate("-5m"), name)
+		assert.False(t, validate("soon"), name)
+	}
+}
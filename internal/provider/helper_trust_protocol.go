@@ -0,0 +1,23 @@
+package provider
+
+import "strings"
+
+const (
+	trustProtocolTypeSaml2         = "saml2"
+	trustProtocolTypeOpenIdConnect = "openIdConnect"
+)
+
+// trustProtocolType maps the protocol of a trust configuration as reported by the API, e.g. `OpenID Connect` or
+// `SAML 2.0`, to a stable protocol type which can be used in conditions. Unknown protocols are returned as they are.
+func trustProtocolType(protocol string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(protocol, " ", ""))
+
+	switch {
+	case strings.HasPrefix(normalized, "saml"):
+		return trustProtocolTypeSaml2
+	case strings.HasPrefix(normalized, "openid"), normalized == "oidc":
+		return trustProtocolTypeOpenIdConnect
+	}
+
+	return protocol
+}
@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustProtocolType(t *testing.T) {
+	assert.Equal(t, "openIdConnect", trustProtocolType("OpenID Connect"))
+	assert.Equal(t, "openIdConnect", trustProtocolType("OIDC"))
+	assert.Equal(t, "saml2", trustProtocolType("SAML 2.0"))
+	assert.Equal(t, "saml2", trustProtocolType("saml2"))
+	assert.Equal(t, "", trustProtocolType(""))
+	assert.Equal(t, "Kerberos", trustProtocolType("Kerberos"))
+}
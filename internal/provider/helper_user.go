@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+// userExistenceDiagnostics turns the result of looking up a user into diagnostics. A user that is unknown to the
+// given origin is reported on the `user_name` attribute, as assigning it would create an assignment that never resolves.
+func userExistenceDiagnostics(username string, origin string, res btpcli.CommandResponse, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if res.StatusCode == http.StatusNotFound {
+		diags.AddAttributeError(path.Root("user_name"), "User Not Found",
+			fmt.Sprintf("The user '%s' does not exist in the identity provider with origin '%s'. Make sure the user name and the origin are correct.", username, origin))
+		return diags
+	}
+
+	if err != nil {
+		diags.AddError("API Error Reading Resource User", fmt.Sprintf("%s", err))
+	}
+
+	return diags
+}
@@ -1,21 +1,40 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	testingResource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
@@ -47,6 +66,18 @@ provider "btp" {
     `, cliServerURL)
 }
 
+func hclProviderWithClientCredentials(cliServerURL string, clientId string, clientSecret string, tokenUrl string) string {
+	return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    client_id      = "%s"
+    client_secret  = "%s"
+    token_url      = "%s"
+}
+    `, cliServerURL, clientId, clientSecret, tokenUrl)
+}
+
 func getProviders(httpClient *http.Client) map[string]func() (tfprotov6.ProviderServer, error) {
 	btpProvider := NewWithClient(httpClient).(*btpcliProvider)
 	btpProvider.betaFeaturesEnabled = true // allows beta resources/datasource to be int. tested
@@ -225,25 +256,1012 @@ func notContainsCheckFunc(unexpectedSubString string) testingResource.CheckResou
 	}
 }
 
+func TestProvider_ConfigureWithClientCredentials(t *testing.T) {
+	t.Run("happy path - login with client credentials", func(t *testing.T) {
+		var loginBody string
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				bytes, _ := io.ReadAll(r.Body)
+				loginBody = string(bytes)
+
+				fmt.Fprintf(w, `{"issuer": "terraformintcanary.authentication.eu10.hana.ondemand.com", "user": "sb-client-id", "refreshToken": "abc"}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithClientCredentials(srv.URL, "sb-client-id", "client-secret", "https://terraformintcanary.authentication.eu10.hana.ondemand.com/oauth/token") + `data "btp_whoami" "me" {}`,
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("data.btp_whoami.me", "id", "sb-client-id"),
+						func(_ *terraform.State) error {
+							expectedBody := `{"customIdp":"","subdomain":"terraformintcanary","userName":"","password":"","clientId":"sb-client-id","clientSecret":"client-secret","tokenUrl":"https://terraformintcanary.authentication.eu10.hana.ondemand.com/oauth/token"}`
+							if strings.TrimSpace(loginBody) != expectedBody {
+								return fmt.Errorf("unexpected login request: %s", loginBody)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - client_secret and token_url mandatory", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config: `
+provider "btp" {
+    globalaccount = "terraformintcanary"
+    client_id     = "sb-client-id"
+}
+data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`globalaccount, client_id, client_secret and token_url must be given.`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithClientCertificate(t *testing.T) {
+	hclProviderWithClientCertificate := func(cliServerURL string, certificate string, key string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url         = "%s"
+    globalaccount          = "terraformintcanary"
+    tls_client_certificate = <<EOT
+%sEOT
+    tls_client_key         = <<EOT
+%sEOT
+}
+    `, cliServerURL, certificate, key)
+	}
+
+	certificate, key := generateTestCertificatePEM(t)
+
+	t.Run("happy path - login with client certificate", func(t *testing.T) {
+		var mutex sync.Mutex
+		var loginBody string
+		var peerCertificates int
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				bytes, _ := io.ReadAll(r.Body)
+				loginBody = string(bytes)
+				peerCertificates = len(r.TLS.PeerCertificates)
+
+				fmt.Fprintf(w, `{"issuer": "accounts.sap.com", "user": "john.doe@int.test", "mail": "john.doe@int.test", "refreshToken": "abc"}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		srv.StartTLS()
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithClientCertificate(srv.URL, certificate, key) + `data "btp_whoami" "me" {}`,
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("data.btp_whoami.me", "id", "john.doe@int.test"),
+						func(_ *terraform.State) error {
+							mutex.Lock()
+							defer mutex.Unlock()
+
+							expectedBody := `{"customIdp":"","subdomain":"terraformintcanary","userName":"","password":""}`
+							if strings.TrimSpace(loginBody) != expectedBody {
+								return fmt.Errorf("unexpected login request: %s", loginBody)
+							}
+							if peerCertificates != 1 {
+								return fmt.Errorf("expected the client certificate to be presented, got %d certificates", peerCertificates)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - certificate and key must be given together", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+provider "btp" {
+    globalaccount          = "terraformintcanary"
+    tls_client_certificate = <<EOT
+%sEOT
+}
+data "btp_whoami" "me" {}`, certificate),
+					ExpectError: regexp.MustCompile(`tls_client_certificate and tls_client_key must be given together.`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - invalid key pair", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithClientCertificate("https://cpcli.local", certificate, "this-is-not-a-key\n") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`The client certificate can't be used`),
+				},
+			},
+		})
+	})
+}
+
+func generateTestCertificatePEM(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "john.doe@int.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestProvider_ConfigureWithSessionFile(t *testing.T) {
+	t.Run("happy path - cached session is reused", func(t *testing.T) {
+		var mutex sync.Mutex
+		logins := 0
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			w.Header().Set(btpcli.HeaderCLIReplacementRefreshToken, "abc")
+
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				logins++
+				fmt.Fprintf(w, `{"issuer": "accounts.sap.com", "user": "john.doe@int.test", "mail": "john.doe@int.test", "refreshToken": "abc"}`)
+				return
+			}
+
+			if strings.HasPrefix(r.URL.Path, "/command/") && r.Header.Get(btpcli.HeaderCLIRefreshToken) != "abc" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		}))
+		defer srv.Close()
+
+		sessionFile := filepath.Join(t.TempDir(), "session.json")
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    session_file   = "%s"
+}
+data "btp_whoami" "me" {}`, srv.URL, sessionFile),
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("data.btp_whoami.me", "id", "john.doe@int.test"),
+						func(_ *terraform.State) error {
+							mutex.Lock()
+							defer mutex.Unlock()
+
+							// the provider is configured for each command, but only the first configuration logs in
+							if logins != 1 {
+								return fmt.Errorf("expected a single login, got %d", logins)
+							}
+
+							info, err := os.Stat(sessionFile)
+							if err != nil {
+								return err
+							}
+							if info.Mode().Perm() != 0600 {
+								return fmt.Errorf("unexpected permissions of the session file: %s", info.Mode().Perm())
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithIdentityProviderPerGlobalAccount(t *testing.T) {
+	hclProviderWithIdentityProviders := func(cliServerURL string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    idp            = "default-idp"
+    idps           = {
+        terraformintcanary = "canary-idp"
+        othercanary        = "other-idp"
+    }
+}
+    `, cliServerURL)
+	}
+
+	hclProviderWithoutMatchingIdentityProvider := func(cliServerURL string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    idp            = "default-idp"
+    idps           = {
+        othercanary = "other-idp"
+    }
+}
+    `, cliServerURL)
+	}
+
+	tests := []struct {
+		description string
+		config      func(cliServerURL string) string
+		expectedIdp string
+	}{
+		{
+			description: "happy path - idp of the global account takes precedence",
+			config:      hclProviderWithIdentityProviders,
+			expectedIdp: "canary-idp",
+		},
+		{
+			description: "happy path - fallback to idp if the global account has no entry",
+			config:      hclProviderWithoutMatchingIdentityProvider,
+			expectedIdp: "default-idp",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var loginBody string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/login/") {
+					bytes, _ := io.ReadAll(r.Body)
+					loginBody = string(bytes)
+
+					fmt.Fprintf(w, `{"issuer": "%s.accounts.ondemand.com", "user": "john.doe@int.test", "mail": "john.doe@int.test", "refreshToken": "abc"}`, test.expectedIdp)
+					return
+				}
+
+				w.Header().Set("X-Cpcli-Backend-Status", "200")
+				fmt.Fprintf(w, "{}")
+			}))
+			defer srv.Close()
+
+			testingResource.Test(t, testingResource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: getProviders(srv.Client()),
+				Steps: []testingResource.TestStep{
+					{
+						Config: test.config(srv.URL) + `data "btp_whoami" "me" {}`,
+						Check: testingResource.ComposeAggregateTestCheckFunc(
+							testingResource.TestCheckResourceAttr("data.btp_whoami.me", "id", "john.doe@int.test"),
+							func(_ *terraform.State) error {
+								if !strings.Contains(loginBody, `"customIdp":"`+test.expectedIdp+`"`) {
+									return fmt.Errorf("unexpected login request: %s", loginBody)
+								}
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestProvider_ConfigureWithIdentityProviderFallbacks(t *testing.T) {
+	hclProviderWithIdpFallbacks := func(cliServerURL string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url      = "%s"
+    globalaccount       = "terraformintcanary"
+    username            = "john.doe@int.test"
+    password            = "redacted"
+    idp                 = "primary-idp"
+    login_idp_fallbacks = ["first-fallback-idp", "second-fallback-idp"]
+}
+    `, cliServerURL)
+	}
+
+	var mutex sync.Mutex
+
+	newLoginTestServer := func(validIdp string, logins *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				var body struct {
+					CustomIdp string `json:"customIdp"`
+				}
+
+				content, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(content, &body); err != nil {
+					t.Errorf("unexpected login request: %s", content)
+				}
+
+				*logins = append(*logins, body.CustomIdp)
+
+				if body.CustomIdp != validIdp {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				fmt.Fprintf(w, `{"issuer": "%s.accounts.ondemand.com", "user": "john.doe@int.test", "mail": "john.doe@int.test", "refreshToken": "abc"}`, validIdp)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		}))
+	}
+
+	t.Run("happy path - fallback is used if the primary login fails", func(t *testing.T) {
+		logins := []string{}
+		srv := newLoginTestServer("first-fallback-idp", &logins)
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithIdpFallbacks(srv.URL) + `data "btp_whoami" "me" {}`,
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("data.btp_whoami.me", "id", "john.doe@int.test"),
+						testingResource.TestCheckResourceAttr("data.btp_whoami.me", "issuer", "first-fallback-idp.accounts.ondemand.com"),
+						func(_ *terraform.State) error {
+							mutex.Lock()
+							defer mutex.Unlock()
+
+							// the provider is configured for each command, hence only check the attempts of the first configuration
+							if len(logins) < 2 || logins[0] != "primary-idp" || logins[1] != "first-fallback-idp" {
+								return fmt.Errorf("unexpected login attempts: %v", logins)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - all logins fail", func(t *testing.T) {
+		logins := []string{}
+		srv := newLoginTestServer("another-idp", &logins)
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithIdpFallbacks(srv.URL) + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Login failed. Check your credentials.`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithPollInterval(t *testing.T) {
+	hclProviderWithPollInterval := func(cliServerURL string, pollInterval string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    poll_interval  = "%s"
+}
+    `, cliServerURL, pollInterval)
+	}
+
+	t.Run("happy path - poll interval is honored", func(t *testing.T) {
+		const pollInterval = 200 * time.Millisecond
+
+		var mutex sync.Mutex
+		var createdAt time.Time
+		polls := []time.Time{}
+		deleted := false
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			state := "OK"
+			switch r.URL.RawQuery {
+			case "create":
+				createdAt = time.Now()
+				state = "CREATING"
+			case "delete":
+				deleted = true
+				state = "DELETING"
+			case "get":
+				if deleted {
+					w.Header().Set("X-Cpcli-Backend-Status", "404")
+					fmt.Fprint(w, `{"error": "subaccount not found"}`)
+					return
+				}
+
+				// the subaccount becomes available with the third poll
+				if polls = append(polls, time.Now()); len(polls) < 3 {
+					state = "CREATING"
+				}
+			}
+
+			fmt.Fprintf(w, `{"guid": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "displayName": "my-subaccount", "subdomain": "my-subaccount", "region": "eu10", "state": "%s"}`, state)
+		}))
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithPollInterval(srv.URL, pollInterval.String()) + hclResourceSubaccount("uut", "my-subaccount", "eu10", "my-subaccount"),
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("btp_subaccount.uut", "state", "OK"),
+						func(_ *terraform.State) error {
+							mutex.Lock()
+							defer mutex.Unlock()
+
+							if len(polls) < 3 {
+								return fmt.Errorf("expected at least 3 polls, got %d", len(polls))
+							}
+
+							previous := createdAt
+							for i, poll := range polls[:3] {
+								if gap := poll.Sub(previous); gap < pollInterval || gap >= defaultPollInterval {
+									return fmt.Errorf("expected poll %d to happen after %s, got %s", i+1, pollInterval, gap)
+								}
+								previous = poll
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - poll interval must be a positive duration", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithPollInterval("https://cpcli.cf.sap.hana.ondemand.com", "0s") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Attribute poll_interval value must be a positive duration`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - max poll interval must be a positive duration", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config: `
+provider "btp" {
+    cli_server_url    = "https://cpcli.cf.sap.hana.ondemand.com"
+    globalaccount     = "terraformintcanary"
+    username          = "john.doe@int.test"
+    password          = "redacted"
+    max_poll_interval = "-1m"
+}
+data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Attribute max_poll_interval value must be a positive duration`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithRetries(t *testing.T) {
+	hclProviderWithRetries := func(cliServerURL string, maxRetries int) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url  = "%s"
+    globalaccount   = "terraformintcanary"
+    username        = "john.doe@int.test"
+    password        = "redacted"
+    request_timeout = "30s"
+    max_retries     = %d
+}
+    `, cliServerURL, maxRetries)
+	}
+
+	// newServer simulates a CLI server which is temporarily unavailable for the first requests of the global account
+	newServer := func(unavailableRequests int) (*httptest.Server, *int32) {
+		var requests int32
+
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			if !strings.HasSuffix(r.URL.Path, "/accounts/global-account") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			if atomic.AddInt32(&requests, 1) <= int32(unavailableRequests) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"guid": "795b53bb-a3f0-4769-adf0-26173282a975", "displayName": "My Global Account", "subdomain": "terraformintcanary"}`)
+		})), &requests
+	}
+
+	t.Run("happy path - transient errors are retried", func(t *testing.T) {
+		srv, requests := newServer(2)
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithRetries(srv.URL, 2) + `data "btp_globalaccount" "uut" {}`,
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("data.btp_globalaccount.uut", "name", "My Global Account"),
+						func(_ *terraform.State) error {
+							if count := atomic.LoadInt32(requests); count < 3 {
+								return fmt.Errorf("expected the unavailable requests to be retried, got %d requests", count)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - retries disabled", func(t *testing.T) {
+		srv, _ := newServer(1)
+		defer srv.Close()
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithRetries(srv.URL, 0) + `data "btp_globalaccount" "uut" {}`,
+					ExpectError: regexp.MustCompile(`Received response with unexpected status \[Status: 503`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - max_retries must not be negative", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithRetries("https://cpcli.cf.sap.hana.ondemand.com", -1) + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Attribute max_retries value must be at least 0`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithLoginTimeout(t *testing.T) {
+	// the identity provider responds slowly, whereas commands are answered right away
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	t.Run("error path - login exceeds login_timeout", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []testingResource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+provider "btp" {
+    cli_server_url  = "%s"
+    globalaccount   = "terraformintcanary"
+    username        = "john.doe@int.test"
+    password        = "redacted"
+    login_timeout   = "100ms"
+    request_timeout = "1m"
+}
+    `, srv.URL) + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Login timed out. The authentication didn't complete within 100ms`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithUnknownCredentials(t *testing.T) {
+	hclProviderWithUnknownCredential := func(attribute string, credentials string) string {
+		return fmt.Sprintf(`
+resource "terraform_data" "credential" {
+    input = "redacted"
+}
+
+provider "btp" {
+    globalaccount = "terraformintcanary"
+    %s = terraform_data.credential.output
+%s
+}
+
+data "btp_whoami" "me" {}`, attribute, credentials)
+	}
+
+	tests := []struct {
+		attribute   string
+		credentials string
+	}{
+		{attribute: "username", credentials: `    password = "redacted"`},
+		{attribute: "password", credentials: `    username = "john.doe@int.test"`},
+		{attribute: "client_id", credentials: `    client_secret = "redacted"`},
+		{attribute: "client_secret", credentials: `    client_id = "sb-client-id"`},
+		{attribute: "tls_client_certificate", credentials: `    tls_client_key = "redacted"`},
+		{attribute: "tls_client_key", credentials: `    tls_client_certificate = "redacted"`},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("error path - %s unknown during planning", test.attribute), func(t *testing.T) {
+			testingResource.Test(t, testingResource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: getProviders(nil),
+				Steps: []testingResource.TestStep{
+					{
+						Config:      hclProviderWithUnknownCredential(test.attribute, test.credentials),
+						ExpectError: regexp.MustCompile(fmt.Sprintf("(?s)Unknown Provider Argument.*`%s`", test.attribute)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestProbeCapabilities(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	srvUrl, _ := url.Parse(srv.URL)
+	client := btpcli.NewClientFacade(btpcli.NewV2ClientWithHttpClient(srv.Client(), srvUrl))
+
+	var output bytes.Buffer
+	probeCapabilities(tflogtest.RootLogger(context.Background(), &output), client)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if assert.NoError(t, err) && assert.Len(t, entries, 1) {
+		assert.Equal(t, "warn", entries[0]["@level"])
+		assert.Equal(t, "unable to probe the capabilities of the CLI server, all commands are sent as is", entries[0]["@message"])
+	}
+	assert.Nil(t, client.GetCapabilities())
+}
+
+func TestProvider_ConfigureWithProxy(t *testing.T) {
+	hclProviderWithProxy := func(proxyURL string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "http://localhost:1"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    proxy_url      = "%s"
+}
+    `, proxyURL)
+	}
+
+	t.Run("happy path - requests are sent through the proxy", func(t *testing.T) {
+		var proxiedRequests int32
+
+		// the proxy answers on behalf of the CLI server, which isn't reachable otherwise
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Host != "localhost:1" || r.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("proxy-user:proxy-pass")) {
+				w.WriteHeader(http.StatusProxyAuthRequired)
+				return
+			}
+
+			atomic.AddInt32(&proxiedRequests, 1)
+
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, `{"user": "john.doe@int.test", "mail": "john.doe@int.test", "issuer": "accounts.sap.com"}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer proxy.Close()
+
+		proxyURL := strings.Replace(proxy.URL, "http://", "http://proxy-user:proxy-pass@", 1)
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(http.DefaultClient),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithProxy(proxyURL) + `data "btp_whoami" "me" {}`,
+					Check: testingResource.ComposeAggregateTestCheckFunc(
+						testingResource.TestCheckResourceAttr("data.btp_whoami.me", "email", "john.doe@int.test"),
+						func(_ *terraform.State) error {
+							if atomic.LoadInt32(&proxiedRequests) == 0 {
+								return fmt.Errorf("expected the requests to be sent through the proxy")
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - proxy_url without scheme", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithProxy("proxy.example.com:3128") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`proxy_url must be an absolute URL with the scheme http, https or socks5`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigureWithCACertificate(t *testing.T) {
+	hclProviderWithTLSSettings := func(cliServerURL string, settings string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "terraformintcanary"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+    %s
+}
+    `, cliServerURL, settings)
+	}
+
+	// the server presents a certificate of its own CA, which isn't trusted by the system
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, `{"user": "john.doe@int.test", "mail": "john.doe@int.test", "issuer": "accounts.sap.com"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	caCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	caCertificateFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertificateFile, []byte(caCertificate), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("happy path - server certificate is verified against the CA certificate", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(http.DefaultClient),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithTLSSettings(srv.URL, fmt.Sprintf("tls_ca_certificate = <<EOT\n%sEOT", caCertificate)) + `data "btp_whoami" "me" {}`,
+					Check:  testingResource.TestCheckResourceAttr("data.btp_whoami.me", "email", "john.doe@int.test"),
+				},
+				{
+					Config: hclProviderWithTLSSettings(srv.URL, fmt.Sprintf("tls_ca_certificate = %q", caCertificateFile)) + `data "btp_whoami" "me" {}`,
+					Check:  testingResource.TestCheckResourceAttr("data.btp_whoami.me", "email", "john.doe@int.test"),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - server certificate isn't verified if requested", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(http.DefaultClient),
+			Steps: []testingResource.TestStep{
+				{
+					Config: hclProviderWithTLSSettings(srv.URL, "tls_insecure_skip_verify = true") + `data "btp_whoami" "me" {}`,
+					Check:  testingResource.TestCheckResourceAttr("data.btp_whoami.me", "email", "john.doe@int.test"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - server certificate of an unknown CA", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(http.DefaultClient),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithTLSSettings(srv.URL, "") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`certificate signed by unknown authority`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - CA certificate isn't PEM-encoded", func(t *testing.T) {
+		invalidFile := filepath.Join(t.TempDir(), "invalid.pem")
+		if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(http.DefaultClient),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithTLSSettings(srv.URL, fmt.Sprintf("tls_ca_certificate = %q", invalidFile)) + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`The CA certificate can't be used: no PEM-encoded certificate found`),
+				},
+			},
+		})
+	})
+}
+
+func TestProvider_ConfigValidation(t *testing.T) {
+	hclProviderWithGlobalaccount := func(cliServerURL string, globalaccount string) string {
+		return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url = "%s"
+    globalaccount  = "%s"
+    username       = "john.doe@int.test"
+    password       = "redacted"
+}
+    `, cliServerURL, globalaccount)
+	}
+
+	t.Run("error path - cli_server_url without scheme", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL("cpcli.cf.sap.hana.ondemand.com") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Attribute cli_server_url value must be a valid URL with the scheme "https"`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - cli_server_url with plain http", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL("http://cpcli.cf.sap.hana.ondemand.com") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Attribute cli_server_url value must be a valid URL with the scheme "https"`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - globalaccount malformed", func(t *testing.T) {
+		testingResource.Test(t, testingResource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []testingResource.TestStep{
+				{
+					Config:      hclProviderWithGlobalaccount("https://cpcli.cf.sap.hana.ondemand.com", "https://terraformintcanary.accounts.ondemand.com") + `data "btp_whoami" "me" {}`,
+					ExpectError: regexp.MustCompile(`Attribute globalaccount value must be a valid subdomain`),
+				},
+			},
+		})
+	})
+}
+
 func TestProvider_HasResources(t *testing.T) {
 	expectedResources := []string{
 		"btp_directory",
+		"btp_directory_entitlement",
 		//"btp_directory_role",
 		"btp_directory_role_collection",
 		"btp_directory_role_collection_assignment",
+		"btp_globalaccount",
 		"btp_globalaccount_resource_provider",
 		//"btp_globalaccount_role",
 		"btp_globalaccount_role_collection",
 		"btp_globalaccount_role_collection_assignment",
+		"btp_globalaccount_security_settings",
 		"btp_globalaccount_trust_configuration",
 		"btp_subaccount",
+		"btp_subaccount_api_credential",
+		"btp_subaccount_blueprint",
 		"btp_subaccount_entitlement",
 		"btp_subaccount_environment_instance",
+		"btp_subaccount_labels",
 		//"btp_subaccount_role",
 		"btp_subaccount_role_collection",
 		"btp_subaccount_role_collection_assignment",
+		"btp_subaccount_role_collections",
+		"btp_subaccount_security_settings",
 		"btp_subaccount_service_instance",
+		"btp_subaccount_service_instance_sharing",
 		"btp_subaccount_service_binding",
+		"btp_subaccount_service_broker",
 		"btp_subaccount_subscription",
 		"btp_subaccount_trust_configuration",
 	}
@@ -264,11 +1282,13 @@ func TestProvider_HasResources(t *testing.T) {
 
 func TestProvider_HasDatasources(t *testing.T) {
 	expectedDataSources := []string{
+		"btp_directories",
 		"btp_directory",
 		/*TODO: Depending on customer feedback
 		"btp_directory_app",
 		"btp_directory_apps",
 		*/
+		"btp_directory_entitlement_usage",
 		"btp_directory_entitlements",
 		"btp_directory_labels",
 		"btp_directory_role",
@@ -283,6 +1303,7 @@ func TestProvider_HasDatasources(t *testing.T) {
 		"btp_globalaccount_apps",
 		*/
 		"btp_globalaccount_entitlements",
+		"btp_globalaccount_inventory",
 		/*TODO: Depending on customer feedback
 		"btp_globalaccount_resource_provider",
 		"btp_globalaccount_resource_providers",
@@ -295,15 +1316,20 @@ func TestProvider_HasDatasources(t *testing.T) {
 		"btp_globalaccount_trust_configurations",
 		"btp_globalaccount_user",
 		"btp_globalaccount_users",
+		"btp_provider_config",
 		"btp_regions",
+		"btp_server_info",
 		"btp_subaccount",
+		"btp_subaccount_admins",
 		"btp_subaccount_app",
 		"btp_subaccount_apps",
 		"btp_subaccount_entitlements",
 		"btp_subaccount_environment_instance",
 		"btp_subaccount_environment_instances",
 		"btp_subaccount_environments",
+		"btp_subaccount_group_role_collections",
 		"btp_subaccount_labels",
+		"btp_subaccount_quota",
 		"btp_subaccount_role",
 		"btp_subaccount_role_collection",
 		"btp_subaccount_role_collections",
@@ -331,6 +1357,9 @@ func TestProvider_HasDatasources(t *testing.T) {
 		"btp_subaccount_user",
 		"btp_subaccount_users",
 		"btp_subaccounts",
+		"btp_subaccounts_entitlements",
+		"btp_subaccounts_service_offerings",
+		"btp_user_effective_permissions",
 		"btp_whoami",
 	}
 
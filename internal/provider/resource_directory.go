@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -23,6 +27,8 @@ import (
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
+const directoryAdminRoleCollectionName = "Directory Administrator"
+
 func newDirectoryResource() resource.Resource {
 	return &directoryResource{}
 }
@@ -98,6 +104,20 @@ __Further documentation:__
 				Optional:            true,
 				Computed:            true,
 			},
+			"administrators": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The users of the identity provider given by the `default_origin` of the provider to be assigned to the `" + directoryAdminRoleCollectionName + "` role collection of the directory. Only supported for directories with the `AUTHORIZATIONS` feature. Assignments of the role collection that are made outside of Terraform are not tracked.",
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"force_delete": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, destroying the directory first deletes all its subdirectories and subaccounts, including all their data, service instances and subscriptions. This can't be undone. If set to `false` (default), destroying a directory that isn't empty fails.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the directory.",
 				Computed:            true,
@@ -158,7 +178,7 @@ __Further documentation:__
 }
 
 func (rs *directoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state directoryType
+	var state directoryResourceType
 
 	diags := req.State.Get(ctx, &state)
 
@@ -173,15 +193,19 @@ func (rs *directoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	state, diags = directoryValueFrom(ctx, cliRes)
+	newState, diags := directoryResourceValueFrom(ctx, cliRes)
+	newState.Administrators = state.Administrators
+	if !state.ForceDelete.IsNull() {
+		newState.ForceDelete = state.ForceDelete
+	}
 	resp.Diagnostics.Append(diags...)
 
-	diags = resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (rs *directoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan directoryType
+	var plan directoryResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -219,9 +243,6 @@ func (rs *directoryResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	plan, diags = directoryValueFrom(ctx, cliRes)
-	resp.Diagnostics.Append(diags...)
-
 	createStateConf := &tfutils.StateChangeConf{
 		Pending: []string{cis.StateCreating, cis.StateStarted},
 		Target:  []string{cis.StateOK, cis.StateCreationFailed, cis.StateCanceled},
@@ -235,30 +256,42 @@ func (rs *directoryResource) Create(ctx context.Context, req resource.CreateRequ
 			return subRes, subRes.EntityState, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := createStateConf.WaitForStateContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Creating Resource Directory", fmt.Sprintf("%s", err))
+		return
 	}
 
-	plan, diags = directoryValueFrom(ctx, updatedRes.(cis.DirectoryResponseObject))
+	state, diags := directoryResourceValueFrom(ctx, updatedRes.(cis.DirectoryResponseObject))
+	state.ForceDelete = plan.ForceDelete
+	resp.Diagnostics.Append(diags...)
+
+	state.Administrators, diags = rs.updateAdministrators(ctx, state.ID.ValueString(), plan.Administrators, types.SetNull(types.StringType))
 	resp.Diagnostics.Append(diags...)
 
-	diags = resp.State.Set(ctx, &plan)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (rs *directoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan directoryType
+	var plan, state directoryResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	args := btpcli.DirectoryUpdateInput{
 		DirectoryId: plan.ID.ValueString(),
 	}
@@ -285,9 +318,6 @@ func (rs *directoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	plan, diags = directoryValueFrom(ctx, cliRes)
-	resp.Diagnostics.Append(diags...)
-
 	updateStateConf := &tfutils.StateChangeConf{
 		Pending: []string{cis.StateUpdating, cis.StateStarted},
 		Target:  []string{cis.StateOK, cis.StateUpdateFailed, cis.StateCanceled},
@@ -301,41 +331,149 @@ func (rs *directoryResource) Update(ctx context.Context, req resource.UpdateRequ
 			return subRes, subRes.EntityState, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := updateStateConf.WaitForStateContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Updating Resource Directory", fmt.Sprintf("%s", err))
+		return
 	}
 
-	plan, diags = directoryValueFrom(ctx, updatedRes.(cis.DirectoryResponseObject))
+	newState, diags := directoryResourceValueFrom(ctx, updatedRes.(cis.DirectoryResponseObject))
+	newState.ForceDelete = plan.ForceDelete
+	resp.Diagnostics.Append(diags...)
+
+	newState.Administrators, diags = rs.updateAdministrators(ctx, plan.ID.ValueString(), plan.Administrators, state.Administrators)
 	resp.Diagnostics.Append(diags...)
 
-	diags = resp.State.Set(ctx, plan)
+	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
 }
 
+// updateAdministrators assigns and unassigns users of the default origin of the provider to and from the directory
+// administrator role collection, so that the current administrators become the planned ones. It returns the
+// administrators assigned afterwards, i.e. failed assignments and unassignments are left out.
+func (rs *directoryResource) updateAdministrators(ctx context.Context, directoryId string, planned types.Set, current types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var plannedAdministrators, currentAdministrators []string
+	diags.Append(planned.ElementsAs(ctx, &plannedAdministrators, true)...)
+	diags.Append(current.ElementsAs(ctx, &currentAdministrators, true)...)
+	if diags.HasError() {
+		return current, diags
+	}
+
+	origin := defaultOrigin(rs.cli).ValueString()
+	isEqual := func(a, b string) bool { return a == b }
+
+	assigned := map[string]bool{}
+	for _, administrator := range currentAdministrators {
+		assigned[administrator] = true
+	}
+
+	for _, administrator := range tfutils.SetDifference(currentAdministrators, plannedAdministrators, isEqual) {
+		_, _, err := rs.cli.Security.RoleCollection.UnassignUserByDirectory(ctx, directoryId, directoryAdminRoleCollectionName, administrator, origin)
+		if err != nil {
+			diags.AddError("API Error Unassigning Administrator (Directory)", fmt.Sprintf("%s", err))
+			continue
+		}
+
+		delete(assigned, administrator)
+	}
+
+	for _, administrator := range tfutils.SetDifference(plannedAdministrators, currentAdministrators, isEqual) {
+		_, _, err := rs.cli.Security.RoleCollection.AssignUserByDirectory(ctx, directoryId, directoryAdminRoleCollectionName, administrator, origin)
+		if err != nil {
+			diags.AddError("API Error Assigning Administrator (Directory)", fmt.Sprintf("%s", err))
+			continue
+		}
+
+		assigned[administrator] = true
+	}
+
+	if !diags.HasError() {
+		return planned, diags
+	}
+
+	administrators := make([]string, 0, len(assigned))
+	for administrator := range assigned {
+		administrators = append(administrators, administrator)
+	}
+	sort.Strings(administrators)
+
+	result, setDiags := types.SetValueFrom(ctx, types.StringType, administrators)
+	diags.Append(setDiags...)
+
+	return result, diags
+}
+
 func (rs *directoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state directoryType
+	var state directoryResourceType
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cliRes, _, err := rs.cli.Accounts.Directory.Delete(ctx, state.ID.ValueString())
-	if err != nil {
+	if state.ForceDelete.ValueBool() {
+		directory, _, err := rs.cli.Accounts.Directory.GetWithHierarchy(ctx, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Deleting Resource Directory", fmt.Sprintf("%s", err))
+			return
+		}
+
+		if blocking := deleteDirectoryContent(ctx, rs.cli, directory); len(blocking) > 0 {
+			resp.Diagnostics.AddError("API Error Deleting Resource Directory", fmt.Sprintf("The directory %s can't be deleted, as the following children couldn't be deleted:\n%s", state.ID.ValueString(), strings.Join(blocking, "\n")))
+			return
+		}
+	}
+
+	if err := deleteDirectoryAndWait(ctx, rs.cli, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Directory", fmt.Sprintf("%s", err))
 		return
 	}
+}
+
+// deleteDirectoryContent deletes the subdirectories and subaccounts of the directory depth-first, waiting for each
+// deletion to finish. It returns the children which couldn't be deleted, together with the reason.
+func deleteDirectoryContent(ctx context.Context, cli *btpcli.ClientFacade, directory cis.DirectoryResponseObject) []string {
+	blocking := []string{}
+
+	for _, child := range directory.Children {
+		if childBlocking := deleteDirectoryContent(ctx, cli, child); len(childBlocking) > 0 {
+			blocking = append(blocking, childBlocking...)
+			continue
+		}
+
+		if err := deleteDirectoryAndWait(ctx, cli, child.Guid); err != nil {
+			blocking = append(blocking, fmt.Sprintf("- directory '%s' (%s): %s", child.DisplayName, child.Guid, err))
+		}
+	}
+
+	for _, subaccount := range directory.Subaccounts {
+		if err := deleteSubaccountAndWait(ctx, cli, subaccount.Guid, defaultOperationTimeout); err != nil {
+			blocking = append(blocking, fmt.Sprintf("- subaccount '%s' (%s): %s", subaccount.DisplayName, subaccount.Guid, err))
+		}
+	}
+
+	return blocking
+}
+
+// deleteDirectoryAndWait deletes the directory and waits until the deletion has finished.
+func deleteDirectoryAndWait(ctx context.Context, cli *btpcli.ClientFacade, directoryId string) error {
+	cliRes, _, err := cli.Accounts.Directory.Delete(ctx, directoryId)
+	if err != nil {
+		return err
+	}
 
 	deleteStateConf := &tfutils.StateChangeConf{
 		Pending: []string{cis.StateDeleting, cis.StateStarted},
-		Target:  []string{cis.StateOK, cis.StateDeletionFailed, cis.StateCanceled, "DELETED"},
+		Target:  []string{cis.StateOK, "DELETED"},
 		Refresh: func() (interface{}, string, error) {
-			subRes, comRes, err := rs.cli.Accounts.Directory.Get(ctx, cliRes.Guid)
+			subRes, comRes, err := cli.Accounts.Directory.Get(ctx, cliRes.Guid)
 
 			if comRes.StatusCode == http.StatusNotFound || comRes.StatusCode == http.StatusForbidden {
 				return subRes, "DELETED", nil
@@ -350,19 +488,20 @@ func (rs *directoryResource) Delete(ctx context.Context, req resource.DeleteRequ
 				return subRes, subRes.EntityState, err
 			}
 
+			if subRes.EntityState == cis.StateDeletionFailed || subRes.EntityState == cis.StateCanceled {
+				return subRes, subRes.EntityState, deletionFailedError(subRes.EntityState, subRes.StateMessage)
+			}
+
 			return subRes, subRes.EntityState, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(cli),
+		MinTimeout: pollInterval(cli),
+		MaxTimeout: maxPollInterval(cli),
 	}
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
-
-	if err != nil {
-		resp.Diagnostics.AddError("API Error Deleting Resource Directory", fmt.Sprintf("%s", err))
-		return
-	}
+	return err
 }
 
 func (rs *directoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
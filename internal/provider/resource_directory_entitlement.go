@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+func newDirectoryEntitlementResource() resource.Resource {
+	return &directoryEntitlementResource{}
+}
+
+type directoryEntitlementResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *directoryEntitlementResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_directory_entitlement", req.ProviderTypeName)
+}
+
+func (rs *directoryEntitlementResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *directoryEntitlementResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Assigns the entitlement plan of a service, multitenant application, or environment, to a directory. The plan can be assigned automatically to the subaccounts which are created in the directory in the future.
+
+__Tips:__
+* You must be assigned to the global account admin role.
+* The directory must be configured to manage its own entitlements.
+
+__Further documentation:__
+<https://help.sap.com/docs/btp/sap-business-technology-platform/entitlements-and-quotas>`,
+		Attributes: map[string]schema.Attribute{
+			"directory_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the directory.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the entitled service plan.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the entitled service.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"plan_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the entitled service plan.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`PLATFORM`", " A service required for using a specific platform; for example, Application Runtime is required for the Cloud Foundry platform.") +
+					getFormattedValueAsTableRow("`SERVICE`", "A commercial or technical service. that has a numeric quota (amount) when entitled or assigned to a resource. When assigning entitlements of this type, use the 'amount' option.") +
+					getFormattedValueAsTableRow("`ELASTIC_SERVICE`", "A commercial or technical service that has no numeric quota (amount) when entitled or assigned to a resource. Generally this type of service can be as many times as needed when enabled, but may in some cases be restricted by the service owner.") +
+					getFormattedValueAsTableRow("`ELASTIC_LIMITED`", "An elastic service that can be enabled for only one subaccount per global account.") +
+					getFormattedValueAsTableRow("`APPLICATION`", "A multitenant application to which consumers can subscribe. As opposed to applications defined as a 'QUOTA_BASED_APPLICATION', these applications do not have a numeric quota and are simply enabled or disabled as entitlements per subaccount.") +
+					getFormattedValueAsTableRow("`QUOTA_BASED_APPLICATION`", "A multitenant application to which consumers can subscribe. As opposed to applications defined as 'APPLICATION', these applications have an numeric quota that limits consumer usage of the subscribed application per subaccount.") +
+					getFormattedValueAsTableRow("`ENVIRONMENT`", " An environment service; for example, Cloud Foundry."),
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"plan_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the entitled service plan.",
+				Computed:            true,
+			},
+			"amount": schema.Int64Attribute{
+				MarkdownDescription: "The quota assigned to the directory.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 2000000000),
+				},
+			},
+			"auto_assign": schema.BoolAttribute{
+				MarkdownDescription: "Whether the plan is automatically assigned to the subaccounts which are created in the directory in the future.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"auto_distribute_amount": schema.Int64Attribute{
+				MarkdownDescription: "The quota of the plan which is automatically assigned to the subaccounts which are created in the directory in the future. Only relevant if `auto_assign` is set and the plan has a numeric quota.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 2000000000),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
+					getFormattedValueAsTableRow("state", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`OK`", "The CRUD operation or series of operations completed successfully.") +
+					getFormattedValueAsTableRow("`STARTED`", "The processing operation started") +
+					getFormattedValueAsTableRow("`PROCESSING`", "The processing operation is in progress") +
+					getFormattedValueAsTableRow("`PROCESSING_FAILED`", "The processing operation failed"),
+				Computed: true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
+				Computed:            true,
+			},
+			"created_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the resource was created in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (rs *directoryEntitlementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state directoryEntitlementType
+
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entitlement, _, err := rs.cli.Accounts.Entitlement.GetAssignedByDirectory(ctx, state.DirectoryId.ValueString(), state.ServiceName.ValueString(), state.PlanName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Entitlement (Directory)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	if entitlement == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updatedState, diags := directoryEntitlementValueFrom(ctx, *entitlement)
+
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &updatedState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *directoryEntitlementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	rs.createOrUpdate(ctx, req.Plan, &resp.Diagnostics, &resp.State, "Creating")
+}
+
+func (rs *directoryEntitlementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state directoryEntitlementType
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Amount.IsUnknown() && hasPlanQuota(plan.Amount, plan.Category) && plan.Amount.ValueInt64() < state.Amount.ValueInt64() {
+		resp.Diagnostics.Append(rs.checkAmountCoversChildren(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	rs.createOrUpdate(ctx, req.Plan, &resp.Diagnostics, &resp.State, "Updating")
+}
+
+// checkAmountCoversChildren rejects a reduction of the amount below the quota which the subaccounts and subdirectories
+// of the directory already consume, as it can't be taken back from them implicitly.
+func (rs *directoryEntitlementResource) checkAmountCoversChildren(ctx context.Context, plan directoryEntitlementType) (diags diag.Diagnostics) {
+	entitlement, _, err := rs.cli.Accounts.Entitlement.GetAssignedByDirectory(ctx, plan.DirectoryId.ValueString(), plan.ServiceName.ValueString(), plan.PlanName.ValueString())
+	if err != nil {
+		diags.AddError("API Error Updating Resource Entitlement (Directory)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	if entitlement == nil {
+		return
+	}
+
+	consumed := quotaConsumedByChildren(entitlement.Plan, plan.DirectoryId.ValueString())
+	if float64(plan.Amount.ValueInt64()) < consumed {
+		diags.AddAttributeError(path.Root("amount"), "Amount Below Consumption of the Children",
+			fmt.Sprintf("The amount of %s:%s can't be reduced to %d, as the subaccounts and subdirectories of the directory %s already consume %g. Reduce their quota first.", plan.ServiceName.ValueString(), plan.PlanName.ValueString(), plan.Amount.ValueInt64(), plan.DirectoryId.ValueString(), consumed))
+	}
+
+	return
+}
+
+func (rs *directoryEntitlementResource) createOrUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseDiagnostics *diag.Diagnostics, responseState *tfsdk.State, action string) {
+	var plan directoryEntitlementType
+	diags := requestPlan.Get(ctx, &plan)
+	responseDiagnostics.Append(diags...)
+	if responseDiagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if !hasPlanQuota(plan.Amount, plan.Category) {
+		_, err = rs.cli.Accounts.Entitlement.EnableInDirectory(ctx, plan.DirectoryId.ValueString(), plan.ServiceName.ValueString(), plan.PlanName.ValueString(), plan.AutoAssign.ValueBool())
+	} else {
+		_, err = rs.cli.Accounts.Entitlement.AssignToDirectory(ctx, plan.DirectoryId.ValueString(), plan.ServiceName.ValueString(), plan.PlanName.ValueString(), int(plan.Amount.ValueInt64()), plan.AutoAssign.ValueBool(), int(plan.AutoDistributeAmount.ValueInt64()))
+	}
+
+	if err != nil {
+		responseDiagnostics.AddError(fmt.Sprintf("API Error %s Resource Entitlement (Directory)", action), fmt.Sprintf("%s", err))
+		return
+	}
+
+	// wait for the entitlement to become effective
+	createStateConf := &tfutils.StateChangeConf{
+		Pending: []string{cis_entitlements.StateStarted, cis_entitlements.StateProcessing},
+		Target:  []string{cis_entitlements.StateOK},
+		Refresh: func() (interface{}, string, error) {
+			entitlement, _, err := rs.cli.Accounts.Entitlement.GetAssignedByDirectory(ctx, plan.DirectoryId.ValueString(), plan.ServiceName.ValueString(), plan.PlanName.ValueString())
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			if entitlement == nil {
+				return nil, cis_entitlements.StateProcessing, nil
+			}
+			// No error returned even if operation failed
+			if entitlement.Assignment.EntityState == cis_entitlements.StateProcessingFailed {
+				return *entitlement, entitlement.Assignment.EntityState, errors.New("undefined API error during entitlement processing")
+			}
+
+			return *entitlement, entitlement.Assignment.EntityState, nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	entitlement, err := createStateConf.WaitForStateContext(ctx)
+	if err != nil {
+		responseDiagnostics.AddError(fmt.Sprintf("API Error %s Resource Entitlement (Directory)", action), fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedState, diags := directoryEntitlementValueFrom(ctx, entitlement.(btpcli.UnfoldedEntitlement))
+	responseDiagnostics.Append(diags...)
+
+	diags = responseState.Set(ctx, &updatedState)
+	responseDiagnostics.Append(diags...)
+}
+
+func (rs *directoryEntitlementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state directoryEntitlementType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if !hasPlanQuota(state.Amount, state.Category) {
+		_, err = rs.cli.Accounts.Entitlement.DisableInDirectory(ctx, state.DirectoryId.ValueString(), state.ServiceName.ValueString(), state.PlanName.ValueString())
+	} else {
+		_, err = rs.cli.Accounts.Entitlement.AssignToDirectory(ctx, state.DirectoryId.ValueString(), state.ServiceName.ValueString(), state.PlanName.ValueString(), 0, false, 0)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Entitlement (Directory)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	deleteStateConf := &tfutils.StateChangeConf{
+		Pending: []string{cis_entitlements.StateStarted, cis_entitlements.StateProcessing},
+		Target:  []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			entitlement, _, err := rs.cli.Accounts.Entitlement.GetAssignedByDirectory(ctx, state.DirectoryId.ValueString(), state.ServiceName.ValueString(), state.PlanName.ValueString())
+
+			if err != nil {
+				return entitlement, cis_entitlements.StateProcessingFailed, err
+			}
+
+			if entitlement == nil {
+				return entitlement, "DELETED", nil
+			}
+
+			// No error returned even if operation failed
+			if entitlement.Assignment.EntityState == cis_entitlements.StateProcessingFailed {
+				return *entitlement, entitlement.Assignment.EntityState, errors.New("undefined API error during entitlement processing")
+			}
+
+			return entitlement, cis_entitlements.StateProcessing, nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	_, err = deleteStateConf.WaitForStateContext(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Entitlement (Directory)", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (rs *directoryEntitlementResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: directory,service_name,plan_name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("directory_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_name"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plan_name"), idParts[2])...)
+}
+
+// quotaConsumedByChildren sums up the quota of the plan which is assigned to the direct children of the directory.
+func quotaConsumedByChildren(plan cis_entitlements.AssignedServicePlanResponseObject, directoryId string) float64 {
+	var consumed float64
+
+	for _, assignment := range plan.AssignmentInfo {
+		if assignment.ParentId == directoryId && assignment.EntityId != directoryId {
+			consumed += assignment.Amount
+		}
+	}
+
+	return consumed
+}
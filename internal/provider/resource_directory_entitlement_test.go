@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestResourceDirectoryEntitlement(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - toggle auto assignment", func(t *testing.T) {
+		srv := newDirectoryEntitlementTestServer(t, 0)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 10, false, 0),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "directory_id", "5357bda0-8651-4eab-a69d-12d282bc3247"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "amount", "10"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "auto_assign", "false"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "auto_distribute_amount", "0"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "state", "OK"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 10, true, 2),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "amount", "10"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "auto_assign", "true"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "auto_distribute_amount", "2"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 10, false, 0),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "auto_assign", "false"),
+						resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "auto_distribute_amount", "0"),
+					),
+				},
+				{
+					ResourceName:      "btp_directory_entitlement.uut",
+					ImportStateId:     "5357bda0-8651-4eab-a69d-12d282bc3247,hana-cloud,hana",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+
+	t.Run("error path - amount reduced below the consumption of the children", func(t *testing.T) {
+		srv := newDirectoryEntitlementTestServer(t, 4)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 10, false, 0),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 2, false, 0),
+					ExpectError: regexp.MustCompile(`The amount of hana-cloud:hana can't be reduced to 2, as the subaccounts and\s+subdirectories of the directory 5357bda0-8651-4eab-a69d-12d282bc3247 already\s+consume 4`),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 4, false, 0),
+					Check:  resource.TestCheckResourceAttr("btp_directory_entitlement.uut", "amount", "4"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - import with wrong key", func(t *testing.T) {
+		srv := newDirectoryEntitlementTestServer(t, 0)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryEntitlement("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "hana-cloud", "hana", 10, false, 0),
+				},
+				{
+					ResourceName:  "btp_directory_entitlement.uut",
+					ImportStateId: "5357bda0-8651-4eab-a69d-12d282bc3247",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`Expected import identifier with format: directory,service_name,plan_name. Got:`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - directory_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceDirectoryEntitlement("uut", "this-is-not-a-uuid", "hana-cloud", "hana", 10, false, 0),
+					ExpectError: regexp.MustCompile(`Attribute directory_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+// newDirectoryEntitlementTestServer simulates the entitlement commands of the CLI server for a single directory
+// and the quota-based plan hana-cloud/hana. If childAmount is positive, a subaccount in the directory consumes this
+// amount of the quota.
+func newDirectoryEntitlementTestServer(t *testing.T, childAmount int) *httptest.Server {
+	var mutex sync.Mutex
+	assignment := map[string]string{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if !strings.HasSuffix(r.URL.Path, "/accounts/entitlement") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.RawQuery == "assign" {
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			assignment = body.ParamValues
+			if assignment["amount"] == "0" {
+				assignment = map[string]string{}
+			}
+
+			fmt.Fprint(w, "{}")
+			return
+		}
+
+		if len(assignment) == 0 {
+			fmt.Fprint(w, `{"assignedServices": []}`)
+			return
+		}
+
+		autoDistributeAmount := assignment["autoDistributeAmount"]
+		if autoDistributeAmount == "" {
+			autoDistributeAmount = "0"
+		}
+
+		child := ""
+		if childAmount > 0 {
+			child = fmt.Sprintf(`, {"entityId": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "entityType": "SUBACCOUNT", "entityState": "OK", "amount": %d, "parentId": "%s", "parentType": "DIRECTORY"}`, childAmount, assignment["directory"])
+		}
+
+		fmt.Fprintf(w, `{"assignedServices": [{"name": "hana-cloud", "servicePlans": [{"name": "hana", "uniqueIdentifier": "hana-cloud-hana", "category": "SERVICE", "assignmentInfo": [{"entityId": "%s", "entityType": "DIRECTORY", "entityState": "OK", "amount": %s, "autoAssign": %s, "autoDistributeAmount": %s}%s]}]}]}`, assignment["directory"], assignment["amount"], assignment["autoAssign"], autoDistributeAmount, child)
+	}))
+}
+
+func hclResourceDirectoryEntitlement(resourceName string, directoryId string, serviceName string, planName string, amount int, autoAssign bool, autoDistributeAmount int) string {
+	return fmt.Sprintf(`
+resource "btp_directory_entitlement" "%s" {
+    directory_id           = "%s"
+    service_name           = "%s"
+    plan_name              = "%s"
+    amount                 = %d
+    auto_assign            = %t
+    auto_distribute_amount = %d
+}`, resourceName, directoryId, serviceName, planName, amount, autoAssign, autoDistributeAmount)
+}
@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -29,6 +31,7 @@ type directoryRoleCollectionAssignmentType struct {
 	Username           types.String `tfsdk:"user_name"`
 	Groupname          types.String `tfsdk:"group_name"`
 	Origin             types.String `tfsdk:"origin"`
+	CheckUserExists    types.Bool   `tfsdk:"check_user_exists"`
 }
 
 type directoryRoleCollectionAssignmentResource struct {
@@ -106,9 +109,15 @@ func (rs *directoryRoleCollectionAssignmentResource) Schema(_ context.Context, _
 				Computed:            true,
 				Default:             stringdefault.StaticString("ldap"),
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					originRequiresReplace(),
 				},
 			},
+			"check_user_exists": schema.BoolAttribute{
+				MarkdownDescription: "If set to true, the assignment fails if the user doesn't exist in the identity provider given by `origin`. As this requires an additional request, the check is skipped by default. The default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -124,6 +133,11 @@ func (rs *directoryRoleCollectionAssignmentResource) Read(ctx context.Context, r
 	}
 
 	// This resource is not supposed to be read by definition. However nothing the user can do about that, hence no error message is raised via resp.Diagnostics.
+	if state.CheckUserExists.IsNull() {
+		// assignments created by earlier versions of the provider don't know about this attribute
+		state.CheckUserExists = types.BoolValue(false)
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -136,15 +150,30 @@ func (rs *directoryRoleCollectionAssignmentResource) Create(ctx context.Context,
 		return
 	}
 
-	var err error
-	if !plan.Username.IsNull() {
-		// assign user
-		_, _, err = rs.cli.Security.RoleCollection.AssignUserByDirectory(ctx, plan.DirectoryId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Username.ValueString(), plan.Origin.ValueString())
-	} else {
-		// assign group
-		_, _, err = rs.cli.Security.RoleCollection.AssignGroupByDirectory(ctx, plan.DirectoryId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Groupname.ValueString(), plan.Origin.ValueString())
+	if plan.CheckUserExists.ValueBool() && !plan.Username.IsNull() {
+		_, res, err := rs.cli.Security.User.GetByDirectory(ctx, plan.DirectoryId.ValueString(), plan.Username.ValueString(), normalizeOrigin(plan.Origin.ValueString()))
+		resp.Diagnostics.Append(userExistenceDiagnostics(plan.Username.ValueString(), plan.Origin.ValueString(), res, err)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
+	// the authorization caches might not yet know about a newly created role collection, hence the assignment is retried
+	err := retryOnForbidden(ctx, 1*time.Minute, func() (btpcli.CommandResponse, error) {
+		var res btpcli.CommandResponse
+		var err error
+
+		if !plan.Username.IsNull() {
+			// assign user
+			_, res, err = rs.cli.Security.RoleCollection.AssignUserByDirectory(ctx, plan.DirectoryId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Username.ValueString(), normalizeOrigin(plan.Origin.ValueString()))
+		} else {
+			// assign group
+			_, res, err = rs.cli.Security.RoleCollection.AssignGroupByDirectory(ctx, plan.DirectoryId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Groupname.ValueString(), normalizeOrigin(plan.Origin.ValueString()))
+		}
+
+		return res, err
+	})
+
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Creating Resource Role Collection Assignment (Directory)", fmt.Sprintf("%s", err))
 		return
@@ -165,9 +194,8 @@ func (rs *directoryRoleCollectionAssignmentResource) Update(ctx context.Context,
 		return
 	}
 
-	// since all the attributes are marked to be replaced in case of update, this should never be reached.
-	resp.Diagnostics.AddError("API Error Updating Resource Role Collection Assignment (Directory)", "This resource is not supposed to be updated")
-
+	// all attributes but `check_user_exists` are marked to be replaced in case of update, the origin only if it differs by more than case and
+	// surrounding whitespace. As nothing changes on the server side, there is nothing to do.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -186,10 +214,10 @@ func (rs *directoryRoleCollectionAssignmentResource) Delete(ctx context.Context,
 	var err error
 	if !state.Username.IsNull() {
 		// unassign user
-		_, _, err = rs.cli.Security.RoleCollection.UnassignUserByDirectory(ctx, state.DirectoryId.ValueString(), state.RoleCollectionName.ValueString(), state.Username.ValueString(), state.Origin.ValueString())
+		_, _, err = rs.cli.Security.RoleCollection.UnassignUserByDirectory(ctx, state.DirectoryId.ValueString(), state.RoleCollectionName.ValueString(), state.Username.ValueString(), normalizeOrigin(state.Origin.ValueString()))
 	} else {
 		// unassign group
-		_, _, err = rs.cli.Security.RoleCollection.UnassignGroupByDirectory(ctx, state.DirectoryId.ValueString(), state.RoleCollectionName.ValueString(), state.Groupname.ValueString(), state.Origin.ValueString())
+		_, _, err = rs.cli.Security.RoleCollection.UnassignGroupByDirectory(ctx, state.DirectoryId.ValueString(), state.RoleCollectionName.ValueString(), state.Groupname.ValueString(), normalizeOrigin(state.Origin.ValueString()))
 	}
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Role Collection Assignment (Directory)", fmt.Sprintf("%s", err))
@@ -87,6 +87,22 @@ func TestResourceDirectoryRoleCollectionAssignment(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - user doesn't exist in origin", func(t *testing.T) {
+		srv, _ := newRoleCollectionAssignmentUserTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryRoleCollectionAssignmentWithUserCheck("uut", "05368777-4934-41e8-9f3c-6ec5f4d564b9", "Directory Viewer", "ghost@test.com"),
+					ExpectError: regexp.MustCompile(`The user 'ghost@test.com' does not exist in the identity provider with origin\s+'ldap'`),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - role_collection_name mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -121,3 +137,14 @@ resource "btp_directory_role_collection_assignment" "%s"{
 	origin               = "%s"
 }`, resourceName, directoryId, roleCollectionName, userName, origin)
 }
+
+func hclResourceDirectoryRoleCollectionAssignmentWithUserCheck(resourceName string, directoryId string, roleCollectionName string, userName string) string {
+
+	return fmt.Sprintf(`
+resource "btp_directory_role_collection_assignment" "%s"{
+    directory_id         = "%s"
+	role_collection_name = "%s"
+	user_name            = "%s"
+	check_user_exists    = true
+}`, resourceName, directoryId, roleCollectionName, userName)
+}
@@ -1,10 +1,21 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
 )
 
 func TestResourceDirectory(t *testing.T) {
@@ -47,6 +58,156 @@ func TestResourceDirectory(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("happy path - directory with administrators", func(t *testing.T) {
+		srv, administrators := newDirectoryAdministratorsTestServer(t, defaultUserOrigin, "")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{"jane.doe@test.com", "john.doe@test.com"}),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_directory.uut", "administrators.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_directory.uut", "administrators.*", "jane.doe@test.com"),
+						resource.TestCheckTypeSetElemAttr("btp_directory.uut", "administrators.*", "john.doe@test.com"),
+						checkDirectoryAdministrators(administrators, "jane.doe@test.com", "john.doe@test.com"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{"john.doe@test.com", "max.mustermann@test.com"}),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_directory.uut", "administrators.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_directory.uut", "administrators.*", "john.doe@test.com"),
+						resource.TestCheckTypeSetElemAttr("btp_directory.uut", "administrators.*", "max.mustermann@test.com"),
+						checkDirectoryAdministrators(administrators, "john.doe@test.com", "max.mustermann@test.com"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectory("uut", "my-new-directory", "This is a new directory"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckNoResourceAttr("btp_directory.uut", "administrators"),
+						checkDirectoryAdministrators(administrators),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - administrators of the default origin of the provider", func(t *testing.T) {
+		srv, administrators := newDirectoryAdministratorsTestServer(t, "my-ias", "")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithDefaultOrigin(srv.URL, "my-ias") + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{"jane.doe@test.com"}),
+					Check:  checkDirectoryAdministrators(administrators, "jane.doe@test.com"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - failed assignments are left out of the state", func(t *testing.T) {
+		srv, administrators := newDirectoryAdministratorsTestServer(t, defaultUserOrigin, "unknown.user@test.com")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{"jane.doe@test.com"}),
+					Check:  checkDirectoryAdministrators(administrators, "jane.doe@test.com"),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{"jane.doe@test.com", "john.doe@test.com", "unknown.user@test.com"}),
+					ExpectError: regexp.MustCompile(`API Error Assigning Administrator \(Directory\)`),
+				},
+				{
+					Config:   hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{"jane.doe@test.com", "john.doe@test.com"}),
+					PlanOnly: true,
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - force delete directory with subaccount", func(t *testing.T) {
+		srv, operations := newDirectoryForceDeleteTestServer(t, false, "")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithForceDelete("uut", "my-new-directory", true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_directory.uut", "force_delete", "true"),
+						checkDirectoryOperations(operations),
+					),
+				},
+			},
+			CheckDestroy: checkDirectoryOperations(operations, "delete subaccount 59cd458e-e66e-4b60-b6d8-8f219379f9a5", "delete directory 05368777-4934-41e8-9f3c-6ec5f4d564b9"),
+		})
+	})
+
+	t.Run("error path - force delete reports blocking children", func(t *testing.T) {
+		srv, _ := newDirectoryForceDeleteTestServer(t, true, "")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithForceDelete("uut", "my-new-directory", true),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceDirectoryWithForceDelete("uut", "my-new-directory", true),
+					Destroy:     true,
+					ExpectError: regexp.MustCompile(`subaccount 'My Subaccount' \(59cd458e-e66e-4b60-b6d8-8f219379f9a5\):(.|\n)*subaccount is locked`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - force delete reports failed deletion of children", func(t *testing.T) {
+		srv, _ := newDirectoryForceDeleteTestServer(t, false, cis.StateDeletionFailed)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithPollIntervals(srv.URL, "1s", "1s") + hclResourceDirectoryWithForceDelete("uut", "my-new-directory", true),
+				},
+				{
+					Config:      hclProviderWithPollIntervals(srv.URL, "1s", "1s") + hclResourceDirectoryWithForceDelete("uut", "my-new-directory", true),
+					Destroy:     true,
+					ExpectError: regexp.MustCompile(`subaccount 'My Subaccount' \(59cd458e-e66e-4b60-b6d8-8f219379f9a5\):(.|\n)*DELETION_FAILED(.|\n)*service instances still exist`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - administrator must not be empty", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceDirectoryWithAdministrators("uut", "my-new-directory", []string{""}),
+					ExpectError: regexp.MustCompile(`string length must be at least 1, got: 0`),
+				},
+			},
+		})
+	})
 }
 
 func hclResourceDirectory(resourceName string, displayName string, description string) string {
@@ -55,3 +216,192 @@ func hclResourceDirectory(resourceName string, displayName string, description s
         description = "%s"
     }`, resourceName, displayName, description)
 }
+
+func hclResourceDirectoryWithAdministrators(resourceName string, displayName string, administrators []string) string {
+	administratorsJson, _ := json.Marshal(administrators)
+
+	return fmt.Sprintf(`resource "btp_directory" "%s" {
+        name           = "%s"
+        description    = "This is a new directory"
+        administrators = %s
+    }`, resourceName, displayName, string(administratorsJson))
+}
+
+func hclResourceDirectoryWithForceDelete(resourceName string, displayName string, forceDelete bool) string {
+	return fmt.Sprintf(`resource "btp_directory" "%s" {
+        name         = "%s"
+        description  = "This is a new directory"
+        force_delete = %t
+    }`, resourceName, displayName, forceDelete)
+}
+
+// newDirectoryAdministratorsTestServer simulates a CLI server with a single directory, which expects the users to be of
+// the given origin and fails to assign the given failing user. The returned function lists the users currently assigned
+// to the directory administrator role collection.
+func newDirectoryAdministratorsTestServer(t *testing.T, origin string, failingUser string) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	administrators := map[string]bool{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if strings.HasSuffix(r.URL.Path, "/security/role-collection") {
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, directoryAdminRoleCollectionName, body.ParamValues["roleCollectionName"])
+			assert.Equal(t, origin, body.ParamValues["origin"])
+
+			if body.ParamValues["userName"] == failingUser {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "User %s not found."}`, failingUser)
+				return
+			}
+
+			mu.Lock()
+			if r.URL.RawQuery == "assign" {
+				administrators[body.ParamValues["userName"]] = true
+			} else {
+				delete(administrators, body.ParamValues["userName"])
+			}
+			mu.Unlock()
+
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		fmt.Fprintf(w, `{"guid": "05368777-4934-41e8-9f3c-6ec5f4d564b9", "displayName": "my-new-directory", "description": "This is a new directory", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "directoryFeatures": ["DEFAULT", "ENTITLEMENTS", "AUTHORIZATIONS"], "entityState": "OK"}`)
+	}))
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		res := []string{}
+		for administrator := range administrators {
+			res = append(res, administrator)
+		}
+		sort.Strings(res)
+
+		return res
+	}
+}
+
+func checkDirectoryAdministrators(administrators func() []string, expected ...string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if expected == nil {
+			expected = []string{}
+		}
+
+		if actual := administrators(); !assert.ObjectsAreEqual(expected, actual) {
+			return fmt.Errorf("expected the administrators %v to be assigned, got %v", expected, actual)
+		}
+
+		return nil
+	}
+}
+
+// newDirectoryForceDeleteTestServer simulates a CLI server with a single directory containing one subaccount and records
+// the deletions in the order they are requested. If failSubaccountDelete is set, the first deletion of the subaccount fails.
+// newDirectoryForceDeleteTestServer simulates a directory containing one subaccount. The first deletion of the subaccount
+// is rejected if failSubaccountDelete is set, or ends in the given failedDeletionState, if any.
+func newDirectoryForceDeleteTestServer(t *testing.T, failSubaccountDelete bool, failedDeletionState string) (*httptest.Server, *[]string) {
+	const directoryId = "05368777-4934-41e8-9f3c-6ec5f4d564b9"
+	const subaccountId = "59cd458e-e66e-4b60-b6d8-8f219379f9a5"
+
+	var mu sync.Mutex
+	operations := []string{}
+	deleted := map[string]bool{}
+	subaccountState := cis.StateOK
+	subaccountStateMessage := ""
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		subaccount := fmt.Sprintf(`{"guid": "%s", "displayName": "My Subaccount", "subdomain": "my-subaccount", "region": "eu10", "state": "%s", "stateMessage": "%s"}`, subaccountId, subaccountState, subaccountStateMessage)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/subaccount"):
+			switch r.URL.RawQuery {
+			case "delete":
+				operations = append(operations, "delete subaccount "+subaccountId)
+				if failSubaccountDelete {
+					failSubaccountDelete = false
+					w.Header().Set("X-Cpcli-Backend-Status", "409")
+					fmt.Fprint(w, `{"error": "subaccount is locked"}`)
+					return
+				}
+				if len(failedDeletionState) > 0 {
+					subaccountState, subaccountStateMessage = failedDeletionState, "service instances still exist"
+					failedDeletionState = ""
+					break
+				}
+				deleted[subaccountId] = true
+			case "get":
+				if deleted[subaccountId] {
+					w.Header().Set("X-Cpcli-Backend-Status", "404")
+					fmt.Fprint(w, `{"error": "subaccount not found"}`)
+					return
+				}
+			}
+
+			fmt.Fprint(w, subaccount)
+		case strings.HasSuffix(r.URL.Path, "/accounts/directory"):
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			switch r.URL.RawQuery {
+			case "delete":
+				operations = append(operations, "delete directory "+directoryId)
+				deleted[directoryId] = true
+			case "get":
+				if deleted[directoryId] {
+					w.Header().Set("X-Cpcli-Backend-Status", "404")
+					fmt.Fprint(w, `{"error": "directory not found"}`)
+					return
+				}
+			}
+
+			subaccounts := ""
+			if body.ParamValues["showHierarchy"] == "true" && !deleted[subaccountId] {
+				subaccounts = subaccount
+			}
+
+			fmt.Fprintf(w, `{"guid": "%s", "displayName": "my-new-directory", "description": "This is a new directory", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "directoryFeatures": ["DEFAULT"], "entityState": "OK", "subaccounts": [%s]}`, directoryId, subaccounts)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return srv, &operations
+}
+
+func checkDirectoryOperations(operations *[]string, expected ...string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if expected == nil {
+			expected = []string{}
+		}
+
+		if !assert.ObjectsAreEqual(expected, *operations) {
+			return fmt.Errorf("expected the operations %v, got %v", expected, *operations)
+		}
+
+		return nil
+	}
+}
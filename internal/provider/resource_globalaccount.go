@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+)
+
+func newGlobalaccountResource() resource.Resource {
+	return &globalaccountResource{}
+}
+
+type globalaccountResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *globalaccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_globalaccount", req.ProviderTypeName)
+}
+
+func (rs *globalaccountResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *globalaccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the labels of the global account the provider is configured for.
+
+__Tip:__
+You must be assigned to the global account admin role.
+
+The global account can't be created or deleted. Removing the resource only removes it from the Terraform state and keeps the labels of the global account.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the global account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the global account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subdomain": schema.StringAttribute{
+				MarkdownDescription: "The subdomain of the global account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.SetType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "The set of words or phrases assigned to the global account. The labels replace all existing labels of the global account, an empty map removes them.",
+				Computed:            true,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (rs *globalaccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state globalaccountResourceType
+
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Accounts.GlobalAccount.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Global Account", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags = globalaccountResourceValueFrom(ctx, cliRes)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *globalaccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan globalaccountResourceType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the global account already exists, hence its current labels are the starting point
+	cliRes, _, err := rs.cli.Accounts.GlobalAccount.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Creating Resource Global Account", fmt.Sprintf("%s", err))
+		return
+	}
+
+	current, diags := globalaccountResourceValueFrom(ctx, cliRes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := rs.updateLabels(ctx, plan, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *globalaccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state globalaccountResourceType
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags = rs.updateLabels(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *globalaccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the global account can't be deleted, removing the resource from the state is all there is to do
+}
+
+func (rs *globalaccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateLabels applies the planned labels to the global account, if they differ from the current ones. Labels which
+// aren't configured are left untouched.
+func (rs *globalaccountResource) updateLabels(ctx context.Context, plan globalaccountResourceType, current globalaccountResourceType) (globalaccountResourceType, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.Labels.IsUnknown() || plan.Labels.IsNull() {
+		return current, diags
+	}
+
+	var plannedLabels, currentLabels map[string][]string
+
+	diags.Append(plan.Labels.ElementsAs(ctx, &plannedLabels, false)...)
+	diags.Append(current.Labels.ElementsAs(ctx, &currentLabels, false)...)
+	if diags.HasError() {
+		return current, diags
+	}
+
+	if !labelsChanged(plannedLabels, currentLabels) {
+		return current, diags
+	}
+
+	cliRes, _, err := rs.cli.Accounts.GlobalAccount.Update(ctx, &btpcli.GlobalAccountUpdateInput{
+		Labels: plannedLabels,
+	})
+	if err != nil {
+		diags.AddError("API Error Updating Resource Global Account", fmt.Sprintf("%s", err))
+		return current, diags
+	}
+
+	updated, updatedDiags := globalaccountResourceValueFrom(ctx, cliRes)
+	diags.Append(updatedDiags...)
+
+	return updated, diags
+}
+
+type globalaccountLabel struct {
+	Key    string
+	Values []string
+}
+
+// labelsChanged reports whether any label has been added, changed or removed.
+func labelsChanged(planned map[string][]string, current map[string][]string) bool {
+	plannedLabels := globalaccountLabelsFrom(planned)
+	currentLabels := globalaccountLabelsFrom(current)
+
+	isEqual := func(a, b globalaccountLabel) bool {
+		return a.Key == b.Key && len(tfutils.SetDifference(a.Values, b.Values, stringsAreEqual)) == 0 && len(tfutils.SetDifference(b.Values, a.Values, stringsAreEqual)) == 0
+	}
+
+	toBeUpdated := tfutils.SetDifference(plannedLabels, currentLabels, isEqual)
+	toBeRemoved := tfutils.SetDifference(currentLabels, plannedLabels, func(a, b globalaccountLabel) bool {
+		return a.Key == b.Key
+	})
+
+	return len(toBeUpdated) > 0 || len(toBeRemoved) > 0
+}
+
+func globalaccountLabelsFrom(labels map[string][]string) []globalaccountLabel {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]globalaccountLabel, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, globalaccountLabel{Key: key, Values: labels[key]})
+	}
+
+	return result
+}
+
+func stringsAreEqual(a, b string) bool {
+	return a == b
+}
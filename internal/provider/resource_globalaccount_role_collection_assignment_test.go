@@ -72,6 +72,22 @@ func TestResourceGlobalaccountRoleCollectionAssignment(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - user doesn't exist in origin", func(t *testing.T) {
+		srv, _ := newRoleCollectionAssignmentUserTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccountRoleCollectionAssignmentWithUserCheck("uut", "Global Account Viewer", "ghost@test.com"),
+					ExpectError: regexp.MustCompile(`The user 'ghost@test.com' does not exist in the identity provider with origin\s+'ldap'`),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - role_collection_name mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -104,3 +120,13 @@ resource "btp_globalaccount_role_collection_assignment" "%s"{
 	origin               = "%s"
 }`, resourceName, roleCollectionName, userName, origin)
 }
+
+func hclResourceGlobalaccountRoleCollectionAssignmentWithUserCheck(resourceName string, roleCollectionName string, userName string) string {
+
+	return fmt.Sprintf(`
+resource "btp_globalaccount_role_collection_assignment" "%s"{
+	role_collection_name = "%s"
+	user_name            = "%s"
+	check_user_exists    = true
+}`, resourceName, roleCollectionName, userName)
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+)
+
+func newGlobalaccountSecuritySettingsResource() resource.Resource {
+	return &globalaccountSecuritySettingsResource{}
+}
+
+type globalaccountSecuritySettingsResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *globalaccountSecuritySettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_globalaccount_security_settings", req.ProviderTypeName)
+}
+
+func (rs *globalaccountSecuritySettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *globalaccountSecuritySettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the security settings of the global account.
+
+__Tip:__
+The security settings of a global account can't be deleted. Removing the resource resets the settings to their defaults. Settings which aren't configured keep their current value, which is exposed as computed value. If the server supports it, changes are rejected if the settings have been modified by someone else since they were last read.
+
+__Further documentation:__
+<https://help.sap.com/docs/btp/sap-business-technology-platform/trust-and-federation-with-identity-providers>`,
+		Attributes: map[string]schema.Attribute{
+			"default_identity_provider": schema.StringAttribute{
+				MarkdownDescription: "The origin of the identity provider that is used by default for the login screen of the global account. The identity provider must be configured as trust configuration of the global account.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"access_token_validity": schema.Int64Attribute{
+				MarkdownDescription: "The validity of access tokens issued by the global account in seconds. The value `-1` means that the default validity of the tenant is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(defaultTokenValidity),
+				},
+			},
+			"refresh_token_validity": schema.Int64Attribute{
+				MarkdownDescription: "The validity of refresh tokens issued by the global account in seconds. The value `-1` means that the default validity of the tenant is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(defaultTokenValidity),
+				},
+			},
+			"custom_email_domains": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The custom domains which are allowed for the email addresses of users, e.g. in addition to the domains of SAP ID service. An empty set removes all custom domains.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"iframe_domains": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The domains which are trusted to embed the login screen of the global account in an iframe, e.g. `https://example.com`. An empty set removes all trusted domains.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty or contain whitespace")),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The subdomain of the global account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (rs *globalaccountSecuritySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state globalaccountSecuritySettingsType
+
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, rawRes, err := rs.cli.Security.Settings.GetByGlobalAccount(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Security Settings (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedState, diags := globalaccountSecuritySettingsValueFrom(ctx, rs.cli.GetGlobalAccountSubdomain(), cliRes)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(setSecuritySettingsETag(ctx, resp.Private, rawRes.ETag)...)
+
+	diags = resp.State.Set(ctx, &updatedState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *globalaccountSecuritySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan globalaccountSecuritySettingsType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, etag, diags := rs.updateSecuritySettings(ctx, plan, "")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setSecuritySettingsETag(ctx, resp.Private, etag)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *globalaccountSecuritySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan globalaccountSecuritySettingsType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the settings are only updated if nobody else has changed them since the last read
+	priorETag, diags := securitySettingsETagFrom(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, etag, diags := rs.updateSecuritySettings(ctx, plan, priorETag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setSecuritySettingsETag(ctx, resp.Private, etag)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *globalaccountSecuritySettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state globalaccountSecuritySettingsType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenValidity := defaultTokenValidity
+	iframeDomains := ""
+
+	_, _, err := rs.cli.Security.Settings.UpdateByGlobalAccount(ctx, btpcli.SecuritySettingsUpdateInput{
+		DefaultIdp:           defaultIdentityProviderOrigin,
+		AccessTokenValidity:  &tokenValidity,
+		RefreshTokenValidity: &tokenValidity,
+		CustomEmailDomains:   []string{},
+		IframeDomains:        &iframeDomains,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Security Settings (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (rs *globalaccountSecuritySettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// the provider is bound to a single global account, so only its settings can be imported
+	if req.ID != rs.cli.GetGlobalAccountSubdomain() {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: globalaccount subdomain of the provider (%s). Got: %q", rs.cli.GetGlobalAccountSubdomain(), req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateSecuritySettings applies the plan and returns the resulting settings along with their ETag. If an ETag is given,
+// the settings are only updated if they still have this ETag.
+func (rs *globalaccountSecuritySettingsResource) updateSecuritySettings(ctx context.Context, plan globalaccountSecuritySettingsType, etag string) (globalaccountSecuritySettingsType, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	args := btpcli.SecuritySettingsUpdateInput{}
+
+	if !plan.DefaultIdentityProvider.IsUnknown() && !plan.DefaultIdentityProvider.IsNull() {
+		args.DefaultIdp = plan.DefaultIdentityProvider.ValueString()
+
+		diags.Append(rs.validateTrustConfigurationExists(ctx, args.DefaultIdp)...)
+		if diags.HasError() {
+			return plan, "", diags
+		}
+	}
+
+	if !plan.AccessTokenValidity.IsUnknown() && !plan.AccessTokenValidity.IsNull() {
+		accessTokenValidity := int(plan.AccessTokenValidity.ValueInt64())
+		args.AccessTokenValidity = &accessTokenValidity
+	}
+
+	if !plan.RefreshTokenValidity.IsUnknown() && !plan.RefreshTokenValidity.IsNull() {
+		refreshTokenValidity := int(plan.RefreshTokenValidity.ValueInt64())
+		args.RefreshTokenValidity = &refreshTokenValidity
+	}
+
+	if !plan.CustomEmailDomains.IsUnknown() && !plan.CustomEmailDomains.IsNull() {
+		args.CustomEmailDomains = []string{}
+		diags.Append(plan.CustomEmailDomains.ElementsAs(ctx, &args.CustomEmailDomains, false)...)
+		if diags.HasError() {
+			return plan, "", diags
+		}
+	}
+
+	if !plan.IframeDomains.IsUnknown() && !plan.IframeDomains.IsNull() {
+		var iframeDomains []string
+		diags.Append(plan.IframeDomains.ElementsAs(ctx, &iframeDomains, false)...)
+		if diags.HasError() {
+			return plan, "", diags
+		}
+
+		joinedIframeDomains := strings.Join(iframeDomains, " ")
+		args.IframeDomains = &joinedIframeDomains
+	}
+
+	updateCtx := ctx
+	if len(etag) > 0 {
+		updateCtx = btpcli.WithIfMatch(ctx, etag)
+	}
+
+	_, _, err := rs.cli.Security.Settings.UpdateByGlobalAccount(updateCtx, args)
+	if errors.Is(err, btpcli.ErrConcurrentModification) {
+		diags.AddError("Conflict Updating Resource Security Settings (Global Account)", fmt.Sprintf("%s Run terraform plan again to review the changes made to the security settings of global account %s in the meantime.", err, rs.cli.GetGlobalAccountSubdomain()))
+		return plan, "", diags
+	} else if err != nil {
+		diags.AddError("API Error Updating Resource Security Settings (Global Account)", fmt.Sprintf("%s", err))
+		return plan, "", diags
+	}
+
+	cliRes, rawRes, err := rs.cli.Security.Settings.GetByGlobalAccount(ctx)
+	if err != nil {
+		diags.AddError("API Error Reading Resource Security Settings (Global Account)", fmt.Sprintf("%s", err))
+		return plan, "", diags
+	}
+
+	state, stateDiags := globalaccountSecuritySettingsValueFrom(ctx, rs.cli.GetGlobalAccountSubdomain(), cliRes)
+	diags.Append(stateDiags...)
+
+	return state, rawRes.ETag, diags
+}
+
+func (rs *globalaccountSecuritySettingsResource) validateTrustConfigurationExists(ctx context.Context, origin string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	trustConfigurations, _, err := rs.cli.Security.Trust.ListByGlobalAccount(ctx)
+	if err != nil {
+		diags.AddError("API Error Reading Resource Trust Configurations (Global Account)", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	for _, trustConfiguration := range trustConfigurations {
+		if trustConfiguration.OriginKey == origin {
+			return diags
+		}
+	}
+
+	diags.AddAttributeError(path.Root("default_identity_provider"), "Invalid Default Identity Provider", fmt.Sprintf("The global account %s has no trust configuration with origin '%s'.", rs.cli.GetGlobalAccountSubdomain(), origin))
+
+	return diags
+}
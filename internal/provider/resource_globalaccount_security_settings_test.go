@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceGlobalaccountSecuritySettings(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - set and reset settings", func(t *testing.T) {
+		srv, _ := newGlobalaccountSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccountSecuritySettings("uut", "terraformint-platform", 3600, `["example.com"]`, `["https://example.com", "https://example.org"]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "id", "terraformintcanary"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "default_identity_provider", "terraformint-platform"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "access_token_validity", "3600"),
+						// settings which aren't configured expose their current value
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "refresh_token_validity", "-1"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "custom_email_domains.#", "1"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount_security_settings.uut", "custom_email_domains.*", "example.com"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "iframe_domains.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount_security_settings.uut", "iframe_domains.*", "https://example.com"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount_security_settings.uut", "iframe_domains.*", "https://example.org"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccountSecuritySettings("uut", "sap.default", -1, `[]`, `[]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "default_identity_provider", "sap.default"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "access_token_validity", "-1"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "custom_email_domains.#", "0"),
+						resource.TestCheckResourceAttr("btp_globalaccount_security_settings.uut", "iframe_domains.#", "0"),
+					),
+				},
+				{
+					ResourceName:      "btp_globalaccount_security_settings.uut",
+					ImportStateId:     "terraformintcanary",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+	t.Run("happy path - delete resets the settings to their defaults", func(t *testing.T) {
+		srv, settings := newGlobalaccountSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccountSecuritySettings("uut", "terraformint-platform", 3600, `["example.com"]`, `["https://example.com"]`),
+				},
+			},
+		})
+
+		assert.Equal(t, map[string]string{
+			"defaultIdp":           "sap.default",
+			"accessTokenValidity":  "-1",
+			"refreshTokenValidity": "-1",
+			"customEmailDomains":   "[]",
+			"iframeDomains":        "",
+		}, settings)
+	})
+	t.Run("error path - default identity provider without trust configuration", func(t *testing.T) {
+		srv, _ := newGlobalaccountSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccountSecuritySettings("uut", "unknown-platform", -1, `[]`, `[]`),
+					ExpectError: regexp.MustCompile(`no trust configuration with origin 'unknown-platform'`),
+				},
+			},
+		})
+	})
+	t.Run("error path - iframe domains must not contain whitespace", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceGlobalaccountSecuritySettings("uut", "sap.default", -1, `[]`, `["https://example.com https://example.org"]`),
+					ExpectError: regexp.MustCompile(`must not be empty or contain whitespace`),
+				},
+			},
+		})
+	})
+	t.Run("error path - import of another global account", func(t *testing.T) {
+		srv, _ := newGlobalaccountSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					ResourceName:  "btp_globalaccount_security_settings.uut",
+					ImportStateId: "another-globalaccount",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`Expected import identifier with format: globalaccount subdomain of the provider`),
+					Config:        hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccountSecuritySettings("uut", "sap.default", -1, `[]`, `[]`),
+				},
+			},
+		})
+	})
+}
+
+// newGlobalaccountSecuritySettingsTestServer simulates the security settings and trust commands of the CLI server for
+// a global account with the trust configurations `sap.default` and `terraformint-platform`. The returned map holds the
+// current settings as passed to the CLI server.
+func newGlobalaccountSecuritySettingsTestServer(t *testing.T) (*httptest.Server, map[string]string) {
+	settings := map[string]string{
+		"defaultIdp":           "sap.default",
+		"accessTokenValidity":  "-1",
+		"refreshTokenValidity": "-1",
+		"customEmailDomains":   "[]",
+		"iframeDomains":        "",
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		if body.ParamValues["globalAccount"] != "terraformintcanary" {
+			t.Errorf("unexpected scope of request: %s", content)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/trust") && r.URL.RawQuery == "list":
+			fmt.Fprintf(w, `[{"originKey": "sap.default"}, {"originKey": "terraformint-platform"}]`)
+		case strings.HasSuffix(r.URL.Path, "/security/settings") && r.URL.RawQuery == "update":
+			for key := range settings {
+				if value, ok := body.ParamValues[key]; ok {
+					settings[key] = value
+				}
+			}
+
+			fmt.Fprintf(w, "{}")
+		case strings.HasSuffix(r.URL.Path, "/security/settings") && r.URL.RawQuery == "get":
+			fmt.Fprintf(w, `{"defaultIdp": "%s", "iframeDomains": "%s", "tokenPolicySettings": {"accessTokenValidity": %s, "refreshTokenValidity": %s}, "customEmailDomains": %s}`, settings["defaultIdp"], settings["iframeDomains"], settings["accessTokenValidity"], settings["refreshTokenValidity"], settings["customEmailDomains"])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), settings
+}
+
+func hclResourceGlobalaccountSecuritySettings(resourceName string, defaultIdentityProvider string, accessTokenValidity int, customEmailDomains string, iframeDomains string) string {
+	template := `
+resource "btp_globalaccount_security_settings" "%s" {
+    default_identity_provider = "%s"
+    access_token_validity     = %d
+    custom_email_domains      = %s
+    iframe_domains            = %s
+}`
+
+	return fmt.Sprintf(template, resourceName, defaultIdentityProvider, accessTokenValidity, customEmailDomains, iframeDomains)
+}
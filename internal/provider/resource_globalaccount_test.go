@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceGlobalaccount(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - set, change and clear labels", func(t *testing.T) {
+		srv, updates := newGlobalaccountLabelsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccount("uut", `{"cost-center" = ["4711"], "owner" = ["alice", "bob"]}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "id", "795b53bb-a3f0-4769-adf0-26173282a975"),
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "name", "My Global Account"),
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "subdomain", "my-globalaccount"),
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "labels.%", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount.uut", "labels.cost-center.*", "4711"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount.uut", "labels.owner.*", "alice"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount.uut", "labels.owner.*", "bob"),
+						checkGlobalaccountLabelUpdates(updates, 1),
+					),
+				},
+				{
+					// only the order of the values differs, hence nothing is updated
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccount("uut", `{"cost-center" = ["4711"], "owner" = ["bob", "alice"]}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						checkGlobalaccountLabelUpdates(updates, 1),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccount("uut", `{"owner" = ["carol"]}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "labels.%", "1"),
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "labels.owner.#", "1"),
+						resource.TestCheckTypeSetElemAttr("btp_globalaccount.uut", "labels.owner.*", "carol"),
+						checkGlobalaccountLabelUpdates(updates, 2),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceGlobalaccount("uut", `{}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_globalaccount.uut", "labels.%", "0"),
+						checkGlobalaccountLabelUpdates(updates, 3),
+					),
+				},
+				{
+					ResourceName:      "btp_globalaccount.uut",
+					ImportStateId:     "795b53bb-a3f0-4769-adf0-26173282a975",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
+
+func TestLabelsChanged(t *testing.T) {
+	current := map[string][]string{"owner": {"alice", "bob"}}
+
+	assert.False(t, labelsChanged(map[string][]string{"owner": {"bob", "alice"}}, current))
+	assert.True(t, labelsChanged(map[string][]string{"owner": {"alice"}}, current))
+	assert.True(t, labelsChanged(map[string][]string{"owner": {"alice", "bob"}, "cost-center": {"4711"}}, current))
+	assert.True(t, labelsChanged(map[string][]string{}, current))
+}
+
+// newGlobalaccountLabelsTestServer simulates the global account commands of the CLI server and counts the label updates.
+func newGlobalaccountLabelsTestServer(t *testing.T) (*httptest.Server, *int) {
+	labels := `{"team": ["unmanaged"]}`
+	updates := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if !strings.HasSuffix(r.URL.Path, "/accounts/global-account") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.RawQuery == "update" {
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			labels = body.ParamValues["labels"]
+			updates++
+		}
+
+		fmt.Fprintf(w, `{"guid": "795b53bb-a3f0-4769-adf0-26173282a975", "displayName": "My Global Account", "subdomain": "my-globalaccount", "labels": %s}`, labels)
+	})), &updates
+}
+
+func checkGlobalaccountLabelUpdates(updates *int, expected int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if *updates != expected {
+			return fmt.Errorf("expected %d label updates, got %d", expected, *updates)
+		}
+		return nil
+	}
+}
+
+func hclResourceGlobalaccount(resourceName string, labels string) string {
+	template := `
+resource "btp_globalaccount" "%s" {
+    labels = %s
+}`
+
+	return fmt.Sprintf(template, resourceName, labels)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 )
@@ -89,6 +90,14 @@ __Further documentation:__
 				MarkdownDescription: "The protocol used to establish trust with the identity provider.",
 				Computed:            true,
 			},
+			"protocol_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the protocol used to establish trust with the identity provider. Possible values are: \n " +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`saml2`", "The trust is established with SAML 2.0.") +
+					getFormattedValueAsTableRow("`openIdConnect`", "The trust is established with OpenID Connect."),
+				Computed: true,
+			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "Shows whether the identity provider is currently active or not.",
 				Computed:            true,
@@ -117,7 +126,9 @@ func (rs *globalaccountTrustConfigurationResource) Read(ctx context.Context, req
 		return
 	}
 
+	origin := state.Origin
 	state, diags = globalaccountTrustConfigurationFromValue(ctx, cliRes)
+	state.Origin = originOrPrior(cliRes.OriginKey, origin)
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &state)
@@ -147,7 +158,7 @@ func (rs *globalaccountTrustConfigurationResource) Create(ctx context.Context, r
 	}
 
 	if !plan.Origin.IsUnknown() {
-		origin := plan.Origin.ValueString()
+		origin := normalizeOrigin(plan.Origin.ValueString())
 		cliReq.Origin = &origin
 	}
 
@@ -163,7 +174,9 @@ func (rs *globalaccountTrustConfigurationResource) Create(ctx context.Context, r
 		return
 	}
 
+	origin := plan.Origin
 	plan, diags = globalaccountTrustConfigurationFromValue(ctx, cliRes)
+	plan.Origin = originOrPrior(cliRes.OriginKey, origin)
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &plan)
@@ -178,10 +191,27 @@ func (rs *globalaccountTrustConfigurationResource) Update(ctx context.Context, r
 		return
 	}
 
-	resp.Diagnostics.AddError("API Error Updating Resource Trust Configuration (Global Account)", "this resource is not supposed to be updated")
+	var state globalaccountTrustConfigurationType
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	isUnchanged := func(planned types.String, current types.String) bool {
+		return planned.IsUnknown() || planned.Equal(current)
+	}
+
+	// the trust configuration is not supposed to be updated, only the spelling of the origin may change
+	if !isUnchanged(plan.IdentityProvider, state.IdentityProvider) || !isUnchanged(plan.Name, state.Name) || !isUnchanged(plan.Description, state.Description) || !originsEqual(plan.Origin, state.Origin) {
+		resp.Diagnostics.AddError("API Error Updating Resource Trust Configuration (Global Account)", "this resource is not supposed to be updated")
+		return
+	}
+
+	state.Origin = plan.Origin
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (rs *globalaccountTrustConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -5,16 +5,24 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
@@ -26,6 +34,9 @@ func newSubaccountResource() resource.Resource {
 	return &subaccountResource{}
 }
 
+var _ resource.ResourceWithModifyPlan = &subaccountResource{}
+var _ resource.ResourceWithValidateConfig = &subaccountResource{}
+
 type subaccountResource struct {
 	cli *btpcli.ClientFacade
 }
@@ -42,7 +53,7 @@ func (rs *subaccountResource) Configure(_ context.Context, req resource.Configur
 	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
 }
 
-func (rs *subaccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (rs *subaccountResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Creates a subaccount in a global account or directory.
 
@@ -68,7 +79,7 @@ __Further documentation:__
 				},
 			},
 			"region": schema.StringAttribute{
-				MarkdownDescription: "The region in which the subaccount was created.",
+				MarkdownDescription: "The region in which the subaccount was created. Changing the region deletes the subaccount and recreates it in the new region, which requires `allow_region_change` to be set to `true`.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -107,11 +118,57 @@ __Further documentation:__
 				Computed:            true,
 				Optional:            true,
 			},
+			"custom_properties": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The custom properties assigned to the subaccount. In contrast to `labels`, each property has a single value.",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 			"beta_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Shows whether the subaccount can use beta services and applications.",
 				Optional:            true,
 				Computed:            true,
 			},
+			"beta_features": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The beta features enabled for the subaccount. The features are enabled while the subaccount is created, so that they are available right from the start. Features added to or removed from the set later on are enabled or disabled respectively, and features enabled or disabled outside of Terraform are detected as a change. If not set, the features enabled for the subaccount are shown. Requires `beta_enabled` to be `true`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"allow_region_change": schema.BoolAttribute{
+				MarkdownDescription: "Allows Terraform to delete and recreate the subaccount if the `region` is changed. All data and content of the subaccount is lost in this case. As long as the value is `false`, a change of the region is rejected during planning.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"validate_entitlements_on_move": schema.BoolAttribute{
+				MarkdownDescription: "Checks during planning whether the new parent distributes all entitlements assigned to the subaccount, if the `parent_id` is changed. Plans which the new parent doesn't distribute are reported as an error, before the subaccount is touched.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_until_usable": schema.BoolAttribute{
+				MarkdownDescription: "Waits after the creation of the subaccount until its service marketplace can be queried, so that dependent resources like entitlements or subscriptions can be created right away. The wait is bounded by the `create` timeout. The default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"timeouts": resourceTimeoutsAttributes(ctx, "subaccount"),
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Prevents the subaccount from being deleted by Terraform as long as the value is `true`. To delete the subaccount, set the value to `false` and apply the change first.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the subaccount.",
 				Computed:            true,
@@ -136,6 +193,24 @@ __Further documentation:__
 				MarkdownDescription: "The features of parent entity of the subaccount.",
 				Computed:            true,
 			},
+			"geo_access": schema.StringAttribute{
+				MarkdownDescription: "The data residency of the region in which the subaccount was created, i.e. the geographic locations from where the subaccount can be accessed. Possible values are: \n" +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`STANDARD`", "The subaccount can be accessed from any geographic location.") +
+					getFormattedValueAsTableRow("`EU_ACCESS`", "The subaccount can be accessed only within locations in the EU."),
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"iaas_provider": schema.StringAttribute{
+				MarkdownDescription: "The infrastructure provider of the region in which the subaccount was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "The current state of the subaccount. Possible values are: \n" +
 					getFormattedValueAsTableRow("state", "description") +
@@ -187,7 +262,7 @@ __Further documentation:__
 }
 
 func (rs *subaccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data subaccountType
+	var data subaccountResourceType
 
 	diags := req.State.Get(ctx, &data)
 
@@ -202,21 +277,63 @@ func (rs *subaccountResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	data, diags = subaccountValueFrom(ctx, cliRes)
+	deletionProtection := data.DeletionProtection
+	if deletionProtection.IsNull() {
+		deletionProtection = types.BoolValue(false)
+	}
+
+	allowRegionChange := data.AllowRegionChange
+	if allowRegionChange.IsNull() {
+		allowRegionChange = types.BoolValue(false)
+	}
+
+	validateEntitlementsOnMove := data.ValidateEntitlementsOnMove
+	if validateEntitlementsOnMove.IsNull() {
+		validateEntitlementsOnMove = types.BoolValue(false)
+	}
+
+	waitUntilUsable := data.WaitUntilUsable
+	if waitUntilUsable.IsNull() {
+		waitUntilUsable = types.BoolValue(false)
+	}
+
+	betaFeatures := data.BetaFeatures
+	timeouts := data.Timeouts
+	geoAccess, iaasProvider := rs.subaccountDataResidency(ctx, cliRes.Region, data.GeoAccess, data.IaasProvider)
+
+	data, diags = subaccountResourceValueFrom(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
+	data.DeletionProtection = deletionProtection
+	data.AllowRegionChange = allowRegionChange
+	data.ValidateEntitlementsOnMove = validateEntitlementsOnMove
+	data.WaitUntilUsable = waitUntilUsable
+	data.Timeouts = timeouts
+	data.GeoAccess = geoAccess
+	data.IaasProvider = iaasProvider
+
+	if cliRes.BetaFeatures == nil {
+		// not all server versions report the beta features
+		data.BetaFeatures = betaFeatures
+	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (rs *subaccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan subaccountType
+	var plan subaccountResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	args := btpcli.SubaccountCreateInput{
 		DisplayName: plan.Name.ValueString(),
 		Subdomain:   plan.Subdomain.ValueString(),
@@ -238,14 +355,29 @@ func (rs *subaccountResource) Create(ctx context.Context, req resource.CreateReq
 		args.BetaEnabled = betaEnabled
 	}
 
+	if !plan.BetaFeatures.IsUnknown() {
+		var betaFeatures []string
+		plan.BetaFeatures.ElementsAs(ctx, &betaFeatures, false)
+		sort.Strings(betaFeatures)
+		args.BetaFeatures = betaFeatures
+	}
+
 	if !plan.Labels.IsUnknown() {
 		var labels map[string][]string
 		plan.Labels.ElementsAs(ctx, &labels, false)
 		args.Labels = labels
 	}
 
+	if !plan.CustomProperties.IsUnknown() {
+		var customProperties map[string]string
+		plan.CustomProperties.ElementsAs(ctx, &customProperties, false)
+		args.CustomProperties = subaccountCustomPropertiesFrom(customProperties)
+	}
+
 	args.UsedForProduction = mapUsageToUsedForProduction(plan.Usage.ValueString())
 
+	createStart := time.Now()
+
 	cliRes, _, err := rs.cli.Accounts.Subaccount.Create(ctx, &args)
 
 	if err != nil {
@@ -253,7 +385,14 @@ func (rs *subaccountResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	plan, diags = subaccountValueFrom(ctx, cliRes)
+	deletionProtection := plan.DeletionProtection
+	allowRegionChange := plan.AllowRegionChange
+	validateEntitlementsOnMove := plan.ValidateEntitlementsOnMove
+	waitUntilUsable := plan.WaitUntilUsable
+	betaFeatures := plan.BetaFeatures
+	plannedTimeouts := plan.Timeouts
+
+	plan, diags = subaccountResourceValueFrom(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
 
 	createStateConf := &tfutils.StateChangeConf{
@@ -268,9 +407,10 @@ func (rs *subaccountResource) Create(ctx context.Context, req resource.CreateReq
 
 			return subRes, subRes.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    createTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := createStateConf.WaitForStateContext(ctx)
@@ -278,27 +418,96 @@ func (rs *subaccountResource) Create(ctx context.Context, req resource.CreateReq
 	if err != nil {
 		updatedRes = cliRes
 		resp.Diagnostics.AddError("API Error Creating Resource Subaccount", fmt.Sprintf("%s", err))
+	} else if waitUntilUsable.ValueBool() && updatedRes.(cis.SubaccountResponseObject).State == cis.StateOK {
+		if remainingTimeout := createTimeout - time.Since(createStart); remainingTimeout <= 0 {
+			resp.Diagnostics.AddWarning("Subaccount Not Checked for Usability", "The subaccount has been created, but the create timeout expired before its service marketplace could be queried. Dependent resources might fail until the subaccount is usable.")
+		} else if err := rs.waitUntilUsable(ctx, cliRes.Guid, remainingTimeout); err != nil {
+			resp.Diagnostics.AddError("API Error Creating Resource Subaccount", fmt.Sprintf("the subaccount has been created, but its service marketplace can't be queried yet: %s", err))
+		}
 	}
 
-	plan, diags = subaccountValueFrom(ctx, updatedRes.(cis.SubaccountResponseObject))
+	plan, diags = subaccountResourceValueFrom(ctx, updatedRes.(cis.SubaccountResponseObject))
 	resp.Diagnostics.Append(diags...)
+	plan.DeletionProtection = deletionProtection
+	plan.AllowRegionChange = allowRegionChange
+	plan.ValidateEntitlementsOnMove = validateEntitlementsOnMove
+	plan.WaitUntilUsable = waitUntilUsable
+	if !betaFeatures.IsUnknown() {
+		plan.BetaFeatures = betaFeatures
+	}
+	plan.Timeouts = plannedTimeouts
+	plan.GeoAccess, plan.IaasProvider = rs.subaccountDataResidency(ctx, plan.Region.ValueString(), types.StringNull(), types.StringNull())
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// waitUntilUsable polls the service marketplace of a newly created subaccount until it can be queried. Entitlements
+// and subscriptions might fail until then, although the subaccount itself is already in state OK. Only the statuses
+// with which the backend reports a marketplace that is not set up yet are retried, any other error is returned immediately.
+func (rs *subaccountResource) waitUntilUsable(ctx context.Context, subaccountId string, timeout time.Duration) error {
+	const stateUsable, stateNotUsable = "USABLE", "NOT_USABLE"
+
+	usableStateConf := &tfutils.StateChangeConf{
+		Pending: []string{stateNotUsable},
+		Target:  []string{stateUsable},
+		Refresh: func() (interface{}, string, error) {
+			offerings, comRes, err := rs.cli.Services.Offering.List(ctx, subaccountId, "", "", "")
+
+			if err != nil {
+				if !isMarketplaceNotUsableYet(comRes) {
+					return offerings, "", err
+				}
+
+				tflog.Debug(ctx, "service marketplace of the subaccount not queryable yet", map[string]interface{}{"subaccount_id": subaccountId, "error": err.Error()})
+				return offerings, stateNotUsable, nil
+			}
+
+			return offerings, stateUsable, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	_, err := usableStateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// isMarketplaceNotUsableYet tells whether the service marketplace of a subaccount has been rejected, because it is still
+// being set up: it isn't known yet (404), conflicts with the ongoing setup (409) or isn't available yet (503).
+func isMarketplaceNotUsableYet(res btpcli.CommandResponse) bool {
+	switch res.StatusCode {
+	case http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
 func (rs *subaccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan subaccountType
+	var plan, state subaccountResourceType
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	betaEnabled := plan.BetaEnabled.ValueBool()
+
 	args := btpcli.SubaccountUpdateInput{
-		BetaEnabled:  plan.BetaEnabled.ValueBool(),
+		BetaEnabled:  &betaEnabled,
 		Description:  plan.Description.ValueString(),
 		Directory:    plan.ParentID.ValueString(),
 		DisplayName:  plan.Name.ValueString(),
@@ -309,15 +518,44 @@ func (rs *subaccountResource) Update(ctx context.Context, req resource.UpdateReq
 	plan.Labels.ElementsAs(ctx, &labels, false)
 	args.Labels = labels
 
+	if !plan.CustomProperties.IsUnknown() {
+		var planned, current map[string]string
+		plan.CustomProperties.ElementsAs(ctx, &planned, false)
+		state.CustomProperties.ElementsAs(ctx, &current, false)
+		args.CustomProperties = subaccountCustomPropertiesToBeUpdated(planned, current)
+	}
+
+	if !plan.BetaFeatures.IsUnknown() {
+		var planned, current []string
+		plan.BetaFeatures.ElementsAs(ctx, &planned, false)
+		state.BetaFeatures.ElementsAs(ctx, &current, false)
+		args.EnableBetaFeatures, args.DisableBetaFeatures = subaccountBetaFeaturesToBeChanged(planned, current)
+	}
+
 	args.UsedForProduction = mapUsageToUsedForProduction(plan.Usage.ValueString())
 
-	cliRes, _, err := rs.cli.Accounts.Subaccount.Update(ctx, &args)
+	cliRes, comRes, err := rs.cli.Accounts.Subaccount.Update(ctx, &args)
+	if err != nil && args.BetaEnabled != nil && rs.isBetaAlreadySet(ctx, comRes, args.SubaccountId, *args.BetaEnabled) {
+		// the beta features are already in the planned state, hence only the other changes are applied
+		tflog.Debug(ctx, "beta features of the subaccount already set, retrying the update without them", map[string]interface{}{"error": err.Error()})
+
+		args.BetaEnabled = nil
+		cliRes, _, err = rs.cli.Accounts.Subaccount.Update(ctx, &args)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Updating Resource Subaccount", fmt.Sprintf("%s", err))
 		return
 	}
 
-	plan, diags = subaccountValueFrom(ctx, cliRes)
+	deletionProtection := plan.DeletionProtection
+	allowRegionChange := plan.AllowRegionChange
+	validateEntitlementsOnMove := plan.ValidateEntitlementsOnMove
+	waitUntilUsable := plan.WaitUntilUsable
+	betaFeatures := plan.BetaFeatures
+	plannedTimeouts := plan.Timeouts
+
+	plan, diags = subaccountResourceValueFrom(ctx, cliRes)
 	resp.Diagnostics.Append(diags...)
 
 	updateStateConf := &tfutils.StateChangeConf{
@@ -332,42 +570,292 @@ func (rs *subaccountResource) Update(ctx context.Context, req resource.UpdateReq
 
 			return subRes, subRes.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    updateTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := updateStateConf.WaitForStateContext(ctx)
 	if err != nil {
+		updatedRes = cliRes
 		resp.Diagnostics.AddError("API Error Updating Resource Subaccount", fmt.Sprintf("%s", err))
 	}
 
-	plan, diags = subaccountValueFrom(ctx, updatedRes.(cis.SubaccountResponseObject))
+	plan, diags = subaccountResourceValueFrom(ctx, updatedRes.(cis.SubaccountResponseObject))
 	resp.Diagnostics.Append(diags...)
+	plan.DeletionProtection = deletionProtection
+	plan.AllowRegionChange = allowRegionChange
+	plan.ValidateEntitlementsOnMove = validateEntitlementsOnMove
+	plan.WaitUntilUsable = waitUntilUsable
+	if !betaFeatures.IsUnknown() {
+		plan.BetaFeatures = betaFeatures
+	}
+	plan.Timeouts = plannedTimeouts
+	plan.GeoAccess, plan.IaasProvider = rs.subaccountDataResidency(ctx, plan.Region.ValueString(), state.GeoAccess, state.IaasProvider)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+func (rs *subaccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var betaEnabled types.Bool
+	var betaFeatures types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("beta_enabled"), &betaEnabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("beta_features"), &betaFeatures)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once they are known
+	if betaEnabled.IsUnknown() || betaFeatures.IsUnknown() || len(betaFeatures.Elements()) == 0 {
+		return
+	}
+
+	if !betaEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("beta_features"), "Beta Features Require Beta Enabled", "Beta features can only be enabled for a subaccount whose `beta_enabled` is `true`.")
+	}
+}
+
+func (rs *subaccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		// nothing to check on creation or deletion
+		return
+	}
+
+	var state, plan subaccountResourceType
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Region.IsUnknown() && !plan.Region.Equal(state.Region) {
+		resp.Diagnostics.Append(checkSubaccountRegionChange(state, plan)...)
+	}
+
+	if plan.ValidateEntitlementsOnMove.ValueBool() && !plan.ParentID.IsUnknown() && !plan.ParentID.Equal(state.ParentID) {
+		resp.Diagnostics.Append(rs.checkSubaccountMoveEntitlements(ctx, state, plan)...)
+	}
+}
+
+// isBetaAlreadySet reports whether the CLI server rejected an update as a bad request, because the beta features of the
+// subaccount are already enabled or disabled. Some server versions report this as an error instead of ignoring it. As
+// the message of the error isn't stable, the subaccount is read to confirm that its beta features are in the requested state.
+func (rs *subaccountResource) isBetaAlreadySet(ctx context.Context, res btpcli.CommandResponse, subaccountId string, betaEnabled bool) bool {
+	if res.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	subRes, _, err := rs.cli.Accounts.Subaccount.Get(ctx, subaccountId)
+
+	return err == nil && subRes.BetaEnabled == betaEnabled
+}
+
+// subaccountCustomPropertiesFrom converts the custom properties into the CLI input, sorted by key.
+func subaccountCustomPropertiesFrom(customProperties map[string]string) []btpcli.SubaccountCustomPropertyInput {
+	if len(customProperties) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(customProperties))
+	for key := range customProperties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	properties := make([]btpcli.SubaccountCustomPropertyInput, 0, len(keys))
+	for _, key := range keys {
+		properties = append(properties, btpcli.SubaccountCustomPropertyInput{Key: key, Value: customProperties[key]})
+	}
+
+	return properties
+}
+
+// subaccountCustomPropertiesToBeUpdated returns the added and changed custom properties, followed by the removed ones without value.
+func subaccountCustomPropertiesToBeUpdated(planned map[string]string, current map[string]string) []btpcli.SubaccountCustomPropertyInput {
+	plannedProperties := subaccountCustomPropertiesFrom(planned)
+	currentProperties := subaccountCustomPropertiesFrom(current)
+
+	toBeUpdated := tfutils.SetDifference(plannedProperties, currentProperties, func(a, b btpcli.SubaccountCustomPropertyInput) bool {
+		return a.Key == b.Key && a.Value == b.Value
+	})
+
+	toBeRemoved := tfutils.SetDifference(currentProperties, plannedProperties, func(a, b btpcli.SubaccountCustomPropertyInput) bool {
+		return a.Key == b.Key
+	})
+
+	for _, property := range toBeRemoved {
+		toBeUpdated = append(toBeUpdated, btpcli.SubaccountCustomPropertyInput{Key: property.Key})
+	}
+
+	return toBeUpdated
+}
+
+// subaccountBetaFeaturesToBeChanged returns the beta features to be enabled and to be disabled, each sorted by name.
+func subaccountBetaFeaturesToBeChanged(planned []string, current []string) (toBeEnabled []string, toBeDisabled []string) {
+	toBeEnabled = tfutils.SetDifference(planned, current, stringsAreEqual)
+	toBeDisabled = tfutils.SetDifference(current, planned, stringsAreEqual)
+
+	sort.Strings(toBeEnabled)
+	sort.Strings(toBeDisabled)
+
+	return
+}
+
+func checkSubaccountRegionChange(state subaccountResourceType, plan subaccountResourceType) (diags diag.Diagnostics) {
+	if !plan.AllowRegionChange.ValueBool() {
+		diags.AddAttributeError(path.Root("region"), "Region Change Not Allowed",
+			fmt.Sprintf("Changing the region of the subaccount %s from '%s' to '%s' requires the subaccount to be deleted and recreated. Set `allow_region_change` to `true` to confirm the recreation.", state.ID.ValueString(), state.Region.ValueString(), plan.Region.ValueString()))
+		return
+	}
+
+	diags.AddAttributeWarning(path.Root("region"), "Subaccount Will Be Recreated",
+		fmt.Sprintf("Changing the region of the subaccount %s from '%s' to '%s' deletes the subaccount and creates a new one. All data, service instances, subscriptions and role assignments of the subaccount are lost and the new subaccount gets a different ID.", state.ID.ValueString(), state.Region.ValueString(), plan.Region.ValueString()))
+	return
+}
+
+// subaccountDataResidency returns the data residency of the region of the subaccount. As the region can't be changed
+// in place, values already known are kept and the available regions are only looked up if they are missing. The data
+// residency is informational only, hence a failed lookup doesn't fail the operation.
+func (rs *subaccountResource) subaccountDataResidency(ctx context.Context, region string, geoAccess types.String, iaasProvider types.String) (types.String, types.String) {
+	if geoAccess.ValueString() != "" || iaasProvider.ValueString() != "" {
+		return geoAccess, iaasProvider
+	}
+
+	cliRes, _, err := rs.cli.Accounts.AvailableRegion.List(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to look up the data residency of the subaccount region", map[string]interface{}{"region": region, "error": err.Error()})
+		return types.StringNull(), types.StringNull()
+	}
+
+	return subaccountDataResidencyFrom(cliRes, region)
+}
+
+// subaccountDataResidencyFrom picks the data residency of the region from the available data centers. All data centers
+// of a region share the same data residency, independent of the environment they support.
+func subaccountDataResidencyFrom(regions cis.DataCenterResponseCollection, region string) (types.String, types.String) {
+	for _, dataCenter := range regions.Datacenters {
+		if dataCenter.Region == region {
+			return stringNullIfEmpty(dataCenter.GeoAccess), stringNullIfEmpty(dataCenter.IaasProvider)
+		}
+	}
+
+	return types.StringNull(), types.StringNull()
+}
+
+// checkSubaccountMoveEntitlements reports the entitlements of the subaccount which aren't distributed by its new parent.
+// The entitlements are distributed by the nearest directory that manages entitlements, or by the global account if
+// there is none. Everything the global account is entitled to is available anyway, hence nothing is checked then.
+func (rs *subaccountResource) checkSubaccountMoveEntitlements(ctx context.Context, state subaccountResourceType, plan subaccountResourceType) (diags diag.Diagnostics) {
+	const summary = "API Error Validating Entitlements of the New Parent"
+
+	globalAccount, _, err := rs.cli.Accounts.GlobalAccount.Get(ctx)
+	if err != nil {
+		diags.AddError(summary, fmt.Sprintf("%s", err))
+		return
+	}
+
+	directoryId := plan.ParentID.ValueString()
+	for directoryId != globalAccount.Guid {
+		directory, _, err := rs.cli.Accounts.Directory.Get(ctx, directoryId)
+		if err != nil {
+			diags.AddError(summary, fmt.Sprintf("%s", err))
+			return
+		}
+
+		if directoryManagesEntitlements(directory) {
+			break
+		}
+
+		directoryId = directory.ParentGUID
+	}
+
+	if directoryId == globalAccount.Guid {
+		return
+	}
+
+	assigned, _, err := rs.cli.Accounts.Entitlement.ListBySubaccount(ctx, state.ID.ValueString())
+	if err != nil {
+		diags.AddError(summary, fmt.Sprintf("%s", err))
+		return
+	}
+
+	distributed, _, err := rs.cli.Accounts.Entitlement.ListByDirectory(ctx, directoryId)
+	if err != nil {
+		diags.AddError(summary, fmt.Sprintf("%s", err))
+		return
+	}
+
+	distributedPlans := subaccountEntitledServicesFrom(distributed)
+
+	var incompatible []string
+	for servicePlan := range subaccountEntitledServicesFrom(assigned) {
+		if _, ok := distributedPlans[servicePlan]; !ok {
+			incompatible = append(incompatible, "- "+servicePlan)
+		}
+	}
+
+	if len(incompatible) == 0 {
+		return
+	}
+	sort.Strings(incompatible)
+
+	diags.AddAttributeError(path.Root("parent_id"), "Incompatible Entitlements of the New Parent",
+		fmt.Sprintf("The subaccount %s can't be moved to %s, as the directory %s doesn't distribute the following entitlements assigned to the subaccount:\n%s\n\nAssign the entitlements to the directory first or set `validate_entitlements_on_move` to `false` to skip the check.", state.ID.ValueString(), plan.ParentID.ValueString(), directoryId, strings.Join(incompatible, "\n")))
+	return
+}
+
+// directoryManagesEntitlements reports whether the directory distributes entitlements to the subaccounts below it.
+func directoryManagesEntitlements(directory cis.DirectoryResponseObject) bool {
+	for _, feature := range directory.DirectoryFeatures {
+		if feature == "ENTITLEMENTS" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (rs *subaccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state subaccountType
+	var state subaccountResourceType
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cliRes, _, err := rs.cli.Accounts.Subaccount.Delete(ctx, state.ID.ValueString())
-	if err != nil {
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Subaccount Is Protected Against Deletion", fmt.Sprintf("The subaccount %s has `deletion_protection` enabled. Set `deletion_protection` to `false` and apply the change before deleting the subaccount.", state.ID.ValueString()))
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := deleteSubaccountAndWait(ctx, rs.cli, state.ID.ValueString(), deleteTimeout); err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Subaccount", fmt.Sprintf("%s", err))
 		return
 	}
+}
+
+// deleteSubaccountAndWait deletes the subaccount and waits at most for the given timeout until the deletion has finished.
+func deleteSubaccountAndWait(ctx context.Context, cli *btpcli.ClientFacade, subaccountId string, timeout time.Duration) error {
+	cliRes, _, err := cli.Accounts.Subaccount.Delete(ctx, subaccountId)
+	if err != nil {
+		return err
+	}
 
 	deleteStateConf := &tfutils.StateChangeConf{
 		Pending: []string{cis.StateDeleting, cis.StateStarted},
-		Target:  []string{cis.StateOK, cis.StateDeletionFailed, cis.StateCanceled, "DELETED"},
+		Target:  []string{cis.StateOK, "DELETED"},
 		Refresh: func() (interface{}, string, error) {
-			subRes, comRes, err := rs.cli.Accounts.Subaccount.Get(ctx, cliRes.Guid)
+			subRes, comRes, err := cli.Accounts.Subaccount.Get(ctx, cliRes.Guid)
 
 			if comRes.StatusCode == http.StatusNotFound {
 				return subRes, "DELETED", nil
@@ -377,19 +865,29 @@ func (rs *subaccountResource) Delete(ctx context.Context, req resource.DeleteReq
 				return subRes, subRes.State, err
 			}
 
+			if subRes.State == cis.StateDeletionFailed || subRes.State == cis.StateCanceled {
+				return subRes, subRes.State, deletionFailedError(subRes.State, subRes.StateMessage)
+			}
+
 			return subRes, subRes.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    timeout,
+		Delay:      pollInterval(cli),
+		MinTimeout: pollInterval(cli),
+		MaxTimeout: maxPollInterval(cli),
 	}
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
+	return err
+}
 
-	if err != nil {
-		resp.Diagnostics.AddError("API Error Deleting Resource Subaccount", fmt.Sprintf("%s", err))
-		return
+// deletionFailedError describes a deletion of a subaccount or directory which ended in the given failure state.
+func deletionFailedError(state string, stateMessage string) error {
+	if len(stateMessage) == 0 {
+		return fmt.Errorf("the deletion ended in state %s", state)
 	}
+
+	return fmt.Errorf("the deletion ended in state %s: %s", state, stateMessage)
 }
 
 func (rs *subaccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
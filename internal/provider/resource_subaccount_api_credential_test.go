@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_api"
+)
+
+func TestResourceSubaccountApiCredential(t *testing.T) {
+	t.Run("happy path - client secret", func(t *testing.T) {
+		srv := newSubaccountApiCredentialTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountApiCredential("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-pipeline", ""),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "id", "sb-my-pipeline!b1"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "name", "my-pipeline"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "read_only", "false"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "client_id", "sb-my-pipeline!b1"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "client_secret", "a-client-secret"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "token_url", "https://my-subaccount.authentication.eu10.hana.ondemand.com/oauth/token"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "api_url", "https://api.authentication.eu10.hana.ondemand.com"),
+						resource.TestCheckNoResourceAttr("btp_subaccount_api_credential.uut", "certificate_passed"),
+					),
+				},
+				{
+					// the client secret isn't returned anymore, but kept in the state
+					RefreshState: true,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "client_secret", "a-client-secret"),
+					),
+				},
+				{
+					ResourceName:            "btp_subaccount_api_credential.uut",
+					ImportStateId:           "59cd458e-e66e-4b60-b6d8-8f219379f9a5,my-pipeline",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"client_secret"},
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - certificate", func(t *testing.T) {
+		srv := newSubaccountApiCredentialTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountApiCredential("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-pipeline", "-----BEGIN CERTIFICATE-----"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "client_id", "sb-my-pipeline!b1"),
+						resource.TestCheckResourceAttr("btp_subaccount_api_credential.uut", "certificate_passed", "-----BEGIN CERTIFICATE-----"),
+						resource.TestCheckNoResourceAttr("btp_subaccount_api_credential.uut", "client_secret"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountApiCredential("uut", "this-is-not-a-uuid", "my-pipeline", ""),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - import failure", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					ResourceName:  "btp_subaccount_api_credential.uut",
+					ImportStateId: "59cd458e-e66e-4b60-b6d8-8f219379f9a5",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`Expected import identifier with format: subaccount_id,name. Got:`),
+					Config:        hclProvider() + hclResourceSubaccountApiCredential("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-pipeline", ""),
+				},
+			},
+		})
+	})
+}
+
+func hclResourceSubaccountApiCredential(resourceName string, subaccountId string, name string, certificate string) string {
+	if len(certificate) > 0 {
+		return fmt.Sprintf(`
+		resource "btp_subaccount_api_credential" "%s" {
+			subaccount_id      = "%s"
+			name               = "%s"
+			certificate_passed = "%s"
+		}`, resourceName, subaccountId, name, certificate)
+	}
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_api_credential" "%s" {
+			subaccount_id = "%s"
+			name          = "%s"
+		}`, resourceName, subaccountId, name)
+}
+
+// newSubaccountApiCredentialTestServer returns a CLI server which keeps the API credentials of a subaccount in memory.
+// Like the real server, it only returns the client secret on creation and only if no certificate is passed.
+func newSubaccountApiCredentialTestServer() *httptest.Server {
+	apiCredentials := map[string]xsuaa_api.ApiCredential{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		if !strings.HasSuffix(r.URL.Path, "/security/api-credential") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		name := payload.ParamValues["name"]
+		apiCredential, ok := apiCredentials[name]
+
+		switch r.URL.RawQuery {
+		case "create":
+			apiCredential = xsuaa_api.ApiCredential{
+				Name:        name,
+				ClientId:    fmt.Sprintf("sb-%s!b1", name),
+				Certificate: payload.ParamValues["certificate"],
+				TokenUrl:    "https://my-subaccount.authentication.eu10.hana.ondemand.com/oauth/token",
+				ApiUrl:      "https://api.authentication.eu10.hana.ondemand.com",
+				ReadOnly:    payload.ParamValues["readOnly"] == "true",
+			}
+			apiCredentials[name], ok = apiCredential, true
+
+			if len(apiCredential.Certificate) == 0 {
+				apiCredential.ClientSecret = "a-client-secret"
+			}
+		case "delete":
+			delete(apiCredentials, name)
+		}
+
+		if !ok {
+			w.Header().Set("X-Cpcli-Backend-Status", "404")
+			fmt.Fprintf(w, `{"error": "API credential not found"}`)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		_ = json.NewEncoder(w).Encode(apiCredential)
+	}))
+}
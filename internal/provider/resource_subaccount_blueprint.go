@@ -0,0 +1,464 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+	"github.com/SAP/terraform-provider-btp/internal/validation/jsonvalidator"
+)
+
+func newSubaccountBlueprintResource() resource.Resource {
+	return &subaccountBlueprintResource{}
+}
+
+type subaccountBlueprintEntitlementType struct {
+	ServiceName types.String `tfsdk:"service_name"`
+	PlanName    types.String `tfsdk:"plan_name"`
+	Amount      types.Int64  `tfsdk:"amount"`
+}
+
+type subaccountBlueprintSubscriptionType struct {
+	AppName    types.String `tfsdk:"app_name"`
+	PlanName   types.String `tfsdk:"plan_name"`
+	Parameters types.String `tfsdk:"parameters"`
+}
+
+type subaccountBlueprintType struct {
+	ID            types.String                          `tfsdk:"id"`
+	Name          types.String                          `tfsdk:"name"`
+	Subdomain     types.String                          `tfsdk:"subdomain"`
+	Region        types.String                          `tfsdk:"region"`
+	Description   types.String                          `tfsdk:"description"`
+	Labels        types.Map                             `tfsdk:"labels"`
+	Entitlements  []subaccountBlueprintEntitlementType  `tfsdk:"entitlements"`
+	Subscriptions []subaccountBlueprintSubscriptionType `tfsdk:"subscriptions"`
+	State         types.String                          `tfsdk:"state"`
+	Timeouts      timeouts.Value                        `tfsdk:"timeouts"`
+}
+
+type subaccountBlueprintResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *subaccountBlueprintResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_blueprint", req.ProviderTypeName)
+}
+
+func (rs *subaccountBlueprintResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *subaccountBlueprintResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Creates a subaccount from a blueprint, i.e. the subaccount together with its entitlements and the subscriptions to applications.
+
+The blueprint is applied as a whole: if assigning an entitlement or subscribing to an application fails, the subscriptions created so far are removed and the subaccount is deleted again. Any change of the blueprint re-creates the subaccount.
+
+__Tip:__
+You must be assigned to the admin role of the global account or directory.`,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "A descriptive name of the subaccount for customer-facing UIs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"subdomain": schema.StringAttribute{
+				MarkdownDescription: "The subdomain that becomes part of the path used to access the authorization tenant of the subaccount. Must be unique within the defined region. Use only letters (a-z), digits (0-9), and hyphens (not at the start or end). Maximum length is 63 characters. Cannot be changed after the subaccount has been created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The region in which the subaccount was created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the subaccount for customer-facing UIs.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.SetType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "The set of words or phrases assigned to the subaccount.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"entitlements": schema.ListNestedAttribute{
+				MarkdownDescription: "The service plans the subaccount is entitled to. The entitlements are assigned in the given order.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the entitled service.",
+							Required:            true,
+						},
+						"plan_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the entitled service plan.",
+							Required:            true,
+						},
+						"amount": schema.Int64Attribute{
+							MarkdownDescription: "The quota assigned to the subaccount. If not set, the service plan is only enabled, which is the way to go for plans that don't have a numeric quota.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"subscriptions": schema.ListNestedAttribute{
+				MarkdownDescription: "The applications the subaccount is subscribed to. The subscriptions are created in the given order, after all entitlements have been assigned.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"app_name": schema.StringAttribute{
+							MarkdownDescription: "The unique registration name of the deployed multitenant application as defined by the app developer.",
+							Required:            true,
+						},
+						"plan_name": schema.StringAttribute{
+							MarkdownDescription: "The plan name of the application to which the consumer has subscribed.",
+							Required:            true,
+						},
+						"parameters": schema.StringAttribute{
+							MarkdownDescription: "The parameters of the subscription as a valid JSON object.",
+							Optional:            true,
+							Validators: []validator.String{
+								jsonvalidator.ValidJSON(),
+							},
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "The current state of the subaccount.",
+				Computed:            true,
+			},
+			"timeouts": resourceTimeoutsAttributes(ctx, "subaccount blueprint"),
+		},
+	}
+}
+
+func (rs *subaccountBlueprintResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subaccountBlueprintType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, comRes, err := rs.cli.Accounts.Subaccount.Get(ctx, state.ID.ValueString())
+	if comRes.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Subaccount Blueprint", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// the entitlements and subscriptions are owned by the blueprint, only the subaccount itself is refreshed
+	state.State = types.StringValue(cliRes.State)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountBlueprintResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subaccountBlueprintType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	deleteTimeout, diags := plan.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args := btpcli.SubaccountCreateInput{
+		DisplayName: plan.Name.ValueString(),
+		Subdomain:   plan.Subdomain.ValueString(),
+		Region:      plan.Region.ValueString(),
+		Description: plan.Description.ValueString(),
+	}
+
+	if !plan.Labels.IsNull() {
+		var labels map[string][]string
+		plan.Labels.ElementsAs(ctx, &labels, false)
+		args.Labels = labels
+	}
+
+	cliRes, _, err := rs.cli.Accounts.Subaccount.Create(ctx, &args)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Creating Resource Subaccount Blueprint", fmt.Sprintf("%s", err))
+		return
+	}
+
+	plan.ID = types.StringValue(cliRes.Guid)
+	plan.State = types.StringValue(cliRes.State)
+
+	// the whole blueprint is applied within the create timeout, the rollback is bounded by the delete timeout
+	createCtx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	subaccount, err := rs.waitForSubaccountCreation(createCtx, cliRes.Guid, createTimeout)
+	if err == nil {
+		plan.State = types.StringValue(subaccount.State)
+
+		for _, entitlement := range plan.Entitlements {
+			if err = rs.assignEntitlement(createCtx, cliRes.Guid, entitlement, createTimeout); err != nil {
+				err = fmt.Errorf("unable to assign the entitlement %s/%s: %w", entitlement.ServiceName.ValueString(), entitlement.PlanName.ValueString(), err)
+				break
+			}
+		}
+	}
+
+	// subscriptions that have been requested, whether they succeeded or not, must be removed in case of a rollback
+	var subscriptions []subaccountBlueprintSubscriptionType
+
+	if err == nil {
+		for _, subscription := range plan.Subscriptions {
+			var requested bool
+
+			_, requested, err = subscribeAndWait(createCtx, rs.cli, cliRes.Guid, subscription.AppName.ValueString(), subscription.PlanName.ValueString(), subscription.Parameters.ValueString(), nil, createTimeout, false)
+			if requested {
+				subscriptions = append(subscriptions, subscription)
+			}
+
+			if err != nil {
+				err = fmt.Errorf("unable to subscribe to the application %s: %w", subscription.AppName.ValueString(), err)
+				break
+			}
+		}
+	}
+
+	if err == nil {
+		diags = resp.State.Set(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.AddError("API Error Creating Resource Subaccount Blueprint", fmt.Sprintf("%s", err))
+
+	if rollbackErr := rs.teardown(ctx, cliRes.Guid, subscriptions, deleteTimeout); rollbackErr != nil {
+		// keep track of the subaccount, so that it can be destroyed once the cause has been fixed
+		resp.Diagnostics.AddError("Rollback of Subaccount Blueprint Failed", fmt.Sprintf("The subaccount %s could not be removed after the failure: %s", cliRes.Guid, rollbackErr))
+
+		diags = resp.State.Set(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+func (rs *subaccountBlueprintResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all attributes of the blueprint but the timeouts require a replacement of the subaccount, hence there is nothing to update
+	var plan subaccountBlueprintType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountBlueprintResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountBlueprintType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := rs.teardown(ctx, state.ID.ValueString(), state.Subscriptions, deleteTimeout); err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Subaccount Blueprint", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (rs *subaccountBlueprintResource) waitForSubaccountCreation(ctx context.Context, subaccountId string, timeout time.Duration) (cis.SubaccountResponseObject, error) {
+	createStateConf := &tfutils.StateChangeConf{
+		Pending: []string{cis.StateCreating, cis.StateStarted},
+		Target:  []string{cis.StateOK},
+		Refresh: func() (interface{}, string, error) {
+			subRes, _, err := rs.cli.Accounts.Subaccount.Get(ctx, subaccountId)
+
+			if err != nil {
+				return subRes, "", err
+			}
+
+			if subRes.State == cis.StateCreationFailed || subRes.State == cis.StateCanceled {
+				return subRes, subRes.State, fmt.Errorf("the subaccount creation ended in state %s", subRes.State)
+			}
+
+			return subRes, subRes.State, nil
+		},
+		Timeout:    timeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	subRes, err := createStateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return cis.SubaccountResponseObject{}, err
+	}
+
+	return subRes.(cis.SubaccountResponseObject), nil
+}
+
+func (rs *subaccountBlueprintResource) assignEntitlement(ctx context.Context, subaccountId string, entitlement subaccountBlueprintEntitlementType, timeout time.Duration) error {
+	serviceName, planName := entitlement.ServiceName.ValueString(), entitlement.PlanName.ValueString()
+
+	var err error
+	if entitlement.Amount.IsNull() {
+		_, err = rs.cli.Accounts.Entitlement.EnableInSubaccount(ctx, subaccountId, serviceName, planName)
+	} else {
+		_, err = rs.cli.Accounts.Entitlement.AssignToSubaccount(ctx, subaccountId, serviceName, planName, int(entitlement.Amount.ValueInt64()))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// wait for the entitlement to become effective
+	assignStateConf := &tfutils.StateChangeConf{
+		Pending: []string{cis_entitlements.StateStarted, cis_entitlements.StateProcessing},
+		Target:  []string{cis_entitlements.StateOK},
+		Refresh: func() (interface{}, string, error) {
+			assignment, _, err := rs.cli.Accounts.Entitlement.GetAssignedBySubaccount(ctx, subaccountId, serviceName, planName)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			if assignment == nil {
+				return nil, cis_entitlements.StateProcessing, nil
+			}
+
+			// No error returned even if operation failed
+			if assignment.Assignment.EntityState == cis_entitlements.StateProcessingFailed {
+				return *assignment, assignment.Assignment.EntityState, errors.New("undefined API error during entitlement processing")
+			}
+
+			return *assignment, assignment.Assignment.EntityState, nil
+		},
+		Timeout:    timeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	_, err = assignStateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// teardown unsubscribes the subaccount from the given applications in reverse order and deletes the subaccount
+// afterwards, all within the given timeout. The entitlements of the subaccount are released together with the subaccount.
+func (rs *subaccountBlueprintResource) teardown(ctx context.Context, subaccountId string, subscriptions []subaccountBlueprintSubscriptionType, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for i := len(subscriptions) - 1; i >= 0; i-- {
+		if err := unsubscribeAndWait(ctx, rs.cli, subaccountId, subscriptions[i].AppName.ValueString(), subscriptions[i].PlanName.ValueString(), timeout, false); err != nil {
+			return fmt.Errorf("unable to unsubscribe from the application %s: %w", subscriptions[i].AppName.ValueString(), err)
+		}
+	}
+
+	cliRes, comRes, err := rs.cli.Accounts.Subaccount.Delete(ctx, subaccountId)
+	if comRes.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	deleteStateConf := &tfutils.StateChangeConf{
+		Pending: []string{cis.StateDeleting, cis.StateStarted},
+		Target:  []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			subRes, comRes, err := rs.cli.Accounts.Subaccount.Get(ctx, cliRes.Guid)
+
+			if comRes.StatusCode == http.StatusNotFound {
+				return subRes, "DELETED", nil
+			}
+
+			if err != nil {
+				return subRes, subRes.State, err
+			}
+
+			if subRes.State == cis.StateDeletionFailed {
+				return subRes, subRes.State, errors.New("undefined API error during subaccount deletion")
+			}
+
+			return subRes, subRes.State, nil
+		},
+		Timeout:    timeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	_, err = deleteStateConf.WaitForStateContext(ctx)
+	return err
+}
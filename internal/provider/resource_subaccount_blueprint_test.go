@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestResourceSubaccountBlueprint(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - lifecycle", func(t *testing.T) {
+		rec := setupVCR(t, "fixtures/resource_subaccount_blueprint")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + hclResourceSubaccountBlueprint("uut", "integration-test-blueprint", "eu12", "integration-test-blueprint"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestMatchResourceAttr("btp_subaccount_blueprint.uut", "id", regexpValidUUID),
+						resource.TestCheckResourceAttr("btp_subaccount_blueprint.uut", "name", "integration-test-blueprint"),
+						resource.TestCheckResourceAttr("btp_subaccount_blueprint.uut", "subdomain", "integration-test-blueprint"),
+						resource.TestCheckResourceAttr("btp_subaccount_blueprint.uut", "region", "eu12"),
+						resource.TestCheckResourceAttr("btp_subaccount_blueprint.uut", "state", "OK"),
+						resource.TestCheckResourceAttr("btp_subaccount_blueprint.uut", "entitlements.#", "2"),
+						resource.TestCheckResourceAttr("btp_subaccount_blueprint.uut", "subscriptions.#", "1"),
+					),
+				},
+				{
+					// the blueprint is unchanged, hence nothing happens
+					Config:   hclProvider() + hclResourceSubaccountBlueprint("uut", "integration-test-blueprint", "eu12", "integration-test-blueprint"),
+					PlanOnly: true,
+				},
+			},
+		})
+	})
+
+	t.Run("error path - rollback after failed subscription", func(t *testing.T) {
+		srv, operations := newSubaccountBlueprintRollbackTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountBlueprint("uut", "My Project", "eu10", "my-project"),
+					ExpectError: regexp.MustCompile(`unable to subscribe to the application auditlog-viewer: subscription failed: the app provider rejected the subscription`),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL),
+					Check: checkSubaccountBlueprintOperations(operations, []string{
+						"create subaccount",
+						"assign alert-notification/free",
+						"assign hana-cloud/hana",
+						"subscribe auditlog-viewer",
+						"unsubscribe auditlog-viewer",
+						"delete subaccount",
+					}),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountBlueprintRollbackTestServer simulates the subaccount, entitlement and subscription commands of the CLI
+// server and records the operations in the order they are requested. The app provider of auditlog-viewer rejects the
+// subscription, which can't be provoked on a real landscape.
+func newSubaccountBlueprintRollbackTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	const subaccountId = "59cd458e-e66e-4b60-b6d8-8f219379f9a5"
+	const failingApp = "auditlog-viewer"
+
+	var mutex sync.Mutex
+	operations := []string{}
+	deleted := false
+	assignments := []string{}
+	subscriptions := map[string]string{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/subaccount"):
+			switch r.URL.RawQuery {
+			case "create":
+				operations = append(operations, "create subaccount")
+			case "delete":
+				operations = append(operations, "delete subaccount")
+				deleted = true
+			case "subscribe":
+				appName := body.ParamValues["appName"]
+				operations = append(operations, "subscribe "+appName)
+				subscriptions[appName] = "SUBSCRIBED"
+				if appName == failingApp {
+					subscriptions[appName] = "SUBSCRIBE_FAILED"
+				}
+				fmt.Fprint(w, "{}")
+				return
+			case "unsubscribe":
+				appName := body.ParamValues["appName"]
+				operations = append(operations, "unsubscribe "+appName)
+				subscriptions[appName] = "NOT_SUBSCRIBED"
+				fmt.Fprint(w, "{}")
+				return
+			case "get":
+				if deleted {
+					w.Header().Set("X-Cpcli-Backend-Status", "404")
+					fmt.Fprint(w, `{"error": "subaccount not found"}`)
+					return
+				}
+			}
+
+			fmt.Fprintf(w, `{"guid": "%s", "displayName": "My Project", "subdomain": "my-project", "region": "eu10", "state": "OK"}`, subaccountId)
+		case strings.HasSuffix(r.URL.Path, "/accounts/entitlement"):
+			if r.URL.RawQuery == "assign" {
+				assignment := fmt.Sprintf("%s/%s", body.ParamValues["serviceName"], body.ParamValues["servicePlanName"])
+				operations = append(operations, "assign "+assignment)
+				assignments = append(assignments, assignment)
+				fmt.Fprint(w, "{}")
+				return
+			}
+
+			services := []string{}
+			for _, assignment := range assignments {
+				serviceName, planName, _ := strings.Cut(assignment, "/")
+				services = append(services, fmt.Sprintf(`{"name": "%s", "servicePlans": [{"name": "%s", "assignmentInfo": [{"entityId": "%s", "entityType": "SUBACCOUNT", "entityState": "OK"}]}]}`, serviceName, planName, subaccountId))
+			}
+
+			fmt.Fprintf(w, `{"assignedServices": [%s]}`, strings.Join(services, ","))
+		case strings.HasSuffix(r.URL.Path, "/accounts/subscription"):
+			appName := body.ParamValues["appName"]
+			fmt.Fprintf(w, `{"appName": "%s", "planName": "free", "state": "%s", "subscriptionError": {"errorMessage": "the app provider rejected the subscription"}}`, appName, subscriptions[appName])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), &operations
+}
+
+func checkSubaccountBlueprintOperations(operations *[]string, expected []string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if strings.Join(*operations, ", ") != strings.Join(expected, ", ") {
+			return fmt.Errorf("expected the operations %v, got %v", expected, *operations)
+		}
+		return nil
+	}
+}
+
+func hclResourceSubaccountBlueprint(resourceName string, displayName string, region string, subdomain string) string {
+	return fmt.Sprintf(`
+resource "btp_subaccount_blueprint" "%s" {
+    name      = "%s"
+    subdomain = "%s"
+    region    = "%s"
+    entitlements = [
+        {
+            service_name = "alert-notification"
+            plan_name    = "free"
+        },
+        {
+            service_name = "hana-cloud"
+            plan_name    = "hana"
+            amount       = 1
+        }
+    ]
+    subscriptions = [
+        {
+            app_name  = "auditlog-viewer"
+            plan_name = "free"
+        }
+    ]
+}`, resourceName, displayName, subdomain, region)
+}
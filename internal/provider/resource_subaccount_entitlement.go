@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis_entitlements"
@@ -114,6 +116,12 @@ __Further documentation:__
 					int64validator.Between(1, 2000000000),
 				},
 			},
+			"validate_quota": schema.BoolAttribute{
+				MarkdownDescription: "If set to true, the requested `amount` is checked against the quota remaining in the global account before it is assigned, which requires an additional request. Amounts exceeding the remaining quota are reported as an error, before the subaccount is touched. The default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "The current state of the entitlement. Possible values are: \n " +
 					getFormattedValueAsTableRow("state", "description") +
@@ -153,6 +161,11 @@ func (rs *subaccountEntitlementResource) Read(ctx context.Context, req resource.
 	}
 
 	updatedState, diags := subaccountEntitlementValueFrom(ctx, *entitlement)
+	updatedState.ValidateQuota = state.ValidateQuota
+	if updatedState.ValidateQuota.IsNull() {
+		// entitlements that have been imported or created by earlier versions of the provider don't know about this attribute
+		updatedState.ValidateQuota = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(diags...)
 
@@ -161,14 +174,23 @@ func (rs *subaccountEntitlementResource) Read(ctx context.Context, req resource.
 }
 
 func (rs *subaccountEntitlementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	rs.createOrUpdate(ctx, req.Plan, &resp.Diagnostics, &resp.State, "Creating")
+	rs.createOrUpdate(ctx, req.Plan, 0, &resp.Diagnostics, &resp.State, "Creating")
 }
 
 func (rs *subaccountEntitlementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	rs.createOrUpdate(ctx, req.Plan, &resp.Diagnostics, &resp.State, "Updating")
+	var state subaccountEntitlementType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rs.createOrUpdate(ctx, req.Plan, state.Amount.ValueInt64(), &resp.Diagnostics, &resp.State, "Updating")
 }
 
-func (rs *subaccountEntitlementResource) createOrUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseDiagnostics *diag.Diagnostics, responseState *tfsdk.State, action string) {
+// createOrUpdate assigns the planned entitlement to the subaccount. The currentAmount is the amount already assigned
+// to the subaccount, which doesn't need to be covered by the remaining quota of the global account.
+func (rs *subaccountEntitlementResource) createOrUpdate(ctx context.Context, requestPlan tfsdk.Plan, currentAmount int64, responseDiagnostics *diag.Diagnostics, responseState *tfsdk.State, action string) {
 	var plan subaccountEntitlementType
 	diags := requestPlan.Get(ctx, &plan)
 	responseDiagnostics.Append(diags...)
@@ -176,8 +198,15 @@ func (rs *subaccountEntitlementResource) createOrUpdate(ctx context.Context, req
 		return
 	}
 
+	if plan.ValidateQuota.ValueBool() && hasPlanQuota(plan.Amount, plan.Category) {
+		responseDiagnostics.Append(rs.checkRemainingQuota(ctx, plan, currentAmount)...)
+		if responseDiagnostics.HasError() {
+			return
+		}
+	}
+
 	var err error
-	if !hasPlanQuota(plan) {
+	if !hasPlanQuota(plan.Amount, plan.Category) {
 		_, err = rs.cli.Accounts.Entitlement.EnableInSubaccount(ctx, plan.SubaccountId.ValueString(), plan.ServiceName.ValueString(), plan.PlanName.ValueString())
 	} else {
 		_, err = rs.cli.Accounts.Entitlement.AssignToSubaccount(ctx, plan.SubaccountId.ValueString(), plan.ServiceName.ValueString(), plan.PlanName.ValueString(), int(plan.Amount.ValueInt64()))
@@ -210,8 +239,9 @@ func (rs *subaccountEntitlementResource) createOrUpdate(ctx context.Context, req
 			return *entitlement, entitlement.Assignment.EntityState, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	entitlement, err := createStateConf.WaitForStateContext(ctx)
@@ -222,12 +252,51 @@ func (rs *subaccountEntitlementResource) createOrUpdate(ctx context.Context, req
 
 	// The amount field is always set, even if not specified. Distinguish between operations via category
 	updatedState, diags := subaccountEntitlementValueFrom(ctx, entitlement.(btpcli.UnfoldedEntitlement))
+	updatedState.ValidateQuota = plan.ValidateQuota
 	responseDiagnostics.Append(diags...)
 
 	diags = responseState.Set(ctx, &updatedState)
 	responseDiagnostics.Append(diags...)
 }
 
+// checkRemainingQuota reports an error, if the global account has less quota of the plan remaining than the planned
+// amount requires in addition to the currentAmount. Plans without a numeric quota are skipped, plans which aren't
+// entitled to the global account are left to the CLI server to reject.
+func (rs *subaccountEntitlementResource) checkRemainingQuota(ctx context.Context, plan subaccountEntitlementType, currentAmount int64) (diags diag.Diagnostics) {
+	cliRes, _, err := rs.cli.Accounts.Entitlement.ListByGlobalAccount(ctx)
+	if err != nil {
+		diags.AddError("API Error Reading Entitlements (Global Account)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	for _, service := range cliRes.EntitledServices {
+		if service.Name != plan.ServiceName.ValueString() {
+			continue
+		}
+
+		for _, servicePlan := range service.ServicePlans {
+			if servicePlan.Name != plan.PlanName.ValueString() {
+				continue
+			}
+
+			if servicePlan.Unlimited || !hasPlanQuota(plan.Amount, types.StringValue(servicePlan.Category)) {
+				return
+			}
+
+			required := plan.Amount.ValueInt64() - currentAmount
+			remaining := int64(servicePlan.RemainingAmount)
+
+			if required > remaining {
+				diags.AddAttributeError(path.Root("amount"), "Insufficient Quota of the Global Account",
+					fmt.Sprintf("The amount %d of %s:%s requires %d more units than the %d remaining in the global account, the shortfall is %d. Increase the quota of the global account or reduce the amount.", plan.Amount.ValueInt64(), plan.ServiceName.ValueString(), plan.PlanName.ValueString(), required, remaining, required-remaining))
+			}
+			return
+		}
+	}
+
+	return
+}
+
 func (rs *subaccountEntitlementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state subaccountEntitlementType
 	diags := req.State.Get(ctx, &state)
@@ -237,7 +306,7 @@ func (rs *subaccountEntitlementResource) Delete(ctx context.Context, req resourc
 	}
 
 	var err error
-	if !hasPlanQuota(state) {
+	if !hasPlanQuota(state.Amount, state.Category) {
 		_, err = rs.cli.Accounts.Entitlement.DisableInSubaccount(ctx, state.SubaccountId.ValueString(), state.ServiceName.ValueString(), state.PlanName.ValueString())
 	} else {
 		_, err = rs.cli.Accounts.Entitlement.AssignToSubaccount(ctx, state.SubaccountId.ValueString(), state.ServiceName.ValueString(), state.PlanName.ValueString(), 0)
@@ -271,8 +340,9 @@ func (rs *subaccountEntitlementResource) Delete(ctx context.Context, req resourc
 			return entitlement, cis_entitlements.StateProcessing, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
@@ -299,15 +369,15 @@ func (rs *subaccountEntitlementResource) ImportState(ctx context.Context, req re
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plan_name"), idParts[2])...)
 }
 
-func hasPlanQuota(state subaccountEntitlementType) bool {
+func hasPlanQuota(amount types.Int64, category types.String) bool {
 
 	// Case 1: CREATE with a explicitly non-specified amount by caller
-	if state.Amount.ValueInt64() == 0 {
+	if amount.ValueInt64() == 0 {
 		return false
 	}
 
 	// Case 2: Categories that allow enabling/disabling only
-	planCategory := state.Category.ValueString()
+	planCategory := category.ValueString()
 	if planCategory == "ELASTIC_SERVICE" || planCategory == "ELASTIC_LIMITED" || planCategory == "APPLICATION" {
 		return false
 	}
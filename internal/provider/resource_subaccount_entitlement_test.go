@@ -2,7 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -114,6 +117,36 @@ func TestResourceSubaccountEntitlement(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - amount exceeds the remaining quota of the global account", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			// the entitlement must not be assigned
+			if r.URL.RawQuery != "list" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"entitledServices": [{"name": "alert-notification", "servicePlans": [{"name": "standard", "category": "SERVICE", "amount": 10, "remainingAmount": 2}]}]}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountEntitlementWithValidatedAmount("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "alert-notification", "standard", "5"),
+					ExpectError: regexp.MustCompile(`(?s)Insufficient Quota of the Global Account.*the shortfall is 3`),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - zero amount", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -147,3 +180,13 @@ func hclResourceSubaccountEntitlementWithAmount(resourceName string, subaccountI
         amount = %s
     }`, resourceName, subaccountId, serviceName, planName, amount)
 }
+
+func hclResourceSubaccountEntitlementWithValidatedAmount(resourceName string, subaccountId string, serviceName string, planName string, amount string) string {
+	return fmt.Sprintf(`resource "btp_subaccount_entitlement" "%s" {
+        subaccount_id  = "%s"
+        service_name   = "%s"
+        plan_name      = "%s"
+        amount         = %s
+        validate_quota = true
+    }`, resourceName, subaccountId, serviceName, planName, amount)
+}
@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -42,7 +41,7 @@ func (rs *subaccountEnvironmentInstanceResource) Configure(_ context.Context, re
 	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
 }
 
-func (rs *subaccountEnvironmentInstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (rs *subaccountEnvironmentInstanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Creates an environment instance, such as a Cloud Foundry org, in a subaccount.
 
@@ -145,6 +144,12 @@ __Further documentation:__
 				MarkdownDescription: "An identifier that represents the last operation. This ID is returned by the environment brokers.",
 				Computed:            true,
 			},
+			"outputs": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The outputs of the environment broker, e.g. the API endpoint of a Cloud Foundry org or the kubeconfig URL of a Kyma runtime.",
+				Computed:            true,
+				Sensitive:           true,
+			},
 			"plan_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the service plan for the environment instance in the corresponding service broker's catalog.",
 				Computed:            true,
@@ -182,12 +187,13 @@ __Further documentation:__
 					getFormattedValueAsTableRow("`Deprovision`", "The environment instance is deleted."),
 				Computed: true,
 			},
+			"timeouts": resourceTimeoutsAttributes(ctx, "environment instance"),
 		},
 	}
 }
 
 func (rs *subaccountEnvironmentInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state subaccountEnvironmentInstanceType
+	var state subaccountEnvironmentInstanceResourceType
 
 	diags := req.State.Get(ctx, &state)
 
@@ -202,7 +208,8 @@ func (rs *subaccountEnvironmentInstanceResource) Read(ctx context.Context, req r
 		return
 	}
 
-	updatedState, diags := subaccountEnvironmentInstanceValueFrom(ctx, cliRes)
+	updatedState, diags := subaccountEnvironmentInstanceResourceValueFrom(ctx, cliRes)
+	updatedState.Timeouts = state.Timeouts
 
 	if !state.Parameters.IsNull() {
 		updatedState.Parameters = state.Parameters
@@ -221,14 +228,21 @@ func (rs *subaccountEnvironmentInstanceResource) Read(ctx context.Context, req r
 }
 
 func (rs *subaccountEnvironmentInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan subaccountEnvironmentInstanceType
+	var plan subaccountEnvironmentInstanceResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	parameters := plan.Parameters.ValueString()
+	plannedTimeouts := plan.Timeouts
 
 	cliRes, _, err := rs.cli.Accounts.EnvironmentInstance.Create(ctx, &btpcli.SubaccountEnvironmentInstanceCreateInput{
 		SubaccountID:    plan.SubaccountId.ValueString(),
@@ -244,8 +258,9 @@ func (rs *subaccountEnvironmentInstanceResource) Create(ctx context.Context, req
 		return
 	}
 
-	plan, diags = subaccountEnvironmentInstanceValueFrom(ctx, cliRes)
+	plan, diags = subaccountEnvironmentInstanceResourceValueFrom(ctx, cliRes)
 	plan.Parameters = types.StringValue(parameters)
+	plan.Timeouts = plannedTimeouts
 	resp.Diagnostics.Append(diags...)
 
 	createStateConf := &tfutils.StateChangeConf{
@@ -260,9 +275,10 @@ func (rs *subaccountEnvironmentInstanceResource) Create(ctx context.Context, req
 
 			return subRes, subRes.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    createTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := createStateConf.WaitForStateContext(ctx)
@@ -270,8 +286,14 @@ func (rs *subaccountEnvironmentInstanceResource) Create(ctx context.Context, req
 		resp.Diagnostics.AddError("API Error Creating Resource Environment Instance (Subaccount)", fmt.Sprintf("%s", err))
 	}
 
-	plan, diags = subaccountEnvironmentInstanceValueFrom(ctx, updatedRes.(provisioning.EnvironmentInstanceResponseObject))
+	if updatedRes == nil {
+		// e.g. after a timeout the instance is still being created, hence it is kept in the state
+		updatedRes = cliRes
+	}
+
+	plan, diags = subaccountEnvironmentInstanceResourceValueFrom(ctx, updatedRes.(provisioning.EnvironmentInstanceResponseObject))
 	plan.Parameters = types.StringValue(parameters)
+	plan.Timeouts = plannedTimeouts
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &plan)
@@ -279,9 +301,28 @@ func (rs *subaccountEnvironmentInstanceResource) Create(ctx context.Context, req
 }
 
 func (rs *subaccountEnvironmentInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan subaccountEnvironmentInstanceType
+	var plan, state subaccountEnvironmentInstanceResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PlanName.Equal(state.PlanName) && plan.Parameters.Equal(state.Parameters) {
+		// only the timeouts have changed, which don't require the environment instance to be touched
+		state.Timeouts = plan.Timeouts
+
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -309,9 +350,10 @@ func (rs *subaccountEnvironmentInstanceResource) Update(ctx context.Context, req
 
 			return subRes, subRes.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    updateTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := updateStateConf.WaitForStateContext(ctx)
@@ -319,9 +361,17 @@ func (rs *subaccountEnvironmentInstanceResource) Update(ctx context.Context, req
 		resp.Diagnostics.AddError("API Error Updating Resource Environment Instance (Subaccount)", fmt.Sprintf("%s", err))
 	}
 
-	state, diags := subaccountEnvironmentInstanceValueFrom(ctx, updatedRes.(provisioning.EnvironmentInstanceResponseObject))
+	if updatedRes == nil {
+		// e.g. after a timeout the update is still in progress, hence the instance is kept in its previous state
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	state, diags = subaccountEnvironmentInstanceResourceValueFrom(ctx, updatedRes.(provisioning.EnvironmentInstanceResponseObject))
 	// TODO: this temporary workaround ignores the actual "parameters" value which is diverging from the planned state by an additional "status" attribute
 	state.Parameters = plan.Parameters
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &state)
@@ -329,13 +379,19 @@ func (rs *subaccountEnvironmentInstanceResource) Update(ctx context.Context, req
 }
 
 func (rs *subaccountEnvironmentInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state subaccountEnvironmentInstanceType
+	var state subaccountEnvironmentInstanceResourceType
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	cliRes, _, err := rs.cli.Accounts.EnvironmentInstance.Delete(ctx, state.SubaccountId.ValueString(), state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Environment Instance (Subaccount)", fmt.Sprintf("%s", err))
@@ -358,9 +414,10 @@ func (rs *subaccountEnvironmentInstanceResource) Delete(ctx context.Context, req
 
 			return subRes, subRes.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    deleteTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
@@ -3,8 +3,13 @@ package provider
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -44,6 +49,8 @@ func TestResourceSubaccountEnvironmentInstance(t *testing.T) {
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "last_modified", regexpValidRFC3999Format),
 						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "type", "Provision"),
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "labels", regexp.MustCompile(`"API Endpoint":"https:\/\/api\.cf\.eu12\.hana\.ondemand\.com"`)),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.API Endpoint", "https://api.cf.eu12.hana.ondemand.com"),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.Org Name", "cf-terraform-org"),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"instance_name":"cf-terraform-org"`)),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"id":"john.doe@int.test"`)),
 					),
@@ -80,6 +87,8 @@ func TestResourceSubaccountEnvironmentInstance(t *testing.T) {
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "last_modified", regexpValidRFC3999Format),
 						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "type", "Provision"),
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "labels", regexp.MustCompile(`"API Endpoint":"https:\/\/api\.cf\.eu12\.hana\.ondemand\.com"`)),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.API Endpoint", "https://api.cf.eu12.hana.ondemand.com"),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.Org Name", "cf-terraform-org"),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"instance_name":"cf-terraform-org"`)),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"id":"john.doe@int.test"`)),
 					),
@@ -98,6 +107,8 @@ func TestResourceSubaccountEnvironmentInstance(t *testing.T) {
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "last_modified", regexpValidRFC3999Format),
 						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "type", "Update"),
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "labels", regexp.MustCompile(`"API Endpoint":"https:\/\/api\.cf\.eu12\.hana\.ondemand\.com"`)),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.API Endpoint", "https://api.cf.eu12.hana.ondemand.com"),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.Org Name", "cf-terraform-org"),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"instance_name":"cf-terraform-org"`)),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", notContainsCheckFunc(`"id":"john.doe@int.test"`)),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"id":"jane.doe@int.test"`)),
@@ -118,6 +129,8 @@ func TestResourceSubaccountEnvironmentInstance(t *testing.T) {
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "last_modified", regexpValidRFC3999Format),
 						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "type", "Update"),
 						resource.TestMatchResourceAttr("btp_subaccount_environment_instance.uut", "labels", regexp.MustCompile(`"API Endpoint":"https:\/\/api\.cf\.eu12\.hana\.ondemand\.com"`)),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.API Endpoint", "https://api.cf.eu12.hana.ondemand.com"),
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "outputs.Org Name", "cf-terraform-org"),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", containsCheckFunc(`"instance_name":"cf-terraform-org"`)),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", notContainsCheckFunc(`"id":"john.doe@int.test"`)),
 						resource.TestCheckResourceAttrWith("btp_subaccount_environment_instance.uut", "parameters", notContainsCheckFunc(`"id":"jane.doe@int.test"`)),
@@ -127,10 +140,155 @@ func TestResourceSubaccountEnvironmentInstance(t *testing.T) {
 		})
 	})
 
+	t.Run("happy path - polling backs off up to the max poll interval", func(t *testing.T) {
+		const maxPollInterval = 400 * time.Millisecond
+
+		srv, polls := newEnvironmentInstanceCreatingTestServer(6)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithPollIntervals(srv.URL, "100ms", maxPollInterval.String()) + hclResourceSubaccountEnvironmentInstanceKymaWithTimeouts("uut",
+						"ef23ace8-6ade-4d78-9c1f-8df729548bbf",
+						"kyma-from-terraform",
+						`create = "1m"`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_environment_instance.uut", "state", "OK"),
+						func(_ *terraform.State) error {
+							observed := polls()
+							if len(observed) < 6 {
+								return fmt.Errorf("expected at least 6 polls, got %d", len(observed))
+							}
+
+							// the intervals double from the poll interval (100ms, 200ms, 400ms, 800ms, ...), but are capped
+							for i := 4; i < 6; i++ {
+								if gap := observed[i].Sub(observed[i-1]); gap < maxPollInterval || gap >= 2*maxPollInterval {
+									return fmt.Errorf("expected poll %d to happen after %s, got %s", i+1, maxPollInterval, gap)
+								}
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - create timeout reports the last state", func(t *testing.T) {
+		srv, _ := newEnvironmentInstanceCreatingTestServer(-1)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithPollIntervals(srv.URL, "100ms", "100ms") + hclResourceSubaccountEnvironmentInstanceKymaWithTimeouts("uut",
+						"ef23ace8-6ade-4d78-9c1f-8df729548bbf",
+						"kyma-from-terraform",
+						`create = "1s"`),
+					ExpectError: regexp.MustCompile(`timeout while waiting for state to become 'OK, CREATION_FAILED' \(last state: 'CREATING', timeout: 1s\)`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - timeouts must be valid durations", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + hclResourceSubaccountEnvironmentInstanceKymaWithTimeouts("uut",
+						"ef23ace8-6ade-4d78-9c1f-8df729548bbf",
+						"kyma-from-terraform",
+						`delete = "one hour"`),
+					ExpectError: regexp.MustCompile(`value must be a positive duration`),
+				},
+			},
+		})
+	})
+
 	// Error cases for CREATE lead to errors as no resource was created, but plugin test framework tries to delete the non existent resources
 	// See also: https://github.com/hashicorp/terraform-plugin-testing/issues/85
 }
 
+func hclProviderWithPollIntervals(cliServerURL string, pollInterval string, maxPollInterval string) string {
+	return fmt.Sprintf(`
+provider "btp" {
+    cli_server_url    = "%s"
+    globalaccount     = "terraformintcanary"
+    username          = "john.doe@int.test"
+    password          = "redacted"
+    poll_interval     = "%s"
+    max_poll_interval = "%s"
+}
+    `, cliServerURL, pollInterval, maxPollInterval)
+}
+
+func hclResourceSubaccountEnvironmentInstanceKymaWithTimeouts(resourceName string, subaccountId string, name string, timeouts string) string {
+	return fmt.Sprintf(`
+resource "btp_subaccount_environment_instance" "%s"{
+    subaccount_id    = "%s"
+	name             = "%s"
+	environment_type = "kyma"
+	plan_name        = "azure"
+	service_name     = "kymaruntime"
+	landscape_label  = "kyma"
+	parameters       = "{}"
+	timeouts         = { %s }
+}`, resourceName, subaccountId, name, timeouts)
+}
+
+// newEnvironmentInstanceCreatingTestServer simulates an environment instance which is created with the given number of
+// polls, or never if the number is negative. Once deleted, the environment instance can't be found anymore. The
+// returned function lists the times of the polls during the creation.
+func newEnvironmentInstanceCreatingTestServer(pollsUntilCreated int) (*httptest.Server, func() []time.Time) {
+	var mutex sync.Mutex
+	var deleted bool
+	polls := []time.Time{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		state := "CREATING"
+		switch r.URL.RawQuery {
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "environment instance not found"}`)
+				return
+			}
+
+			if polls = append(polls, time.Now()); pollsUntilCreated >= 0 && len(polls) >= pollsUntilCreated {
+				state = "OK"
+			}
+		}
+
+		fmt.Fprintf(w, `{"id": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "subaccountGUID": "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "name": "kyma-from-terraform", "environmentType": "kyma", "planName": "azure", "serviceName": "kymaruntime", "landscapeLabel": "kyma", "parameters": "{}", "state": "%s"}`, state)
+	}))
+
+	return srv, func() []time.Time {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return append([]time.Time{}, polls...)
+	}
+}
+
 func hclResourceSubaccountEnvironmentInstanceCF(resourceName string, subaccountId string, name string, planName string, landscapeLabel string, orgName string, user string) string {
 	cfParameters := cfOrgParameters{
 		InstanceName: orgName,
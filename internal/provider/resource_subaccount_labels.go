@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+var _ resource.ResourceWithModifyPlan = &subaccountLabelsResource{}
+
+func newSubaccountLabelsResource() resource.Resource {
+	return &subaccountLabelsResource{}
+}
+
+type subaccountLabelsType struct {
+	SubaccountId    types.String `tfsdk:"subaccount_id"`
+	Id              types.String `tfsdk:"id"`
+	Labels          types.Map    `tfsdk:"labels"`
+	RemoveUnmanaged types.Bool   `tfsdk:"remove_unmanaged"`
+	UnmanagedLabels types.Map    `tfsdk:"unmanaged_labels"`
+}
+
+type subaccountLabelsResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *subaccountLabelsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_labels", req.ProviderTypeName)
+}
+
+func (rs *subaccountLabelsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *subaccountLabelsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages labels of a subaccount, independent of the tool that manages the subaccount itself.
+
+By default the labels are managed additively: labels with other keys, e.g. added by other tools, are left untouched. If ` + "`remove_unmanaged`" + ` is set, the managed labels are authoritative and all other labels are removed on apply.
+
+__Tip:__
+You must be assigned to the global account admin role, or the subaccount admin if the subaccount is in a directory that is configured to manage its authorizations.
+
+__Further documentation:__
+<https://help.sap.com/docs/btp/sap-business-technology-platform/account-model>`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.SetType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "The labels managed by the resource. Changed or removed values of these labels are restored on apply. Removing a label from the map removes it from the subaccount.",
+				Required:            true,
+			},
+			"remove_unmanaged": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, all labels of the subaccount that aren't part of `labels` are removed on apply. The default is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"unmanaged_labels": schema.MapAttribute{
+				ElementType: types.SetType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "The labels of the subaccount that aren't managed by the resource. Always empty if `remove_unmanaged` is set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (rs *subaccountLabelsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subaccountLabelsType
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Accounts.Subaccount.Get(ctx, state.SubaccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Labels (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	var managedKeys map[string][]string
+	if state.Labels.IsNull() {
+		// an imported resource manages all labels the subaccount currently has
+		managedKeys = cliRes.Labels
+	} else {
+		resp.Diagnostics.Append(state.Labels.ElementsAs(ctx, &managedKeys, false)...)
+	}
+
+	if state.RemoveUnmanaged.IsNull() {
+		state.RemoveUnmanaged = types.BoolValue(false)
+	}
+
+	state, diags = subaccountLabelsValueFrom(ctx, cliRes, managedKeys, state.RemoveUnmanaged)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountLabelsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subaccountLabelsType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := rs.applyLabels(ctx, plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountLabelsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state subaccountLabelsType
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previouslyManaged map[string][]string
+	resp.Diagnostics.Append(state.Labels.ElementsAs(ctx, &previouslyManaged, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags = rs.applyLabels(ctx, plan, previouslyManaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountLabelsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountLabelsType
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managed map[string][]string
+	resp.Diagnostics.Append(state.Labels.ElementsAs(ctx, &managed, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Accounts.Subaccount.Get(ctx, state.SubaccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Labels (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// only the managed labels are removed, all others are kept
+	labels := map[string][]string{}
+	for key, values := range cliRes.Labels {
+		if _, isManaged := managed[key]; !isManaged {
+			labels[key] = values
+		}
+	}
+
+	if !labelsChanged(labels, cliRes.Labels) {
+		return
+	}
+
+	if _, err = rs.updateSubaccountLabels(ctx, cliRes, labels); err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Labels (Subaccount)", fmt.Sprintf("%s", err))
+	}
+}
+
+func (rs *subaccountLabelsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("subaccount_id"), req, resp)
+}
+
+func (rs *subaccountLabelsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// nothing to plan on deletion
+		return
+	}
+
+	var plan subaccountLabelsType
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RemoveUnmanaged.ValueBool() {
+		// in authoritative mode any unmanaged label is drift that is corrected on apply
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_labels"), map[string][]string{})...)
+		return
+	}
+
+	if req.State.Raw.IsNull() || plan.Labels.IsUnknown() {
+		return
+	}
+
+	var state subaccountLabelsType
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planned, unmanaged map[string][]string
+	resp.Diagnostics.Append(plan.Labels.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.UnmanagedLabels.ElementsAs(ctx, &unmanaged, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the unmanaged labels only change if the resource starts managing some of them
+	for key := range planned {
+		delete(unmanaged, key)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_labels"), unmanaged)...)
+}
+
+// applyLabels sets the planned labels on the subaccount. The labels which were managed before, but aren't part of the
+// plan anymore, are removed. In authoritative mode all labels except for the planned ones are removed.
+func (rs *subaccountLabelsResource) applyLabels(ctx context.Context, plan subaccountLabelsType, previouslyManaged map[string][]string) (subaccountLabelsType, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var planned map[string][]string
+	diags.Append(plan.Labels.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	cliRes, _, err := rs.cli.Accounts.Subaccount.Get(ctx, plan.SubaccountId.ValueString())
+	if err != nil {
+		diags.AddError("API Error Updating Resource Labels (Subaccount)", fmt.Sprintf("%s", err))
+		return plan, diags
+	}
+
+	labels := map[string][]string{}
+
+	if !plan.RemoveUnmanaged.ValueBool() {
+		for key, values := range cliRes.Labels {
+			if _, wasManaged := previouslyManaged[key]; !wasManaged {
+				labels[key] = values
+			}
+		}
+	}
+
+	for key, values := range planned {
+		labels[key] = values
+	}
+
+	if labelsChanged(labels, cliRes.Labels) {
+		cliRes, err = rs.updateSubaccountLabels(ctx, cliRes, labels)
+		if err != nil {
+			diags.AddError("API Error Updating Resource Labels (Subaccount)", fmt.Sprintf("%s", err))
+			return plan, diags
+		}
+	}
+
+	state, valueDiags := subaccountLabelsValueFrom(ctx, cliRes, planned, plan.RemoveUnmanaged)
+	diags.Append(valueDiags...)
+
+	return state, diags
+}
+
+// updateSubaccountLabels replaces the labels of the subaccount. All other properties of the subaccount are kept.
+func (rs *subaccountLabelsResource) updateSubaccountLabels(ctx context.Context, subaccount cis.SubaccountResponseObject, labels map[string][]string) (cis.SubaccountResponseObject, error) {
+	cliRes, _, err := rs.cli.Accounts.Subaccount.Update(ctx, &btpcli.SubaccountUpdateInput{
+		SubaccountId: subaccount.Guid,
+		Labels:       labels,
+	})
+
+	return cliRes, err
+}
+
+// subaccountLabelsValueFrom splits the labels of the subaccount into the ones with a managed key and the unmanaged ones.
+func subaccountLabelsValueFrom(ctx context.Context, value cis.SubaccountResponseObject, managedKeys map[string][]string, removeUnmanaged types.Bool) (subaccountLabelsType, diag.Diagnostics) {
+	managed := map[string][]string{}
+	unmanaged := map[string][]string{}
+
+	for key, values := range value.Labels {
+		if _, isManaged := managedKeys[key]; isManaged {
+			managed[key] = values
+		} else {
+			unmanaged[key] = values
+		}
+	}
+
+	labels := subaccountLabelsType{
+		SubaccountId:    types.StringValue(value.Guid),
+		Id:              types.StringValue(value.Guid),
+		RemoveUnmanaged: removeUnmanaged,
+	}
+
+	var diags, summary diag.Diagnostics
+
+	labels.Labels, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, managed)
+	summary.Append(diags...)
+
+	labels.UnmanagedLabels, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, unmanaged)
+	summary.Append(diags...)
+
+	return labels, summary
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestResourceSubaccountLabels(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - unmanaged labels are only removed if requested", func(t *testing.T) {
+		srv, labels := newSubaccountLabelsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", `{"owner" = ["alice"]}`, false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "id", "59cd458e-e66e-4b60-b6d8-8f219379f9a5"),
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "labels.%", "1"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_labels.uut", "labels.owner.*", "alice"),
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "remove_unmanaged", "false"),
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "unmanaged_labels.%", "1"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_labels.uut", "unmanaged_labels.team.*", "unmanaged"),
+						checkSubaccountLabels(labels, `{"owner":["alice"],"team":["unmanaged"]}`),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", `{"owner" = ["alice"]}`, true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "labels.%", "1"),
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "remove_unmanaged", "true"),
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "unmanaged_labels.%", "0"),
+						checkSubaccountLabels(labels, `{"owner":["alice"]}`),
+					),
+				},
+			},
+			CheckDestroy: checkSubaccountLabels(labels, `{}`),
+		})
+	})
+
+	t.Run("happy path - releasing a label removes it from the subaccount", func(t *testing.T) {
+		srv, labels := newSubaccountLabelsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", `{"owner" = ["alice"], "cost-center" = ["4711"]}`, false),
+					Check:  checkSubaccountLabels(labels, `{"cost-center":["4711"],"owner":["alice"],"team":["unmanaged"]}`),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", `{"owner" = ["bob"]}`, false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_labels.uut", "labels.owner.*", "bob"),
+						resource.TestCheckResourceAttr("btp_subaccount_labels.uut", "unmanaged_labels.%", "1"),
+						checkSubaccountLabels(labels, `{"owner":["bob"],"team":["unmanaged"]}`),
+					),
+				},
+			},
+			CheckDestroy: checkSubaccountLabels(labels, `{"team":["unmanaged"]}`),
+		})
+	})
+
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountLabels("uut", "this-is-not-a-uuid", `{"owner" = ["alice"]}`, false),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountLabelsTestServer simulates the subaccount commands of the CLI server for a subaccount that initially
+// has a label managed by some other tool. The current labels of the subaccount are returned as JSON.
+func newSubaccountLabelsTestServer(t *testing.T) (*httptest.Server, *string) {
+	var mutex sync.Mutex
+	labels := `{"team":["unmanaged"]}`
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if !strings.HasSuffix(r.URL.Path, "/accounts/subaccount") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.RawQuery == "update" {
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			labels = body.ParamValues["labels"]
+		}
+
+		fmt.Fprintf(w, `{"guid": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "displayName": "My Subaccount", "state": "OK", "labels": %s}`, labels)
+	})), &labels
+}
+
+func checkSubaccountLabels(labels *string, expected string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if err := compareJSON(*labels, expected); err != nil {
+			return fmt.Errorf("unexpected labels of the subaccount: %s", err)
+		}
+		return nil
+	}
+}
+
+func hclResourceSubaccountLabels(resourceName string, subaccountId string, labels string, removeUnmanaged bool) string {
+	return fmt.Sprintf(`
+resource "btp_subaccount_labels" "%s" {
+    subaccount_id    = "%s"
+    labels           = %s
+    remove_unmanaged = %t
+}`, resourceName, subaccountId, labels, removeUnmanaged)
+}
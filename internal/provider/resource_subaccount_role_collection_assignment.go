@@ -3,21 +3,36 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_trust"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+	"github.com/SAP/terraform-provider-btp/internal/validation/durationvalidator"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
+// defaultUserOrigin is the origin of the users and groups hosted by the default identity provider.
+const defaultUserOrigin = "ldap"
+
+// defaultConsistencyTimeout bounds the wait for an assignment to be reflected by its role collection.
+const defaultConsistencyTimeout = 30 * time.Second
+
+var _ resource.ResourceWithModifyPlan = &subaccountRoleCollectionAssignmentResource{}
+
 func newSubaccountRoleCollectionAssignmentResource() resource.Resource {
 	return &subaccountRoleCollectionAssignmentResource{}
 }
@@ -29,6 +44,9 @@ type subaccountRoleCollectionAssignmentType struct {
 	Username           types.String `tfsdk:"user_name"`
 	Groupname          types.String `tfsdk:"group_name"`
 	Origin             types.String `tfsdk:"origin"`
+	OriginFromTrust    types.Bool   `tfsdk:"origin_from_trust"`
+	CheckUserExists    types.Bool   `tfsdk:"check_user_exists"`
+	ConsistencyTimeout types.String `tfsdk:"consistency_timeout"`
 }
 
 type subaccountRoleCollectionAssignmentResource struct {
@@ -101,12 +119,38 @@ func (rs *subaccountRoleCollectionAssignmentResource) Schema(_ context.Context,
 				},
 			},
 			"origin": schema.StringAttribute{
-				MarkdownDescription: "The identity provider that hosts the user or a group. The default value is `ldap`.",
+				MarkdownDescription: "The identity provider that hosts the user or a group. The default value is `ldap`, unless `origin_from_trust` is set.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("ldap"),
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					originRequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"origin_from_trust": schema.BoolAttribute{
+				MarkdownDescription: "If set to true and no `origin` is given, the origin defaults to the only custom trust configuration of the subaccount instead of `ldap`. " +
+					"The assignment fails if the subaccount has more than one custom trust configuration. The default value is `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"check_user_exists": schema.BoolAttribute{
+				MarkdownDescription: "If set to true, the assignment fails if the user doesn't exist in the identity provider given by `origin`. As this requires an additional request, the check is skipped by default. The default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"consistency_timeout": schema.StringAttribute{
+				MarkdownDescription: "The maximum duration to wait after the assignment or its removal until the role collection reflects it, given as a duration like `30s` or `2m`. " +
+					"If the timeout expires, a warning is reported. `0s` disables the wait. The default value is `30s`.",
+				Optional: true,
+				Validators: []validator.String{
+					durationvalidator.NonNegativeDuration(),
 				},
 			},
 		},
@@ -124,6 +168,16 @@ func (rs *subaccountRoleCollectionAssignmentResource) Read(ctx context.Context,
 	}
 
 	// This resource is not supposed to be read by definition. However nothing the user can do about that, hence no error message is raised via resp.Diagnostics.
+	if state.OriginFromTrust.IsNull() {
+		// assignments created by earlier versions of the provider don't know about this attribute
+		state.OriginFromTrust = types.BoolValue(false)
+	}
+
+	if state.CheckUserExists.IsNull() {
+		// assignments created by earlier versions of the provider don't know about this attribute
+		state.CheckUserExists = types.BoolValue(false)
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -136,20 +190,49 @@ func (rs *subaccountRoleCollectionAssignmentResource) Create(ctx context.Context
 		return
 	}
 
-	var err error
-	if !plan.Username.IsNull() {
-		// assign user
-		_, _, err = rs.cli.Security.RoleCollection.AssignUserBySubaccount(ctx, plan.SubaccountId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Username.ValueString(), plan.Origin.ValueString())
-	} else {
-		// assign group
-		_, _, err = rs.cli.Security.RoleCollection.AssignGroupBySubaccount(ctx, plan.SubaccountId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Groupname.ValueString(), plan.Origin.ValueString())
+	if plan.Origin.IsUnknown() {
+		// the subaccount wasn't known during planning
+		origin, diags := rs.defaultOrigin(ctx, plan.SubaccountId.ValueString(), plan.OriginFromTrust.ValueBool())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		plan.Origin = types.StringValue(origin)
 	}
 
+	if plan.CheckUserExists.ValueBool() && !plan.Username.IsNull() {
+		_, res, err := rs.cli.Security.User.GetBySubaccount(ctx, plan.SubaccountId.ValueString(), plan.Username.ValueString(), normalizeOrigin(plan.Origin.ValueString()))
+		resp.Diagnostics.Append(userExistenceDiagnostics(plan.Username.ValueString(), plan.Origin.ValueString(), res, err)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// the authorization caches might not yet know about a newly created role collection, hence the assignment is retried
+	err := retryOnForbidden(ctx, 1*time.Minute, func() (btpcli.CommandResponse, error) {
+		var res btpcli.CommandResponse
+		var err error
+
+		if !plan.Username.IsNull() {
+			// assign user
+			_, res, err = rs.cli.Security.RoleCollection.AssignUserBySubaccount(ctx, plan.SubaccountId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Username.ValueString(), normalizeOrigin(plan.Origin.ValueString()))
+		} else {
+			// assign group
+			_, res, err = rs.cli.Security.RoleCollection.AssignGroupBySubaccount(ctx, plan.SubaccountId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Groupname.ValueString(), normalizeOrigin(plan.Origin.ValueString()))
+		}
+
+		return res, err
+	})
+
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Creating Resource Role Collection Assignment (Subaccount)", fmt.Sprintf("%s", err))
 		return
 	}
 
+	// the role collection is eventually consistent, so that an immediate read might not see the assignment yet
+	resp.Diagnostics.Append(rs.waitForAssignment(ctx, plan, true)...)
+
 	// Setting ID of state - required by hashicorps terraform plugin testing framework for Create. See issue https://github.com/hashicorp/terraform-plugin-testing/issues/84
 	plan.Id = types.StringValue(fmt.Sprintf("%s,%s,%s", plan.SubaccountId.ValueString(), plan.RoleCollectionName.ValueString(), plan.Username.ValueString()))
 
@@ -157,6 +240,91 @@ func (rs *subaccountRoleCollectionAssignmentResource) Create(ctx context.Context
 	resp.Diagnostics.Append(diags...)
 }
 
+func (rs *subaccountRoleCollectionAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// nothing to resolve on deletion
+		return
+	}
+
+	var config, plan subaccountRoleCollectionAssignmentType
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Origin.IsNull() || plan.SubaccountId.IsUnknown() || plan.OriginFromTrust.IsUnknown() {
+		// an explicit origin needs no default, otherwise it is resolved on creation
+		return
+	}
+
+	origin, diags := rs.defaultOrigin(ctx, plan.SubaccountId.ValueString(), plan.OriginFromTrust.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("origin"), origin)...)
+
+	if !req.State.Raw.IsNull() {
+		var state subaccountRoleCollectionAssignmentType
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if normalizeOrigin(state.Origin.ValueString()) != origin {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("origin"))
+		}
+	}
+}
+
+// defaultOrigin returns the origin used for the assignment if none is given.
+func (rs *subaccountRoleCollectionAssignmentResource) defaultOrigin(ctx context.Context, subaccountId string, fromTrust bool) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !fromTrust {
+		return defaultUserOrigin, diags
+	}
+
+	trusts, _, err := rs.cli.Security.Trust.ListBySubaccount(ctx, subaccountId)
+	if err != nil {
+		diags.AddError("API Error Reading Resource Trust Configurations (Subaccount)", fmt.Sprintf("%s", err))
+		return "", diags
+	}
+
+	origin, err := originFromCustomTrust(trusts)
+	if err != nil {
+		diags.AddAttributeError(path.Root("origin"), "Origin Cannot Be Defaulted", fmt.Sprintf("%s", err))
+		return "", diags
+	}
+
+	return origin, diags
+}
+
+// originFromCustomTrust returns the origin of the only trust configuration that isn't the default identity provider.
+// The origin of the default identity provider is returned, if there is no custom trust configuration.
+func originFromCustomTrust(trusts xsuaa_trust.TrustConfigurationResponseCollectionObject) (string, error) {
+	var origins []string
+
+	for _, trust := range trusts {
+		if trust.OriginKey != defaultIdentityProviderOrigin {
+			origins = append(origins, trust.OriginKey)
+		}
+	}
+
+	switch len(origins) {
+	case 0:
+		return defaultUserOrigin, nil
+	case 1:
+		return origins[0], nil
+	default:
+		return "", fmt.Errorf("the subaccount has %d custom trust configurations (%s), the origin must be given explicitly", len(origins), strings.Join(origins, ", "))
+	}
+}
+
 func (rs *subaccountRoleCollectionAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan subaccountRoleCollectionAssignmentType
 	diags := req.Plan.Get(ctx, &plan)
@@ -165,9 +333,8 @@ func (rs *subaccountRoleCollectionAssignmentResource) Update(ctx context.Context
 		return
 	}
 
-	// since all the attributes are marked to be replaced in case of update, this should never be reached.
-	resp.Diagnostics.AddError("API Error Updating Role Collection Assignment (Subaccount)", "This resource is not supposed to be updated")
-
+	// all attributes but `check_user_exists` and `consistency_timeout` are marked to be replaced in case of update, the origin only if it
+	// differs by more than case and surrounding whitespace. As nothing changes on the server side, there is nothing to do.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -186,16 +353,70 @@ func (rs *subaccountRoleCollectionAssignmentResource) Delete(ctx context.Context
 	var err error
 	if !state.Username.IsNull() {
 		// unassign user
-		_, _, err = rs.cli.Security.RoleCollection.UnassignUserBySubaccount(ctx, state.SubaccountId.ValueString(), state.RoleCollectionName.ValueString(), state.Username.ValueString(), state.Origin.ValueString())
+		_, _, err = rs.cli.Security.RoleCollection.UnassignUserBySubaccount(ctx, state.SubaccountId.ValueString(), state.RoleCollectionName.ValueString(), state.Username.ValueString(), normalizeOrigin(state.Origin.ValueString()))
 	} else {
 		// unassign group
-		_, _, err = rs.cli.Security.RoleCollection.UnassignGroupBySubaccount(ctx, state.SubaccountId.ValueString(), state.RoleCollectionName.ValueString(), state.Groupname.ValueString(), state.Origin.ValueString())
+		_, _, err = rs.cli.Security.RoleCollection.UnassignGroupBySubaccount(ctx, state.SubaccountId.ValueString(), state.RoleCollectionName.ValueString(), state.Groupname.ValueString(), normalizeOrigin(state.Origin.ValueString()))
 	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Role Collection Assignment (Subaccount)", fmt.Sprintf("%s", err))
 		return
 	}
+
+	resp.Diagnostics.Append(rs.waitForAssignment(ctx, state, false)...)
+}
+
+// waitForAssignment polls the role collection until the assignment is present or absent, but at most for the
+// configured consistency timeout. As the assignment itself has succeeded, an expired timeout only results in a warning.
+func (rs *subaccountRoleCollectionAssignmentResource) waitForAssignment(ctx context.Context, assignment subaccountRoleCollectionAssignmentType, present bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	timeout := defaultConsistencyTimeout
+	if !assignment.ConsistencyTimeout.IsNull() && !assignment.ConsistencyTimeout.IsUnknown() {
+		var err error
+		if timeout, err = time.ParseDuration(assignment.ConsistencyTimeout.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("consistency_timeout"), "Invalid Consistency Timeout", fmt.Sprintf("%s", err))
+			return diags
+		}
+	}
+
+	if timeout <= 0 {
+		return diags
+	}
+
+	const statePresent, stateAbsent = "PRESENT", "ABSENT"
+
+	pending, target := []string{stateAbsent}, []string{statePresent}
+	if !present {
+		pending, target = target, pending
+	}
+
+	waitConf := &tfutils.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			roleCollection, _, err := rs.cli.Security.RoleCollection.GetBySubaccount(ctx, assignment.SubaccountId.ValueString(), assignment.RoleCollectionName.ValueString())
+			if err != nil {
+				return roleCollection, "", err
+			}
+
+			if roleCollectionHasAssignment(roleCollection, assignment.Username.ValueString(), assignment.Groupname.ValueString(), normalizeOrigin(assignment.Origin.ValueString())) {
+				return roleCollection, statePresent, nil
+			}
+
+			return roleCollection, stateAbsent, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	if _, err := waitConf.WaitForStateContext(ctx); err != nil {
+		diags.AddWarning("Role Collection Assignment Not Yet Consistent (Subaccount)", fmt.Sprintf("The role collection '%s' doesn't reflect the change of the assignment yet, subsequent reads might show a difference: %s", assignment.RoleCollectionName.ValueString(), err))
+	}
+
+	return diags
 }
 
 func (rs *subaccountRoleCollectionAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
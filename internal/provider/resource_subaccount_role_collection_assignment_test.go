@@ -1,11 +1,23 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_trust"
 )
 
 func TestResourceRolCollectionAssignment(t *testing.T) {
@@ -99,6 +111,427 @@ func TestResourceRolCollectionAssignment(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("happy path - retry on forbidden after role collection creation", func(t *testing.T) {
+		assignCalls := 0
+		users := newRoleCollectionUsersTestState()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			var payload struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("unable to decode request body: %s", err)
+			}
+
+			if r.URL.RawQuery == "assign" {
+				assignCalls++
+
+				if assignCalls == 1 {
+					w.Header().Set("X-Cpcli-Backend-Status", "403")
+					fmt.Fprintf(w, `{"error": "Access denied"}`)
+					return
+				}
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			if !users.handle(w, r, payload.ParamValues) {
+				fmt.Fprintf(w, "{}")
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignment("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "role_collection_name", "Destination Administrator"),
+						func(_ *terraform.State) error {
+							if assignCalls != 2 {
+								return fmt.Errorf("expected the assignment to be retried once, got %d calls", assignCalls)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - origin defaults to the only custom trust configuration", func(t *testing.T) {
+		srv, assignedOrigins := newRoleCollectionAssignmentTrustTestServer(t, `[{"originKey": "sap.default"}, {"originKey": "my-ias"}]`)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithOriginFromTrust("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "origin", "my-ias"),
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "origin_from_trust", "true"),
+						func(_ *terraform.State) error {
+							if len(*assignedOrigins) != 1 || (*assignedOrigins)[0] != "my-ias" {
+								return fmt.Errorf("expected the user to be assigned with origin my-ias, got %v", *assignedOrigins)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - origins differing in case and whitespace don't produce diffs", func(t *testing.T) {
+		srv, assignedOrigins := newRoleCollectionAssignmentTrustTestServer(t, `[]`)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithOrigin("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", " My-IAS "),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "origin", " My-IAS "),
+						func(_ *terraform.State) error {
+							if len(*assignedOrigins) != 1 || (*assignedOrigins)[0] != "My-IAS" {
+								return fmt.Errorf("expected the user to be assigned with the trimmed origin My-IAS, got %v", *assignedOrigins)
+							}
+							return nil
+						},
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithOrigin("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", "my-ias"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("btp_subaccount_role_collection_assignment.uut", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "origin", "my-ias"),
+						func(_ *terraform.State) error {
+							if len(*assignedOrigins) != 1 {
+								return fmt.Errorf("expected the assignment not to be recreated, got the assignments %v", *assignedOrigins)
+							}
+							return nil
+						},
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithOrigin("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", "my-ias"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectEmptyPlan(),
+						},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - wait until the role collection reflects the assignment", func(t *testing.T) {
+		srv, reads := newRoleCollectionAssignmentConsistencyTestServer(t, 3)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithPollIntervals(srv.URL, "100ms", "100ms") + hclResourceRoleCollectionAssignment("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "user_name", "jenny.doe@test.com"),
+						func(_ *terraform.State) error {
+							if reads.Load() < 3 {
+								return fmt.Errorf("expected the role collection to be polled until it reflects the assignment, got %d reads", reads.Load())
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - update consistency timeout in place", func(t *testing.T) {
+		srv, _ := newRoleCollectionAssignmentConsistencyTestServer(t, 1)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithConsistencyTimeout("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", "1m"),
+					Check:  resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "consistency_timeout", "1m"),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithConsistencyTimeout("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", "5m"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("btp_subaccount_role_collection_assignment.uut", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "consistency_timeout", "5m"),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - expired consistency timeout doesn't fail the assignment", func(t *testing.T) {
+		srv, _ := newRoleCollectionAssignmentConsistencyTestServer(t, math.MaxInt32)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithPollIntervals(srv.URL, "100ms", "100ms") + hclResourceRoleCollectionAssignmentWithConsistencyTimeout("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", "1s"),
+					Check:  resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "consistency_timeout", "1s"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - consistency_timeout not a valid duration", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceRoleCollectionAssignmentWithConsistencyTimeout("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com", "soon"),
+					ExpectError: regexp.MustCompile(`value must be a duration of zero or more`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - user doesn't exist in origin", func(t *testing.T) {
+		srv, assignedUsers := newRoleCollectionAssignmentUserTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithUserCheck("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "ghost@test.com"),
+					ExpectError: regexp.MustCompile(`The user 'ghost@test.com' does not exist in the identity provider with origin\s+'ldap'`),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithUserCheck("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collection_assignment.uut", "check_user_exists", "true"),
+						func(_ *terraform.State) error {
+							if len(*assignedUsers) != 1 || (*assignedUsers)[0] != "jenny.doe@test.com" {
+								return fmt.Errorf("expected only jenny.doe@test.com to be assigned, got %v", *assignedUsers)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - origin cannot be defaulted with several custom trust configurations", func(t *testing.T) {
+		srv, _ := newRoleCollectionAssignmentTrustTestServer(t, `[{"originKey": "sap.default"}, {"originKey": "my-ias"}, {"originKey": "other-ias"}]`)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceRoleCollectionAssignmentWithOriginFromTrust("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "Destination Administrator", "jenny.doe@test.com"),
+					ExpectError: regexp.MustCompile(`the subaccount has 2 custom trust configurations \(my-ias, other-ias\)`),
+				},
+			},
+		})
+	})
+}
+
+func TestOriginFromCustomTrust(t *testing.T) {
+	t.Run("no custom trust configuration", func(t *testing.T) {
+		origin, err := originFromCustomTrust(xsuaa_trust.TrustConfigurationResponseCollectionObject{
+			{OriginKey: "sap.default"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ldap", origin)
+	})
+	t.Run("single custom trust configuration", func(t *testing.T) {
+		origin, err := originFromCustomTrust(xsuaa_trust.TrustConfigurationResponseCollectionObject{
+			{OriginKey: "sap.default"},
+			{OriginKey: "my-ias"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "my-ias", origin)
+	})
+	t.Run("several custom trust configurations", func(t *testing.T) {
+		_, err := originFromCustomTrust(xsuaa_trust.TrustConfigurationResponseCollectionObject{
+			{OriginKey: "sap.default"},
+			{OriginKey: "my-ias"},
+			{OriginKey: "other-ias"},
+		})
+
+		assert.EqualError(t, err, "the subaccount has 2 custom trust configurations (my-ias, other-ias), the origin must be given explicitly")
+	})
+}
+
+// newRoleCollectionAssignmentTrustTestServer returns a CLI server which lists the given trust configurations and records the origins of all user assignments.
+func newRoleCollectionAssignmentTrustTestServer(t *testing.T, trusts string) (*httptest.Server, *[]string) {
+	assignedOrigins := []string{}
+	users := newRoleCollectionUsersTestState()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode request body: %s", err)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/trust"):
+			fmt.Fprint(w, trusts)
+		case users.handle(w, r, payload.ParamValues):
+		case r.URL.RawQuery == "assign":
+			assignedOrigins = append(assignedOrigins, payload.ParamValues["origin"])
+			fmt.Fprintf(w, "{}")
+		default:
+			fmt.Fprintf(w, "{}")
+		}
+	})), &assignedOrigins
+}
+
+// newRoleCollectionAssignmentUserTestServer returns a CLI server which only knows the user jenny.doe@test.com and records the names of all assigned users.
+func newRoleCollectionAssignmentUserTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	assignedUsers := []string{}
+	users := newRoleCollectionUsersTestState()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode request body: %s", err)
+		}
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/user") && payload.ParamValues["userName"] != "jenny.doe@test.com":
+			w.Header().Set("X-Cpcli-Backend-Status", "404")
+			fmt.Fprintf(w, `{"error": "User not found"}`)
+		case strings.HasSuffix(r.URL.Path, "/security/user"):
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"username": "jenny.doe@test.com", "origin": "ldap"}`)
+		case users.handle(w, r, payload.ParamValues):
+		case r.URL.RawQuery == "assign":
+			assignedUsers = append(assignedUsers, payload.ParamValues["userName"])
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		default:
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "{}")
+		}
+	})), &assignedUsers
+}
+
+// newRoleCollectionAssignmentConsistencyTestServer returns a CLI server whose role collection reflects an assignment
+// only from the given number of reads on. The returned value counts the reads of the role collection.
+func newRoleCollectionAssignmentConsistencyTestServer(t *testing.T, consistentFromRead int32) (*httptest.Server, *atomic.Int32) {
+	reads := &atomic.Int32{}
+	users := newRoleCollectionUsersTestState()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode request body: %s", err)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if strings.HasSuffix(r.URL.Path, "/security/role-collection") && r.URL.RawQuery == "get" && reads.Add(1) < consistentFromRead {
+			fmt.Fprintf(w, `{"name": "%s"}`, payload.ParamValues["roleCollectionName"])
+			return
+		}
+
+		if !users.handle(w, r, payload.ParamValues) {
+			fmt.Fprintf(w, "{}")
+		}
+	})), reads
+}
+
+// roleCollectionUsersTestState keeps track of the users assigned by a test CLI server, so that reads of the role
+// collection reflect the assignments.
+type roleCollectionUsersTestState struct {
+	mu    sync.Mutex
+	users map[string]string
+}
+
+func newRoleCollectionUsersTestState() *roleCollectionUsersTestState {
+	return &roleCollectionUsersTestState{users: map[string]string{}}
+}
+
+// handle records the user assignments and answers the reads of the role collection. It reports whether a response has
+// been written, all other requests are left to the test server.
+func (s *roleCollectionUsersTestState) handle(w http.ResponseWriter, r *http.Request, paramValues map[string]string) bool {
+	if !strings.HasSuffix(r.URL.Path, "/security/role-collection") {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.RawQuery {
+	case "assign":
+		s.users[paramValues["userName"]] = paramValues["origin"]
+	case "unassign":
+		delete(s.users, paramValues["userName"])
+	case "get":
+		userReferences := []string{}
+		for userName, origin := range s.users {
+			userReferences = append(userReferences, fmt.Sprintf(`{"username": "%s", "origin": "%s"}`, userName, origin))
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"name": "%s", "userReferences": [%s]}`, paramValues["roleCollectionName"], strings.Join(userReferences, ", "))
+		return true
+	}
+
+	return false
 }
 
 func hclResourceRoleCollectionAssignment(resourceName string, subaccountId string, roleCollectionName string, userName string) string {
@@ -121,3 +554,36 @@ resource "btp_subaccount_role_collection_assignment" "%s"{
 	origin               = "%s"
 }`, resourceName, subaccountId, roleCollectionName, userName, origin)
 }
+
+func hclResourceRoleCollectionAssignmentWithOriginFromTrust(resourceName string, subaccountId string, roleCollectionName string, userName string) string {
+
+	return fmt.Sprintf(`
+resource "btp_subaccount_role_collection_assignment" "%s"{
+    subaccount_id        = "%s"
+	role_collection_name = "%s"
+	user_name            = "%s"
+	origin_from_trust    = true
+}`, resourceName, subaccountId, roleCollectionName, userName)
+}
+
+func hclResourceRoleCollectionAssignmentWithUserCheck(resourceName string, subaccountId string, roleCollectionName string, userName string) string {
+
+	return fmt.Sprintf(`
+resource "btp_subaccount_role_collection_assignment" "%s"{
+    subaccount_id        = "%s"
+	role_collection_name = "%s"
+	user_name            = "%s"
+	check_user_exists    = true
+}`, resourceName, subaccountId, roleCollectionName, userName)
+}
+
+func hclResourceRoleCollectionAssignmentWithConsistencyTimeout(resourceName string, subaccountId string, roleCollectionName string, userName string, consistencyTimeout string) string {
+
+	return fmt.Sprintf(`
+resource "btp_subaccount_role_collection_assignment" "%s"{
+    subaccount_id        = "%s"
+	role_collection_name = "%s"
+	user_name            = "%s"
+	consistency_timeout  = "%s"
+}`, resourceName, subaccountId, roleCollectionName, userName, consistencyTimeout)
+}
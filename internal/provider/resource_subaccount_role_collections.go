@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+func newSubaccountRoleCollectionsResource() resource.Resource {
+	return &subaccountRoleCollectionsResource{}
+}
+
+type subaccountRoleCollectionSpecType struct {
+	Name        types.String                          `tfsdk:"name"`
+	Description types.String                          `tfsdk:"description"`
+	Roles       []subaccountRoleCollectionRoleRefType `tfsdk:"roles"`
+}
+
+func saRoleCollectionNamesAreEqual(collectionA, collectionB subaccountRoleCollectionSpecType) bool {
+	return collectionA.Name.Equal(collectionB.Name)
+}
+
+func saRoleCollectionSpecsAreEqual(collectionA, collectionB subaccountRoleCollectionSpecType) bool {
+	return saRoleCollectionNamesAreEqual(collectionA, collectionB) &&
+		collectionA.Description.Equal(collectionB.Description) &&
+		len(tfutils.SetDifference(collectionA.Roles, collectionB.Roles, saRoleRefIsEqual)) == 0 &&
+		len(tfutils.SetDifference(collectionB.Roles, collectionA.Roles, saRoleRefIsEqual)) == 0
+}
+
+type subaccountRoleCollectionsType struct {
+	SubaccountId    types.String                       `tfsdk:"subaccount_id"`
+	Id              types.String                       `tfsdk:"id"`
+	RoleCollections []subaccountRoleCollectionSpecType `tfsdk:"role_collections"`
+}
+
+type subaccountRoleCollectionsResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *subaccountRoleCollectionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_role_collections", req.ProviderTypeName)
+}
+
+func (rs *subaccountRoleCollectionsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *subaccountRoleCollectionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Creates a set of role collections in a subaccount, e.g. to roll out the same role collections to many subaccounts.
+
+The role collections are identified by their names. Role collections added to the set are created, the ones removed from the set are deleted. If the creation of a role collection fails, the role collections created in the same apply are deleted again. Role collections of the subaccount that aren't part of the set are left untouched.
+
+__Tip:__
+You must be assigned to the admin role of the subaccount.
+
+__Further documentation:__
+<https://help.sap.com/docs/btp/sap-business-technology-platform/role-collections-and-roles-in-global-accounts-directories-and-subaccounts>`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_collections": schema.SetNestedAttribute{
+				MarkdownDescription: "The role collections managed by the resource.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the role collection.",
+							Required:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the role collection.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+						},
+						"roles": schema.SetNestedAttribute{
+							MarkdownDescription: "The roles referenced by the role collection.",
+							Required:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "The name of the referenced role.",
+										Required:            true,
+									},
+									"role_template_name": schema.StringAttribute{
+										MarkdownDescription: "The name of the referenced role template.",
+										Required:            true,
+									},
+									"role_template_app_id": schema.StringAttribute{
+										MarkdownDescription: "The name of the referenced template app id.",
+										Required:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (rs *subaccountRoleCollectionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subaccountRoleCollectionsType
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Security.RoleCollection.ListBySubaccount(ctx, state.SubaccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	var managed []subaccountRoleCollectionSpecType
+	if state.Id.IsNull() {
+		// an imported resource manages all role collections of the subaccount which can be changed
+		for _, roleCollection := range cliRes {
+			if !roleCollection.IsReadOnly {
+				managed = append(managed, subaccountRoleCollectionSpecType{Name: types.StringValue(roleCollection.Name)})
+			}
+		}
+	} else {
+		managed = state.RoleCollections
+	}
+
+	state = subaccountRoleCollectionsValueFrom(state.SubaccountId.ValueString(), cliRes, managed)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountRoleCollectionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subaccountRoleCollectionsType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(rs.applyRoleCollections(ctx, plan.SubaccountId.ValueString(), nil, plan.RoleCollections)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := rs.readRoleCollections(ctx, plan.SubaccountId.ValueString(), plan.RoleCollections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountRoleCollectionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state subaccountRoleCollectionsType
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(rs.applyRoleCollections(ctx, plan.SubaccountId.ValueString(), state.RoleCollections, plan.RoleCollections)...)
+
+	managed := plan.RoleCollections
+	if resp.Diagnostics.HasError() {
+		// the role collections which couldn't be deleted are still managed by the resource
+		managed = append(managed, tfutils.SetDifference(state.RoleCollections, plan.RoleCollections, saRoleCollectionNamesAreEqual)...)
+	}
+
+	state, diags = rs.readRoleCollections(ctx, plan.SubaccountId.ValueString(), managed)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountRoleCollectionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountRoleCollectionsType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, roleCollection := range state.RoleCollections {
+		_, _, err := rs.cli.Security.RoleCollection.DeleteBySubaccount(ctx, state.SubaccountId.ValueString(), roleCollection.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error Deleting Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+		}
+	}
+}
+
+func (rs *subaccountRoleCollectionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("subaccount_id"), req, resp)
+}
+
+// applyRoleCollections brings the role collections of the subaccount from the current to the planned specs. The new
+// role collections are created first, all or nothing: if one of them fails, the ones created so far are deleted again
+// and nothing else is changed. Afterwards the changed role collections are updated and the removed ones are deleted.
+func (rs *subaccountRoleCollectionsResource) applyRoleCollections(ctx context.Context, subaccountId string, current []subaccountRoleCollectionSpecType, planned []subaccountRoleCollectionSpecType) (diags diag.Diagnostics) {
+	created := []string{}
+
+	for _, roleCollection := range tfutils.SetDifference(planned, current, saRoleCollectionNamesAreEqual) {
+		_, _, err := rs.cli.Security.RoleCollection.CreateBySubaccount(ctx, subaccountId, roleCollection.Name.ValueString(), roleCollection.Description.ValueString())
+		if err == nil {
+			created = append(created, roleCollection.Name.ValueString())
+			err = rs.updateRoles(ctx, subaccountId, roleCollection.Name.ValueString(), nil, roleCollection.Roles)
+		}
+
+		if err != nil {
+			diags.AddError("API Error Creating Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+
+			for _, name := range created {
+				if _, _, err := rs.cli.Security.RoleCollection.DeleteBySubaccount(ctx, subaccountId, name); err != nil {
+					diags.AddError("API Error Deleting Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+				}
+			}
+
+			return
+		}
+	}
+
+	for _, roleCollection := range tfutils.SetDifference(planned, current, saRoleCollectionSpecsAreEqual) {
+		var currentRoleCollection *subaccountRoleCollectionSpecType
+		for i := range current {
+			if saRoleCollectionNamesAreEqual(current[i], roleCollection) {
+				currentRoleCollection = &current[i]
+			}
+		}
+
+		if currentRoleCollection == nil {
+			// created above
+			continue
+		}
+
+		if !roleCollection.Description.Equal(currentRoleCollection.Description) {
+			_, _, err := rs.cli.Security.RoleCollection.UpdateBySubaccount(ctx, subaccountId, roleCollection.Name.ValueString(), roleCollection.Description.ValueString())
+			if err != nil {
+				diags.AddError("API Error Updating Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+				continue
+			}
+		}
+
+		if err := rs.updateRoles(ctx, subaccountId, roleCollection.Name.ValueString(), currentRoleCollection.Roles, roleCollection.Roles); err != nil {
+			diags.AddError("API Error Updating Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+		}
+	}
+
+	for _, roleCollection := range tfutils.SetDifference(current, planned, saRoleCollectionNamesAreEqual) {
+		_, _, err := rs.cli.Security.RoleCollection.DeleteBySubaccount(ctx, subaccountId, roleCollection.Name.ValueString())
+		if err != nil {
+			diags.AddError("API Error Deleting Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+		}
+	}
+
+	return
+}
+
+// updateRoles removes the roles that aren't planned anymore from the role collection and adds the new ones.
+func (rs *subaccountRoleCollectionsResource) updateRoles(ctx context.Context, subaccountId string, roleCollectionName string, current []subaccountRoleCollectionRoleRefType, planned []subaccountRoleCollectionRoleRefType) error {
+	for _, role := range tfutils.SetDifference(current, planned, saRoleRefIsEqual) {
+		_, err := rs.cli.Security.Role.RemoveBySubaccount(ctx, subaccountId, roleCollectionName, role.Name.ValueString(), role.RoleTemplateAppId.ValueString(), role.RoleTemplateName.ValueString())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, role := range tfutils.SetDifference(planned, current, saRoleRefIsEqual) {
+		_, err := rs.cli.Security.Role.AddBySubaccount(ctx, subaccountId, roleCollectionName, role.Name.ValueString(), role.RoleTemplateAppId.ValueString(), role.RoleTemplateName.ValueString())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readRoleCollections reads the managed role collections of the subaccount.
+func (rs *subaccountRoleCollectionsResource) readRoleCollections(ctx context.Context, subaccountId string, managed []subaccountRoleCollectionSpecType) (state subaccountRoleCollectionsType, diags diag.Diagnostics) {
+	cliRes, _, err := rs.cli.Security.RoleCollection.ListBySubaccount(ctx, subaccountId)
+	if err != nil {
+		diags.AddError("API Error Reading Resource Role Collections (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	return subaccountRoleCollectionsValueFrom(subaccountId, cliRes, managed), diags
+}
+
+// subaccountRoleCollectionsValueFrom picks the managed role collections from the role collections of the subaccount.
+// Managed role collections that don't exist anymore are dropped, so that they are created again on the next apply.
+func subaccountRoleCollectionsValueFrom(subaccountId string, value []xsuaa_authz.RoleCollection, managed []subaccountRoleCollectionSpecType) subaccountRoleCollectionsType {
+	roleCollections := subaccountRoleCollectionsType{
+		SubaccountId:    types.StringValue(subaccountId),
+		Id:              types.StringValue(subaccountId),
+		RoleCollections: []subaccountRoleCollectionSpecType{},
+	}
+
+	managedNames := map[string]bool{}
+	for _, roleCollection := range managed {
+		managedNames[roleCollection.Name.ValueString()] = true
+	}
+
+	for _, roleCollection := range value {
+		if !managedNames[roleCollection.Name] {
+			continue
+		}
+
+		spec := subaccountRoleCollectionSpecType{
+			Name:        types.StringValue(roleCollection.Name),
+			Description: types.StringValue(roleCollection.Description),
+			Roles:       []subaccountRoleCollectionRoleRefType{},
+		}
+
+		for _, role := range roleCollection.RoleReferences {
+			spec.Roles = append(spec.Roles, subaccountRoleCollectionRoleRefType{
+				RoleTemplateName:  types.StringValue(role.RoleTemplateName),
+				RoleTemplateAppId: types.StringValue(role.RoleTemplateAppId),
+				Name:              types.StringValue(role.Name),
+			})
+		}
+
+		roleCollections.RoleCollections = append(roleCollections.RoleCollections, spec)
+	}
+
+	return roleCollections
+}
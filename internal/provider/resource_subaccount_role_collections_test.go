@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_authz"
+)
+
+func TestResourceSubaccountRoleCollections(t *testing.T) {
+	t.Run("happy path - create and remove role collections of the set", func(t *testing.T) {
+		srv, roleCollections := newSubaccountRoleCollectionsTestServer()
+		defer srv.Close()
+
+		expectRoleCollections := func(expected ...string) resource.TestCheckFunc {
+			return func(_ *terraform.State) error {
+				names := roleCollectionNamesOf(roleCollections)
+				sort.Strings(names)
+
+				if strings.Join(names, ", ") != strings.Join(expected, ", ") {
+					return fmt.Errorf("expected the role collections %v, got: %v", expected, names)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountRoleCollections("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", map[string][]string{
+						"Team Admins":   {"Subaccount Admin", "Subaccount Viewer"},
+						"Team Auditors": {"Subaccount Viewer"},
+					}),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collections.uut", "id", "59cd458e-e66e-4b60-b6d8-8f219379f9a5"),
+						resource.TestCheckResourceAttr("btp_subaccount_role_collections.uut", "role_collections.#", "2"),
+						resource.TestCheckTypeSetElemNestedAttrs("btp_subaccount_role_collections.uut", "role_collections.*", map[string]string{
+							"name":        "Team Admins",
+							"description": "Managed by Terraform",
+							"roles.#":     "2",
+						}),
+						resource.TestCheckTypeSetElemNestedAttrs("btp_subaccount_role_collections.uut", "role_collections.*", map[string]string{
+							"name":    "Team Auditors",
+							"roles.#": "1",
+						}),
+						expectRoleCollections("Subaccount Administrator", "Team Admins", "Team Auditors"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountRoleCollections("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", map[string][]string{
+						"Team Admins":     {"Subaccount Admin"},
+						"Team Developers": {"Subaccount Viewer"},
+					}),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_role_collections.uut", "role_collections.#", "2"),
+						resource.TestCheckTypeSetElemNestedAttrs("btp_subaccount_role_collections.uut", "role_collections.*", map[string]string{
+							"name":    "Team Admins",
+							"roles.#": "1",
+						}),
+						resource.TestCheckTypeSetElemNestedAttrs("btp_subaccount_role_collections.uut", "role_collections.*", map[string]string{
+							"name":    "Team Developers",
+							"roles.#": "1",
+						}),
+						expectRoleCollections("Subaccount Administrator", "Team Admins", "Team Developers"),
+					),
+				},
+				{
+					ResourceName:      "btp_subaccount_role_collections.uut",
+					ImportStateId:     "59cd458e-e66e-4b60-b6d8-8f219379f9a5",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+
+		assert.ElementsMatch(t, []string{"Subaccount Administrator"}, roleCollectionNamesOf(roleCollections))
+	})
+
+	t.Run("error path - failed creation deletes the role collections created before", func(t *testing.T) {
+		srv, roleCollections := newSubaccountRoleCollectionsTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountRoleCollections("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", map[string][]string{
+						"Team Admins":   {"Subaccount Admin"},
+						"Team Auditors": {"Broken Role"},
+					}),
+					ExpectError: regexp.MustCompile(`API Error Creating Resource Role Collections \(Subaccount\)`),
+				},
+			},
+		})
+
+		assert.ElementsMatch(t, []string{"Subaccount Administrator"}, roleCollectionNamesOf(roleCollections))
+	})
+
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountRoleCollections("uut", "this-is-not-a-uuid", map[string][]string{"Team Admins": {"Subaccount Admin"}}),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+// hclResourceSubaccountRoleCollections returns a set of role collections which reference the given roles of the role
+// template Subaccount_Admin. The role collection "Team Admins" has a description.
+func hclResourceSubaccountRoleCollections(resourceName string, subaccountId string, roleCollections map[string][]string) string {
+	specs := []string{}
+
+	for name, roles := range roleCollections {
+		roleRefs := []string{}
+		for _, role := range roles {
+			roleRefs = append(roleRefs, fmt.Sprintf(`{
+						name                 = "%s"
+						role_template_app_id = "cis-local!b2"
+						role_template_name   = "Subaccount_Admin"
+					}`, role))
+		}
+
+		description := ""
+		if name == "Team Admins" {
+			description = `description = "Managed by Terraform"`
+		}
+
+		specs = append(specs, fmt.Sprintf(`{
+				name  = "%s"
+				%s
+				roles = [%s]
+			}`, name, description, strings.Join(roleRefs, ", ")))
+	}
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_role_collections" "%s" {
+			subaccount_id    = "%s"
+			role_collections = [%s]
+		}`, resourceName, subaccountId, strings.Join(specs, ", "))
+}
+
+func roleCollectionNamesOf(roleCollections map[string]*xsuaa_authz.RoleCollection) (names []string) {
+	for name := range roleCollections {
+		names = append(names, name)
+	}
+	return
+}
+
+// newSubaccountRoleCollectionsTestServer returns a CLI server which keeps the role collections of a subaccount in
+// memory. The subaccount has the read-only role collection "Subaccount Administrator" and the role "Broken Role" can't
+// be added.
+func newSubaccountRoleCollectionsTestServer() (*httptest.Server, map[string]*xsuaa_authz.RoleCollection) {
+	roleCollections := map[string]*xsuaa_authz.RoleCollection{
+		"Subaccount Administrator": {Name: "Subaccount Administrator", IsReadOnly: true},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		name := payload.ParamValues["roleCollectionName"]
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/role-collection") && r.URL.RawQuery == "list":
+			list := []*xsuaa_authz.RoleCollection{}
+			for _, roleCollection := range roleCollections {
+				list = append(list, roleCollection)
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			_ = json.NewEncoder(w).Encode(list)
+			return
+		case strings.HasSuffix(r.URL.Path, "/security/role-collection") && r.URL.RawQuery == "create":
+			roleCollections[name] = &xsuaa_authz.RoleCollection{Name: name, Description: payload.ParamValues["description"]}
+		case strings.HasSuffix(r.URL.Path, "/security/role") && r.URL.RawQuery == "add":
+			if payload.ParamValues["roleName"] == "Broken Role" {
+				w.Header().Set("X-Cpcli-Backend-Status", "400")
+				fmt.Fprintf(w, `{"error": "role not found"}`)
+				return
+			}
+
+			if roleCollection, ok := roleCollections[name]; ok {
+				roleCollection.RoleReferences = append(roleCollection.RoleReferences, xsuaa_authz.RoleReference{
+					Name:              payload.ParamValues["roleName"],
+					RoleTemplateAppId: payload.ParamValues["roleTemplateAppID"],
+					RoleTemplateName:  payload.ParamValues["roleTemplateName"],
+				})
+			}
+		case strings.HasSuffix(r.URL.Path, "/security/role") && r.URL.RawQuery == "remove":
+			if roleCollection, ok := roleCollections[name]; ok {
+				roleReferences := []xsuaa_authz.RoleReference{}
+				for _, role := range roleCollection.RoleReferences {
+					if role.Name != payload.ParamValues["roleName"] {
+						roleReferences = append(roleReferences, role)
+					}
+				}
+				roleCollection.RoleReferences = roleReferences
+			}
+		case strings.HasSuffix(r.URL.Path, "/security/role-collection") && r.URL.RawQuery == "delete":
+			defer delete(roleCollections, name)
+		}
+
+		roleCollection, ok := roleCollections[name]
+		if !ok {
+			w.Header().Set("X-Cpcli-Backend-Status", "404")
+			fmt.Fprintf(w, `{"error": "role collection not found"}`)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		_ = json.NewEncoder(w).Encode(roleCollection)
+	})), roleCollections
+}
@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+// defaultIdentityProviderOrigin is the origin of SAP ID service, which is the default identity provider of every subaccount.
+const defaultIdentityProviderOrigin = "sap.default"
+
+// defaultTokenValidity makes the tenant fall back to its default validity of tokens.
+const defaultTokenValidity = -1
+
+// securitySettingsETagKey is the key of the private state, which holds the ETag of the settings as of the last read.
+const securitySettingsETagKey = "etag"
+
+func newSubaccountSecuritySettingsResource() resource.Resource {
+	return &subaccountSecuritySettingsResource{}
+}
+
+type subaccountSecuritySettingsResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *subaccountSecuritySettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_security_settings", req.ProviderTypeName)
+}
+
+func (rs *subaccountSecuritySettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *subaccountSecuritySettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the security settings of a subaccount.
+
+__Tip:__
+The security settings of a subaccount can't be deleted. Removing the resource resets the settings to their defaults. Settings which aren't configured keep their current value, which is exposed as computed value. If the server supports it, changes are rejected if the settings have been modified by someone else since they were last read.
+
+__Further documentation:__
+<https://help.sap.com/docs/btp/sap-business-technology-platform/trust-and-federation-with-identity-providers>`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"default_identity_provider": schema.StringAttribute{
+				MarkdownDescription: "The origin of the identity provider that is used by default for the login screen of the subaccount. The identity provider must be configured as trust configuration of the subaccount.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"access_token_validity": schema.Int64Attribute{
+				MarkdownDescription: "The validity of access tokens issued by the subaccount in seconds. The value `-1` means that the default validity of the tenant is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(defaultTokenValidity),
+				},
+			},
+			"refresh_token_validity": schema.Int64Attribute{
+				MarkdownDescription: "The validity of refresh tokens issued by the subaccount in seconds. The value `-1` means that the default validity of the tenant is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(defaultTokenValidity),
+				},
+			},
+			"custom_email_domains": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The custom domains which are allowed for the email addresses of users, e.g. in addition to the domains of SAP ID service. An empty set removes all custom domains.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (rs *subaccountSecuritySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subaccountSecuritySettingsType
+
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, rawRes, err := rs.cli.Security.Settings.GetBySubaccount(ctx, state.SubaccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Security Settings (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedState, diags := subaccountSecuritySettingsValueFrom(ctx, state.SubaccountId.ValueString(), cliRes)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(setSecuritySettingsETag(ctx, resp.Private, rawRes.ETag)...)
+
+	diags = resp.State.Set(ctx, &updatedState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountSecuritySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subaccountSecuritySettingsType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, etag, diags := rs.updateSecuritySettings(ctx, plan, "")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setSecuritySettingsETag(ctx, resp.Private, etag)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountSecuritySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan subaccountSecuritySettingsType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the settings are only updated if nobody else has changed them since the last read
+	priorETag, diags := securitySettingsETagFrom(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, etag, diags := rs.updateSecuritySettings(ctx, plan, priorETag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setSecuritySettingsETag(ctx, resp.Private, etag)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountSecuritySettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountSecuritySettingsType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenValidity := defaultTokenValidity
+
+	_, _, err := rs.cli.Security.Settings.UpdateBySubaccount(ctx, state.SubaccountId.ValueString(), btpcli.SecuritySettingsUpdateInput{
+		DefaultIdp:           defaultIdentityProviderOrigin,
+		AccessTokenValidity:  &tokenValidity,
+		RefreshTokenValidity: &tokenValidity,
+		CustomEmailDomains:   []string{},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Security Settings (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (rs *subaccountSecuritySettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("subaccount_id"), req, resp)
+}
+
+// updateSecuritySettings applies the plan and returns the resulting settings along with their ETag. If an ETag is given,
+// the settings are only updated if they still have this ETag.
+func (rs *subaccountSecuritySettingsResource) updateSecuritySettings(ctx context.Context, plan subaccountSecuritySettingsType, etag string) (subaccountSecuritySettingsType, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	subaccountId := plan.SubaccountId.ValueString()
+	args := btpcli.SecuritySettingsUpdateInput{}
+
+	if !plan.DefaultIdentityProvider.IsUnknown() && !plan.DefaultIdentityProvider.IsNull() {
+		args.DefaultIdp = plan.DefaultIdentityProvider.ValueString()
+
+		diags.Append(rs.validateTrustConfigurationExists(ctx, subaccountId, args.DefaultIdp)...)
+		if diags.HasError() {
+			return plan, "", diags
+		}
+	}
+
+	if !plan.AccessTokenValidity.IsUnknown() && !plan.AccessTokenValidity.IsNull() {
+		accessTokenValidity := int(plan.AccessTokenValidity.ValueInt64())
+		args.AccessTokenValidity = &accessTokenValidity
+	}
+
+	if !plan.RefreshTokenValidity.IsUnknown() && !plan.RefreshTokenValidity.IsNull() {
+		refreshTokenValidity := int(plan.RefreshTokenValidity.ValueInt64())
+		args.RefreshTokenValidity = &refreshTokenValidity
+	}
+
+	if !plan.CustomEmailDomains.IsUnknown() && !plan.CustomEmailDomains.IsNull() {
+		args.CustomEmailDomains = []string{}
+		diags.Append(plan.CustomEmailDomains.ElementsAs(ctx, &args.CustomEmailDomains, false)...)
+		if diags.HasError() {
+			return plan, "", diags
+		}
+	}
+
+	updateCtx := ctx
+	if len(etag) > 0 {
+		updateCtx = btpcli.WithIfMatch(ctx, etag)
+	}
+
+	_, _, err := rs.cli.Security.Settings.UpdateBySubaccount(updateCtx, subaccountId, args)
+	if errors.Is(err, btpcli.ErrConcurrentModification) {
+		diags.AddError("Conflict Updating Resource Security Settings (Subaccount)", fmt.Sprintf("%s Run terraform plan again to review the changes made to the security settings of subaccount %s in the meantime.", err, subaccountId))
+		return plan, "", diags
+	} else if err != nil {
+		diags.AddError("API Error Updating Resource Security Settings (Subaccount)", fmt.Sprintf("%s", err))
+		return plan, "", diags
+	}
+
+	cliRes, rawRes, err := rs.cli.Security.Settings.GetBySubaccount(ctx, subaccountId)
+	if err != nil {
+		diags.AddError("API Error Reading Resource Security Settings (Subaccount)", fmt.Sprintf("%s", err))
+		return plan, "", diags
+	}
+
+	state, stateDiags := subaccountSecuritySettingsValueFrom(ctx, subaccountId, cliRes)
+	diags.Append(stateDiags...)
+
+	return state, rawRes.ETag, diags
+}
+
+func (rs *subaccountSecuritySettingsResource) validateTrustConfigurationExists(ctx context.Context, subaccountId string, origin string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	trustConfigurations, _, err := rs.cli.Security.Trust.ListBySubaccount(ctx, subaccountId)
+	if err != nil {
+		diags.AddError("API Error Reading Resource Trust Configurations (Subaccount)", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	for _, trustConfiguration := range trustConfigurations {
+		if trustConfiguration.OriginKey == origin {
+			return diags
+		}
+	}
+
+	diags.AddAttributeError(path.Root("default_identity_provider"), "Invalid Default Identity Provider", fmt.Sprintf("The subaccount %s has no trust configuration with origin '%s'.", subaccountId, origin))
+
+	return diags
+}
+
+// securitySettingsETagFrom returns the ETag stored in the private state, or an empty string if the server hasn't reported one.
+func securitySettingsETagFrom(ctx context.Context, private interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}) (etag string, diags diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, securitySettingsETagKey)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+
+	if err := json.Unmarshal(value, &etag); err != nil {
+		diags.AddError("Invalid Private State of Resource Security Settings (Subaccount)", fmt.Sprintf("%s", err))
+	}
+
+	return etag, diags
+}
+
+// setSecuritySettingsETag stores the ETag in the private state. An empty ETag overwrites the previous one, in case the
+// server doesn't report ETags anymore.
+func setSecuritySettingsETag(ctx context.Context, private interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}, etag string) diag.Diagnostics {
+	// the values of the private state must be valid JSON
+	value, _ := json.Marshal(etag)
+
+	return private.SetKey(ctx, securitySettingsETagKey, value)
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestResourceSubaccountSecuritySettings(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - set default identity provider", func(t *testing.T) {
+		srv, _ := newSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettings("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "terraformint-platform"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "id", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"),
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "default_identity_provider", "terraformint-platform"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettings("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "sap.default"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "default_identity_provider", "sap.default"),
+					),
+				},
+				{
+					ResourceName:      "btp_subaccount_security_settings.uut",
+					ImportStateId:     "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+	t.Run("happy path - set token validities and custom email domains", func(t *testing.T) {
+		srv, _ := newSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettingsWithTokenPolicy("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", 3600, 86400, `["example.com", "example.org"]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "access_token_validity", "3600"),
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "refresh_token_validity", "86400"),
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "custom_email_domains.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_security_settings.uut", "custom_email_domains.*", "example.com"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_security_settings.uut", "custom_email_domains.*", "example.org"),
+						// settings which aren't configured expose their current value
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "default_identity_provider", "sap.default"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettingsWithTokenPolicy("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", -1, 86400, `[]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "access_token_validity", "-1"),
+						resource.TestCheckResourceAttr("btp_subaccount_security_settings.uut", "custom_email_domains.#", "0"),
+					),
+				},
+				{
+					ResourceName:      "btp_subaccount_security_settings.uut",
+					ImportStateId:     "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+	t.Run("error path - default identity provider without trust configuration", func(t *testing.T) {
+		srv, _ := newSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettings("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "unknown-platform"),
+					ExpectError: regexp.MustCompile(`no trust configuration with origin 'unknown-platform'`),
+				},
+			},
+		})
+	})
+	t.Run("error path - settings modified concurrently", func(t *testing.T) {
+		srv, modifyConcurrently := newSecuritySettingsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettings("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "terraformint-platform"),
+				},
+				{
+					PreConfig:   modifyConcurrently,
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSecuritySettings("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "sap.default"),
+					ExpectError: regexp.MustCompile(`Conflict Updating Resource Security Settings`),
+				},
+			},
+		})
+	})
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountSecuritySettings("uut", "this-is-not-a-uuid", "sap.default"),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+// newSecuritySettingsTestServer simulates the security settings and trust commands of the CLI server for a
+// subaccount with the trust configurations `sap.default` and `terraformint-platform`. Once modifyConcurrently has been
+// called, the settings are changed by someone else right after they have been read the next time.
+func newSecuritySettingsTestServer(t *testing.T) (srv *httptest.Server, modifyConcurrently func()) {
+	defaultIdp := "sap.default"
+	accessTokenValidity, refreshTokenValidity := "-1", "-1"
+	customEmailDomains := "[]"
+	version := 1
+	concurrentModificationPending := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/trust") && r.URL.RawQuery == "list":
+			fmt.Fprintf(w, `[{"originKey": "sap.default"}, {"originKey": "terraformint-platform"}]`)
+		case strings.HasSuffix(r.URL.Path, "/security/settings") && r.URL.RawQuery == "update":
+			if ifMatch := r.Header.Get("If-Match"); len(ifMatch) > 0 && ifMatch != fmt.Sprintf(`"%d"`, version) {
+				w.Header().Set("X-Cpcli-Backend-Status", "412")
+				fmt.Fprintf(w, `{"error": "The settings have been modified in the meantime."}`)
+				return
+			}
+
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			if value, ok := body.ParamValues["defaultIdp"]; ok {
+				defaultIdp = value
+			}
+			if value, ok := body.ParamValues["accessTokenValidity"]; ok {
+				accessTokenValidity = value
+			}
+			if value, ok := body.ParamValues["refreshTokenValidity"]; ok {
+				refreshTokenValidity = value
+			}
+			if value, ok := body.ParamValues["customEmailDomains"]; ok {
+				customEmailDomains = value
+			}
+
+			version++
+			fmt.Fprintf(w, "{}")
+		case strings.HasSuffix(r.URL.Path, "/security/settings") && r.URL.RawQuery == "get":
+			w.Header().Set("Etag", fmt.Sprintf(`"%d"`, version))
+			fmt.Fprintf(w, `{"defaultIdp": "%s", "tokenPolicySettings": {"accessTokenValidity": %s, "refreshTokenValidity": %s}, "customEmailDomains": %s}`, defaultIdp, accessTokenValidity, refreshTokenValidity, customEmailDomains)
+
+			if concurrentModificationPending {
+				concurrentModificationPending = false
+				version++
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), func() { concurrentModificationPending = true }
+}
+
+func hclResourceSubaccountSecuritySettings(resourceName string, subaccountId string, defaultIdentityProvider string) string {
+	template := `
+resource "btp_subaccount_security_settings" "%s" {
+    subaccount_id             = "%s"
+    default_identity_provider = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, defaultIdentityProvider)
+}
+
+func hclResourceSubaccountSecuritySettingsWithTokenPolicy(resourceName string, subaccountId string, accessTokenValidity int, refreshTokenValidity int, customEmailDomains string) string {
+	template := `
+resource "btp_subaccount_security_settings" "%s" {
+    subaccount_id          = "%s"
+    access_token_validity  = %d
+    refresh_token_validity = %d
+    custom_email_domains   = %s
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, accessTokenValidity, refreshTokenValidity, customEmailDomains)
+}
@@ -76,6 +76,15 @@ func (rs *subaccountServiceBindingResource) Schema(_ context.Context, _ resource
 					jsonvalidator.ValidJSON(),
 				},
 			},
+			"rotate_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value which, if changed, replaces the service binding by a new one and thereby rotates its credentials. " +
+					"To ensure that the consuming applications never lose access, set `create_before_destroy` in the `lifecycle` of the resource and change the `name` along with the trigger, " +
+					"so that the new binding is created before the old one is deleted.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"labels": schema.MapAttribute{
 				ElementType: types.SetType{
 					ElemType: types.StringType,
@@ -156,6 +165,8 @@ func (rs *subaccountServiceBindingResource) Read(ctx context.Context, req resour
 		updatedState.Parameters = types.StringValue("{}")
 	}
 
+	updatedState.RotateTrigger = state.RotateTrigger
+
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &updatedState)
@@ -177,7 +188,15 @@ func (rs *subaccountServiceBindingResource) Create(ctx context.Context, req reso
 		Parameters:        plan.Parameters.ValueString(),
 	}
 
-	cliRes, _, err := rs.cli.Services.Binding.Create(ctx, cliReq)
+	// the instance might still be busy with an operation, even if it is reported as ready
+	var cliRes servicemanager.ServiceBindingResponseObject
+	err := retryWhileBusy(ctx, 1*time.Minute, func() (btpcli.CommandResponse, error) {
+		var res btpcli.CommandResponse
+		var err error
+
+		cliRes, res, err = rs.cli.Services.Binding.Create(ctx, cliReq)
+		return res, err
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Creating Resource Service Binding (Subaccount)", fmt.Sprintf("%s", err))
 		return
@@ -204,8 +223,9 @@ func (rs *subaccountServiceBindingResource) Create(ctx context.Context, req reso
 			return subRes, subRes.LastOperation.State, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := createStateConf.WaitForStateContext(ctx)
@@ -215,6 +235,7 @@ func (rs *subaccountServiceBindingResource) Create(ctx context.Context, req reso
 
 	updatedPlan, diags = subaccountServiceBindingValueFrom(ctx, updatedRes.(servicemanager.ServiceBindingResponseObject))
 	updatedPlan.Parameters = plan.Parameters
+	updatedPlan.RotateTrigger = plan.RotateTrigger
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &updatedPlan)
@@ -271,8 +292,9 @@ func (rs *subaccountServiceBindingResource) Delete(ctx context.Context, req reso
 			return subRes, subRes.LastOperation.State, nil
 		},
 		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -37,6 +41,71 @@ func TestResourceSubaccountServiceBinding(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - rotate_trigger replaces the binding before deleting the old one", func(t *testing.T) {
+		srv, operations := newServiceBindingRotationTestServer(0)
+		defer srv.Close()
+
+		expectOperations := func(expected ...string) resource.TestCheckFunc {
+			return func(_ *terraform.State) error {
+				if strings.Join(*operations, ", ") != strings.Join(expected, ", ") {
+					return fmt.Errorf("expected the operations %v, got: %v", expected, *operations)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBindingWithRotateTrigger("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "df532d07-57a7-415e-a261-23a398ef068a", "2023-10"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "id", "b2c6fd5f-e6ab-4f35-a2cc-5a6c1b9b50a1"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "name", "my-binding-2023-10"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "rotate_trigger", "2023-10"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "credentials", `{"secret": "secret-of-my-binding-2023-10"}`),
+						expectOperations("create my-binding-2023-10"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBindingWithRotateTrigger("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "df532d07-57a7-415e-a261-23a398ef068a", "2023-11"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "id", "3c4a1a5e-4f2d-4c4b-9a4e-0f7d5b2e8c11"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "name", "my-binding-2023-11"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "rotate_trigger", "2023-11"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "credentials", `{"secret": "secret-of-my-binding-2023-11"}`),
+						expectOperations("create my-binding-2023-10", "create my-binding-2023-11", "delete b2c6fd5f-e6ab-4f35-a2cc-5a6c1b9b50a1"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - creation retried while the service instance is busy", func(t *testing.T) {
+		srv, operations := newServiceBindingRotationTestServer(1)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBindingWithRotateTrigger("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "df532d07-57a7-415e-a261-23a398ef068a", "2023-10"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_binding.uut", "id", "b2c6fd5f-e6ab-4f35-a2cc-5a6c1b9b50a1"),
+						func(_ *terraform.State) error {
+							if strings.Join(*operations, ", ") != "busy my-binding-2023-10, create my-binding-2023-10" {
+								return fmt.Errorf("expected the creation to be retried once, got: %v", *operations)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - subacount_id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -110,6 +179,21 @@ func hclResourceSubaccountServiceBinding(resourceName string, subaccountId strin
 		}`, resourceName, subaccountId, serviceInstanceId, name)
 }
 
+func hclResourceSubaccountServiceBindingWithRotateTrigger(resourceName string, subaccountId string, serviceInstanceId string, rotateTrigger string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_service_binding" "%s"{
+		    subaccount_id       = "%s"
+			service_instance_id = "%s"
+			name                = "my-binding-%s"
+			rotate_trigger      = "%s"
+
+			lifecycle {
+				create_before_destroy = true
+			}
+		}`, resourceName, subaccountId, serviceInstanceId, rotateTrigger, rotateTrigger)
+}
+
 func hclResourceSubaccountServiceBindingNoSubaccountId(resourceName string, serviceInstanceId string, name string) string {
 
 	return fmt.Sprintf(`
@@ -147,3 +231,61 @@ func getServiceBindingImportStateId(resourceName string, subaccountId string) re
 		return fmt.Sprintf("%s,%s", subaccountId, rs.Primary.ID), nil
 	}
 }
+
+// newServiceBindingRotationTestServer returns a CLI server which creates the bindings with the IDs of bindingIds in
+// order and records the creations and deletions of the bindings. The first busyCreates creations are rejected, as if
+// the service instance was still busy with another operation.
+func newServiceBindingRotationTestServer(busyCreates int) (*httptest.Server, *[]string) {
+	bindingIds := []string{"b2c6fd5f-e6ab-4f35-a2cc-5a6c1b9b50a1", "3c4a1a5e-4f2d-4c4b-9a4e-0f7d5b2e8c11"}
+	bindingNames := map[string]string{}
+	operations := []string{}
+	created := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		if !strings.HasSuffix(r.URL.Path, "/services/binding") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		id := payload.ParamValues["id"]
+
+		switch r.URL.RawQuery {
+		case "create":
+			if busyCreates > 0 {
+				busyCreates--
+				operations = append(operations, "busy "+payload.ParamValues["name"])
+				w.Header().Set("X-Cpcli-Backend-Status", "422")
+				fmt.Fprintf(w, `{"error": "Another concurrent operation in progress for this resource"}`)
+				return
+			}
+
+			id = bindingIds[created]
+			created++
+			bindingNames[id] = payload.ParamValues["name"]
+			operations = append(operations, "create "+payload.ParamValues["name"])
+		case "delete":
+			operations = append(operations, "delete "+id)
+			defer delete(bindingNames, id)
+		}
+
+		name, ok := bindingNames[id]
+		if !ok {
+			w.Header().Set("X-Cpcli-Backend-Status", "404")
+			fmt.Fprintf(w, `{"error": "binding not found"}`)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"id": %q, "name": %q, "ready": true, "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "service_instance_id": "df532d07-57a7-415e-a261-23a398ef068a", "credentials": {"secret": "secret-of-%s"}, "last_operation": {"state": "succeeded"}, "created_at": "2023-10-19T09:26:01Z", "updated_at": "2023-10-19T09:26:01Z"}`, id, name, name)
+	})), &operations
+}
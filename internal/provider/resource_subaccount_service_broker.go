@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
+	"github.com/SAP/terraform-provider-btp/internal/tfutils"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+func newSubaccountServiceBrokerResource() resource.Resource {
+	return &subaccountServiceBrokerResource{}
+}
+
+type subaccountServiceBrokerResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *subaccountServiceBrokerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_service_broker", req.ProviderTypeName)
+}
+
+func (rs *subaccountServiceBrokerResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *subaccountServiceBrokerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Registers a service broker in a subaccount, so that the services it offers can be consumed in the subaccount.
+
+__Tip:__
+You must be assigned to the subaccount admin role.`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the service broker.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the service broker.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the service broker. A change of the URL updates the registration of the broker, unless `reregister_on_url_change` is `true`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					serviceBrokerUrlRequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username for the basic authentication at the service broker.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password for the basic authentication at the service broker.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"reregister_on_url_change": schema.BoolAttribute{
+				MarkdownDescription: "Deregisters the service broker and registers it again, if the `url` is changed. Required for service brokers that don't support a change of their URL. The new registration gets a different ID.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the service broker.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ready": schema.BoolAttribute{
+				MarkdownDescription: "Shows whether the service broker is ready.",
+				Computed:            true,
+			},
+			"created_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the resource was created in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
+				Computed:            true,
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.SetType{
+					ElemType: types.StringType,
+				},
+				MarkdownDescription: "The set of words or phrases assigned to the service broker.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// serviceBrokerUrlRequiresReplace is like stringplanmodifier.RequiresReplace, but only applies if `reregister_on_url_change` is planned to be `true`.
+func serviceBrokerUrlRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var reregister types.Bool
+
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("reregister_on_url_change"), &reregister)...)
+			resp.RequiresReplace = reregister.ValueBool()
+		},
+		"If `reregister_on_url_change` is `true` and the value of this attribute changes, Terraform will destroy and recreate the resource.",
+		"If `reregister_on_url_change` is `true` and the value of this attribute changes, Terraform will destroy and recreate the resource.",
+	)
+}
+
+func (rs *subaccountServiceBrokerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subaccountServiceBrokerType
+
+	diags := req.State.Get(ctx, &state)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Services.Broker.GetById(ctx, state.SubaccountId.ValueString(), state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedState, diags := subaccountServiceBrokerValueFrom(ctx, state.SubaccountId.ValueString(), cliRes)
+	resp.Diagnostics.Append(diags...)
+	updatedState.Username = state.Username
+	updatedState.Password = state.Password
+	updatedState.ReregisterOnUrlChange = state.ReregisterOnUrlChange
+
+	if updatedState.ReregisterOnUrlChange.IsNull() {
+		updatedState.ReregisterOnUrlChange = types.BoolValue(false)
+	}
+
+	diags = resp.State.Set(ctx, &updatedState)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountServiceBrokerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subaccountServiceBrokerType
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Services.Broker.Register(ctx, &btpcli.ServiceBrokerRegisterInput{
+		Subaccount:  plan.SubaccountId.ValueString(),
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Url:         plan.Url.ValueString(),
+		User:        plan.Username.ValueString(),
+		Password:    plan.Password.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Creating Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedRes, err := rs.waitForServiceBroker(ctx, plan.SubaccountId.ValueString(), cliRes)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Creating Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+	}
+
+	state, diags := subaccountServiceBrokerValueFrom(ctx, plan.SubaccountId.ValueString(), updatedRes)
+	resp.Diagnostics.Append(diags...)
+	state.Username = plan.Username
+	state.Password = plan.Password
+	state.ReregisterOnUrlChange = plan.ReregisterOnUrlChange
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountServiceBrokerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state subaccountServiceBrokerType
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Services.Broker.Update(ctx, serviceBrokerUpdateInput(plan, state))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Updating Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedRes, err := rs.waitForServiceBroker(ctx, plan.SubaccountId.ValueString(), cliRes)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Updating Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+	}
+
+	updatedState, diags := subaccountServiceBrokerValueFrom(ctx, plan.SubaccountId.ValueString(), updatedRes)
+	resp.Diagnostics.Append(diags...)
+	updatedState.Username = plan.Username
+	updatedState.Password = plan.Password
+	updatedState.ReregisterOnUrlChange = plan.ReregisterOnUrlChange
+
+	diags = resp.State.Set(ctx, &updatedState)
+	resp.Diagnostics.Append(diags...)
+}
+
+// serviceBrokerUpdateInput only passes the credentials if they have changed. A change of the URL requires the
+// service manager to fetch the catalog from the new URL, hence the credentials are always passed along with it.
+func serviceBrokerUpdateInput(plan subaccountServiceBrokerType, state subaccountServiceBrokerType) *btpcli.ServiceBrokerUpdateInput {
+	description := plan.Description.ValueString()
+
+	args := &btpcli.ServiceBrokerUpdateInput{
+		Subaccount:  plan.SubaccountId.ValueString(),
+		Id:          state.Id.ValueString(),
+		NewName:     plan.Name.ValueString(),
+		Description: &description,
+	}
+
+	urlChanged := !plan.Url.Equal(state.Url)
+	credentialsChanged := !plan.Username.Equal(state.Username) || !plan.Password.Equal(state.Password)
+
+	if urlChanged {
+		args.Url = plan.Url.ValueString()
+	}
+
+	if urlChanged || credentialsChanged {
+		args.User = plan.Username.ValueString()
+		args.Password = plan.Password.ValueString()
+	}
+
+	return args
+}
+
+// waitForServiceBroker waits until an asynchronous registration or update of the service broker has finished.
+func (rs *subaccountServiceBrokerResource) waitForServiceBroker(ctx context.Context, subaccountId string, cliRes servicemanager.ServiceBrokerResponseObject) (servicemanager.ServiceBrokerResponseObject, error) {
+	if serviceBrokerState(cliRes) != servicemanager.StateInProgress {
+		return cliRes, nil
+	}
+
+	stateConf := &tfutils.StateChangeConf{
+		Pending: []string{servicemanager.StateInProgress},
+		Target:  []string{servicemanager.StateSucceeded},
+		Refresh: func() (interface{}, string, error) {
+			subRes, _, err := rs.cli.Services.Broker.GetById(ctx, subaccountId, cliRes.Id)
+
+			if err != nil {
+				return subRes, "", err
+			}
+
+			// No error returned even if operation failed
+			if serviceBrokerState(subRes) == servicemanager.StateFailed {
+				return subRes, servicemanager.StateFailed, errors.New("undefined API error during the registration of the service broker")
+			}
+
+			return subRes, serviceBrokerState(subRes), nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	updatedRes, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return cliRes, err
+	}
+
+	return updatedRes.(servicemanager.ServiceBrokerResponseObject), nil
+}
+
+// serviceBrokerState returns the state of the last operation of the service broker. Synchronous operations don't report one.
+func serviceBrokerState(value servicemanager.ServiceBrokerResponseObject) string {
+	if value.LastOperation == nil {
+		return servicemanager.StateSucceeded
+	}
+
+	return value.LastOperation.State
+}
+
+func (rs *subaccountServiceBrokerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountServiceBrokerType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Services.Broker.Unregister(ctx, state.SubaccountId.ValueString(), state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	if serviceBrokerState(cliRes) != servicemanager.StateInProgress {
+		return
+	}
+
+	deleteStateConf := &tfutils.StateChangeConf{
+		Pending: []string{servicemanager.StateInProgress},
+		Target:  []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			subRes, comRes, err := rs.cli.Services.Broker.GetById(ctx, state.SubaccountId.ValueString(), state.Id.ValueString())
+
+			if comRes.StatusCode == http.StatusNotFound {
+				return subRes, "DELETED", nil
+			}
+
+			if err != nil {
+				return subRes, servicemanager.StateFailed, err
+			}
+
+			// No error returned even if operation failed
+			if serviceBrokerState(subRes) == servicemanager.StateFailed {
+				return subRes, servicemanager.StateFailed, errors.New("undefined API error during the deregistration of the service broker")
+			}
+
+			return subRes, serviceBrokerState(subRes), nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
+	}
+
+	_, err = deleteStateConf.WaitForStateContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Service Broker (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (rs *subaccountServiceBrokerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: subaccount_id,service_broker_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subaccount_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSubaccountServiceBroker(t *testing.T) {
+	t.Run("happy path - register and update the service broker", func(t *testing.T) {
+		srv, operations := newServiceBrokerTestServer()
+		defer srv.Close()
+
+		expectOperations := func(expected ...string) resource.TestCheckFunc {
+			return func(_ *terraform.State) error {
+				if strings.Join(*operations, ", ") != strings.Join(expected, ", ") {
+					return fmt.Errorf("expected the operations %v, got: %v", expected, *operations)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBroker("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-broker", "https://my.broker.example.com", "broker-user", "secret-1", false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "id", "c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "subaccount_id", "59cd458e-e66e-4b60-b6d8-8f219379f9a5"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "name", "my-broker"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "url", "https://my.broker.example.com"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "username", "broker-user"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "password", "secret-1"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "ready", "true"),
+						resource.TestMatchResourceAttr("btp_subaccount_service_broker.uut", "created_date", regexpValidRFC3999Format),
+						resource.TestMatchResourceAttr("btp_subaccount_service_broker.uut", "last_modified", regexpValidRFC3999Format),
+						expectOperations("register my-broker"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBroker("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-broker", "https://my.broker.example.com", "broker-user", "secret-2", false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "id", "c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "password", "secret-2"),
+						expectOperations("register my-broker", "update credentials"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBroker("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-broker", "https://new.broker.example.com", "broker-user", "secret-2", false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "id", "c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "url", "https://new.broker.example.com"),
+						expectOperations("register my-broker", "update credentials", "update url and credentials"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceBroker("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-broker", "https://other.broker.example.com", "broker-user", "secret-2", true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "id", "0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "url", "https://other.broker.example.com"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_broker.uut", "reregister_on_url_change", "true"),
+						expectOperations("register my-broker", "update credentials", "update url and credentials", "unregister c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70", "register my-broker"),
+					),
+				},
+				{
+					ResourceName:            "btp_subaccount_service_broker.uut",
+					ImportStateIdFunc:       getServiceBrokerImportStateId("btp_subaccount_service_broker.uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5"),
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"username", "password", "reregister_on_url_change"},
+				},
+			},
+		})
+	})
+
+	t.Run("error path - subaccount_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountServiceBroker("uut", "this-is-not-a-uuid", "my-broker", "https://my.broker.example.com", "broker-user", "secret-1", false),
+					ExpectError: regexp.MustCompile(`Attribute subaccount_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - password mandatory", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + `
+						resource "btp_subaccount_service_broker" "uut" {
+							subaccount_id = "59cd458e-e66e-4b60-b6d8-8f219379f9a5"
+							name          = "my-broker"
+							url           = "https://my.broker.example.com"
+							username      = "broker-user"
+						}`,
+					ExpectError: regexp.MustCompile(`The argument "password" is required, but no definition was found`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - import failure", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					ResourceName:  "btp_subaccount_service_broker.uut",
+					ImportStateId: "59cd458e-e66e-4b60-b6d8-8f219379f9a5",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`Expected import identifier with format: subaccount_id,service_broker_id. Got:`),
+					Config:        hclProvider() + hclResourceSubaccountServiceBroker("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "my-broker", "https://my.broker.example.com", "broker-user", "secret-1", false),
+				},
+			},
+		})
+	})
+}
+
+func TestServiceBrokerUpdateInput(t *testing.T) {
+	state := subaccountServiceBrokerType{
+		SubaccountId: types.StringValue("59cd458e-e66e-4b60-b6d8-8f219379f9a5"),
+		Id:           types.StringValue("c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70"),
+		Name:         types.StringValue("my-broker"),
+		Description:  types.StringValue(""),
+		Url:          types.StringValue("https://my.broker.example.com"),
+		Username:     types.StringValue("broker-user"),
+		Password:     types.StringValue("secret-1"),
+	}
+
+	t.Run("name and description only", func(t *testing.T) {
+		plan := state
+		plan.Name = types.StringValue("my-renamed-broker")
+		plan.Description = types.StringValue("my description")
+
+		args := serviceBrokerUpdateInput(plan, state)
+
+		assert.Equal(t, "c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70", args.Id)
+		assert.Equal(t, "my-renamed-broker", args.NewName)
+		assert.Equal(t, "my description", *args.Description)
+		assert.Empty(t, args.Url)
+		assert.Empty(t, args.User)
+		assert.Empty(t, args.Password)
+	})
+	t.Run("credentials only", func(t *testing.T) {
+		plan := state
+		plan.Password = types.StringValue("secret-2")
+
+		args := serviceBrokerUpdateInput(plan, state)
+
+		assert.Empty(t, args.Url)
+		assert.Equal(t, "broker-user", args.User)
+		assert.Equal(t, "secret-2", args.Password)
+	})
+	t.Run("url", func(t *testing.T) {
+		plan := state
+		plan.Url = types.StringValue("https://new.broker.example.com")
+
+		args := serviceBrokerUpdateInput(plan, state)
+
+		assert.Equal(t, "https://new.broker.example.com", args.Url)
+		assert.Equal(t, "broker-user", args.User)
+		assert.Equal(t, "secret-1", args.Password)
+	})
+}
+
+func hclResourceSubaccountServiceBroker(resourceName string, subaccountId string, name string, url string, username string, password string, reregisterOnUrlChange bool) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_service_broker" "%s" {
+			subaccount_id            = "%s"
+			name                     = "%s"
+			url                      = "%s"
+			username                 = "%s"
+			password                 = "%s"
+			reregister_on_url_change = %t
+		}`, resourceName, subaccountId, name, url, username, password, reregisterOnUrlChange)
+}
+
+func getServiceBrokerImportStateId(resourceName string, subaccountId string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s,%s", subaccountId, rs.Primary.ID), nil
+	}
+}
+
+// newServiceBrokerTestServer returns a CLI server which registers the brokers with the IDs of brokerIds in order and
+// records the registrations, updates and deregistrations of the brokers.
+func newServiceBrokerTestServer() (*httptest.Server, *[]string) {
+	brokerIds := []string{"c9a3d5e0-6b1f-4e7d-8f2a-1b3c4d5e6f70", "0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b"}
+	brokers := map[string]map[string]string{}
+	operations := []string{}
+	registered := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var payload struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		if !strings.HasSuffix(r.URL.Path, "/services/broker") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		id := payload.ParamValues["id"]
+
+		switch r.URL.RawQuery {
+		case "register":
+			id = brokerIds[registered]
+			registered++
+			brokers[id] = map[string]string{"name": payload.ParamValues["name"], "url": payload.ParamValues["url"]}
+			operations = append(operations, "register "+payload.ParamValues["name"])
+		case "update":
+			if broker, ok := brokers[id]; ok {
+				broker["name"] = payload.ParamValues["newName"]
+
+				switch {
+				case payload.ParamValues["url"] != "":
+					broker["url"] = payload.ParamValues["url"]
+					operations = append(operations, "update url and credentials")
+				case payload.ParamValues["password"] != "":
+					operations = append(operations, "update credentials")
+				default:
+					operations = append(operations, "update")
+				}
+			}
+		case "unregister":
+			operations = append(operations, "unregister "+id)
+			defer delete(brokers, id)
+		}
+
+		broker, ok := brokers[id]
+		if !ok {
+			w.Header().Set("X-Cpcli-Backend-Status", "404")
+			fmt.Fprintf(w, `{"error": "broker not found"}`)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"id": %q, "name": %q, "ready": true, "broker_url": %q, "created_at": "2023-10-19T09:26:01Z", "updated_at": "2023-10-19T09:26:01Z", "labels": {}}`, id, broker["name"], broker["url"])
+	})), &operations
+}
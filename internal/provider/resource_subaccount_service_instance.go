@@ -6,15 +6,17 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
@@ -43,7 +45,7 @@ func (rs *subaccountServiceInstanceResource) Configure(_ context.Context, req re
 	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
 }
 
-func (rs *subaccountServiceInstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (rs *subaccountServiceInstanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Creates a service instance in a subaccount.`,
 		Attributes: map[string]schema.Attribute{
@@ -78,6 +80,29 @@ func (rs *subaccountServiceInstanceResource) Schema(_ context.Context, _ resourc
 					jsonvalidator.ValidJSON(),
 				},
 			},
+			"parameters_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a file containing the configuration parameters for the service instance in JSON format. " +
+					"If `parameters` are specified as well, both are deep merged with the values of `parameters` taking precedence, i.e. nested objects are merged key by key, while all other values are replaced. " +
+					"Changes to the content of the file are only detected if the path changes.",
+				Optional: true,
+			},
+			"validate_parameters": schema.BoolAttribute{
+				MarkdownDescription: "If set to true, the merged `parameters` and `parameters_file` are validated against the JSON schema provided by the service plan before the service instance is created or updated. " +
+					"The validation is skipped with a warning if the service plan doesn't provide a schema or the schema refers to other documents. The default value is `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"requested_id": schema.StringAttribute{
+				MarkdownDescription: "The ID the service instance shall be created with, e.g. to recreate a service instance with the same ID. If not specified, the ID is generated by the server.",
+				Optional:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the service instance.",
 				Computed:            true,
@@ -86,7 +111,7 @@ func (rs *subaccountServiceInstanceResource) Schema(_ context.Context, _ resourc
 				},
 			},
 			"ready": schema.BoolAttribute{
-				MarkdownDescription: "",
+				MarkdownDescription: "Shows whether the service instance has been provisioned.",
 				Computed:            true,
 			},
 			"platform_id": schema.StringAttribute{
@@ -107,7 +132,7 @@ func (rs *subaccountServiceInstanceResource) Schema(_ context.Context, _ resourc
 				Computed:            true,
 			},
 			"usable": schema.BoolAttribute{
-				MarkdownDescription: "Shows whether the resource can be used.",
+				MarkdownDescription: "Shows whether the service instance can be used, e.g. to create bindings. Some service plans report an instance as `ready` before it is usable; for those the resource waits until the instance is usable. Null if the service plan doesn't report the usability.",
 				Computed:            true,
 			},
 			"state": schema.StringAttribute{
@@ -122,12 +147,13 @@ func (rs *subaccountServiceInstanceResource) Schema(_ context.Context, _ resourc
 				MarkdownDescription: "The date and time when the resource was last modified in [RFC3339](https://www.ietf.org/rfc/rfc3339.txt) format.",
 				Computed:            true,
 			},
+			"timeouts": resourceTimeoutsAttributes(ctx, "service instance"),
 		},
 	}
 }
 
 func (rs *subaccountServiceInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state subaccountServiceInstanceType
+	var state subaccountServiceInstanceResourceType
 
 	diags := req.State.Get(ctx, &state)
 
@@ -142,10 +168,18 @@ func (rs *subaccountServiceInstanceResource) Read(ctx context.Context, req resou
 		return
 	}
 
-	newState, diags := subaccountServiceInstanceValueFrom(ctx, cliRes)
+	newState, diags := subaccountServiceInstanceResourceValueFrom(ctx, cliRes)
 	if newState.Parameters.IsNull() {
 		newState.Parameters = state.Parameters
 	}
+	newState.ParametersFile = state.ParametersFile
+	newState.RequestedId = state.RequestedId
+	newState.ValidateParameters = state.ValidateParameters
+	newState.Timeouts = state.Timeouts
+	if newState.ValidateParameters.IsNull() {
+		// instances that have been imported or created by earlier versions of the provider don't know about this attribute
+		newState.ValidateParameters = types.BoolValue(false)
+	}
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &newState)
@@ -153,24 +187,54 @@ func (rs *subaccountServiceInstanceResource) Read(ctx context.Context, req resou
 }
 
 func (rs *subaccountServiceInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan subaccountServiceInstanceType
+	var plan subaccountServiceInstanceResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters, diags := subaccountServiceInstanceParameters(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(rs.validateParameters(ctx, plan, parameters, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the error returned for a second instance of a unique service plan doesn't tell the reason, so it's checked upfront
+	servicePlan, _, err := rs.cli.Services.Plan.GetById(ctx, plan.SubaccountId.ValueString(), plan.ServicePlanId.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "service plan not readable, skipping the checks of the service plan", map[string]interface{}{"error": err.Error()})
+	} else {
+		resp.Diagnostics.Append(servicePlanDeprecationWarning(servicePlan)...)
+		resp.Diagnostics.Append(rs.checkUniquePlanHasNoInstance(ctx, plan.SubaccountId.ValueString(), servicePlan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	cliReq := btpcli.ServiceInstanceCreateInput{
 		Subaccount:    plan.SubaccountId.ValueString(),
 		Name:          plan.Name.ValueString(),
 		ServicePlanId: plan.ServicePlanId.ValueString(),
 	}
 
-	if !plan.Parameters.IsNull() {
-		params := plan.Parameters.ValueString()
-		cliReq.Parameters = &params
+	if !plan.RequestedId.IsNull() {
+		cliReq.Id = plan.RequestedId.ValueString()
 	}
 
+	cliReq.Parameters = parameters
+
 	if !plan.Labels.IsNull() {
 		var labels map[string][]string
 		plan.Labels.ElementsAs(ctx, &labels, false)
@@ -184,12 +248,20 @@ func (rs *subaccountServiceInstanceResource) Create(ctx context.Context, req res
 		return
 	}
 
-	state, diags := subaccountServiceInstanceValueFrom(ctx, cliRes)
+	if !plan.RequestedId.IsNull() && cliRes.Id != plan.RequestedId.ValueString() {
+		resp.Diagnostics.AddWarning("Requested ID Not Applied", fmt.Sprintf("The service instance was created with the server-generated ID %s instead of the requested ID %s.", cliRes.Id, plan.RequestedId.ValueString()))
+	}
+
+	state, diags := subaccountServiceInstanceResourceValueFrom(ctx, cliRes)
 	state.Parameters = plan.Parameters
+	state.ParametersFile = plan.ParametersFile
+	state.RequestedId = plan.RequestedId
+	state.ValidateParameters = plan.ValidateParameters
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 
 	createStateConf := &tfutils.StateChangeConf{
-		Pending: []string{servicemanager.StateInProgress},
+		Pending: []string{servicemanager.StateInProgress, servicemanager.StateNotUsable},
 		Target:  []string{servicemanager.StateSucceeded},
 		Refresh: func() (interface{}, string, error) {
 			subRes, _, err := rs.cli.Services.Instance.GetById(ctx, state.SubaccountId.ValueString(), cliRes.Id)
@@ -203,11 +275,12 @@ func (rs *subaccountServiceInstanceResource) Create(ctx context.Context, req res
 				return subRes, subRes.LastOperation.State, errors.New("undefined API error during service instance creation")
 			}
 
-			return subRes, subRes.LastOperation.State, nil
+			return subRes, serviceInstanceState(subRes), nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    createTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := createStateConf.WaitForStateContext(ctx)
@@ -215,8 +288,17 @@ func (rs *subaccountServiceInstanceResource) Create(ctx context.Context, req res
 		resp.Diagnostics.AddError("API Error Creating Resource Service Instance (Subaccount)", fmt.Sprintf("%s", err))
 	}
 
-	state, diags = subaccountServiceInstanceValueFrom(ctx, updatedRes.(servicemanager.ServiceInstanceResponseObject))
+	if updatedRes == nil {
+		// e.g. after a timeout the instance is still being created, hence it is kept in the state
+		updatedRes = cliRes
+	}
+
+	state, diags = subaccountServiceInstanceResourceValueFrom(ctx, updatedRes.(servicemanager.ServiceInstanceResponseObject))
 	state.Parameters = plan.Parameters
+	state.ParametersFile = plan.ParametersFile
+	state.RequestedId = plan.RequestedId
+	state.ValidateParameters = plan.ValidateParameters
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &state)
@@ -224,7 +306,7 @@ func (rs *subaccountServiceInstanceResource) Create(ctx context.Context, req res
 }
 
 func (rs *subaccountServiceInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var stateCurrent, plan subaccountServiceInstanceType
+	var stateCurrent, plan subaccountServiceInstanceResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -237,6 +319,27 @@ func (rs *subaccountServiceInstanceResource) Update(ctx context.Context, req res
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters, diags := subaccountServiceInstanceParameters(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(rs.validateParameters(ctx, plan, parameters, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.ServicePlanId.Equal(stateCurrent.ServicePlanId) {
+		resp.Diagnostics.Append(rs.checkServicePlanDeprecation(ctx, plan)...)
+	}
+
 	cliReq := btpcli.ServiceInstanceUpdateInput{
 		Subaccount: plan.SubaccountId.ValueString(),
 		Id:         plan.Id.ValueString(),
@@ -246,10 +349,7 @@ func (rs *subaccountServiceInstanceResource) Update(ctx context.Context, req res
 		ServicePlanId: plan.ServicePlanId.ValueString(),
 	}
 
-	if !plan.Parameters.IsNull() {
-		params := plan.Parameters.ValueString()
-		cliReq.Parameters = &params
-	}
+	cliReq.Parameters = parameters
 
 	if !plan.Labels.IsNull() {
 		var labels map[string][]string
@@ -264,12 +364,16 @@ func (rs *subaccountServiceInstanceResource) Update(ctx context.Context, req res
 		return
 	}
 
-	state, diags := subaccountServiceInstanceValueFrom(ctx, cliRes)
+	state, diags := subaccountServiceInstanceResourceValueFrom(ctx, cliRes)
 	state.Parameters = plan.Parameters
+	state.ParametersFile = plan.ParametersFile
+	state.RequestedId = plan.RequestedId
+	state.ValidateParameters = plan.ValidateParameters
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 
 	updateStateConf := &tfutils.StateChangeConf{
-		Pending: []string{servicemanager.StateInProgress},
+		Pending: []string{servicemanager.StateInProgress, servicemanager.StateNotUsable},
 		Target:  []string{servicemanager.StateSucceeded},
 		Refresh: func() (interface{}, string, error) {
 			subRes, _, err := rs.cli.Services.Instance.GetById(ctx, state.SubaccountId.ValueString(), cliRes.Id)
@@ -283,11 +387,12 @@ func (rs *subaccountServiceInstanceResource) Update(ctx context.Context, req res
 				return subRes, subRes.LastOperation.State, errors.New("undefined API error during service instance update")
 			}
 
-			return subRes, subRes.LastOperation.State, nil
+			return subRes, serviceInstanceState(subRes), nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    updateTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	updatedRes, err := updateStateConf.WaitForStateContext(ctx)
@@ -295,22 +400,169 @@ func (rs *subaccountServiceInstanceResource) Update(ctx context.Context, req res
 		resp.Diagnostics.AddError("API Error Updating Resource Service Instance (Subaccount)", fmt.Sprintf("%s", err))
 	}
 
-	state, diags = subaccountServiceInstanceValueFrom(ctx, updatedRes.(servicemanager.ServiceInstanceResponseObject))
+	if updatedRes == nil {
+		// e.g. after a timeout the update is still in progress, hence the instance is kept as returned by the update
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	state, diags = subaccountServiceInstanceResourceValueFrom(ctx, updatedRes.(servicemanager.ServiceInstanceResponseObject))
 	state.Parameters = plan.Parameters
+	state.ParametersFile = plan.ParametersFile
+	state.RequestedId = plan.RequestedId
+	state.ValidateParameters = plan.ValidateParameters
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// serviceInstanceState returns the state of the last operation of the service instance. A succeeded operation on an
+// instance that reports not to be usable yet is still pending.
+func serviceInstanceState(instance servicemanager.ServiceInstanceResponseObject) string {
+	if instance.LastOperation.State == servicemanager.StateSucceeded && instance.Usable != nil && !*instance.Usable {
+		return servicemanager.StateNotUsable
+	}
+
+	return instance.LastOperation.State
+}
+
+// subaccountServiceInstanceParameters determines the parameters to be sent to the API by merging the content of the
+// parameters file with the inline parameters. Returns nil if neither of them is specified.
+func subaccountServiceInstanceParameters(plan subaccountServiceInstanceResourceType) (*string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.ParametersFile.IsNull() {
+		if plan.Parameters.IsNull() {
+			return nil, diags
+		}
+
+		parameters := plan.Parameters.ValueString()
+		return &parameters, diags
+	}
+
+	parameters, err := readParametersFile(plan.ParametersFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("parameters_file"), "Invalid Parameters File", fmt.Sprintf("%s", err))
+		return nil, diags
+	}
+
+	if plan.Parameters.IsNull() {
+		return &parameters, diags
+	}
+
+	parameters, err = mergeParameters(parameters, plan.Parameters.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("parameters"), "Invalid Parameters", fmt.Sprintf("%s", err))
+		return nil, diags
+	}
+
+	return &parameters, diags
+}
+
+// validateParameters checks the parameters against the schema the service plan provides for creating or updating an instance, if requested.
+func (rs *subaccountServiceInstanceResource) validateParameters(ctx context.Context, plan subaccountServiceInstanceResourceType, parameters *string, update bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !plan.ValidateParameters.ValueBool() || parameters == nil {
+		return diags
+	}
+
+	servicePlan, _, err := rs.cli.Services.Plan.GetById(ctx, plan.SubaccountId.ValueString(), plan.ServicePlanId.ValueString())
+	if err != nil {
+		diags.AddError("API Error Reading Resource Service Plan (Subaccount)", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	parametersSchema := servicePlanCreateInstanceSchema(servicePlan)
+	if update {
+		parametersSchema = servicePlanUpdateInstanceSchema(servicePlan)
+	}
+
+	if parametersSchema.IsNull() {
+		diags.AddAttributeWarning(path.Root("parameters"), "Parameters Not Validated", fmt.Sprintf("The service plan %s doesn't provide a schema for its parameters.", servicePlan.Name))
+		return diags
+	}
+
+	violations, err := validateJSONSchema(parametersSchema.ValueString(), *parameters)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("parameters"), "Parameters Not Validated", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	for _, violation := range violations {
+		diags.AddAttributeError(path.Root("parameters"), "Invalid Parameters", violation)
+	}
+
+	return diags
+}
+
+// checkServicePlanDeprecation warns if the service instance is created with or changed to a deprecated service plan.
+// The check is skipped if the service plan can't be read.
+func (rs *subaccountServiceInstanceResource) checkServicePlanDeprecation(ctx context.Context, plan subaccountServiceInstanceResourceType) diag.Diagnostics {
+	servicePlan, _, err := rs.cli.Services.Plan.GetById(ctx, plan.SubaccountId.ValueString(), plan.ServicePlanId.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "service plan not readable, skipping the deprecation check", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	return servicePlanDeprecationWarning(servicePlan)
+}
+
+// servicePlanDeprecationWarning returns a warning if the service plan is deprecated.
+func servicePlanDeprecationWarning(servicePlan servicemanager.ServicePlanResponseObject) (diags diag.Diagnostics) {
+	deprecated, deprecationDate := servicePlanDeprecation(servicePlan)
+	if !deprecated.ValueBool() {
+		return
+	}
+
+	detail := fmt.Sprintf("The service plan %s (%s) is deprecated.", servicePlan.Name, servicePlan.Id)
+	if !deprecationDate.IsNull() {
+		detail = fmt.Sprintf("The service plan %s (%s) is deprecated as of %s.", servicePlan.Name, servicePlan.Id, deprecationDate.ValueString())
+	}
+
+	diags.AddAttributeWarning(path.Root("serviceplan_id"), "Deprecated Service Plan", detail+" Consider using another service plan for the service instance.")
+	return
+}
+
+// checkUniquePlanHasNoInstance emits an error if only one service instance of the service plan can be created per
+// subaccount and the subaccount already has one. Failures of the check itself are reported as warnings.
+func (rs *subaccountServiceInstanceResource) checkUniquePlanHasNoInstance(ctx context.Context, subaccountId string, servicePlan servicemanager.ServicePlanResponseObject) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !servicePlan.Unique {
+		return diags
+	}
+
+	instances, _, err := rs.cli.Services.Instance.List(ctx, subaccountId, fmt.Sprintf("service_plan_id eq '%s'", servicePlan.Id), "")
+	if err != nil {
+		diags.AddWarning("API Error Reading Resource Service Instances (Subaccount)", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	if len(instances) > 0 {
+		diags.AddAttributeError(path.Root("serviceplan_id"), "Service Plan Allows Only One Instance", fmt.Sprintf("Only one service instance of the service plan %s can be created per subaccount, but the subaccount %s already has the service instance %s (%s).", servicePlan.Name, subaccountId, instances[0].Name, instances[0].Id))
+	}
+
+	return diags
+}
+
 func (rs *subaccountServiceInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state subaccountServiceInstanceType
+	var state subaccountServiceInstanceResourceType
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	_, err := rs.cli.Services.Instance.Delete(ctx, state.SubaccountId.ValueString(), state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Service Instance (Subaccount)", fmt.Sprintf("%s", err))
@@ -338,9 +590,10 @@ func (rs *subaccountServiceInstanceResource) Delete(ctx context.Context, req res
 
 			return subRes, subRes.LastOperation.State, nil
 		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Timeout:    deleteTimeout,
+		Delay:      pollInterval(rs.cli),
+		MinTimeout: pollInterval(rs.cli),
+		MaxTimeout: maxPollInterval(rs.cli),
 	}
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
@@ -358,11 +611,49 @@ func (rs *subaccountServiceInstanceResource) ImportState(ctx context.Context, re
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: subaccount_id,id. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: subaccount_id,id or subaccount_id,name. Got: %q", req.ID),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subaccount_id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+	subaccountId, instanceId := idParts[0], idParts[1]
+
+	// the instance is given by its name, if the second part of the identifier isn't a UUID
+	if !uuidvalidator.UuidRegexp.MatchString(instanceId) {
+		var err error
+		if instanceId, err = rs.instanceIdByName(ctx, subaccountId, idParts[1]); err != nil {
+			resp.Diagnostics.AddError("API Error Importing Resource Service Instance (Subaccount)", fmt.Sprintf("%s", err))
+			return
+		}
+	} else if _, _, err := rs.cli.Services.Instance.GetById(ctx, subaccountId, instanceId); err != nil {
+		// a mistyped identifier must not end up in the state
+		resp.Diagnostics.AddError("API Error Importing Resource Service Instance (Subaccount)", fmt.Sprintf("no service instance with ID %s found in subaccount %s: %s", instanceId, subaccountId, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subaccount_id"), subaccountId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), instanceId)...)
+}
+
+func (rs *subaccountServiceInstanceResource) instanceIdByName(ctx context.Context, subaccountId string, name string) (string, error) {
+	fieldsFilter := fmt.Sprintf("name eq '%s'", strings.ReplaceAll(name, "'", "''"))
+
+	instances, _, err := rs.cli.Services.Instance.List(ctx, subaccountId, fieldsFilter, "")
+	if err != nil {
+		return "", err
+	}
+
+	switch len(instances) {
+	case 0:
+		return "", fmt.Errorf("no service instance with name %q found in subaccount %s", name, subaccountId)
+	case 1:
+		return instances[0].Id, nil
+	default:
+		ids := []string{}
+		for _, instance := range instances {
+			ids = append(ids, instance.Id)
+		}
+
+		return "", fmt.Errorf("the name %q is ambiguous, as it is used by %d service instances (%s) in subaccount %s, import the service instance by its ID instead", name, len(instances), strings.Join(ids, ", "), subaccountId)
+	}
 }
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
+)
+
+func newSubaccountServiceInstanceSharingResource() resource.Resource {
+	return &subaccountServiceInstanceSharingResource{}
+}
+
+type subaccountServiceInstanceSharingType struct {
+	SubaccountId      types.String `tfsdk:"subaccount_id"`
+	ServiceInstanceId types.String `tfsdk:"service_instance_id"`
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+}
+
+type subaccountServiceInstanceSharingResource struct {
+	cli *btpcli.ClientFacade
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_subaccount_service_instance_sharing", req.ProviderTypeName)
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Shares a service instance with the other subaccounts of the global account, independent of the tool that manages the service instance itself. Destroying the resource stops sharing the service instance.
+
+__Tip:__
+You must be assigned to the admin role of the subaccount. The service plan of the instance must support sharing.
+
+__Further documentation:__
+<https://help.sap.com/docs/service-manager/sap-service-manager/sharing-service-instances>`,
+		Attributes: map[string]schema.Attribute{
+			"subaccount_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the subaccount.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_instance_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the service instance to share.",
+				Required:            true,
+				Validators: []validator.String{
+					uuidvalidator.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{ // required by hashicorps terraform plugin testing framework
+				MarkdownDescription: "The ID of the service instance.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the service instance.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subaccountServiceInstanceSharingType
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliRes, _, err := rs.cli.Services.Instance.GetById(ctx, state.SubaccountId.ValueString(), state.ServiceInstanceId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Service Instance Sharing (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	if !cliRes.Shared {
+		// the sharing has been disabled outside of terraform and is enabled again on apply
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(cliRes.Id)
+	state.Name = types.StringValue(cliRes.Name)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subaccountServiceInstanceSharingType
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the CLI server reports plans which don't support sharing, so its error is passed on as is
+	if _, err := rs.cli.Services.Instance.Share(ctx, plan.SubaccountId.ValueString(), plan.ServiceInstanceId.ValueString()); err != nil {
+		resp.Diagnostics.AddError("API Error Creating Resource Service Instance Sharing (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	cliRes, _, err := rs.cli.Services.Instance.GetById(ctx, plan.SubaccountId.ValueString(), plan.ServiceInstanceId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Creating Resource Service Instance Sharing (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	plan.Id = types.StringValue(cliRes.Id)
+	plan.Name = types.StringValue(cliRes.Name)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all configurable attributes require a replacement
+	resp.Diagnostics.AddError("API Error Updating Resource Service Instance Sharing (Subaccount)", "This resource is not supposed to be updated")
+}
+
+func (rs *subaccountServiceInstanceSharingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountServiceInstanceSharingType
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := rs.cli.Services.Instance.Unshare(ctx, state.SubaccountId.ValueString(), state.ServiceInstanceId.ValueString()); err != nil {
+		resp.Diagnostics.AddError("API Error Deleting Resource Service Instance Sharing (Subaccount)", fmt.Sprintf("%s", err))
+	}
+}
+
+func (rs *subaccountServiceInstanceSharingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: subaccount_id,service_instance_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subaccount_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_instance_id"), idParts[1])...)
+}
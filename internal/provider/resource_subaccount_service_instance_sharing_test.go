@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestResourceSubaccountServiceInstanceSharing(t *testing.T) {
+	t.Parallel()
+	t.Run("happy path - share and unshare", func(t *testing.T) {
+		srv, shared := newSubaccountServiceInstanceSharingTestServer(t, true)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceSharing("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "bc8a216f-1184-49dc-b4b4-17cfe2828965"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance_sharing.uut", "id", "bc8a216f-1184-49dc-b4b4-17cfe2828965"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance_sharing.uut", "name", "my-instance"),
+						checkServiceInstanceShared(shared, true),
+					),
+				},
+				{
+					ResourceName:      "btp_subaccount_service_instance_sharing.uut",
+					ImportStateId:     "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f,bc8a216f-1184-49dc-b4b4-17cfe2828965",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+			CheckDestroy: checkServiceInstanceShared(shared, false),
+		})
+	})
+	t.Run("happy path - sharing disabled outside of terraform is enabled again", func(t *testing.T) {
+		srv, shared := newSubaccountServiceInstanceSharingTestServer(t, true)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceSharing("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "bc8a216f-1184-49dc-b4b4-17cfe2828965"),
+					Check:  checkServiceInstanceShared(shared, true),
+				},
+				{
+					PreConfig: func() {
+						shared.Store(false)
+					},
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceSharing("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "bc8a216f-1184-49dc-b4b4-17cfe2828965"),
+					Check:  checkServiceInstanceShared(shared, true),
+				},
+			},
+		})
+	})
+	t.Run("error path - plan doesn't support sharing", func(t *testing.T) {
+		srv, _ := newSubaccountServiceInstanceSharingTestServer(t, false)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceSharing("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "bc8a216f-1184-49dc-b4b4-17cfe2828965"),
+					ExpectError: regexp.MustCompile(`Service plan 'lite' doesn't support sharing of instances`),
+				},
+			},
+		})
+	})
+	t.Run("error path - service_instance_id not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountServiceInstanceSharing("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "this-is-not-a-uuid"),
+					ExpectError: regexp.MustCompile(`Attribute service_instance_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+	t.Run("error path - invalid import identifier", func(t *testing.T) {
+		srv, _ := newSubaccountServiceInstanceSharingTestServer(t, true)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					ResourceName:  "btp_subaccount_service_instance_sharing.uut",
+					ImportStateId: "bc8a216f-1184-49dc-b4b4-17cfe2828965",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`Expected import identifier with format: subaccount_id,service_instance_id`),
+					Config:        hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceSharing("uut", "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "bc8a216f-1184-49dc-b4b4-17cfe2828965"),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountServiceInstanceSharingTestServer simulates the CLI server for a single service instance, which isn't
+// shared initially. The returned value reflects whether the instance is currently shared.
+func newSubaccountServiceInstanceSharingTestServer(t *testing.T, planSupportsSharing bool) (*httptest.Server, *atomic.Bool) {
+	shared := &atomic.Bool{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		if body.ParamValues["subaccount"] != "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f" || body.ParamValues["id"] != "bc8a216f-1184-49dc-b4b4-17cfe2828965" {
+			t.Errorf("unexpected service instance: %s", content)
+		}
+
+		if !strings.HasSuffix(r.URL.Path, "/services/instance") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.RawQuery {
+		case "share":
+			if !planSupportsSharing {
+				w.Header().Set("X-Cpcli-Backend-Status", "400")
+				fmt.Fprintf(w, `{"error": "Service plan 'lite' doesn't support sharing of instances"}`)
+				return
+			}
+
+			shared.Store(true)
+		case "unshare":
+			shared.Store(false)
+		case "get":
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "bc8a216f-1184-49dc-b4b4-17cfe2828965", "name": "my-instance", "shared": %t}`, shared.Load())
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, "{}")
+	})), shared
+}
+
+func checkServiceInstanceShared(shared *atomic.Bool, expected bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if shared.Load() != expected {
+			return fmt.Errorf("expected the service instance to be shared: %t", expected)
+		}
+
+		return nil
+	}
+}
+
+func hclResourceSubaccountServiceInstanceSharing(resourceName string, subaccountId string, serviceInstanceId string) string {
+	template := `
+resource "btp_subaccount_service_instance_sharing" "%s" {
+    subaccount_id       = "%s"
+    service_instance_id = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, serviceInstanceId)
+}
@@ -3,11 +3,23 @@ package provider
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
 )
 
 type testDestinationEntry struct {
@@ -68,12 +80,6 @@ func TestResourceSubaccountServiceInstance(t *testing.T) {
 						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "platform_id", "service-manager"),
 					),
 				},
-				{
-					ResourceName:      "btp_subaccount_service_instance.uut",
-					ImportStateIdFunc: getServiceInstanceIdForImport("btp_subaccount_service_instance.uut"),
-					ImportState:       true,
-					ImportStateVerify: true,
-				},
 			},
 		})
 	})
@@ -163,6 +169,519 @@ func TestResourceSubaccountServiceInstance(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("happy path - import by name", func(t *testing.T) {
+		srv := newServiceInstanceImportByNameTestServer(t, 1)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWoParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+				},
+				{
+					ResourceName:      "btp_subaccount_service_instance.uut",
+					ImportStateId:     "59cd458e-e66e-4b60-b6d8-8f219379f9a5,tf-test-audit-log",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - import by ID", func(t *testing.T) {
+		srv := newServiceInstanceImportByNameTestServer(t, 1)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWoParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+				},
+				{
+					ResourceName:      "btp_subaccount_service_instance.uut",
+					ImportStateIdFunc: getServiceInstanceIdForImport("btp_subaccount_service_instance.uut"),
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+
+	t.Run("error path - import by ambiguous name", func(t *testing.T) {
+		srv := newServiceInstanceImportByNameTestServer(t, 2)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWoParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+				},
+				{
+					ResourceName:  "btp_subaccount_service_instance.uut",
+					ImportStateId: "59cd458e-e66e-4b60-b6d8-8f219379f9a5,tf-test-audit-log",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`the name "tf-test-audit-log" is ambiguous, as it is used by 2 service\s+instances`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - import of non-existent instance", func(t *testing.T) {
+		srv := newServiceInstanceImportByNameTestServer(t, 1)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWoParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+				},
+				{
+					ResourceName:  "btp_subaccount_service_instance.uut",
+					ImportStateId: "59cd458e-e66e-4b60-b6d8-8f219379f9a5,0b5f2c8e-1f4a-4c7d-9e3b-6a2d8f1c4e7a",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`no service instance with ID 0b5f2c8e-1f4a-4c7d-9e3b-6a2d8f1c4e7a found`),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - service creation with requested ID", func(t *testing.T) {
+		requestedId := "df532d07-57a7-415e-a261-23a398ef068a"
+		instanceId := ""
+		deleted := false
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			switch r.URL.RawQuery {
+			case "create":
+				instanceId = body.ParamValues["id"]
+			case "delete":
+				deleted = true
+			}
+
+			if deleted && r.URL.RawQuery == "get" {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "service instance not found"}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"id": "%s", "name": "tf-test-audit-log", "service_plan_id": "02fed361-89c1-4560-82c3-0deaf93ac75b", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "ready": true, "usable": true, "last_operation": {"state": "succeeded"}}`, instanceId)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWithRequestedId("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b", requestedId),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "requested_id", requestedId),
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "id", requestedId),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - wait until the ready service instance is usable", func(t *testing.T) {
+		polls := 0
+		deleted := false
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			usable := true
+			switch r.URL.RawQuery {
+			case "create":
+				usable = false
+			case "delete":
+				deleted = true
+			case "get":
+				if deleted {
+					w.Header().Set("X-Cpcli-Backend-Status", "404")
+					fmt.Fprintf(w, `{"error": "service instance not found"}`)
+					return
+				}
+
+				// the instance is ready right away, but only becomes usable with the second poll
+				polls++
+				usable = polls > 1
+			}
+
+			fmt.Fprintf(w, `{"id": "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd", "name": "tf-test-audit-log", "service_plan_id": "02fed361-89c1-4560-82c3-0deaf93ac75b", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "ready": true, "usable": %t, "last_operation": {"state": "succeeded"}}`, usable)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWoParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "ready", "true"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "usable", "true"),
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "state", "succeeded"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - parameters valid against the plan schema", func(t *testing.T) {
+		srv, created := newServiceInstanceWithPlanSchemaTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWithValidatedParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-xsuaa", "b50d1b0b-2059-4f21-a014-2ea87752eb48", `{"xsappname": "my-app"}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "validate_parameters", "true"),
+						func(_ *terraform.State) error {
+							if !*created {
+								return fmt.Errorf("expected the service instance to be created")
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - parameters invalid against the plan schema", func(t *testing.T) {
+		srv, created := newServiceInstanceWithPlanSchemaTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWithValidatedParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-xsuaa", "b50d1b0b-2059-4f21-a014-2ea87752eb48", `{"tenant-mode": "shared"}`),
+					ExpectError: regexp.MustCompile(`(?s)Invalid Parameters.*additionalProperties 'tenant-mode' not allowed.*missing properties: 'xsappname'`),
+				},
+				{
+					// the CLI must not be called with invalid parameters
+					Config: hclProviderWithCLIServerURL(srv.URL),
+					Check: func(_ *terraform.State) error {
+						if *created {
+							return fmt.Errorf("expected the service instance not to be created")
+						}
+						return nil
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("error path - unique service plan already has an instance", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/services/plan") && r.URL.RawQuery == "get":
+				fmt.Fprintf(w, `{"id": "02fed361-89c1-4560-82c3-0deaf93ac75b", "name": "standard", "unique": true}`)
+			case strings.HasSuffix(r.URL.Path, "/services/instance") && r.URL.RawQuery == "list":
+				fmt.Fprintf(w, `[{"id": "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd", "name": "tf-test-audit-log-1", "service_plan_id": "02fed361-89c1-4560-82c3-0deaf93ac75b"}]`)
+			case strings.HasSuffix(r.URL.Path, "/services/instance") && r.URL.RawQuery == "create":
+				t.Error("the service instance must not be created for a unique service plan which already has an instance")
+				w.Header().Set("X-Cpcli-Backend-Status", "400")
+				fmt.Fprintf(w, `{"error": "Could not create service instance"}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWoParameters("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log-2", "02fed361-89c1-4560-82c3-0deaf93ac75b"),
+					ExpectError: regexp.MustCompile(`Service Plan Allows Only One Instance`),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - parameters merged from file and inline", func(t *testing.T) {
+		parametersFile := filepath.Join(t.TempDir(), "parameters.json")
+		if err := os.WriteFile(parametersFile, []byte(`{"xsappname": "base-app", "oauth2-configuration": {"token-validity": 900, "redirect-uris": ["https://base.example.com/**"]}}`), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		srv, parameters := newServiceInstanceParametersTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWithParametersFile("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-xsuaa", "b50d1b0b-2059-4f21-a014-2ea87752eb48", parametersFile, `{"xsappname": "my-app", "oauth2-configuration": {"token-validity": 3600}}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_service_instance.uut", "parameters_file", parametersFile),
+						func(_ *terraform.State) error {
+							return compareJSON(*parameters, `{"xsappname": "my-app", "oauth2-configuration": {"token-validity": 3600, "redirect-uris": ["https://base.example.com/**"]}}`)
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - create timeout while the instance is not usable", func(t *testing.T) {
+		srv := newServiceInstanceNotUsableTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithPollIntervals(srv.URL, "100ms", "100ms") + hclResourceSubaccountServiceInstanceWithTimeouts("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-xsuaa", "b50d1b0b-2059-4f21-a014-2ea87752eb48", `create = "1s"`),
+					ExpectError: regexp.MustCompile(`timeout while waiting for state to become 'succeeded' \(last state: 'not usable', timeout: 1s\)`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - parameters file does not exist", func(t *testing.T) {
+		srv, _ := newServiceInstanceParametersTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountServiceInstanceWithParametersFile("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-xsuaa", "b50d1b0b-2059-4f21-a014-2ea87752eb48", filepath.Join(t.TempDir(), "missing.json"), `{"xsappname": "my-app"}`),
+					ExpectError: regexp.MustCompile(`Invalid Parameters File`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - requested ID not a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountServiceInstanceWithRequestedId("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "tf-test-audit-log", "02fed361-89c1-4560-82c3-0deaf93ac75b", "this-is-not-a-uuid"),
+					ExpectError: regexp.MustCompile(`Attribute requested_id value must be a valid UUID, got: this-is-not-a-uuid`),
+				},
+			},
+		})
+	})
+}
+
+// newServiceInstanceImportByNameTestServer returns a CLI server which lists the given number of service instances with the name used for the import.
+func newServiceInstanceImportByNameTestServer(t *testing.T, instancesWithName int) *httptest.Server {
+	const instanceId = "df532d07-57a7-415e-a261-23a398ef068a"
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		instance := `{"id": "%s", "name": "tf-test-audit-log", "service_plan_id": "02fed361-89c1-4560-82c3-0deaf93ac75b", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "ready": true, "usable": true, "last_operation": {"state": "succeeded"}}`
+
+		switch r.URL.RawQuery {
+		case "list":
+			if body.ParamValues["fieldsFilter"] != "name eq 'tf-test-audit-log'" {
+				t.Errorf("unexpected fields filter: %s", body.ParamValues["fieldsFilter"])
+			}
+
+			instances := []string{fmt.Sprintf(instance, instanceId)}
+			for i := 1; i < instancesWithName; i++ {
+				instances = append(instances, fmt.Sprintf(instance, fmt.Sprintf("df532d07-57a7-415e-a261-23a398ef068%d", i)))
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, "[%s]", strings.Join(instances, ","))
+			return
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted || body.ParamValues["id"] != instanceId {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "service instance not found"}`)
+				return
+			}
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, instance, instanceId)
+	}))
+}
+
+// newServiceInstanceWithPlanSchemaTestServer returns a CLI server serving the plan of servicePlanWithSchemasResponse, which reports whether a service instance has been created.
+func newServiceInstanceWithPlanSchemaTestServer(t *testing.T) (*httptest.Server, *bool) {
+	created := false
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if strings.HasSuffix(r.URL.Path, "/services/plan") {
+			fmt.Fprint(w, servicePlanWithSchemasResponse)
+			return
+		}
+
+		switch r.URL.RawQuery {
+		case "create":
+			created = true
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "service instance not found"}`)
+				return
+			}
+		}
+
+		fmt.Fprintf(w, `{"id": "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd", "name": "tf-test-xsuaa", "service_plan_id": "b50d1b0b-2059-4f21-a014-2ea87752eb48", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "ready": true, "usable": true, "last_operation": {"state": "succeeded"}}`)
+	})), &created
+}
+
+// newServiceInstanceParametersTestServer returns a CLI server which reports the parameters a service instance has been created with.
+func newServiceInstanceParametersTestServer(t *testing.T) (*httptest.Server, *string) {
+	parameters := ""
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch r.URL.RawQuery {
+		case "create":
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+
+			content, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(content, &body); err != nil {
+				t.Errorf("unexpected request body: %s", content)
+			}
+
+			parameters = body.ParamValues["parameters"]
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "service instance not found"}`)
+				return
+			}
+		}
+
+		fmt.Fprintf(w, `{"id": "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd", "name": "tf-test-xsuaa", "service_plan_id": "b50d1b0b-2059-4f21-a014-2ea87752eb48", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "ready": true, "usable": true, "last_operation": {"state": "succeeded"}}`)
+	})), &parameters
+}
+
+// newServiceInstanceNotUsableTestServer returns a CLI server on which the creation of a service instance succeeds, but
+// the instance never becomes usable.
+func newServiceInstanceNotUsableTestServer() *httptest.Server {
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch r.URL.RawQuery {
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "service instance not found"}`)
+				return
+			}
+		}
+
+		fmt.Fprintf(w, `{"id": "2dd9e8a5-8a45-4b31-9cd5-8be29a1e2dfd", "name": "tf-test-xsuaa", "service_plan_id": "b50d1b0b-2059-4f21-a014-2ea87752eb48", "subaccount_id": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "ready": true, "usable": false, "last_operation": {"state": "succeeded"}}`)
+	}))
+}
+
+func compareJSON(actual string, expected string) error {
+	var actualValue, expectedValue interface{}
+
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		return fmt.Errorf("invalid JSON %q: %s", actual, err)
+	}
+
+	json.Unmarshal([]byte(expected), &expectedValue)
+
+	if !reflect.DeepEqual(actualValue, expectedValue) {
+		return fmt.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	return nil
 }
 
 func hclResourceSubaccountServiceInstanceWoParameters(resourceName string, subaccountId string, name string, servicePlanId string) string {
@@ -209,6 +728,30 @@ func hclResourceSubaccountServiceInstanceWithParameters(resourceName string, sub
 		}`, resourceName, subaccountId, name, servicePlanId, string(destParametersJson))
 }
 
+func hclResourceSubaccountServiceInstanceWithRequestedId(resourceName string, subaccountId string, name string, servicePlanId string, requestedId string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_service_instance" "%s"{
+		    subaccount_id    = "%s"
+			name             = "%s"
+			serviceplan_id   = "%s"
+			requested_id     = "%s"
+		}`, resourceName, subaccountId, name, servicePlanId, requestedId)
+}
+
+func hclResourceSubaccountServiceInstanceWithTimeouts(resourceName string, subaccountId string, name string, servicePlanId string, timeouts string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_service_instance" "%s"{
+		    subaccount_id    = "%s"
+			name             = "%s"
+			serviceplan_id   = "%s"
+			timeouts         = {
+				%s
+			}
+		}`, resourceName, subaccountId, name, servicePlanId, timeouts)
+}
+
 func hclResourceSubaccountServiceInstanceNoSubaccountId(resourceName string, name string, servicePlanId string) string {
 
 	return fmt.Sprintf(`
@@ -246,3 +789,62 @@ func getServiceInstanceIdForImport(resourceName string) resource.ImportStateIdFu
 		return fmt.Sprintf("%s,%s", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", rs.Primary.ID), nil
 	}
 }
+
+func hclResourceSubaccountServiceInstanceWithValidatedParameters(resourceName string, subaccountId string, name string, servicePlanId string, parameters string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_service_instance" "%s"{
+		    subaccount_id       = "%s"
+			name                = "%s"
+			serviceplan_id      = "%s"
+			parameters          = %q
+			validate_parameters = true
+		}`, resourceName, subaccountId, name, servicePlanId, parameters)
+}
+
+func hclResourceSubaccountServiceInstanceWithParametersFile(resourceName string, subaccountId string, name string, servicePlanId string, parametersFile string, parameters string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_service_instance" "%s"{
+		    subaccount_id   = "%s"
+			name            = "%s"
+			serviceplan_id  = "%s"
+			parameters_file = %q
+			parameters      = %q
+		}`, resourceName, subaccountId, name, servicePlanId, parametersFile, parameters)
+}
+
+func TestServicePlanDeprecationWarning(t *testing.T) {
+	t.Run("no warning without metadata", func(t *testing.T) {
+		diags := servicePlanDeprecationWarning(servicemanager.ServicePlanResponseObject{Id: "02fed361-89c1-4560-82c3-0deaf93ac75b", Name: "lite"})
+
+		assert.Empty(t, diags)
+	})
+
+	t.Run("no warning for a plan which isn't deprecated", func(t *testing.T) {
+		diags := servicePlanDeprecationWarning(servicemanager.ServicePlanResponseObject{Id: "02fed361-89c1-4560-82c3-0deaf93ac75b", Name: "lite", Metadata: &servicemanager.ServicePlanMetadata{}})
+
+		assert.Empty(t, diags)
+	})
+
+	t.Run("warning for a deprecated plan", func(t *testing.T) {
+		diags := servicePlanDeprecationWarning(servicemanager.ServicePlanResponseObject{Id: "02fed361-89c1-4560-82c3-0deaf93ac75b", Name: "lite", Metadata: &servicemanager.ServicePlanMetadata{Deprecated: true, DeprecationDate: "2023-12-31"}})
+
+		if assert.Len(t, diags, 1) {
+			assert.Equal(t, 0, diags.ErrorsCount())
+			assert.Equal(t, "Deprecated Service Plan", diags[0].Summary())
+			assert.Equal(t, "The service plan lite (02fed361-89c1-4560-82c3-0deaf93ac75b) is deprecated as of 2023-12-31. Consider using another service plan for the service instance.", diags[0].Detail())
+			if withPath, ok := diags[0].(diag.DiagnosticWithPath); assert.True(t, ok) {
+				assert.Equal(t, path.Root("serviceplan_id"), withPath.Path())
+			}
+		}
+	})
+
+	t.Run("warning for a deprecated plan without date", func(t *testing.T) {
+		diags := servicePlanDeprecationWarning(servicemanager.ServicePlanResponseObject{Id: "02fed361-89c1-4560-82c3-0deaf93ac75b", Name: "lite", Metadata: &servicemanager.ServicePlanMetadata{Deprecated: true}})
+
+		if assert.Len(t, diags, 1) {
+			assert.Equal(t, "The service plan lite (02fed361-89c1-4560-82c3-0deaf93ac75b) is deprecated. Consider using another service plan for the service instance.", diags[0].Detail())
+		}
+	})
+}
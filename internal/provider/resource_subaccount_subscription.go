@@ -2,11 +2,11 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,7 +18,6 @@ import (
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/saas_manager_service"
-	"github.com/SAP/terraform-provider-btp/internal/tfutils"
 	"github.com/SAP/terraform-provider-btp/internal/validation/jsonvalidator"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
@@ -43,7 +42,7 @@ func (rs *subaccountSubscriptionResource) Configure(_ context.Context, req resou
 	rs.cli = req.ProviderData.(*btpcli.ClientFacade)
 }
 
-func (rs *subaccountSubscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (rs *subaccountSubscriptionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Subscribes a subaccount to a multitenant application.
 Custom or partner-developed applications are currently not supported.
@@ -79,6 +78,7 @@ You must be assigned to the subaccount admin role.`,
 					jsonvalidator.ValidJSON(),
 				},
 			},
+			"timeouts": subscriptionTimeoutsAttributes(ctx),
 			"additional_plan_features": schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "The list of features specific to this plan.",
@@ -175,15 +175,16 @@ You must be assigned to the subaccount admin role.`,
 				ElementType: types.SetType{
 					ElemType: types.StringType,
 				},
-				MarkdownDescription: "The set of words or phrases assigned to the multitenant application subscription.",
+				MarkdownDescription: "The set of words or phrases assigned to the multitenant application subscription. The labels replace all existing labels of the subscription, an empty map removes them.",
 				Computed:            true,
+				Optional:            true,
 			},
 		},
 	}
 }
 
 func (rs *subaccountSubscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state subaccountSubscriptionType
+	var state subaccountSubscriptionResourceType
 
 	diags := req.State.Get(ctx, &state)
 
@@ -198,7 +199,20 @@ func (rs *subaccountSubscriptionResource) Read(ctx context.Context, req resource
 		return
 	}
 
-	newState, diags := subaccountSubscriptionValueFrom(ctx, cliRes)
+	// the state of an imported subscription only consists of the import identifier, which must refer to an existing subscription
+	if state.Id.IsNull() && cliRes.State == saas_manager_service.StateNotSubscribed {
+		resp.Diagnostics.AddError("Subscription Not Found (Subaccount)", fmt.Sprintf("The subaccount %s has no subscription to the application %s with the plan %s.", state.SubaccountId.ValueString(), state.AppName.ValueString(), state.PlanName.ValueString()))
+		return
+	}
+
+	// e.g. a subscription kept in the state after the create timeout expired may have been rolled back in the meantime
+	if cliRes.State == saas_manager_service.StateNotSubscribed {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	newState, diags := subaccountSubscriptionResourceValueFrom(ctx, cliRes)
+	newState.Timeouts = state.Timeouts
 
 	if newState.Parameters.IsNull() && !state.Parameters.IsNull() {
 		// The parameters are not returned by the API so we transfer the existing state to the read result if not existing
@@ -215,48 +229,65 @@ func (rs *subaccountSubscriptionResource) Read(ctx context.Context, req resource
 }
 
 func (rs *subaccountSubscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan subaccountSubscriptionType
+	var plan subaccountSubscriptionResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, _, err := rs.cli.Accounts.Subaccount.Subscribe(ctx, plan.SubaccountId.ValueString(), plan.AppName.ValueString(), plan.PlanName.ValueString(), plan.Parameters.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("API Error Creating Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
+	configuredTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	createStateConf := &tfutils.StateChangeConf{
-		Pending: []string{saas_manager_service.StateInProcess},
-		Target:  []string{saas_manager_service.StateSubscribed},
-		Refresh: func() (interface{}, string, error) {
-			subRes, _, err := rs.cli.Accounts.Subscription.Get(ctx, plan.SubaccountId.ValueString(), plan.AppName.ValueString(), plan.PlanName.ValueString())
+	var labels map[string][]string
+	if !plan.Labels.IsUnknown() && !plan.Labels.IsNull() {
+		resp.Diagnostics.Append(plan.Labels.ElementsAs(ctx, &labels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-			if err != nil {
-				return subRes, "", err
-			}
+	timeout, wait := subscriptionTimeout(configuredTimeout)
 
-			// No error returned even is subscription failed
-			if subRes.State == saas_manager_service.StateSubscribeFailed {
-				return subRes, subRes.State, errors.New("undefined API error during subscription")
-			}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-			return subRes, subRes.State, nil
-		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
+	var subRes *saas_manager_service.EntitledApplicationsResponseObject
+
+	// a recoverable failure, e.g. caused by a temporarily unavailable app provider, is retried once
+	for attempt := 1; attempt <= 2; attempt++ {
+		var err error
+
+		subRes, _, err = subscribeAndWait(waitCtx, rs.cli, plan.SubaccountId.ValueString(), plan.AppName.ValueString(), plan.PlanName.ValueString(), plan.Parameters.ValueString(), labels, timeout, wait)
+		if err == nil {
+			break
+		}
+
+		if attempt == 1 && subRes != nil && subscriptionFailureIsRecoverable(*subRes) {
+			continue
+		}
 
-	updatedRes, err := createStateConf.WaitForStateContext(ctx)
-	if err != nil {
 		resp.Diagnostics.AddError("API Error Creating Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
+		break
 	}
 
-	updatedPlan, diags := subaccountSubscriptionValueFrom(ctx, updatedRes.(saas_manager_service.EntitledApplicationsResponseObject))
+	if subRes == nil {
+		// e.g. after the create timeout expired the subscription is still in progress, hence it is kept in the state
+		// to be reconciled by the next read instead of being subscribed again
+		cliRes, _, err := rs.cli.Accounts.Subscription.Get(ctx, plan.SubaccountId.ValueString(), plan.AppName.ValueString(), plan.PlanName.ValueString())
+		if err != nil || cliRes.State == saas_manager_service.StateNotSubscribed {
+			return
+		}
+
+		subRes = &cliRes
+	}
+
+	updatedPlan, diags := subaccountSubscriptionResourceValueFrom(ctx, *subRes)
 	updatedPlan.Parameters = plan.Parameters
+	updatedPlan.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &updatedPlan)
@@ -264,57 +295,112 @@ func (rs *subaccountSubscriptionResource) Create(ctx context.Context, req resour
 }
 
 func (rs *subaccountSubscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan subaccountSubscriptionType
+	var plan, state subaccountSubscriptionResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.AddError("API Error Updating Subscription (Subaccount)", "This resource is not supposed to be updated")
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-}
 
-func (rs *subaccountSubscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state subaccountSubscriptionType
-	diags := req.State.Get(ctx, &state)
+	if !plan.SubaccountId.Equal(state.SubaccountId) || !plan.AppName.Equal(state.AppName) || !plan.PlanName.Equal(state.PlanName) {
+		resp.Diagnostics.AddError("API Error Updating Subscription (Subaccount)", "This resource is not supposed to be updated")
+		return
+	}
+
+	// apart from the labels, only the timeouts can be changed, which don't require the subscription to be touched
+	state.Timeouts = plan.Timeouts
+
+	timeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, _, err := rs.cli.Accounts.Subaccount.Unsubscribe(ctx, state.SubaccountId.ValueString(), state.AppName.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("API Error Deleting Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	state, diags = rs.updateLabels(ctx, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	deleteStateConf := &tfutils.StateChangeConf{
-		Pending: []string{saas_manager_service.StateInProcess},
-		Target:  []string{saas_manager_service.StateNotSubscribed},
-		Refresh: func() (interface{}, string, error) {
-			subRes, _, err := rs.cli.Accounts.Subscription.Get(ctx, state.SubaccountId.ValueString(), state.AppName.ValueString(), state.PlanName.ValueString())
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// updateLabels applies the planned labels to the subscription, if they differ from the current ones. Labels which
+// aren't configured are left untouched.
+func (rs *subaccountSubscriptionResource) updateLabels(ctx context.Context, plan subaccountSubscriptionResourceType, current subaccountSubscriptionResourceType) (subaccountSubscriptionResourceType, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.Labels.IsUnknown() || plan.Labels.IsNull() {
+		return current, diags
+	}
+
+	var plannedLabels, currentLabels map[string][]string
 
-			if err != nil {
-				return subRes, subRes.State, err
-			}
+	diags.Append(plan.Labels.ElementsAs(ctx, &plannedLabels, false)...)
+	diags.Append(current.Labels.ElementsAs(ctx, &currentLabels, false)...)
+	if diags.HasError() {
+		return current, diags
+	}
 
-			// No error returned even is unsubscribe failed
-			if subRes.State == saas_manager_service.StateUnsubscribeFailed {
-				return subRes, subRes.State, errors.New("undefined API error during unsubscription")
-			}
+	if !labelsChanged(plannedLabels, currentLabels) {
+		return current, diags
+	}
 
-			return subRes, subRes.State, nil
-		},
-		Timeout:    10 * time.Minute,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+	_, err := rs.cli.Accounts.Subscription.Update(ctx, &btpcli.SubscriptionUpdateInput{
+		Subaccount: plan.SubaccountId.ValueString(),
+		AppName:    plan.AppName.ValueString(),
+		PlanName:   plan.PlanName.ValueString(),
+		Labels:     plannedLabels,
+	})
+	if err != nil {
+		diags.AddError("API Error Updating Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
+		return current, diags
 	}
 
-	_, err = deleteStateConf.WaitForStateContext(ctx)
+	cliRes, _, err := rs.cli.Accounts.Subscription.Get(ctx, plan.SubaccountId.ValueString(), plan.AppName.ValueString(), plan.PlanName.ValueString())
 	if err != nil {
+		diags.AddError("API Error Reading Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
+		return current, diags
+	}
+
+	updated, updatedDiags := subaccountSubscriptionResourceValueFrom(ctx, cliRes)
+	diags.Append(updatedDiags...)
+	updated.Parameters = current.Parameters
+	updated.Timeouts = current.Timeouts
+
+	return updated, diags
+}
+
+func (rs *subaccountSubscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subaccountSubscriptionResourceType
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configuredTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, wait := subscriptionTimeout(configuredTimeout)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := unsubscribeAndWait(ctx, rs.cli, state.SubaccountId.ValueString(), state.AppName.ValueString(), state.PlanName.ValueString(), timeout, wait); err != nil {
 		resp.Diagnostics.AddError("API Error Deleting Resource Subscription (Subaccount)", fmt.Sprintf("%s", err))
 		return
 	}
@@ -335,3 +421,20 @@ func (rs *subaccountSubscriptionResource) ImportState(ctx context.Context, req r
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), idParts[1])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plan_name"), idParts[2])...)
 }
+
+func subscriptionTimeoutsAttributes(ctx context.Context) schema.SingleNestedAttribute {
+	attributes := resourceTimeoutsAttributes(ctx, "subscription")
+	attributes.MarkdownDescription += " If a timeout is configured for the subscription or unsubscription, the CLI server is asked to wait for the operation to be completed before responding, instead of the operation being polled right away."
+
+	return attributes
+}
+
+// subscriptionTimeout returns the duration a subscription or unsubscription may take, given its configured timeout
+// (zero if none is configured). The CLI server is only asked to wait for the operation if a timeout is configured.
+func subscriptionTimeout(configured time.Duration) (timeout time.Duration, wait bool) {
+	if configured > 0 {
+		return configured, true
+	}
+
+	return defaultOperationTimeout, false
+}
@@ -1,11 +1,19 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/saas_manager_service"
 )
 
 func TestResourceSubaccountSubscription(t *testing.T) {
@@ -42,6 +50,232 @@ func TestResourceSubaccountSubscription(t *testing.T) {
 			},
 		})
 	})
+	t.Run("happy path - destroy waits for delayed unsubscription", func(t *testing.T) {
+		srv, pollsAfterUnsubscribe := newSubscriptionTestServer(saas_manager_service.StateNotSubscribed, "")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "state", "SUBSCRIBED"),
+					),
+				},
+			},
+			CheckDestroy: func(_ *terraform.State) error {
+				if *pollsAfterUnsubscribe < 3 {
+					return fmt.Errorf("expected destroy to wait for the unsubscription, got %d polls", *pollsAfterUnsubscribe)
+				}
+				return nil
+			},
+		})
+	})
+
+	t.Run("error path - failed unsubscription is surfaced", func(t *testing.T) {
+		srv, _ := newSubscriptionTestServer(saas_manager_service.StateUnsubscribeFailed, "The app provider rejected the unsubscription")
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+					Destroy:     true,
+					ExpectError: regexp.MustCompile(`unsubscription failed: The app provider rejected the unsubscription`),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - recoverable subscription failure is retried once", func(t *testing.T) {
+		srv, subscriptions := newSubscriptionRetryTestServer("The app provider is temporarily unavailable", http.StatusServiceUnavailable)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "state", "SUBSCRIBED"),
+						func(_ *terraform.State) error {
+							if *subscriptions != 2 {
+								return fmt.Errorf("expected the subscription to be retried once, got %d subscriptions", *subscriptions)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - non-recoverable subscription failure is not retried", func(t *testing.T) {
+		srv, subscriptions := newSubscriptionRetryTestServer("The plan is not available for the region", http.StatusBadRequest)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+					ExpectError: regexp.MustCompile(`subscription failed: The plan is not available for the region`),
+				},
+			},
+			CheckDestroy: func(_ *terraform.State) error {
+				if *subscriptions != 1 {
+					return fmt.Errorf("expected the subscription not to be retried, got %d subscriptions", *subscriptions)
+				}
+				return nil
+			},
+		})
+	})
+
+	t.Run("happy path - wait on server if timeouts are configured", func(t *testing.T) {
+		srv, waits := newSubscriptionWaitTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscriptionWithTimeouts("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", "15m", "5m"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "state", "SUBSCRIBED"),
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "timeouts.create", "15m"),
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "timeouts.delete", "5m"),
+					),
+				},
+			},
+			CheckDestroy: func(_ *terraform.State) error {
+				if waits["subscribe"] != "true" || waits["unsubscribe"] != "true" {
+					return fmt.Errorf("expected the wait flag to be sent to the CLI server, got %v", waits)
+				}
+				return nil
+			},
+		})
+	})
+
+	t.Run("happy path - no wait on server without timeouts", func(t *testing.T) {
+		srv, waits := newSubscriptionWaitTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+				},
+			},
+			CheckDestroy: func(_ *terraform.State) error {
+				if waits["subscribe"] != "" || waits["unsubscribe"] != "" {
+					return fmt.Errorf("expected no wait flag to be sent to the CLI server, got %v", waits)
+				}
+				return nil
+			},
+		})
+	})
+
+	t.Run("happy path - set and change labels", func(t *testing.T) {
+		srv, calls := newSubscriptionLabelsTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscriptionWithLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", `{"cost-center" = ["4711"]}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "labels.%", "1"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_subscription.uut", "labels.cost-center.*", "4711"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscriptionWithLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", `{"cost-center" = ["0815"], "team" = ["platform"]}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "labels.%", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_subscription.uut", "labels.cost-center.*", "0815"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount_subscription.uut", "labels.team.*", "platform"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscriptionWithLabels("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", `{}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_subscription.uut", "labels.%", "0"),
+					),
+				},
+			},
+			CheckDestroy: func(_ *terraform.State) error {
+				// the labels are changed in place
+				if calls["subscribe"] != 1 || calls["update"] != 2 {
+					return fmt.Errorf("expected one subscription and two updates, got %v", calls)
+				}
+				return nil
+			},
+		})
+	})
+
+	t.Run("error path - create timeout expires", func(t *testing.T) {
+		srv, unsubscribed := newSubscriptionInProcessTestServer()
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			// the subscription still in progress is kept in the state, hence it is unsubscribed on destroy
+			CheckDestroy: func(_ *terraform.State) error {
+				if !*unsubscribed {
+					return fmt.Errorf("expected the subscription in progress to be kept in the state and unsubscribed")
+				}
+				return nil
+			},
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithPollIntervals(srv.URL, "1s", "1s") + hclResourceSubaccountSubscriptionWithTimeouts("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", "3s", "5m"),
+					ExpectError: regexp.MustCompile(`API Error Creating Resource Subscription \(Subaccount\)`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - invalid timeout", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountSubscriptionWithTimeouts("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", "ten minutes", "5m"),
+					ExpectError: regexp.MustCompile(`value must be a positive duration`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - zero timeout", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountSubscriptionWithTimeouts("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free", "0s", "5m"),
+					ExpectError: regexp.MustCompile(`value must be a positive duration`),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - subacount_id mandatory", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -103,6 +337,25 @@ func TestResourceSubaccountSubscription(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - import of a non-existing subscription", func(t *testing.T) {
+		srv, _ := newSubscriptionRetryTestServer("", 0)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:        hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountSubscription("uut", "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "auditlog-viewer", "free"),
+					ResourceName:  "btp_subaccount_subscription.uut",
+					ImportStateId: "59cd458e-e66e-4b60-b6d8-8f219379f9a5,auditlog-viewer,free",
+					ImportState:   true,
+					ExpectError:   regexp.MustCompile(`Subscription Not Found \(Subaccount\)`),
+				},
+			},
+		})
+	})
+
 }
 
 func hclResourceSubaccountSubscription(resourceName string, subaccountId string, appName string, planName string) string {
@@ -115,6 +368,31 @@ func hclResourceSubaccountSubscription(resourceName string, subaccountId string,
 		}`, resourceName, subaccountId, appName, planName)
 }
 
+func hclResourceSubaccountSubscriptionWithTimeouts(resourceName string, subaccountId string, appName string, planName string, createTimeout string, deleteTimeout string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_subscription" "%s"{
+		    subaccount_id    = "%s"
+			app_name         = "%s"
+			plan_name        = "%s"
+			timeouts = {
+				create = "%s"
+				delete = "%s"
+			}
+		}`, resourceName, subaccountId, appName, planName, createTimeout, deleteTimeout)
+}
+
+func hclResourceSubaccountSubscriptionWithLabels(resourceName string, subaccountId string, appName string, planName string, labels string) string {
+
+	return fmt.Sprintf(`
+		resource "btp_subaccount_subscription" "%s"{
+		    subaccount_id    = "%s"
+			app_name         = "%s"
+			plan_name        = "%s"
+			labels           = %s
+		}`, resourceName, subaccountId, appName, planName, labels)
+}
+
 func hclResourceSubaccountSubscriptionNoSubaccountId(resourceName string, appName string, planName string) string {
 
 	return fmt.Sprintf(`
@@ -141,3 +419,203 @@ func hclResourceSubaccountSubscriptionNoPlan(resourceName string, subaccountId s
 			app_name         = "%s"
 		}`, resourceName, subaccountId, appName)
 }
+
+// newSubscriptionTestServer simulates a subscription that stays in process for two polls after being unsubscribed.
+// The first unsubscription then reaches the given final state, any further unsubscription succeeds.
+func newSubscriptionTestServer(finalState string, errorMessage string) (*httptest.Server, *int) {
+	unsubscribed := false
+	unsubscriptions := 0
+	pollsAfterUnsubscribe := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if r.URL.RawQuery == "unsubscribe" {
+			unsubscribed = true
+			unsubscriptions++
+			pollsAfterUnsubscribe = 0
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		if r.URL.RawQuery == "subscribe" {
+			unsubscribed = false
+			pollsAfterUnsubscribe = 0
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		state := saas_manager_service.StateSubscribed
+		if unsubscribed {
+			pollsAfterUnsubscribe++
+
+			state = saas_manager_service.StateInProcess
+			if pollsAfterUnsubscribe > 2 {
+				state = saas_manager_service.StateNotSubscribed
+				if unsubscriptions == 1 {
+					state = finalState
+				}
+			}
+		}
+
+		fmt.Fprintf(w, `{"appId": "auditlog-viewer!t49", "appName": "auditlog-viewer", "planName": "free", "quota": 1, "state": "%s", "subscriptionError": {"errorMessage": "%s"}}`, state, errorMessage)
+	}))
+
+	return srv, &pollsAfterUnsubscribe
+}
+
+// newSubscriptionRetryTestServer simulates a CLI server on which the first subscription fails with the given error message and app provider status
+// and any further subscription succeeds. The number of subscribe commands is recorded.
+func newSubscriptionRetryTestServer(errorMessage string, appErrorStatus int) (*httptest.Server, *int) {
+	subscriptions := 0
+	state := saas_manager_service.StateNotSubscribed
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch r.URL.RawQuery {
+		case "subscribe":
+			subscriptions++
+
+			state = saas_manager_service.StateSubscribed
+			if subscriptions == 1 {
+				state = saas_manager_service.StateSubscribeFailed
+			}
+
+			fmt.Fprintf(w, "{}")
+			return
+		case "unsubscribe":
+			state = saas_manager_service.StateNotSubscribed
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		subscriptionError := `{}`
+		if state == saas_manager_service.StateSubscribeFailed {
+			subscriptionError = fmt.Sprintf(`{"errorMessage": "%s", "appError": "{\"status\": %d}"}`, errorMessage, appErrorStatus)
+		}
+
+		fmt.Fprintf(w, `{"appId": "auditlog-viewer!t49", "appName": "auditlog-viewer", "planName": "free", "quota": 1, "state": "%s", "subscriptionError": %s}`, state, subscriptionError)
+	}))
+
+	return srv, &subscriptions
+}
+
+// newSubscriptionInProcessTestServer simulates a CLI server on which a subscription never leaves the state IN_PROCESS,
+// while an unsubscription completes right away. It records whether the app has been unsubscribed.
+func newSubscriptionInProcessTestServer() (*httptest.Server, *bool) {
+	unsubscribed := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if r.URL.RawQuery == "unsubscribe" {
+			unsubscribed = true
+		}
+
+		if r.URL.RawQuery != "get" {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		state := saas_manager_service.StateInProcess
+		if unsubscribed {
+			state = saas_manager_service.StateNotSubscribed
+		}
+
+		fmt.Fprintf(w, `{"appId": "auditlog-viewer!t49", "appName": "auditlog-viewer", "planName": "free", "quota": 1, "state": "%s"}`, state)
+	})), &unsubscribed
+}
+
+// newSubscriptionWaitTestServer simulates a CLI server that completes the subscription and unsubscription right away.
+// The value of the wait flag sent along with the last subscribe and unsubscribe command is recorded per command.
+func newSubscriptionWaitTestServer(t *testing.T) (*httptest.Server, map[string]string) {
+	waits := map[string]string{}
+	state := saas_manager_service.StateNotSubscribed
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if r.URL.RawQuery == "subscribe" || r.URL.RawQuery == "unsubscribe" {
+			var body struct {
+				ParamValues map[string]string `json:"paramValues"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			waits[r.URL.RawQuery] = body.ParamValues["wait"]
+
+			state = saas_manager_service.StateSubscribed
+			if r.URL.RawQuery == "unsubscribe" {
+				state = saas_manager_service.StateNotSubscribed
+			}
+
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		fmt.Fprintf(w, `{"appId": "auditlog-viewer!t49", "appName": "auditlog-viewer", "planName": "free", "quota": 1, "state": "%s"}`, state)
+	}))
+
+	return srv, waits
+}
+
+// newSubscriptionLabelsTestServer simulates a subscription whose labels are assigned on subscribe and replaced on
+// update. It counts the calls per action.
+func newSubscriptionLabelsTestServer(t *testing.T) (*httptest.Server, map[string]int) {
+	calls := map[string]int{}
+	state := saas_manager_service.StateNotSubscribed
+	labels := "{}"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		calls[r.URL.RawQuery]++
+
+		switch r.URL.RawQuery {
+		case "subscribe", "update":
+			if value, ok := body.ParamValues["labels"]; ok {
+				labels = value
+			}
+			state = saas_manager_service.StateSubscribed
+		case "unsubscribe":
+			state = saas_manager_service.StateNotSubscribed
+		case "get":
+			fmt.Fprintf(w, `{"appId": "auditlog-viewer!t49", "appName": "auditlog-viewer", "planName": "free", "quota": 1, "subscribedSubaccountId": "59cd458e-e66e-4b60-b6d8-8f219379f9a5", "state": "%s", "labels": %s}`, state, labels)
+			return
+		}
+
+		fmt.Fprintf(w, "{}")
+	}))
+
+	return srv, calls
+}
@@ -1,14 +1,25 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli"
 )
 
 func TestResourceSubaccount(t *testing.T) {
@@ -213,6 +224,840 @@ func TestResourceSubaccount(t *testing.T) {
 			},
 		})
 	})
+	t.Run("error path - deletion protection prevents destroy", func(t *testing.T) {
+		deleted := false
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			if r.URL.RawQuery == "delete" {
+				deleted = true
+			}
+
+			if deleted && r.URL.RawQuery == "get" {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET"}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithDeletionProtection("uut", "a-subaccount", "eu12", "a-subaccount", true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "deletion_protection", "true"),
+					),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithDeletionProtection("uut", "a-subaccount", "eu12", "a-subaccount", true),
+					Destroy:     true,
+					ExpectError: regexp.MustCompile(`The subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f has\s+` + "`deletion_protection`" + `\s+enabled`),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithDeletionProtection("uut", "a-subaccount", "eu12", "a-subaccount", false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "deletion_protection", "false"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - region change requires confirmation", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/login/") {
+				fmt.Fprintf(w, "{}")
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET"}`)
+		}))
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccount("uut", "a-subaccount", "eu12", "a-subaccount"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "allow_region_change", "false"),
+					),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccount("uut", "a-subaccount", "eu10", "a-subaccount"),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`Region Change Not Allowed`),
+				},
+				{
+					Config:             hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithRegionChange("uut", "a-subaccount", "eu10", "a-subaccount"),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
+	t.Run("happy path - set, change and clear custom properties", func(t *testing.T) {
+		srv, sentCustomProperties := newSubaccountCustomPropertiesTestServer(t)
+		defer srv.Close()
+
+		expectSentCustomProperties := func(expected string) resource.TestCheckFunc {
+			return func(_ *terraform.State) error {
+				if *sentCustomProperties != expected {
+					return fmt.Errorf("expected custom properties %s to be sent, got: %s", expected, *sentCustomProperties)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithCustomProperties("uut", "a-subaccount", "eu12", "a-subaccount", `{ costcenter = "4711", owner = "john.doe" }`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "custom_properties.%", "2"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "custom_properties.costcenter", "4711"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "custom_properties.owner", "john.doe"),
+						expectSentCustomProperties(`[{"key":"costcenter","value":"4711"},{"key":"owner","value":"john.doe"}]`),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithCustomProperties("uut", "a-subaccount", "eu12", "a-subaccount", `{ costcenter = "4712", owner = "john.doe" }`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "custom_properties.%", "2"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "custom_properties.costcenter", "4712"),
+						// only the changed property is sent
+						expectSentCustomProperties(`[{"key":"costcenter","value":"4712"}]`),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithCustomProperties("uut", "a-subaccount", "eu12", "a-subaccount", `{}`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "custom_properties.%", "0"),
+						expectSentCustomProperties(`[{"key":"costcenter"},{"key":"owner"}]`),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - beta features already enabled", func(t *testing.T) {
+		srv, rejectedUpdates := newSubaccountBetaEnabledTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaEnabled("uut", "a-subaccount", "eu12", "a-subaccount", true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_enabled", "true"),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaEnabled("uut", "another-subaccount", "eu12", "a-subaccount", true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "name", "another-subaccount"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_enabled", "true"),
+						func(_ *terraform.State) error {
+							if *rejectedUpdates != 1 {
+								return fmt.Errorf("expected 1 rejected update, got %d", *rejectedUpdates)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - beta features enabled at creation and changed on update", func(t *testing.T) {
+		srv, sentBetaFeatures := newSubaccountBetaFeaturesTestServer(t)
+		defer srv.Close()
+
+		expectSentBetaFeatures := func(parameter string, expected string) resource.TestCheckFunc {
+			return func(_ *terraform.State) error {
+				if sentBetaFeatures[parameter] != expected {
+					return fmt.Errorf("expected %s %s to be sent, got: %s", parameter, expected, sentBetaFeatures[parameter])
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaFeatures("uut", "a-subaccount", "eu12", "a-subaccount", true, `["feature-b", "feature-a"]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_enabled", "true"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_features.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount.uut", "beta_features.*", "feature-a"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount.uut", "beta_features.*", "feature-b"),
+						expectSentBetaFeatures("betaFeatures", `["feature-a","feature-b"]`),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaFeatures("uut", "a-subaccount", "eu12", "a-subaccount", true, `["feature-b", "feature-c"]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_features.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount.uut", "beta_features.*", "feature-c"),
+						// only the changed features are sent
+						expectSentBetaFeatures("enableBetaFeatures", `["feature-c"]`),
+						expectSentBetaFeatures("disableBetaFeatures", `["feature-a"]`),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - beta features changed outside of Terraform", func(t *testing.T) {
+		srv, setBetaFeatures := newSubaccountReportedBetaFeaturesTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaFeatures("uut", "a-subaccount", "eu12", "a-subaccount", true, `["feature-a", "feature-b"]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_features.#", "2"),
+					),
+				},
+				{
+					PreConfig: func() {
+						setBetaFeatures("feature-a")
+					},
+					Config:             hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaFeatures("uut", "a-subaccount", "eu12", "a-subaccount", true, `["feature-a", "feature-b"]`),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaFeatures("uut", "a-subaccount", "eu12", "a-subaccount", true, `["feature-a", "feature-b"]`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_features.#", "2"),
+						resource.TestCheckTypeSetElemAttr("btp_subaccount.uut", "beta_features.*", "feature-b"),
+					),
+				},
+				{
+					// the features are shown, but not disabled, if they are no longer configured
+					Config:   hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaEnabled("uut", "a-subaccount", "eu12", "a-subaccount", true),
+					PlanOnly: true,
+				},
+			},
+		})
+	})
+
+	t.Run("error path - beta features require beta enabled", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountWithBetaFeatures("uut", "a-subaccount", "eu12", "a-subaccount", false, `["feature-a"]`),
+					ExpectError: regexp.MustCompile(`Beta features can only be enabled for a subaccount whose .beta_enabled. is .true.`),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - data residency of the region read once and kept on update", func(t *testing.T) {
+		srv, regionLookups := newSubaccountDataResidencyTestServer(t)
+		defer srv.Close()
+
+		expectRegionLookups := func(expected int) resource.TestCheckFunc {
+			return func(_ *terraform.State) error {
+				if *regionLookups != expected {
+					return fmt.Errorf("expected %d lookups of the available regions, got: %d", expected, *regionLookups)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaEnabled("uut", "a-subaccount", "eu11", "a-subaccount", true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "region", "eu11"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "geo_access", "EU_ACCESS"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "iaas_provider", "AWS"),
+						expectRegionLookups(1),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithBetaEnabled("uut", "a-subaccount", "eu11", "a-subaccount", false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "beta_enabled", "false"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "geo_access", "EU_ACCESS"),
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "iaas_provider", "AWS"),
+						expectRegionLookups(1),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - dependent resources succeed once the subaccount is usable", func(t *testing.T) {
+		srv, offeringLists := newSubaccountMarketplaceTestServer(t, http.StatusServiceUnavailable, 3)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithPollIntervals(srv.URL, "100ms", "100ms") + hclResourceSubaccountWaitingUntilUsable("uut", "a-subaccount", "eu12", "a-subaccount") + `
+data "btp_subaccount_service_offerings" "uut" {
+    subaccount_id = btp_subaccount.uut.id
+}`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "wait_until_usable", "true"),
+						resource.TestCheckResourceAttr("data.btp_subaccount_service_offerings.uut", "values.#", "1"),
+						func(_ *terraform.State) error {
+							if *offeringLists < 3 {
+								return fmt.Errorf("expected the service marketplace to be polled until it can be queried, got %d requests", *offeringLists)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - marketplace rejected for another reason than its setup", func(t *testing.T) {
+		srv, offeringLists := newSubaccountMarketplaceTestServer(t, http.StatusBadRequest, 3)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProviderWithPollIntervals(srv.URL, "100ms", "100ms") + hclResourceSubaccountWaitingUntilUsable("uut", "a-subaccount", "eu12", "a-subaccount"),
+					ExpectError: regexp.MustCompile(`the subaccount has been created, but its service marketplace can't be queried yet`),
+				},
+				{
+					RefreshState: true,
+					Check: func(_ *terraform.State) error {
+						if *offeringLists != 1 {
+							return fmt.Errorf("expected the service marketplace not to be polled again after a non-transient error, got %d requests", *offeringLists)
+						}
+						return nil
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("error path - new parent doesn't distribute the entitlements of the subaccount", func(t *testing.T) {
+		srv := newSubaccountMoveTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithEntitlementValidation("uut", "03760ecf-9d89-4189-a92a-1c7efed09298", "a-subaccount", "eu12", "a-subaccount"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount.uut", "validate_entitlements_on_move", "true"),
+					),
+				},
+				{
+					Config:      hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithEntitlementValidation("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "a-subaccount", "eu12", "a-subaccount"),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`(?s)Incompatible Entitlements of the New Parent.*- hana-cloud:hana\s`),
+				},
+				{
+					// the check is skipped unless requested
+					Config:             hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountWithParent("uut", "5357bda0-8651-4eab-a69d-12d282bc3247", "a-subaccount", "eu12", "a-subaccount"),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
+
+	t.Run("error path - custom property value must not be empty", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      hclProvider() + hclResourceSubaccountWithCustomProperties("uut", "a-subaccount", "eu12", "a-subaccount", `{ costcenter = "" }`),
+					ExpectError: regexp.MustCompile(`string length must be at least 1, got: 0`),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountCustomPropertiesTestServer returns a CLI server which keeps track of the custom properties of a single subaccount
+// and reports the custom properties sent with the last create or update request.
+func newSubaccountCustomPropertiesTestServer(t *testing.T) (*httptest.Server, *string) {
+	customProperties := map[string]string{}
+	sentCustomProperties := ""
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		switch r.URL.RawQuery {
+		case "create", "update":
+			sentCustomProperties = body.ParamValues["customProperties"]
+
+			var properties []struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			}
+			if len(sentCustomProperties) > 0 {
+				if err := json.Unmarshal([]byte(sentCustomProperties), &properties); err != nil {
+					t.Errorf("unexpected custom properties: %s", sentCustomProperties)
+				}
+			}
+
+			for _, property := range properties {
+				if len(property.Value) == 0 {
+					delete(customProperties, property.Key)
+				} else {
+					customProperties[property.Key] = property.Value
+				}
+			}
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+		}
+
+		keys := []string{}
+		for key := range customProperties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		properties := []string{}
+		for _, key := range keys {
+			properties = append(properties, fmt.Sprintf(`{"accountGUID": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "key": %q, "value": %q}`, key, customProperties[key]))
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET", "customProperties": [%s]}`, strings.Join(properties, ","))
+	})), &sentCustomProperties
+}
+
+// newSubaccountBetaEnabledTestServer returns a CLI server which, like some server versions, rejects updates that
+// enable the beta features of a subaccount for which they are already enabled. The rejected updates are counted.
+func newSubaccountBetaEnabledTestServer(t *testing.T) (*httptest.Server, *int) {
+	displayName := ""
+	betaEnabled := false
+	rejectedUpdates := 0
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		switch r.URL.RawQuery {
+		case "create":
+			displayName = body.ParamValues["displayName"]
+			betaEnabled = body.ParamValues["betaEnabled"] == "true"
+		case "update":
+			if body.ParamValues["betaEnabled"] == "true" && betaEnabled {
+				rejectedUpdates++
+				w.Header().Set("X-Cpcli-Backend-Status", "400")
+				fmt.Fprintf(w, `{"error": "Beta features are already enabled for subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}`)
+				return
+			}
+
+			displayName = body.ParamValues["displayName"]
+			if value, ok := body.ParamValues["betaEnabled"]; ok {
+				betaEnabled = value == "true"
+			}
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": %q, "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET", "betaEnabled": %t}`, displayName, betaEnabled)
+	})), &rejectedUpdates
+}
+
+// newSubaccountBetaFeaturesTestServer returns a CLI server which records the beta feature parameters of the last
+// create or update request.
+func newSubaccountBetaFeaturesTestServer(t *testing.T) (*httptest.Server, map[string]string) {
+	sentBetaFeatures := map[string]string{}
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		switch r.URL.RawQuery {
+		case "create", "update":
+			for _, parameter := range []string{"betaFeatures", "enableBetaFeatures", "disableBetaFeatures"} {
+				sentBetaFeatures[parameter] = body.ParamValues[parameter]
+			}
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET", "betaEnabled": true}`)
+	})), sentBetaFeatures
+}
+
+// newSubaccountReportedBetaFeaturesTestServer returns a CLI server which reports the beta features enabled for the
+// subaccount. The returned function replaces the enabled features, as if they were changed outside of Terraform.
+func newSubaccountReportedBetaFeaturesTestServer(t *testing.T) (*httptest.Server, func(features ...string)) {
+	var mu sync.Mutex
+	betaFeatures := map[string]bool{}
+	deleted := false
+
+	decodeFeatures := func(value string) []string {
+		var features []string
+		if len(value) > 0 {
+			assert.NoError(t, json.Unmarshal([]byte(value), &features))
+		}
+		return features
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.RawQuery {
+		case "create":
+			for _, feature := range decodeFeatures(body.ParamValues["betaFeatures"]) {
+				betaFeatures[feature] = true
+			}
+		case "update":
+			for _, feature := range decodeFeatures(body.ParamValues["enableBetaFeatures"]) {
+				betaFeatures[feature] = true
+			}
+			for _, feature := range decodeFeatures(body.ParamValues["disableBetaFeatures"]) {
+				delete(betaFeatures, feature)
+			}
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+		}
+
+		features := []string{}
+		for feature := range betaFeatures {
+			features = append(features, feature)
+		}
+		sort.Strings(features)
+		reported, _ := json.Marshal(features)
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET", "betaEnabled": true, "betaFeatures": %s}`, reported)
+	}))
+
+	return srv, func(features ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		betaFeatures = map[string]bool{}
+		for _, feature := range features {
+			betaFeatures[feature] = true
+		}
+	}
+}
+
+// newSubaccountDataResidencyTestServer returns a CLI server with a subaccount in the region eu11 and counts the
+// lookups of the available regions.
+func newSubaccountDataResidencyTestServer(t *testing.T) (*httptest.Server, *int) {
+	betaEnabled := false
+	deleted := false
+	regionLookups := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		if strings.HasSuffix(r.URL.Path, "/accounts/available-region") {
+			regionLookups++
+			fmt.Fprintf(w, `{"datacenters": [{"name": "cf-eu10", "region": "eu10", "environment": "cloudfoundry", "iaasProvider": "AWS", "geoAccess": "STANDARD"}, {"name": "cf-eu11", "region": "eu11", "environment": "cloudfoundry", "iaasProvider": "AWS", "geoAccess": "EU_ACCESS"}]}`)
+			return
+		}
+
+		switch r.URL.RawQuery {
+		case "create", "update":
+			betaEnabled = body.ParamValues["betaEnabled"] == "true"
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+		}
+
+		fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu11", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET", "betaEnabled": %t}`, betaEnabled)
+	})), &regionLookups
+}
+
+func TestIsMarketplaceNotUsableYet(t *testing.T) {
+	t.Parallel()
+
+	for status, expected := range map[int]bool{
+		http.StatusNotFound:            true,
+		http.StatusConflict:            true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusBadRequest:          false,
+		http.StatusForbidden:           false,
+		http.StatusInternalServerError: false,
+	} {
+		assert.Equal(t, expected, isMarketplaceNotUsableYet(btpcli.CommandResponse{StatusCode: status}), status)
+	}
+}
+
+func TestSubaccountResourceIsBetaAlreadySet(t *testing.T) {
+	t.Parallel()
+
+	update := func(t *testing.T, updateStatus int, updateError string, betaEnabled bool) (bool, int) {
+		gets := 0
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.RawQuery == "update" {
+				w.Header().Set("X-Cpcli-Backend-Status", strconv.Itoa(updateStatus))
+				fmt.Fprint(w, updateError)
+				return
+			}
+
+			gets++
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "state": "OK", "betaEnabled": %t}`, betaEnabled)
+		}))
+		defer srv.Close()
+
+		srvUrl, _ := url.Parse(srv.URL)
+		rs := &subaccountResource{cli: btpcli.NewClientFacade(btpcli.NewV2ClientWithHttpClient(srv.Client(), srvUrl))}
+
+		enable := true
+		_, comRes, err := rs.cli.Accounts.Subaccount.Update(context.TODO(), &btpcli.SubaccountUpdateInput{SubaccountId: "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", BetaEnabled: &enable})
+		assert.Error(t, err)
+
+		return rs.isBetaAlreadySet(context.TODO(), comRes, "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", enable), gets
+	}
+
+	t.Run("happy path - rejected, because beta features are already enabled", func(t *testing.T) {
+		alreadySet, _ := update(t, http.StatusBadRequest, `{"error": "Beta features are already enabled for subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}`, true)
+
+		assert.True(t, alreadySet)
+	})
+	t.Run("error path - bad request for another reason", func(t *testing.T) {
+		alreadySet, _ := update(t, http.StatusBadRequest, `{"error": "Display name must not be empty [Error: 11002/400]"}`, false)
+
+		assert.False(t, alreadySet)
+	})
+	t.Run("error path - other statuses are not classified", func(t *testing.T) {
+		alreadySet, gets := update(t, http.StatusForbidden, `{"error": "You are not authorized to update the beta features of subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}`, true)
+
+		assert.False(t, alreadySet)
+		assert.Equal(t, 0, gets)
+	})
+}
+
+// newSubaccountMarketplaceTestServer returns a CLI server with a newly created subaccount, whose service marketplace
+// is rejected with the given status until the given number of requests. The requests to the marketplace are counted.
+func newSubaccountMarketplaceTestServer(t *testing.T, notUsableStatus int, queryableFromRequest int) (*httptest.Server, *int) {
+	deleted := false
+	offeringLists := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/services/offering") {
+			offeringLists++
+			if offeringLists < queryableFromRequest {
+				w.Header().Set("X-Cpcli-Backend-Status", strconv.Itoa(notUsableStatus))
+				fmt.Fprintf(w, `{"error": "Service Manager is not yet available for subaccount 6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f"}`)
+				return
+			}
+
+			w.Header().Set("X-Cpcli-Backend-Status", "200")
+			fmt.Fprintf(w, `[{"id": "d67ff82d-9bfe-43e3-abd2-f2e21a5362c5", "name": "xsuaa", "ready": true}]`)
+			return
+		}
+
+		switch r.URL.RawQuery {
+		case "delete":
+			deleted = true
+		case "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+		fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET"}`)
+	})), &offeringLists
+}
+
+// newSubaccountMoveTestServer returns a CLI server with a subaccount in the global account, which is entitled to two
+// plans, and a directory managing entitlements, which distributes only one of them.
+func newSubaccountMoveTestServer(t *testing.T) *httptest.Server {
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/global-account"):
+			fmt.Fprintf(w, `{"guid": "03760ecf-9d89-4189-a92a-1c7efed09298", "displayName": "My Global Account", "subdomain": "my-globalaccount"}`)
+		case strings.HasSuffix(r.URL.Path, "/accounts/directory"):
+			fmt.Fprintf(w, `{"guid": "5357bda0-8651-4eab-a69d-12d282bc3247", "displayName": "My Directory", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "globalAccountGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "directoryFeatures": ["DEFAULT", "ENTITLEMENTS"]}`)
+		case strings.HasSuffix(r.URL.Path, "/accounts/entitlement") && body.ParamValues["directory"] != "":
+			fmt.Fprintf(w, `{"entitledServices": [{"name": "alert-notification", "servicePlans": [{"name": "standard", "amount": 1}]}]}`)
+		case strings.HasSuffix(r.URL.Path, "/accounts/entitlement"):
+			fmt.Fprintf(w, `{"entitledServices": [{"name": "alert-notification", "servicePlans": [{"name": "standard", "amount": 1}]}, {"name": "hana-cloud", "servicePlans": [{"name": "hana", "amount": 1}]}]}`)
+		case strings.HasSuffix(r.URL.Path, "/accounts/subaccount"):
+			if r.URL.RawQuery == "delete" {
+				deleted = true
+			}
+
+			if deleted && r.URL.RawQuery == "get" {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprintf(w, `{"error": "subaccount not found"}`)
+				return
+			}
+
+			fmt.Fprintf(w, `{"guid": "6aa64c2f-38c1-49a9-b2e8-cf9fea769b7f", "displayName": "a-subaccount", "region": "eu12", "subdomain": "a-subaccount", "parentGUID": "03760ecf-9d89-4189-a92a-1c7efed09298", "state": "OK", "usedForProduction": "UNSET"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
 }
 
 func hclResourceSubaccount(resourceName string, displayName string, region string, subdomain string) string {
@@ -249,3 +1094,89 @@ resource "btp_subaccount" "%s" {
 
 	return fmt.Sprintf(template, resourceName, displayName, region, subdomain)
 }
+
+func hclResourceSubaccountWithDeletionProtection(resourceName string, displayName string, region string, subdomain string, deletionProtection bool) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    name                = "%s"
+    region              = "%s"
+    subdomain           = "%s"
+    deletion_protection = %t
+}`
+
+	return fmt.Sprintf(template, resourceName, displayName, region, subdomain, deletionProtection)
+}
+
+func hclResourceSubaccountWithRegionChange(resourceName string, displayName string, region string, subdomain string) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    name                = "%s"
+    region              = "%s"
+    subdomain           = "%s"
+    allow_region_change = true
+}`
+
+	return fmt.Sprintf(template, resourceName, displayName, region, subdomain)
+}
+
+func hclResourceSubaccountWithCustomProperties(resourceName string, displayName string, region string, subdomain string, customProperties string) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    name              = "%s"
+    region            = "%s"
+    subdomain         = "%s"
+    custom_properties = %s
+}`
+
+	return fmt.Sprintf(template, resourceName, displayName, region, subdomain, customProperties)
+}
+
+func hclResourceSubaccountWithBetaEnabled(resourceName string, displayName string, region string, subdomain string, betaEnabled bool) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    name         = "%s"
+    region       = "%s"
+    subdomain    = "%s"
+    beta_enabled = %t
+}`
+
+	return fmt.Sprintf(template, resourceName, displayName, region, subdomain, betaEnabled)
+}
+
+func hclResourceSubaccountWithBetaFeatures(resourceName string, displayName string, region string, subdomain string, betaEnabled bool, betaFeatures string) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    name          = "%s"
+    region        = "%s"
+    subdomain     = "%s"
+    beta_enabled  = %t
+    beta_features = %s
+}`
+
+	return fmt.Sprintf(template, resourceName, displayName, region, subdomain, betaEnabled, betaFeatures)
+}
+
+func hclResourceSubaccountWithEntitlementValidation(resourceName string, parentId string, displayName string, region string, subdomain string) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    parent_id                     = "%s"
+    name                          = "%s"
+    region                        = "%s"
+    subdomain                     = "%s"
+    validate_entitlements_on_move = true
+}`
+
+	return fmt.Sprintf(template, resourceName, parentId, displayName, region, subdomain)
+}
+
+func hclResourceSubaccountWaitingUntilUsable(resourceName string, displayName string, region string, subdomain string) string {
+	template := `
+resource "btp_subaccount" "%s" {
+    name              = "%s"
+    region            = "%s"
+    subdomain         = "%s"
+    wait_until_usable = true
+}`
+
+	return fmt.Sprintf(template, resourceName, displayName, region, subdomain)
+}
@@ -5,18 +5,24 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/SAP/terraform-provider-btp/internal/btpcli"
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_trust"
 	"github.com/SAP/terraform-provider-btp/internal/validation/uuidvalidator"
 )
 
+var _ resource.ResourceWithConfigValidators = &subaccountTrustConfigurationResource{}
+
 func newSubaccountTrustConfigurationResource() resource.Resource {
 	return &subaccountTrustConfigurationResource{}
 }
@@ -39,7 +45,7 @@ func (rs *subaccountTrustConfigurationResource) Configure(_ context.Context, req
 
 func (rs *subaccountTrustConfigurationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: `Establishes trust from a subaccount to an Identity Authentication tenant.
+		MarkdownDescription: `Establishes trust from a subaccount to an Identity Authentication tenant or to an OpenID Connect identity provider.
 
 __Further documentation:__
 <https://help.sap.com/docs/btp/sap-business-technology-platform/trust-and-federation-with-identity-providers>`,
@@ -52,8 +58,34 @@ __Further documentation:__
 				},
 			},
 			"identity_provider": schema.StringAttribute{
-				MarkdownDescription: "The name of the Identity Authentication tenant that you want the subaccount to connect.",
-				Required:            true,
+				MarkdownDescription: "The name of the Identity Authentication tenant that you want the subaccount to connect. Either `identity_provider` or `issuer_url` must be given.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"issuer_url": schema.StringAttribute{
+				MarkdownDescription: "The issuer URL of the OpenID Connect identity provider that you want the subaccount to connect. Requires `client_id` and `client_secret`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://`), "must be an https URL"),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the client which is registered at the OpenID Connect identity provider.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "The secret of the client which is registered at the OpenID Connect identity provider.",
+				Optional:            true,
+				Sensitive:           true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
@@ -97,6 +129,14 @@ __Further documentation:__
 				MarkdownDescription: "The protocol used to establish trust with the identity provider.",
 				Computed:            true,
 			},
+			"protocol_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the protocol used to establish trust with the identity provider. Possible values are: \n " +
+					getFormattedValueAsTableRow("value", "description") +
+					getFormattedValueAsTableRow("---", "---") +
+					getFormattedValueAsTableRow("`saml2`", "The trust is established with SAML 2.0.") +
+					getFormattedValueAsTableRow("`openIdConnect`", "The trust is established with OpenID Connect."),
+				Computed: true,
+			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "Shows whether the identity provider is currently active or not.",
 				Computed:            true,
@@ -105,12 +145,31 @@ __Further documentation:__
 				MarkdownDescription: "Shows whether the trust configuration can be modified.",
 				Computed:            true,
 			},
+			"fetch_metadata": schema.BoolAttribute{
+				MarkdownDescription: "If set to `true`, the SAML metadata document of the identity provider is fetched as well. As the document can be large, it is not fetched by default.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "The SAML metadata document which is stored for the identity provider. Only available if `fetch_metadata` is set to `true`.",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (rs *subaccountTrustConfigurationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("identity_provider"), path.MatchRoot("issuer_url")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("issuer_url"), path.MatchRoot("client_id"), path.MatchRoot("client_secret")),
+		// the SAML metadata document only exists for trust configurations with an Identity Authentication tenant
+		resourcevalidator.Conflicting(path.MatchRoot("issuer_url"), path.MatchRoot("fetch_metadata")),
+	}
+}
+
 func (rs *subaccountTrustConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state subaccountTrustConfigurationType
+	var state subaccountTrustConfigurationResourceType
 
 	diags := req.State.Get(ctx, &state)
 
@@ -119,14 +178,24 @@ func (rs *subaccountTrustConfigurationResource) Read(ctx context.Context, req re
 		return
 	}
 
-	cliRes, _, err := rs.cli.Security.Trust.GetBySubaccount(ctx, state.SubaccountId.ValueString(), state.Id.ValueString())
+	fetchMetadata := state.FetchMetadata
+	if fetchMetadata.IsNull() {
+		fetchMetadata = types.BoolValue(false)
+	}
+
+	cliRes, err := getSubaccountTrustConfiguration(ctx, rs.cli, state.SubaccountId.ValueString(), state.Id.ValueString(), fetchMetadata.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Reading Resource Trust Configuration (Subaccount)", fmt.Sprintf("%s", err))
 		return
 	}
 
-	updatedState, diags := subaccountTrustConfigurationFromValue(ctx, cliRes)
+	updatedState, diags := subaccountTrustConfigurationResourceValueFrom(ctx, cliRes)
 	updatedState.SubaccountId = state.SubaccountId
+	updatedState.Origin = originOrPrior(cliRes.OriginKey, state.Origin)
+	updatedState.IssuerUrl = state.IssuerUrl
+	updatedState.ClientId = state.ClientId
+	updatedState.ClientSecret = state.ClientSecret
+	updatedState.FetchMetadata = fetchMetadata
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &updatedState)
@@ -134,46 +203,67 @@ func (rs *subaccountTrustConfigurationResource) Read(ctx context.Context, req re
 }
 
 func (rs *subaccountTrustConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan subaccountTrustConfigurationType
+	var plan subaccountTrustConfigurationResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cliReq := btpcli.TrustConfigurationInput{
-		IdentityProvider: plan.IdentityProvider.ValueString(),
-	}
+	var name, description, origin *string
 
 	if !plan.Name.IsUnknown() {
-		name := plan.Name.ValueString()
-		cliReq.Name = &name
+		name = plan.Name.ValueStringPointer()
 	}
 
 	if !plan.Description.IsUnknown() {
-		description := plan.Description.ValueString()
-		cliReq.Description = &description
+		description = plan.Description.ValueStringPointer()
 	}
 
 	if !plan.Origin.IsUnknown() {
-		origin := plan.Origin.ValueString()
-		cliReq.Origin = &origin
+		normalizedOrigin := normalizeOrigin(plan.Origin.ValueString())
+		origin = &normalizedOrigin
+	}
+
+	var createRes xsuaa_trust.ModifyTrustConfigurationResponseObject
+	var err error
+
+	if !plan.IssuerUrl.IsNull() {
+		createRes, _, err = rs.cli.Security.Trust.CreateOidcBySubaccount(ctx, plan.SubaccountId.ValueString(), btpcli.OidcTrustConfigurationInput{
+			IssuerUrl:    plan.IssuerUrl.ValueString(),
+			ClientId:     plan.ClientId.ValueString(),
+			ClientSecret: plan.ClientSecret.ValueString(),
+			Name:         name,
+			Description:  description,
+			Origin:       origin,
+		})
+	} else {
+		createRes, _, err = rs.cli.Security.Trust.CreateBySubaccount(ctx, plan.SubaccountId.ValueString(), btpcli.TrustConfigurationInput{
+			IdentityProvider: plan.IdentityProvider.ValueString(),
+			Name:             name,
+			Description:      description,
+			Origin:           origin,
+		})
 	}
 
-	createRes, _, err := rs.cli.Security.Trust.CreateBySubaccount(ctx, plan.SubaccountId.ValueString(), cliReq)
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Creating Resource Trust Configuration (Subaccount)", fmt.Sprintf("%s", err))
 		return
 	}
 
-	cliRes, _, err := rs.cli.Security.Trust.GetBySubaccount(ctx, plan.SubaccountId.ValueString(), createRes.OriginKey)
+	cliRes, err := getSubaccountTrustConfiguration(ctx, rs.cli, plan.SubaccountId.ValueString(), createRes.OriginKey, plan.FetchMetadata.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("API Error Reading Resource Trust Configuration (Subaccount)", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := subaccountTrustConfigurationFromValue(ctx, cliRes)
+	state, diags := subaccountTrustConfigurationResourceValueFrom(ctx, cliRes)
 	state.SubaccountId = plan.SubaccountId
+	state.Origin = originOrPrior(cliRes.OriginKey, plan.Origin)
+	state.IssuerUrl = plan.IssuerUrl
+	state.ClientId = plan.ClientId
+	state.ClientSecret = plan.ClientSecret
+	state.FetchMetadata = plan.FetchMetadata
 	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &state)
@@ -181,21 +271,87 @@ func (rs *subaccountTrustConfigurationResource) Create(ctx context.Context, req
 }
 
 func (rs *subaccountTrustConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan subaccountTrustConfigurationType
+	var plan subaccountTrustConfigurationResourceType
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.AddError("API Error Updating Resource Trust Configuration (Subaccount)", "This resource is not supposed to be updated")
+	var state subaccountTrustConfigurationResourceType
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	isUnchanged := func(planned types.String, current types.String) bool {
+		return planned.IsUnknown() || planned.Equal(current)
+	}
+
+	if !isUnchanged(plan.IdentityProvider, state.IdentityProvider) || !originsEqual(plan.Origin, state.Origin) || !isUnchanged(plan.SubaccountId, state.SubaccountId) {
+		resp.Diagnostics.AddError("API Error Updating Resource Trust Configuration (Subaccount)", "This resource is not supposed to be updated")
+		return
+	}
+
+	if plan.IssuerUrl.IsNull() {
+		// only fetching the metadata and the spelling of the origin can be changed, the trust configuration itself is not supposed to be updated
+		if !isUnchanged(plan.Name, state.Name) || !isUnchanged(plan.Description, state.Description) {
+			resp.Diagnostics.AddError("API Error Updating Resource Trust Configuration (Subaccount)", "This resource is not supposed to be updated")
+			return
+		}
+	} else {
+		// the client credentials, the name and the description of an OpenID Connect trust configuration can be changed in place
+		cliReq := btpcli.OidcTrustConfigurationUpdateInput{
+			OriginKey: state.Id.ValueString(),
+		}
+
+		if !plan.ClientId.Equal(state.ClientId) {
+			cliReq.ClientId = plan.ClientId.ValueStringPointer()
+		}
+
+		if !plan.ClientSecret.Equal(state.ClientSecret) {
+			cliReq.ClientSecret = plan.ClientSecret.ValueStringPointer()
+		}
+
+		if !isUnchanged(plan.Name, state.Name) {
+			cliReq.Name = plan.Name.ValueStringPointer()
+		}
+
+		if !isUnchanged(plan.Description, state.Description) {
+			cliReq.Description = plan.Description.ValueStringPointer()
+		}
+
+		if cliReq.ClientId != nil || cliReq.ClientSecret != nil || cliReq.Name != nil || cliReq.Description != nil {
+			_, _, err := rs.cli.Security.Trust.UpdateOidcBySubaccount(ctx, state.SubaccountId.ValueString(), cliReq)
+			if err != nil {
+				resp.Diagnostics.AddError("API Error Updating Resource Trust Configuration (Subaccount)", fmt.Sprintf("%s", err))
+				return
+			}
+		}
+	}
+
+	cliRes, err := getSubaccountTrustConfiguration(ctx, rs.cli, state.SubaccountId.ValueString(), state.Id.ValueString(), plan.FetchMetadata.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error Reading Resource Trust Configuration (Subaccount)", fmt.Sprintf("%s", err))
+		return
+	}
+
+	updatedState, diags := subaccountTrustConfigurationResourceValueFrom(ctx, cliRes)
+	updatedState.SubaccountId = state.SubaccountId
+	updatedState.Origin = originOrPrior(cliRes.OriginKey, plan.Origin)
+	updatedState.IssuerUrl = plan.IssuerUrl
+	updatedState.ClientId = plan.ClientId
+	updatedState.ClientSecret = plan.ClientSecret
+	updatedState.FetchMetadata = plan.FetchMetadata
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &updatedState)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (rs *subaccountTrustConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state subaccountTrustConfigurationType
+	var state subaccountTrustConfigurationResourceType
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -212,3 +368,17 @@ func (rs *subaccountTrustConfigurationResource) Delete(ctx context.Context, req
 func (rs *subaccountTrustConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// getSubaccountTrustConfiguration reads the trust configuration and, if requested, includes the SAML metadata document.
+func getSubaccountTrustConfiguration(ctx context.Context, cli *btpcli.ClientFacade, subaccountId string, origin string, fetchMetadata bool) (xsuaa_trust.TrustConfigurationResponseObject, error) {
+	var cliRes xsuaa_trust.TrustConfigurationResponseObject
+	var err error
+
+	if fetchMetadata {
+		cliRes, _, err = cli.Security.Trust.GetWithMetadataBySubaccount(ctx, subaccountId, origin)
+	} else {
+		cliRes, _, err = cli.Security.Trust.GetBySubaccount(ctx, subaccountId, origin)
+	}
+
+	return cliRes, err
+}
@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestResourceSubaccountTrustConfiguration(t *testing.T) {
@@ -29,6 +37,7 @@ func TestResourceSubaccountTrustConfiguration(t *testing.T) {
 						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "name", "Custom IAS tenant for apps"),
 						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "description", "IAS tenant terraformint.accounts400.ondemand.com (OpenID Connect)"),
 						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "origin", "sap.custom"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
 					),
 				},
 			},
@@ -54,12 +63,177 @@ func TestResourceSubaccountTrustConfiguration(t *testing.T) {
 						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "name", "Custom IAS tenant"),
 						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "description", "IAS tenant terraformint.accounts400.ondemand.com (OpenID Connect)"),
 						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "origin", "sap.custom"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
 					),
 				},
 			},
 		})
 	})
 
+	t.Run("happy path - OpenID Connect lifecycle", func(t *testing.T) {
+		srv, operations := newSubaccountOidcTrustConfigurationTestServer(t)
+		defer srv.Close()
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(srv.Client()),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountTrustConfigurationOidc("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "https://my-oidc-provider.local", "my-client", "my-secret"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "id", "my-oidc-platform"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "issuer_url", "https://my-oidc-provider.local"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "client_id", "my-client"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "client_secret", "my-secret"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "protocol_type", "openIdConnect"),
+						checkSubaccountTrustConfigurationOperations(operations, []string{
+							"create https://my-oidc-provider.local my-client my-secret",
+						}),
+					),
+				},
+				{
+					Config: hclProviderWithCLIServerURL(srv.URL) + hclResourceSubaccountTrustConfigurationOidc("uut", "ef23ace8-6ade-4d78-9c1f-8df729548bbf", "https://my-oidc-provider.local", "my-client", "my-rotated-secret"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "id", "my-oidc-platform"),
+						resource.TestCheckResourceAttr("btp_subaccount_trust_configuration.uut", "client_secret", "my-rotated-secret"),
+						checkSubaccountTrustConfigurationOperations(operations, []string{
+							"create https://my-oidc-provider.local my-client my-secret",
+							"update my-oidc-platform my-rotated-secret",
+						}),
+					),
+				},
+			},
+			CheckDestroy: checkSubaccountTrustConfigurationOperations(operations, []string{
+				"create https://my-oidc-provider.local my-client my-secret",
+				"update my-oidc-platform my-rotated-secret",
+				"delete my-oidc-platform",
+			}),
+		})
+	})
+
+	t.Run("error path - identity_provider and issuer_url are mutually exclusive", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + `
+resource "btp_subaccount_trust_configuration" "uut" {
+    subaccount_id     = "ef23ace8-6ade-4d78-9c1f-8df729548bbf"
+    identity_provider = "terraformint.accounts400.ondemand.com"
+    issuer_url        = "https://my-oidc-provider.local"
+    client_id         = "my-client"
+    client_secret     = "my-secret"
+}`,
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - client credentials are required for OpenID Connect", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + `
+resource "btp_subaccount_trust_configuration" "uut" {
+    subaccount_id = "ef23ace8-6ade-4d78-9c1f-8df729548bbf"
+    issuer_url    = "https://my-oidc-provider.local"
+    client_id     = "my-client"
+}`,
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - metadata can't be fetched for OpenID Connect", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: hclProvider() + `
+resource "btp_subaccount_trust_configuration" "uut" {
+    subaccount_id  = "ef23ace8-6ade-4d78-9c1f-8df729548bbf"
+    issuer_url     = "https://my-oidc-provider.local"
+    client_id      = "my-client"
+    client_secret  = "my-secret"
+    fetch_metadata = true
+}`,
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		})
+	})
+}
+
+// newSubaccountOidcTrustConfigurationTestServer simulates the trust commands of the CLI server for OpenID Connect trust
+// configurations and records the modifying operations in the order they are requested.
+func newSubaccountOidcTrustConfigurationTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	operations := []string{}
+	deleted := false
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/login/") {
+			fmt.Fprintf(w, "{}")
+			return
+		}
+
+		if !strings.Contains(r.URL.Path, "/security/trust") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		var body struct {
+			ParamValues map[string]string `json:"paramValues"`
+		}
+
+		content, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(content, &body); err != nil {
+			t.Errorf("unexpected request body: %s", content)
+		}
+
+		w.Header().Set("X-Cpcli-Backend-Status", "200")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/security/trust/oidc") && r.URL.RawQuery == "create":
+			operations = append(operations, fmt.Sprintf("create %s %s %s", body.ParamValues["issuerUrl"], body.ParamValues["clientId"], body.ParamValues["clientSecret"]))
+			fmt.Fprint(w, `{"originKey": "my-oidc-platform"}`)
+		case strings.HasSuffix(r.URL.Path, "/security/trust/oidc") && r.URL.RawQuery == "update":
+			operations = append(operations, fmt.Sprintf("update %s %s", body.ParamValues["originKey"], body.ParamValues["clientSecret"]))
+			fmt.Fprint(w, `{"originKey": "my-oidc-platform"}`)
+		case strings.HasSuffix(r.URL.Path, "/security/trust") && r.URL.RawQuery == "delete":
+			operations = append(operations, "delete "+body.ParamValues["originKey"])
+			deleted = true
+			fmt.Fprint(w, `{"originKey": "my-oidc-platform"}`)
+		case strings.HasSuffix(r.URL.Path, "/security/trust") && r.URL.RawQuery == "get":
+			if deleted {
+				w.Header().Set("X-Cpcli-Backend-Status", "404")
+				fmt.Fprint(w, `{"error": "trust configuration not found"}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"originKey": "my-oidc-platform", "name": "my-oidc-provider.local", "description": "", "typeOfTrust": "Application", "protocol": "OpenID Connect", "status": "active", "readOnly": false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), &operations
+}
+
+func checkSubaccountTrustConfigurationOperations(operations *[]string, expected []string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if strings.Join(*operations, ", ") != strings.Join(expected, ", ") {
+			return fmt.Errorf("expected the operations %v, got %v", expected, *operations)
+		}
+		return nil
+	}
 }
 
 func hclResourceSubaccountTrustConfigurationComplete(resourceName string, subaccountId string, identityProvider string, name string, description string) string {
@@ -83,3 +257,15 @@ resource "btp_subaccount_trust_configuration" "%s" {
 
 	return fmt.Sprintf(template, resourceName, subaccountId, identityProvider)
 }
+
+func hclResourceSubaccountTrustConfigurationOidc(resourceName string, subaccountId string, issuerUrl string, clientId string, clientSecret string) string {
+	template := `
+resource "btp_subaccount_trust_configuration" "%s" {
+    subaccount_id = "%s"
+    issuer_url    = "%s"
+    client_id     = "%s"
+    client_secret = "%s"
+}`
+
+	return fmt.Sprintf(template, resourceName, subaccountId, issuerUrl, clientId, clientSecret)
+}
@@ -46,3 +46,39 @@ func directoryValueFrom(ctx context.Context, value cis.DirectoryResponseObject)
 
 	return directory, summary
 }
+
+type directoryResourceType struct {
+	ID             types.String `tfsdk:"id"`
+	Administrators types.Set    `tfsdk:"administrators"`
+	CreatedBy      types.String `tfsdk:"created_by"`
+	CreatedDate    types.String `tfsdk:"created_date"`
+	Description    types.String `tfsdk:"description"`
+	Features       types.Set    `tfsdk:"features"`
+	ForceDelete    types.Bool   `tfsdk:"force_delete"`
+	Labels         types.Map    `tfsdk:"labels"`
+	LastModified   types.String `tfsdk:"last_modified"`
+	Name           types.String `tfsdk:"name"`
+	ParentID       types.String `tfsdk:"parent_id"`
+	State          types.String `tfsdk:"state"`
+	Subdomain      types.String `tfsdk:"subdomain"`
+}
+
+func directoryResourceValueFrom(ctx context.Context, value cis.DirectoryResponseObject) (directoryResourceType, diag.Diagnostics) {
+	directory, diags := directoryValueFrom(ctx, value)
+
+	return directoryResourceType{
+		ID:             directory.ID,
+		Administrators: types.SetNull(types.StringType),
+		CreatedBy:      directory.CreatedBy,
+		CreatedDate:    directory.CreatedDate,
+		Description:    directory.Description,
+		Features:       directory.Features,
+		ForceDelete:    types.BoolValue(false),
+		Labels:         directory.Labels,
+		LastModified:   directory.LastModified,
+		Name:           directory.Name,
+		ParentID:       directory.ParentID,
+		State:          directory.State,
+		Subdomain:      directory.Subdomain,
+	}, diags
+}
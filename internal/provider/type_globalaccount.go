@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/cis"
+)
+
+type globalaccountResourceType struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Subdomain types.String `tfsdk:"subdomain"`
+	Labels    types.Map    `tfsdk:"labels"`
+}
+
+func globalaccountResourceValueFrom(ctx context.Context, value cis.GlobalAccountResponseObject) (globalaccountResourceType, diag.Diagnostics) {
+	globalaccount := globalaccountResourceType{
+		ID:        types.StringValue(value.Guid),
+		Name:      types.StringValue(value.DisplayName),
+		Subdomain: types.StringValue(value.Subdomain),
+	}
+
+	labels := value.Labels
+	if labels == nil {
+		// an empty map keeps the state consistent with a configuration that removes all labels
+		labels = map[string][]string{}
+	}
+
+	var diags diag.Diagnostics
+
+	globalaccount.Labels, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, labels)
+
+	return globalaccount, diags
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_settings"
+)
+
+type globalaccountSecuritySettingsType struct {
+	Id                      types.String `tfsdk:"id"`
+	DefaultIdentityProvider types.String `tfsdk:"default_identity_provider"`
+	AccessTokenValidity     types.Int64  `tfsdk:"access_token_validity"`
+	RefreshTokenValidity    types.Int64  `tfsdk:"refresh_token_validity"`
+	CustomEmailDomains      types.Set    `tfsdk:"custom_email_domains"`
+	IframeDomains           types.Set    `tfsdk:"iframe_domains"`
+}
+
+func globalaccountSecuritySettingsValueFrom(ctx context.Context, globalaccountSubdomain string, value xsuaa_settings.TenantSettingsResp) (globalaccountSecuritySettingsType, diag.Diagnostics) {
+	settings := globalaccountSecuritySettingsType{
+		Id:                      types.StringValue(globalaccountSubdomain),
+		DefaultIdentityProvider: types.StringValue(value.DefaultIdp),
+		AccessTokenValidity:     types.Int64Value(int64(value.TokenPolicySettings.AccessTokenValidity)),
+		RefreshTokenValidity:    types.Int64Value(int64(value.TokenPolicySettings.RefreshTokenValidity)),
+	}
+
+	customEmailDomains := value.CustomEmailDomains
+	if customEmailDomains == nil {
+		customEmailDomains = []string{}
+	}
+
+	var diags, diagsIframeDomains diag.Diagnostics
+	settings.CustomEmailDomains, diags = types.SetValueFrom(ctx, types.StringType, customEmailDomains)
+
+	// the server returns the iframe domains as a single string separated by spaces
+	settings.IframeDomains, diagsIframeDomains = types.SetValueFrom(ctx, types.StringType, strings.Fields(value.IframeDomains))
+	diags.Append(diagsIframeDomains...)
+
+	return settings, diags
+}
@@ -15,6 +15,7 @@ type globalaccountTrustConfigurationType struct {
 	Type             types.String `tfsdk:"type"`
 	IdentityProvider types.String `tfsdk:"identity_provider"`
 	Protocol         types.String `tfsdk:"protocol"`
+	ProtocolType     types.String `tfsdk:"protocol_type"`
 	Status           types.String `tfsdk:"status"`
 	ReadOnly         types.Bool   `tfsdk:"read_only"`
 }
@@ -28,6 +29,7 @@ func globalaccountTrustConfigurationFromValue(ctx context.Context, value xsuaa_t
 		Type:             types.StringValue(value.TypeOfTrust),
 		IdentityProvider: types.StringValue(value.IdentityProvider),
 		Protocol:         types.StringValue(value.Protocol),
+		ProtocolType:     types.StringValue(trustProtocolType(value.Protocol)),
 		Status:           types.StringValue(value.Status),
 		ReadOnly:         types.BoolValue(value.ReadOnly),
 	}, diag.Diagnostics{}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -52,3 +53,112 @@ func subaccountValueFrom(ctx context.Context, value cis.SubaccountResponseObject
 
 	return subaccount, diagnostics
 }
+
+type subaccountDataSourceType struct {
+	ID             types.String `tfsdk:"id"`
+	Ancestry       types.List   `tfsdk:"ancestry"`
+	BetaEnabled    types.Bool   `tfsdk:"beta_enabled"`
+	CreatedBy      types.String `tfsdk:"created_by"`
+	CreatedDate    types.String `tfsdk:"created_date"`
+	Description    types.String `tfsdk:"description"`
+	Labels         types.Map    `tfsdk:"labels"`
+	LastModified   types.String `tfsdk:"last_modified"`
+	Name           types.String `tfsdk:"name"`
+	ParentID       types.String `tfsdk:"parent_id"`
+	ParentFeatures types.Set    `tfsdk:"parent_features"`
+	Region         types.String `tfsdk:"region"`
+	State          types.String `tfsdk:"state"`
+	Subdomain      types.String `tfsdk:"subdomain"`
+	Usage          types.String `tfsdk:"usage"`
+}
+
+// subaccountDataSourceValueFrom maps the CLI response onto the data source model. The `ancestry` requires
+// additional requests and must be determined by the caller.
+func subaccountDataSourceValueFrom(ctx context.Context, value cis.SubaccountResponseObject) (subaccountDataSourceType, diag.Diagnostics) {
+	subaccount, diags := subaccountValueFrom(ctx, value)
+
+	return subaccountDataSourceType{
+		ID:             subaccount.ID,
+		Ancestry:       types.ListNull(types.StringType),
+		BetaEnabled:    subaccount.BetaEnabled,
+		CreatedBy:      subaccount.CreatedBy,
+		CreatedDate:    subaccount.CreatedDate,
+		Description:    subaccount.Description,
+		Labels:         subaccount.Labels,
+		LastModified:   subaccount.LastModified,
+		Name:           subaccount.Name,
+		ParentID:       subaccount.ParentID,
+		ParentFeatures: subaccount.ParentFeatures,
+		Region:         subaccount.Region,
+		State:          subaccount.State,
+		Subdomain:      subaccount.Subdomain,
+		Usage:          subaccount.Usage,
+	}, diags
+}
+
+type subaccountResourceType struct {
+	ID                         types.String   `tfsdk:"id"`
+	AllowRegionChange          types.Bool     `tfsdk:"allow_region_change"`
+	BetaEnabled                types.Bool     `tfsdk:"beta_enabled"`
+	BetaFeatures               types.Set      `tfsdk:"beta_features"`
+	CreatedBy                  types.String   `tfsdk:"created_by"`
+	CreatedDate                types.String   `tfsdk:"created_date"`
+	CustomProperties           types.Map      `tfsdk:"custom_properties"`
+	DeletionProtection         types.Bool     `tfsdk:"deletion_protection"`
+	Description                types.String   `tfsdk:"description"`
+	GeoAccess                  types.String   `tfsdk:"geo_access"`
+	IaasProvider               types.String   `tfsdk:"iaas_provider"`
+	Labels                     types.Map      `tfsdk:"labels"`
+	LastModified               types.String   `tfsdk:"last_modified"`
+	Name                       types.String   `tfsdk:"name"`
+	ParentID                   types.String   `tfsdk:"parent_id"`
+	ParentFeatures             types.Set      `tfsdk:"parent_features"`
+	Region                     types.String   `tfsdk:"region"`
+	State                      types.String   `tfsdk:"state"`
+	Subdomain                  types.String   `tfsdk:"subdomain"`
+	Usage                      types.String   `tfsdk:"usage"`
+	ValidateEntitlementsOnMove types.Bool     `tfsdk:"validate_entitlements_on_move"`
+	WaitUntilUsable            types.Bool     `tfsdk:"wait_until_usable"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// subaccountResourceValueFrom leaves out the data residency of the region (`geo_access` and `iaas_provider`), which isn't
+// part of the response and is looked up separately.
+func subaccountResourceValueFrom(ctx context.Context, value cis.SubaccountResponseObject) (subaccountResourceType, diag.Diagnostics) {
+	subaccount, diagnostics := subaccountValueFrom(ctx, value)
+
+	customProperties := map[string]string{}
+	for _, property := range value.CustomProperties {
+		customProperties[property.Key] = property.Value
+	}
+
+	customPropertiesValue, diags := types.MapValueFrom(ctx, types.StringType, customProperties)
+	diagnostics.Append(diags...)
+
+	betaFeatures := types.SetNull(types.StringType)
+	if value.BetaFeatures != nil {
+		betaFeatures, diags = types.SetValueFrom(ctx, types.StringType, value.BetaFeatures)
+		diagnostics.Append(diags...)
+	}
+
+	return subaccountResourceType{
+		ID:               subaccount.ID,
+		BetaEnabled:      subaccount.BetaEnabled,
+		BetaFeatures:     betaFeatures,
+		CreatedBy:        subaccount.CreatedBy,
+		CreatedDate:      subaccount.CreatedDate,
+		CustomProperties: customPropertiesValue,
+		Description:      subaccount.Description,
+		GeoAccess:        types.StringNull(),
+		IaasProvider:     types.StringNull(),
+		Labels:           subaccount.Labels,
+		LastModified:     subaccount.LastModified,
+		Name:             subaccount.Name,
+		ParentID:         subaccount.ParentID,
+		ParentFeatures:   subaccount.ParentFeatures,
+		Region:           subaccount.Region,
+		State:            subaccount.State,
+		Subdomain:        subaccount.Subdomain,
+		Usage:            subaccount.Usage,
+	}, diagnostics
+}
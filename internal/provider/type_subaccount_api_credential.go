@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_api"
+)
+
+type subaccountApiCredentialType struct {
+	SubaccountId      types.String `tfsdk:"subaccount_id"`
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	CertificatePassed types.String `tfsdk:"certificate_passed"`
+	ReadOnly          types.Bool   `tfsdk:"read_only"`
+	ClientId          types.String `tfsdk:"client_id"`
+	ClientSecret      types.String `tfsdk:"client_secret"`
+	TokenUrl          types.String `tfsdk:"token_url"`
+	ApiUrl            types.String `tfsdk:"api_url"`
+}
+
+// subaccountApiCredentialValueFrom maps the API credential, whose client secret is only part of the response on creation.
+func subaccountApiCredentialValueFrom(subaccountId string, value xsuaa_api.ApiCredential) subaccountApiCredentialType {
+	return subaccountApiCredentialType{
+		SubaccountId: types.StringValue(subaccountId),
+		Id:           types.StringValue(value.ClientId),
+		Name:         types.StringValue(value.Name),
+		ReadOnly:     types.BoolValue(value.ReadOnly),
+		ClientId:     types.StringValue(value.ClientId),
+		ClientSecret: stringNullIfEmpty(value.ClientSecret),
+		TokenUrl:     types.StringValue(value.TokenUrl),
+		ApiUrl:       types.StringValue(value.ApiUrl),
+	}
+}
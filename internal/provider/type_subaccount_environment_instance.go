@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -23,6 +25,7 @@ type subaccountEnvironmentInstanceType struct {
 	LastModified    types.String `tfsdk:"last_modified"`
 	Name            types.String `tfsdk:"name"`
 	Operation       types.String `tfsdk:"operation"`
+	Outputs         types.Map    `tfsdk:"outputs"`
 	Parameters      types.String `tfsdk:"parameters"`
 	PlanId          types.String `tfsdk:"plan_id"`
 	PlanName        types.String `tfsdk:"plan_name"`
@@ -64,5 +67,88 @@ func subaccountEnvironmentInstanceValueFrom(ctx context.Context, value provision
 	environmentInstance.CustomLabels, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, value.CustomLabels)
 	diagnostics.Append(diags...)
 
+	environmentInstance.Outputs, diags = types.MapValueFrom(ctx, types.StringType, environmentInstanceOutputsFrom(value.Labels))
+	diagnostics.Append(diags...)
+
 	return environmentInstance, diagnostics
 }
+
+type subaccountEnvironmentInstanceResourceType struct {
+	SubaccountId    types.String   `tfsdk:"subaccount_id"`
+	Id              types.String   `tfsdk:"id"`
+	BrokerId        types.String   `tfsdk:"broker_id"`
+	CreatedDate     types.String   `tfsdk:"created_date"`
+	CustomLabels    types.Map      `tfsdk:"custom_labels"`
+	DashboardUrl    types.String   `tfsdk:"dashboard_url"`
+	Description     types.String   `tfsdk:"description"`
+	EnvironmentType types.String   `tfsdk:"environment_type"`
+	Labels          types.String   `tfsdk:"labels"`
+	LandscapeLabel  types.String   `tfsdk:"landscape_label"`
+	LastModified    types.String   `tfsdk:"last_modified"`
+	Name            types.String   `tfsdk:"name"`
+	Operation       types.String   `tfsdk:"operation"`
+	Outputs         types.Map      `tfsdk:"outputs"`
+	Parameters      types.String   `tfsdk:"parameters"`
+	PlanId          types.String   `tfsdk:"plan_id"`
+	PlanName        types.String   `tfsdk:"plan_name"`
+	PlatformId      types.String   `tfsdk:"platform_id"`
+	ServiceId       types.String   `tfsdk:"service_id"`
+	ServiceName     types.String   `tfsdk:"service_name"`
+	State           types.String   `tfsdk:"state"`
+	TenantId        types.String   `tfsdk:"tenant_id"`
+	Type_           types.String   `tfsdk:"type"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func subaccountEnvironmentInstanceResourceValueFrom(ctx context.Context, value provisioning.EnvironmentInstanceResponseObject) (subaccountEnvironmentInstanceResourceType, diag.Diagnostics) {
+	environmentInstance, diags := subaccountEnvironmentInstanceValueFrom(ctx, value)
+
+	return subaccountEnvironmentInstanceResourceType{
+		SubaccountId:    environmentInstance.SubaccountId,
+		Id:              environmentInstance.Id,
+		BrokerId:        environmentInstance.BrokerId,
+		CreatedDate:     environmentInstance.CreatedDate,
+		CustomLabels:    environmentInstance.CustomLabels,
+		DashboardUrl:    environmentInstance.DashboardUrl,
+		Description:     environmentInstance.Description,
+		EnvironmentType: environmentInstance.EnvironmentType,
+		Labels:          environmentInstance.Labels,
+		LandscapeLabel:  environmentInstance.LandscapeLabel,
+		LastModified:    environmentInstance.LastModified,
+		Name:            environmentInstance.Name,
+		Operation:       environmentInstance.Operation,
+		Outputs:         environmentInstance.Outputs,
+		Parameters:      environmentInstance.Parameters,
+		PlanId:          environmentInstance.PlanId,
+		PlanName:        environmentInstance.PlanName,
+		PlatformId:      environmentInstance.PlatformId,
+		ServiceId:       environmentInstance.ServiceId,
+		ServiceName:     environmentInstance.ServiceName,
+		State:           environmentInstance.State,
+		TenantId:        environmentInstance.TenantId,
+		Type_:           environmentInstance.Type_,
+	}, diags
+}
+
+// environmentInstanceOutputsFrom extracts the broker outputs (e.g. the API endpoint of a Cloud Foundry org or the
+// kubeconfig URL of a Kyma runtime) from the broker-specified labels. Values which are not strings are kept in their
+// JSON representation. If the labels can't be parsed, no outputs are returned.
+func environmentInstanceOutputsFrom(labels string) map[string]string {
+	outputs := map[string]string{}
+
+	var rawOutputs map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(labels), &rawOutputs); err != nil {
+		return outputs
+	}
+
+	for key, rawValue := range rawOutputs {
+		var value string
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			value = string(rawValue)
+		}
+
+		outputs[key] = value
+	}
+
+	return outputs
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/xsuaa_settings"
+)
+
+type subaccountSecuritySettingsType struct {
+	SubaccountId            types.String `tfsdk:"subaccount_id"`
+	Id                      types.String `tfsdk:"id"`
+	DefaultIdentityProvider types.String `tfsdk:"default_identity_provider"`
+	AccessTokenValidity     types.Int64  `tfsdk:"access_token_validity"`
+	RefreshTokenValidity    types.Int64  `tfsdk:"refresh_token_validity"`
+	CustomEmailDomains      types.Set    `tfsdk:"custom_email_domains"`
+}
+
+func subaccountSecuritySettingsValueFrom(ctx context.Context, subaccountId string, value xsuaa_settings.TenantSettingsResp) (subaccountSecuritySettingsType, diag.Diagnostics) {
+	settings := subaccountSecuritySettingsType{
+		SubaccountId:            types.StringValue(subaccountId),
+		Id:                      types.StringValue(subaccountId),
+		DefaultIdentityProvider: types.StringValue(value.DefaultIdp),
+		AccessTokenValidity:     types.Int64Value(int64(value.TokenPolicySettings.AccessTokenValidity)),
+		RefreshTokenValidity:    types.Int64Value(int64(value.TokenPolicySettings.RefreshTokenValidity)),
+	}
+
+	customEmailDomains := value.CustomEmailDomains
+	if customEmailDomains == nil {
+		customEmailDomains = []string{}
+	}
+
+	var diags diag.Diagnostics
+	settings.CustomEmailDomains, diags = types.SetValueFrom(ctx, types.StringType, customEmailDomains)
+
+	return settings, diags
+}
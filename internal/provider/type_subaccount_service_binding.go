@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,6 +24,7 @@ type subaccountServiceBindingType struct {
 	CreatedDate       types.String `tfsdk:"created_date"`
 	LastModified      types.String `tfsdk:"last_modified"`
 	Labels            types.Map    `tfsdk:"labels"`
+	RotateTrigger     types.String `tfsdk:"rotate_trigger"`
 }
 
 func subaccountServiceBindingValueFrom(ctx context.Context, value servicemanager.ServiceBindingResponseObject) (subaccountServiceBindingType, diag.Diagnostics) {
@@ -36,6 +38,7 @@ func subaccountServiceBindingValueFrom(ctx context.Context, value servicemanager
 		State:             types.StringValue(value.LastOperation.State),
 		CreatedDate:       timeToValue(value.CreatedAt),
 		LastModified:      timeToValue(value.UpdatedAt),
+		RotateTrigger:     types.StringNull(),
 	}
 
 	var diags, diagnostics diag.Diagnostics
@@ -51,3 +54,85 @@ func subaccountServiceBindingValueFrom(ctx context.Context, value servicemanager
 
 	return serviceBinding, diagnostics
 }
+
+type subaccountServiceBindingDataSourceType struct {
+	SubaccountId                types.String `tfsdk:"subaccount_id"`
+	ServiceInstanceId           types.String `tfsdk:"service_instance_id"`
+	ServiceInstanceName         types.String `tfsdk:"service_instance_name"`
+	Name                        types.String `tfsdk:"name"`
+	Parameters                  types.String `tfsdk:"parameters"`
+	Id                          types.String `tfsdk:"id"`
+	Ready                       types.Bool   `tfsdk:"ready"`
+	Context                     types.Map    `tfsdk:"context"`
+	BindResource                types.Map    `tfsdk:"bind_resource"`
+	Credentials                 types.String `tfsdk:"credentials"`
+	CredentialsFormat           types.String `tfsdk:"credentials_format"`
+	FormattedCredentials        types.String `tfsdk:"formatted_credentials"`
+	ParseDestinationCredentials types.Bool   `tfsdk:"parse_destination_credentials"`
+	AuthenticationType          types.String `tfsdk:"authentication_type"`
+	PrincipalPropagation        types.Bool   `tfsdk:"principal_propagation"`
+	State                       types.String `tfsdk:"state"`
+	CreatedDate                 types.String `tfsdk:"created_date"`
+	LastModified                types.String `tfsdk:"last_modified"`
+	LastRotated                 types.String `tfsdk:"last_rotated"`
+	RotationHistory             types.List   `tfsdk:"rotation_history"`
+	Labels                      types.Map    `tfsdk:"labels"`
+}
+
+// subaccountServiceBindingDataSourceValueFrom maps the CLI response onto the data source model. The `service_instance_name`
+// is only taken from the binding context, if the context doesn't contain it the caller must resolve it. The `formatted_credentials`
+// depend on the configured `credentials_format` and must be determined by the caller, as well as the authentication settings
+// depending on `parse_destination_credentials`.
+func subaccountServiceBindingDataSourceValueFrom(ctx context.Context, value servicemanager.ServiceBindingResponseObject) (subaccountServiceBindingDataSourceType, diag.Diagnostics) {
+	serviceBinding, diags := subaccountServiceBindingValueFrom(ctx, value)
+
+	lastRotated, rotationHistory, rotationDiags := serviceBindingRotationValueFrom(ctx, value)
+	diags.Append(rotationDiags...)
+
+	return subaccountServiceBindingDataSourceType{
+		SubaccountId:                serviceBinding.SubaccountId,
+		ServiceInstanceId:           serviceBinding.ServiceInstanceId,
+		ServiceInstanceName:         stringNullIfEmpty(value.Context["instance_name"]),
+		Name:                        serviceBinding.Name,
+		Parameters:                  serviceBinding.Parameters,
+		Id:                          serviceBinding.Id,
+		Ready:                       serviceBinding.Ready,
+		Context:                     serviceBinding.Context,
+		BindResource:                serviceBinding.BindResource,
+		Credentials:                 serviceBinding.Credentials,
+		CredentialsFormat:           types.StringNull(),
+		FormattedCredentials:        types.StringNull(),
+		ParseDestinationCredentials: types.BoolNull(),
+		AuthenticationType:          types.StringNull(),
+		PrincipalPropagation:        types.BoolNull(),
+		State:                       serviceBinding.State,
+		CreatedDate:                 serviceBinding.CreatedDate,
+		LastModified:                serviceBinding.LastModified,
+		LastRotated:                 lastRotated,
+		RotationHistory:             rotationHistory,
+		Labels:                      serviceBinding.Labels,
+	}, diags
+}
+
+// serviceBindingRotationValueFrom maps the rotation metadata of the binding. Both values are null if the CLI server
+// doesn't provide them. If only the history is provided, the last rotation is taken from it.
+func serviceBindingRotationValueFrom(ctx context.Context, value servicemanager.ServiceBindingResponseObject) (types.String, types.List, diag.Diagnostics) {
+	if value.RotationHistory == nil {
+		return timeToValue(value.LastRotatedAt), types.ListNull(types.StringType), nil
+	}
+
+	lastRotatedAt := value.LastRotatedAt
+	rotations := []string{}
+
+	for _, rotatedAt := range value.RotationHistory {
+		rotations = append(rotations, rotatedAt.Format(time.RFC3339))
+
+		if rotatedAt.After(lastRotatedAt) {
+			lastRotatedAt = rotatedAt
+		}
+	}
+
+	rotationHistory, diags := types.ListValueFrom(ctx, types.StringType, rotations)
+
+	return timeToValue(lastRotatedAt), rotationHistory, diags
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/SAP/terraform-provider-btp/internal/btpcli/types/servicemanager"
+)
+
+type subaccountServiceBrokerType struct {
+	SubaccountId          types.String `tfsdk:"subaccount_id"`
+	Id                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	Url                   types.String `tfsdk:"url"`
+	Username              types.String `tfsdk:"username"`
+	Password              types.String `tfsdk:"password"`
+	ReregisterOnUrlChange types.Bool   `tfsdk:"reregister_on_url_change"`
+	Ready                 types.Bool   `tfsdk:"ready"`
+	CreatedDate           types.String `tfsdk:"created_date"`
+	LastModified          types.String `tfsdk:"last_modified"`
+	Labels                types.Map    `tfsdk:"labels"`
+}
+
+func subaccountServiceBrokerValueFrom(ctx context.Context, subaccountId string, value servicemanager.ServiceBrokerResponseObject) (subaccountServiceBrokerType, diag.Diagnostics) {
+	serviceBroker := subaccountServiceBrokerType{
+		SubaccountId:          types.StringValue(subaccountId),
+		Id:                    types.StringValue(value.Id),
+		Name:                  types.StringValue(value.Name),
+		Description:           types.StringValue(value.Description),
+		Url:                   types.StringValue(value.BrokerUrl),
+		Username:              types.StringNull(),
+		Password:              types.StringNull(),
+		ReregisterOnUrlChange: types.BoolNull(),
+		Ready:                 types.BoolValue(value.Ready),
+		CreatedDate:           timeToValue(value.CreatedAt),
+		LastModified:          timeToValue(value.UpdatedAt),
+	}
+
+	var diags diag.Diagnostics
+	serviceBroker.Labels, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, value.Labels)
+
+	return serviceBroker, diags
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -16,6 +17,8 @@ type subaccountServiceInstanceType struct {
 	Parameters           types.String `tfsdk:"parameters"`
 	Ready                types.Bool   `tfsdk:"ready"`
 	ServicePlanId        types.String `tfsdk:"serviceplan_id"`
+	ServicePlanName      types.String `tfsdk:"service_plan_name"`
+	OfferingName         types.String `tfsdk:"offering_name"`
 	PlatformId           types.String `tfsdk:"platform_id"`
 	ReferencedInstanceId types.String `tfsdk:"referenced_instance_id"`
 	Shared               types.Bool   `tfsdk:"shared"`
@@ -34,10 +37,12 @@ func subaccountServiceInstanceValueFrom(ctx context.Context, value servicemanage
 		Ready:                types.BoolValue(value.Ready),
 		Name:                 types.StringValue(value.Name),
 		ServicePlanId:        types.StringValue(value.ServicePlanId),
+		ServicePlanName:      types.StringNull(),
+		OfferingName:         types.StringNull(),
 		PlatformId:           types.StringValue(value.PlatformId),
 		ReferencedInstanceId: types.StringValue(value.ReferencedInstanceId),
 		Shared:               types.BoolValue(value.Shared),
-		Usable:               types.BoolValue(value.Usable),
+		Usable:               types.BoolPointerValue(value.Usable),
 		State:                types.StringValue(value.LastOperation.State),
 		CreatedDate:          timeToValue(value.CreatedAt),
 		LastModified:         timeToValue(value.UpdatedAt),
@@ -53,3 +58,50 @@ func subaccountServiceInstanceValueFrom(ctx context.Context, value servicemanage
 
 	return serviceInstance, diagnostics
 }
+
+type subaccountServiceInstanceResourceType struct {
+	SubaccountId         types.String   `tfsdk:"subaccount_id"`
+	Id                   types.String   `tfsdk:"id"`
+	RequestedId          types.String   `tfsdk:"requested_id"`
+	Name                 types.String   `tfsdk:"name"`
+	Parameters           types.String   `tfsdk:"parameters"`
+	ParametersFile       types.String   `tfsdk:"parameters_file"`
+	ValidateParameters   types.Bool     `tfsdk:"validate_parameters"`
+	Ready                types.Bool     `tfsdk:"ready"`
+	ServicePlanId        types.String   `tfsdk:"serviceplan_id"`
+	PlatformId           types.String   `tfsdk:"platform_id"`
+	ReferencedInstanceId types.String   `tfsdk:"referenced_instance_id"`
+	Shared               types.Bool     `tfsdk:"shared"`
+	Context              types.Map      `tfsdk:"context"`
+	Usable               types.Bool     `tfsdk:"usable"`
+	State                types.String   `tfsdk:"state"`
+	CreatedDate          types.String   `tfsdk:"created_date"`
+	LastModified         types.String   `tfsdk:"last_modified"`
+	Labels               types.Map      `tfsdk:"labels"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+func subaccountServiceInstanceResourceValueFrom(ctx context.Context, value servicemanager.ServiceInstanceResponseObject) (subaccountServiceInstanceResourceType, diag.Diagnostics) {
+	serviceInstance, diags := subaccountServiceInstanceValueFrom(ctx, value)
+
+	return subaccountServiceInstanceResourceType{
+		SubaccountId:         serviceInstance.SubaccountId,
+		Id:                   serviceInstance.Id,
+		RequestedId:          types.StringNull(),
+		Name:                 serviceInstance.Name,
+		Parameters:           serviceInstance.Parameters,
+		ParametersFile:       types.StringNull(),
+		ValidateParameters:   types.BoolNull(),
+		Ready:                serviceInstance.Ready,
+		ServicePlanId:        serviceInstance.ServicePlanId,
+		PlatformId:           serviceInstance.PlatformId,
+		ReferencedInstanceId: serviceInstance.ReferencedInstanceId,
+		Shared:               serviceInstance.Shared,
+		Context:              serviceInstance.Context,
+		Usable:               serviceInstance.Usable,
+		State:                serviceInstance.State,
+		CreatedDate:          serviceInstance.CreatedDate,
+		LastModified:         serviceInstance.LastModified,
+		Labels:               serviceInstance.Labels,
+	}, diags
+}
@@ -2,7 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -78,3 +82,216 @@ func subaccountSubscriptionValueFrom(ctx context.Context, value saas_manager_ser
 
 	return subscription, diagnostics
 }
+
+type subaccountSubscriptionResourceType struct {
+	SubaccountId              types.String   `tfsdk:"subaccount_id"`
+	Id                        types.String   `tfsdk:"id"`
+	AppName                   types.String   `tfsdk:"app_name"`
+	PlanName                  types.String   `tfsdk:"plan_name"`
+	Parameters                types.String   `tfsdk:"parameters"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+	AdditionalPlanFeatures    types.Set      `tfsdk:"additional_plan_features"`
+	AppId                     types.String   `tfsdk:"app_id"`
+	AuthenticationProvider    types.String   `tfsdk:"authentication_provider"`
+	Category                  types.String   `tfsdk:"category"`
+	CommercialAppName         types.String   `tfsdk:"commercial_app_name"`
+	CreatedDate               types.String   `tfsdk:"created_date"`
+	CustomerDeveloped         types.Bool     `tfsdk:"customer_developed"`
+	Description               types.String   `tfsdk:"description"`
+	DisplayName               types.String   `tfsdk:"display_name"`
+	FormationSolutionName     types.String   `tfsdk:"formation_solution_name"`
+	GlobalAccountId           types.String   `tfsdk:"globalaccount_id"`
+	Labels                    types.Map      `tfsdk:"labels"`
+	LastModified              types.String   `tfsdk:"last_modified"`
+	PlatformEntityId          types.String   `tfsdk:"platform_entity_id"`
+	Quota                     types.Int64    `tfsdk:"quota"`
+	State                     types.String   `tfsdk:"state"`
+	SubscribedSubaccountId    types.String   `tfsdk:"subscribed_subaccount_id"`
+	SubscribedTenantId        types.String   `tfsdk:"subscribed_tenant_id"`
+	SubscriptionUrl           types.String   `tfsdk:"subscription_url"`
+	SupportsParametersUpdates types.Bool     `tfsdk:"supports_parameters_updates"`
+	SupportsPlanUpdates       types.Bool     `tfsdk:"supports_plan_updates"`
+	TenantId                  types.String   `tfsdk:"tenant_id"`
+}
+
+func subaccountSubscriptionResourceValueFrom(ctx context.Context, value saas_manager_service.EntitledApplicationsResponseObject) (subaccountSubscriptionResourceType, diag.Diagnostics) {
+	subscription, diags := subaccountSubscriptionValueFrom(ctx, value)
+
+	return subaccountSubscriptionResourceType{
+		SubaccountId:              subscription.SubaccountId,
+		Id:                        subscription.Id,
+		AppName:                   subscription.AppName,
+		PlanName:                  subscription.PlanName,
+		Parameters:                subscription.Parameters,
+		AdditionalPlanFeatures:    subscription.AdditionalPlanFeatures,
+		AppId:                     subscription.AppId,
+		AuthenticationProvider:    subscription.AuthenticationProvider,
+		Category:                  subscription.Category,
+		CommercialAppName:         subscription.CommercialAppName,
+		CreatedDate:               subscription.CreatedDate,
+		CustomerDeveloped:         subscription.CustomerDeveloped,
+		Description:               subscription.Description,
+		DisplayName:               subscription.DisplayName,
+		FormationSolutionName:     subscription.FormationSolutionName,
+		GlobalAccountId:           subscription.GlobalAccountId,
+		Labels:                    subscription.Labels,
+		LastModified:              subscription.LastModified,
+		PlatformEntityId:          subscription.PlatformEntityId,
+		Quota:                     subscription.Quota,
+		State:                     subscription.State,
+		SubscribedSubaccountId:    subscription.SubscribedSubaccountId,
+		SubscribedTenantId:        subscription.SubscribedTenantId,
+		SubscriptionUrl:           subscription.SubscriptionUrl,
+		SupportsParametersUpdates: subscription.SupportsParametersUpdates,
+		SupportsPlanUpdates:       subscription.SupportsPlanUpdates,
+		TenantId:                  subscription.TenantId,
+	}, diags
+}
+
+type subaccountSubscriptionAvailablePlanType struct {
+	PlanName    types.String `tfsdk:"plan_name"`
+	Category    types.String `tfsdk:"category"`
+	Description types.String `tfsdk:"description"`
+	Quota       types.Int64  `tfsdk:"quota"`
+	State       types.String `tfsdk:"state"`
+}
+
+var subaccountSubscriptionAvailablePlanObjType = map[string]attr.Type{
+	"plan_name":   types.StringType,
+	"category":    types.StringType,
+	"description": types.StringType,
+	"quota":       types.Int64Type,
+	"state":       types.StringType,
+}
+
+type subaccountSubscriptionDataSourceType struct {
+	SubaccountId              types.String `tfsdk:"subaccount_id"`
+	Id                        types.String `tfsdk:"id"`
+	AppName                   types.String `tfsdk:"app_name"`
+	PlanName                  types.String `tfsdk:"plan_name"`
+	FetchAvailablePlans       types.Bool   `tfsdk:"fetch_available_plans"`
+	AvailablePlans            types.List   `tfsdk:"available_plans"`
+	Recoverable               types.Bool   `tfsdk:"recoverable"`
+	Parameters                types.String `tfsdk:"parameters"`
+	AdditionalPlanFeatures    types.Set    `tfsdk:"additional_plan_features"`
+	AppId                     types.String `tfsdk:"app_id"`
+	AuthenticationProvider    types.String `tfsdk:"authentication_provider"`
+	Category                  types.String `tfsdk:"category"`
+	CommercialAppName         types.String `tfsdk:"commercial_app_name"`
+	CreatedDate               types.String `tfsdk:"created_date"`
+	CustomerDeveloped         types.Bool   `tfsdk:"customer_developed"`
+	Description               types.String `tfsdk:"description"`
+	DisplayName               types.String `tfsdk:"display_name"`
+	FormationSolutionName     types.String `tfsdk:"formation_solution_name"`
+	GlobalAccountId           types.String `tfsdk:"globalaccount_id"`
+	Labels                    types.Map    `tfsdk:"labels"`
+	LastModified              types.String `tfsdk:"last_modified"`
+	PlatformEntityId          types.String `tfsdk:"platform_entity_id"`
+	Quota                     types.Int64  `tfsdk:"quota"`
+	State                     types.String `tfsdk:"state"`
+	SubscribedSubaccountId    types.String `tfsdk:"subscribed_subaccount_id"`
+	SubscribedTenantId        types.String `tfsdk:"subscribed_tenant_id"`
+	SubscriptionUrl           types.String `tfsdk:"subscription_url"`
+	SupportsParametersUpdates types.Bool   `tfsdk:"supports_parameters_updates"`
+	SupportsPlanUpdates       types.Bool   `tfsdk:"supports_plan_updates"`
+	TenantId                  types.String `tfsdk:"tenant_id"`
+}
+
+// subaccountSubscriptionDataSourceValueFrom maps the CLI response onto the data source model. The `available_plans`
+// require an additional request and must be determined by the caller.
+func subaccountSubscriptionDataSourceValueFrom(ctx context.Context, value saas_manager_service.EntitledApplicationsResponseObject) (subaccountSubscriptionDataSourceType, diag.Diagnostics) {
+	subscription, diags := subaccountSubscriptionValueFrom(ctx, value)
+
+	return subaccountSubscriptionDataSourceType{
+		SubaccountId:              subscription.SubaccountId,
+		Id:                        subscription.Id,
+		AppName:                   subscription.AppName,
+		PlanName:                  subscription.PlanName,
+		AvailablePlans:            types.ListNull(types.ObjectType{AttrTypes: subaccountSubscriptionAvailablePlanObjType}),
+		Recoverable:               types.BoolValue(subscriptionFailureIsRecoverable(value)),
+		Parameters:                subscription.Parameters,
+		AdditionalPlanFeatures:    subscription.AdditionalPlanFeatures,
+		AppId:                     subscription.AppId,
+		AuthenticationProvider:    subscription.AuthenticationProvider,
+		Category:                  subscription.Category,
+		CommercialAppName:         subscription.CommercialAppName,
+		CreatedDate:               subscription.CreatedDate,
+		CustomerDeveloped:         subscription.CustomerDeveloped,
+		Description:               subscription.Description,
+		DisplayName:               subscription.DisplayName,
+		FormationSolutionName:     subscription.FormationSolutionName,
+		GlobalAccountId:           subscription.GlobalAccountId,
+		Labels:                    subscription.Labels,
+		LastModified:              subscription.LastModified,
+		PlatformEntityId:          subscription.PlatformEntityId,
+		Quota:                     subscription.Quota,
+		State:                     subscription.State,
+		SubscribedSubaccountId:    subscription.SubscribedSubaccountId,
+		SubscribedTenantId:        subscription.SubscribedTenantId,
+		SubscriptionUrl:           subscription.SubscriptionUrl,
+		SupportsParametersUpdates: subscription.SupportsParametersUpdates,
+		SupportsPlanUpdates:       subscription.SupportsPlanUpdates,
+		TenantId:                  subscription.TenantId,
+	}, diags
+}
+
+// subaccountSubscriptionAvailablePlansFrom determines the plans of the given application among the entitled
+// applications of a subaccount, sorted by plan name.
+func subaccountSubscriptionAvailablePlansFrom(ctx context.Context, appName string, values []saas_manager_service.EntitledApplicationsResponseObject) (types.List, diag.Diagnostics) {
+	plans := []subaccountSubscriptionAvailablePlanType{}
+
+	for _, value := range values {
+		if value.AppName != appName {
+			continue
+		}
+
+		plans = append(plans, subaccountSubscriptionAvailablePlanType{
+			PlanName:    types.StringValue(value.PlanName),
+			Category:    types.StringValue(value.Category),
+			Description: types.StringValue(value.PlanDescription),
+			Quota:       types.Int64Value(int64(value.Quota)),
+			State:       types.StringValue(value.State),
+		})
+	}
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].PlanName.ValueString() < plans[j].PlanName.ValueString()
+	})
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: subaccountSubscriptionAvailablePlanObjType}, plans)
+}
+
+// transientAppErrorStatuses are the statuses of app providers which indicate that a failed subscription is likely to
+// succeed when retried, i.e. timeouts, rate limits and unavailable app providers.
+var transientAppErrorStatuses = map[string]bool{
+	"408": true,
+	"429": true,
+	"502": true,
+	"503": true,
+	"504": true,
+}
+
+// subscriptionFailureIsRecoverable reports whether a failed subscription is likely to succeed when retried. This is
+// decided by the status the app provider responded with, which is passed on in the `appError`. Failures without such
+// a status are considered permanent.
+func subscriptionFailureIsRecoverable(value saas_manager_service.EntitledApplicationsResponseObject) bool {
+	switch value.State {
+	case saas_manager_service.StateSubscribeFailed, saas_manager_service.StateUnsubscribeFailed, saas_manager_service.StateUpdateFailed, saas_manager_service.StateUpdateParametersFailed:
+	default:
+		return false
+	}
+
+	if value.SubscriptionError == nil || len(value.SubscriptionError.AppError) == 0 {
+		return false
+	}
+
+	var appError struct {
+		Status json.Number `json:"status"`
+	}
+
+	if err := json.Unmarshal([]byte(value.SubscriptionError.AppError), &appError); err != nil {
+		return false
+	}
+
+	return transientAppErrorStatuses[appError.Status.String()]
+}
@@ -16,8 +16,11 @@ type subaccountTrustConfigurationType struct {
 	Type             types.String `tfsdk:"type"`
 	IdentityProvider types.String `tfsdk:"identity_provider"`
 	Protocol         types.String `tfsdk:"protocol"`
+	ProtocolType     types.String `tfsdk:"protocol_type"`
 	Status           types.String `tfsdk:"status"`
 	ReadOnly         types.Bool   `tfsdk:"read_only"`
+	FetchMetadata    types.Bool   `tfsdk:"fetch_metadata"`
+	Metadata         types.String `tfsdk:"metadata"`
 }
 
 func subaccountTrustConfigurationFromValue(ctx context.Context, value xsuaa_trust.TrustConfigurationResponseObject) (subaccountTrustConfigurationType, diag.Diagnostics) {
@@ -30,7 +33,52 @@ func subaccountTrustConfigurationFromValue(ctx context.Context, value xsuaa_trus
 		Type:             types.StringValue(value.TypeOfTrust),
 		IdentityProvider: types.StringValue(value.IdentityProvider),
 		Protocol:         types.StringValue(value.Protocol),
+		ProtocolType:     types.StringValue(trustProtocolType(value.Protocol)),
 		Status:           types.StringValue(value.Status),
 		ReadOnly:         types.BoolValue(value.ReadOnly),
+		FetchMetadata:    types.BoolNull(),
+		Metadata:         stringNullIfEmpty(value.Metadata),
 	}, diag.Diagnostics{}
 }
+
+type subaccountTrustConfigurationResourceType struct {
+	SubaccountId     types.String `tfsdk:"subaccount_id"`
+	Origin           types.String `tfsdk:"origin"`
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	Type             types.String `tfsdk:"type"`
+	IdentityProvider types.String `tfsdk:"identity_provider"`
+	IssuerUrl        types.String `tfsdk:"issuer_url"`
+	ClientId         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	Protocol         types.String `tfsdk:"protocol"`
+	ProtocolType     types.String `tfsdk:"protocol_type"`
+	Status           types.String `tfsdk:"status"`
+	ReadOnly         types.Bool   `tfsdk:"read_only"`
+	FetchMetadata    types.Bool   `tfsdk:"fetch_metadata"`
+	Metadata         types.String `tfsdk:"metadata"`
+}
+
+func subaccountTrustConfigurationResourceValueFrom(ctx context.Context, value xsuaa_trust.TrustConfigurationResponseObject) (subaccountTrustConfigurationResourceType, diag.Diagnostics) {
+	trust, diags := subaccountTrustConfigurationFromValue(ctx, value)
+
+	return subaccountTrustConfigurationResourceType{
+		SubaccountId:     trust.SubaccountId,
+		Origin:           trust.Origin,
+		Id:               trust.Id,
+		Name:             trust.Name,
+		Description:      trust.Description,
+		Type:             trust.Type,
+		IdentityProvider: trust.IdentityProvider,
+		IssuerUrl:        types.StringNull(),
+		ClientId:         types.StringNull(),
+		ClientSecret:     types.StringNull(),
+		Protocol:         trust.Protocol,
+		ProtocolType:     trust.ProtocolType,
+		Status:           trust.Status,
+		ReadOnly:         trust.ReadOnly,
+		FetchMetadata:    trust.FetchMetadata,
+		Metadata:         trust.Metadata,
+	}, diags
+}
@@ -5,6 +5,7 @@ package tfutils
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 )
@@ -31,6 +32,7 @@ type StateChangeConf struct {
 	Target         []string         // Target state
 	Timeout        time.Duration    // The amount of time to wait before timeout
 	MinTimeout     time.Duration    // Smallest time to wait before refreshes
+	MaxTimeout     time.Duration    // Largest time to wait before refreshes with the backoff (default: 10s)
 	PollInterval   time.Duration    // Override MinTimeout/backoff and only poll this often
 	NotFoundChecks int              // Number of times to allow not found (nil result from Refresh)
 
@@ -189,14 +191,24 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 			}
 
 			// If a poll interval has been specified, choose that interval.
-			// Otherwise bound the default value.
+			// Otherwise bound the default value. The upper bound never
+			// undercuts the MinTimeout, so that the backoff doesn't poll
+			// more often than configured.
 			if conf.PollInterval > 0 && conf.PollInterval < 180*time.Second {
 				wait = conf.PollInterval
 			} else {
+				maxWait := conf.MaxTimeout
+				if maxWait <= 0 {
+					maxWait = 10 * time.Second
+				}
+				if conf.MinTimeout > maxWait {
+					maxWait = conf.MinTimeout
+				}
+
 				if wait < conf.MinTimeout {
 					wait = conf.MinTimeout
-				} else if wait > 10*time.Second {
-					wait = 10 * time.Second
+				} else if wait > maxWait {
+					wait = maxWait
 				}
 			}
 
@@ -225,6 +237,17 @@ func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface
 			lastResult = r
 		case <-ctx.Done():
 			close(cancelCh)
+
+			// a deadline of the context is reported like a timeout, so that the last observed state isn't lost
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && lastResult.State != "" {
+				return nil, &TimeoutError{
+					LastError:     lastResult.Error,
+					LastState:     lastResult.State,
+					Timeout:       conf.Timeout,
+					ExpectedState: conf.Target,
+				}
+			}
+
 			return nil, ctx.Err()
 		case <-timeout:
 			log.Printf("[WARN] WaitForState timeout after %s", conf.Timeout)
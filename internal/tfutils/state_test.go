@@ -265,6 +265,84 @@ func TestWaitForState_success(t *testing.T) {
 	}
 }
 
+func TestWaitForState_minTimeoutHonored(t *testing.T) {
+	t.Parallel()
+
+	const minTimeout = 300 * time.Millisecond
+
+	refreshes := []time.Time{}
+	states := NewStateGenerator([]string{"pending", "pending", "pending", "running"})
+
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			refreshes = append(refreshes, time.Now())
+			idx, s, err := states.NextState()
+			return idx, s, err
+		},
+		Timeout:    10 * time.Second,
+		MinTimeout: minTimeout,
+	}
+
+	if _, err := conf.WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(refreshes) != 4 {
+		t.Fatalf("expected 4 refreshes, got %d", len(refreshes))
+	}
+
+	// the first wait equals the MinTimeout, the subsequent ones back off but never undercut it
+	if gap := refreshes[1].Sub(refreshes[0]); gap < minTimeout || gap > 2*minTimeout {
+		t.Fatalf("expected the second refresh to happen after %s, got %s", minTimeout, gap)
+	}
+
+	for i := 2; i < len(refreshes); i++ {
+		if gap := refreshes[i].Sub(refreshes[i-1]); gap < minTimeout {
+			t.Fatalf("expected refresh %d to happen not before %s, got %s", i+1, minTimeout, gap)
+		}
+	}
+}
+
+func TestWaitForState_maxTimeoutHonored(t *testing.T) {
+	t.Parallel()
+
+	const minTimeout = 50 * time.Millisecond
+	const maxTimeout = 120 * time.Millisecond
+
+	refreshes := []time.Time{}
+	states := NewStateGenerator([]string{"pending", "pending", "pending", "pending", "pending", "running"})
+
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			refreshes = append(refreshes, time.Now())
+			idx, s, err := states.NextState()
+			return idx, s, err
+		},
+		Timeout:    10 * time.Second,
+		MinTimeout: minTimeout,
+		MaxTimeout: maxTimeout,
+	}
+
+	if _, err := conf.WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(refreshes) != 6 {
+		t.Fatalf("expected 6 refreshes, got %d", len(refreshes))
+	}
+
+	// the waits double from the MinTimeout (50ms, 100ms, 200ms, 400ms, ...), but are capped by the MaxTimeout
+	for i := 4; i < len(refreshes); i++ {
+		if gap := refreshes[i].Sub(refreshes[i-1]); gap < maxTimeout || gap >= 2*maxTimeout {
+			t.Fatalf("expected refresh %d to happen after %s, got %s", i+1, maxTimeout, gap)
+		}
+	}
+}
+
 func TestWaitForState_successUnknownPending(t *testing.T) {
 	t.Parallel()
 
@@ -395,3 +473,36 @@ func TestWaitForStateContext_cancel(t *testing.T) {
 		t.Fatalf("Expected canceled context error, got: %s", err)
 	}
 }
+
+func TestWaitForStateContext_deadlineReportsLastState(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			return struct{}{}, "pending", nil
+		},
+		Timeout:      10 * time.Minute,
+		PollInterval: 5 * time.Millisecond,
+	}
+
+	obj, err := conf.WaitForStateContext(ctx)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected timeout error, got: %v", err)
+	}
+
+	expectedErr := "timeout while waiting for state to become 'running' (last state: 'pending', timeout: 10m0s)"
+	if err.Error() != expectedErr {
+		t.Fatalf("Errors don't match.\nExpected: %q\nGiven: %q\n", expectedErr, err.Error())
+	}
+
+	if obj != nil {
+		t.Fatalf("should not return obj")
+	}
+}
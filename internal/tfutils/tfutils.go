@@ -4,11 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-const btpcliTag = "btpcli"
+const (
+	btpcliTag = "btpcli"
+	// encodeAsJSONOption marks fields whose value is passed to the CLI server as JSON, e.g. `btpcli:"customProperties,encodeasjson"`
+	encodeAsJSONOption = "encodeasjson"
+)
 
 type any interface{}
 type equalityPredicate[E any] func(E, E) bool
@@ -48,7 +55,7 @@ func ToBTPCLIParamsMap(a any) (map[string]string, error) {
 
 	for i := 0; i < v.NumField(); i++ {
 		fieldProps := v.Type().Field(i)
-		tagValue := fieldProps.Tag.Get(btpcliTag)
+		tagValue, encodeAsJSON := parseBTPCLITag(fieldProps.Tag.Get(btpcliTag))
 
 		if len(tagValue) == 0 {
 			continue
@@ -62,6 +69,44 @@ func ToBTPCLIParamsMap(a any) (map[string]string, error) {
 
 		var value string
 
+		if encodeAsJSON && isStringCollection(fieldProps.Type.String()) {
+			values, skip, err := stringElementsOf(field.Interface(), tagValue)
+			if err != nil {
+				return nil, err
+			}
+
+			if skip {
+				continue
+			}
+
+			valueArr, err := json.Marshal(values)
+
+			if err != nil {
+				return nil, err
+			}
+
+			out[tagValue] = string(valueArr)
+			continue
+		}
+
+		if encodeAsJSON {
+			switch field.Kind() {
+			case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+				if field.IsNil() {
+					continue
+				}
+			}
+
+			valueArr, err := json.Marshal(field.Interface())
+
+			if err != nil {
+				return nil, err
+			}
+
+			out[tagValue] = string(valueArr)
+			continue
+		}
+
 		switch fieldProps.Type.String() {
 		case "basetypes.StringValue":
 			fieldVal := field.Interface().(types.String)
@@ -79,10 +124,33 @@ func ToBTPCLIParamsMap(a any) (map[string]string, error) {
 			}
 
 			value = fmt.Sprintf("%v", fieldVal.ValueBool())
+		case "basetypes.Int64Value":
+			fieldVal := field.Interface().(types.Int64)
+
+			if fieldVal.IsUnknown() || fieldVal.IsNull() {
+				continue
+			}
+
+			value = strconv.FormatInt(fieldVal.ValueInt64(), 10)
+		case "basetypes.Float64Value":
+			fieldVal := field.Interface().(types.Float64)
+
+			if fieldVal.IsUnknown() || fieldVal.IsNull() {
+				continue
+			}
+
+			// the CLI server doesn't accept the scientific notation, e.g. for large values
+			value = strconv.FormatFloat(fieldVal.ValueFloat64(), 'f', -1, 64)
 		case "bool":
 			fieldVal := field.Interface().(bool)
 
 			value = fmt.Sprintf("%v", fieldVal)
+		case "*bool":
+			if field.IsNil() {
+				continue
+			}
+
+			value = fmt.Sprintf("%v", field.Elem().Interface().(bool))
 		case "string":
 			fieldVal := field.Interface().(string)
 
@@ -97,8 +165,25 @@ func ToBTPCLIParamsMap(a any) (map[string]string, error) {
 			}
 
 			value = field.Elem().Interface().(string)
-		case "map[string][]string": // TODO would be nice to have `encodethisasjson` tag, instead of an explicit type mapping
+		case "*int":
+			if field.IsNil() {
+				continue
+			}
+
+			value = fmt.Sprintf("%d", field.Elem().Interface().(int))
+		case "[]string", "basetypes.ListValue", "basetypes.SetValue":
+			values, skip, err := stringElementsOf(field.Interface(), tagValue)
+			if err != nil {
+				return nil, err
+			}
+
+			if skip || len(values) == 0 {
+				continue
+			}
 
+			value = strings.Join(values, ",")
+		case "map[string][]string":
+			// prefer the encodeasjson option, this case only remains for fields tagged without it
 			if field.IsNil() {
 				continue
 			}
@@ -120,6 +205,271 @@ func ToBTPCLIParamsMap(a any) (map[string]string, error) {
 	return out, nil
 }
 
+// FromBTPCLIParamsMap is the inverse of ToBTPCLIParamsMap. It populates the fields of the struct out points to with
+// the values of the map, using the same `btpcli` tags. Fields without a value in the map are set to null, or to their
+// zero value if they aren't terraform types.
+func FromBTPCLIParamsMap(m map[string]string, out any) error {
+	v := reflect.ValueOf(out)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported type: expected a pointer to a struct, got %T", out)
+	}
+
+	v = v.Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		fieldProps := v.Type().Field(i)
+		tagValue, encodeAsJSON := parseBTPCLITag(fieldProps.Tag.Get(btpcliTag))
+
+		if len(tagValue) == 0 {
+			continue
+		}
+
+		field := v.Field(i)
+
+		if !field.CanSet() {
+			return fmt.Errorf("the field '%s' assigned to '%s' can't be set", fieldProps.Name, tagValue)
+		}
+
+		value, ok := m[tagValue]
+
+		if encodeAsJSON && isStringCollection(fieldProps.Type.String()) {
+			var values []string
+
+			if ok {
+				if err := json.Unmarshal([]byte(value), &values); err != nil {
+					return fmt.Errorf("the value of '%s' is not valid JSON: %w", tagValue, err)
+				}
+			}
+
+			setStringElements(field, fieldProps.Type.String(), values, ok)
+			continue
+		}
+
+		if encodeAsJSON {
+			field.Set(reflect.Zero(field.Type()))
+
+			if ok {
+				if err := json.Unmarshal([]byte(value), field.Addr().Interface()); err != nil {
+					return fmt.Errorf("the value of '%s' is not valid JSON: %w", tagValue, err)
+				}
+			}
+			continue
+		}
+
+		switch fieldProps.Type.String() {
+		case "basetypes.StringValue":
+			if !ok {
+				field.Set(reflect.ValueOf(types.StringNull()))
+				continue
+			}
+
+			field.Set(reflect.ValueOf(types.StringValue(value)))
+		case "basetypes.BoolValue":
+			if !ok {
+				field.Set(reflect.ValueOf(types.BoolNull()))
+				continue
+			}
+
+			boolValue, err := parseBTPCLIBool(tagValue, value)
+			if err != nil {
+				return err
+			}
+
+			field.Set(reflect.ValueOf(types.BoolValue(boolValue)))
+		case "basetypes.Int64Value":
+			if !ok {
+				field.Set(reflect.ValueOf(types.Int64Null()))
+				continue
+			}
+
+			intValue, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("the value '%s' of '%s' is not a valid integer", value, tagValue)
+			}
+
+			field.Set(reflect.ValueOf(types.Int64Value(intValue)))
+		case "basetypes.Float64Value":
+			if !ok {
+				field.Set(reflect.ValueOf(types.Float64Null()))
+				continue
+			}
+
+			floatValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("the value '%s' of '%s' is not a valid number", value, tagValue)
+			}
+
+			field.Set(reflect.ValueOf(types.Float64Value(floatValue)))
+		case "bool":
+			boolValue := false
+
+			if ok {
+				var err error
+				if boolValue, err = parseBTPCLIBool(tagValue, value); err != nil {
+					return err
+				}
+			}
+
+			field.SetBool(boolValue)
+		case "*bool":
+			field.Set(reflect.Zero(field.Type()))
+
+			if ok {
+				boolValue, err := parseBTPCLIBool(tagValue, value)
+				if err != nil {
+					return err
+				}
+
+				field.Set(reflect.ValueOf(&boolValue))
+			}
+		case "string":
+			field.SetString(value)
+		case "*string":
+			field.Set(reflect.Zero(field.Type()))
+
+			if ok {
+				field.Set(reflect.ValueOf(&value))
+			}
+		case "*int":
+			field.Set(reflect.Zero(field.Type()))
+
+			if ok {
+				intValue, err := strconv.Atoi(value)
+				if err != nil {
+					return fmt.Errorf("the value '%s' of '%s' is not a valid integer", value, tagValue)
+				}
+
+				field.Set(reflect.ValueOf(&intValue))
+			}
+		case "[]string", "basetypes.ListValue", "basetypes.SetValue":
+			var values []string
+
+			if ok && len(value) > 0 {
+				values = strings.Split(value, ",")
+			}
+
+			setStringElements(field, fieldProps.Type.String(), values, ok)
+		case "map[string][]string":
+			field.Set(reflect.Zero(field.Type()))
+
+			if ok {
+				if err := json.Unmarshal([]byte(value), field.Addr().Interface()); err != nil {
+					return fmt.Errorf("the value of '%s' is not valid JSON: %w", tagValue, err)
+				}
+			}
+		default:
+			return fmt.Errorf("the type '%s' assigned to '%s' is not yet supported", fieldProps.Type.String(), tagValue)
+		}
+	}
+
+	return nil
+}
+
+// isStringCollection reports whether the type is one of the collections of strings supported by ToBTPCLIParamsMap.
+func isStringCollection(typeName string) bool {
+	switch typeName {
+	case "[]string", "basetypes.ListValue", "basetypes.SetValue":
+		return true
+	default:
+		return false
+	}
+}
+
+// stringElementsOf returns the elements of a []string, or of a list or set of strings. Null and unknown lists, as well as
+// lists with unknown elements, are skipped. Nil slices are skipped too, while empty ones are not.
+func stringElementsOf(value any, tagValue string) (values []string, skip bool, err error) {
+	var elements []attr.Value
+
+	switch collection := value.(type) {
+	case []string:
+		return collection, collection == nil, nil
+	case types.List:
+		if collection.IsNull() || collection.IsUnknown() {
+			return nil, true, nil
+		}
+
+		elements = collection.Elements()
+	case types.Set:
+		if collection.IsNull() || collection.IsUnknown() {
+			return nil, true, nil
+		}
+
+		elements = collection.Elements()
+	}
+
+	values = []string{}
+
+	for _, element := range elements {
+		stringElement, ok := element.(types.String)
+		if !ok {
+			return nil, false, fmt.Errorf("the elements assigned to '%s' must be strings, got: %T", tagValue, element)
+		}
+
+		if stringElement.IsUnknown() {
+			return nil, true, nil
+		}
+
+		values = append(values, stringElement.ValueString())
+	}
+
+	return values, false, nil
+}
+
+// setStringElements sets the field of one of the types supported by isStringCollection to the values. If the values
+// are missing, lists and sets are set to null and slices to nil.
+func setStringElements(field reflect.Value, typeName string, values []string, ok bool) {
+	elements := []attr.Value{}
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+
+	switch typeName {
+	case "[]string":
+		field.Set(reflect.ValueOf(values))
+	case "basetypes.ListValue":
+		if !ok {
+			field.Set(reflect.ValueOf(types.ListNull(types.StringType)))
+			return
+		}
+
+		field.Set(reflect.ValueOf(types.ListValueMust(types.StringType, elements)))
+	case "basetypes.SetValue":
+		if !ok {
+			field.Set(reflect.ValueOf(types.SetNull(types.StringType)))
+			return
+		}
+
+		field.Set(reflect.ValueOf(types.SetValueMust(types.StringType, elements)))
+	}
+}
+
+// parseBTPCLITag splits a `btpcli` tag into the name of the parameter and its options, e.g. `btpcli:"labels,encodeasjson"`.
+// Fields with the option encodeasjson are passed as JSON regardless of their type. Unknown options are ignored.
+func parseBTPCLITag(tag string) (name string, encodeAsJSON bool) {
+	name, options, _ := strings.Cut(tag, ",")
+
+	for _, option := range strings.Split(options, ",") {
+		if strings.TrimSpace(option) == encodeAsJSONOption {
+			encodeAsJSON = true
+		}
+	}
+
+	return name, encodeAsJSON
+}
+
+// parseBTPCLIBool converts the value of a boolean parameter as produced by ToBTPCLIParamsMap.
+func parseBTPCLIBool(tagValue string, value string) (bool, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("the value '%s' of '%s' is not a valid boolean", value, tagValue)
+	}
+}
+
 // TODO This is a utility function to compute to be removed and to be added substructures in resource configurations.
 // TODO This is required since terraform only computes required CRUD operations on resource level. Changes in inner
 // TODO configurations need to be computed based on the state and plan data by the update operation of a provider.
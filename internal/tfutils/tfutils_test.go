@@ -3,6 +3,7 @@ package tfutils
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -97,12 +98,185 @@ func TestToBTPCLIParamsMap(t *testing.T) {
 			},
 		},
 		{
-			description: "error case - unsupported attribute type",
+			description: "happy path - fields encoded as JSON",
+			uut: struct {
+				AStringField types.String        `tfsdk:"a_string_field" btpcli:"aStringField"`
+				ASliceField  []map[string]string `btpcli:"aSliceField,encodeasjson"`
+				ANilField    []string            `btpcli:"aNilField,encodeasjson"`
+			}{
+				AStringField: types.StringValue("a value"),
+				ASliceField:  []map[string]string{{"key": "a key", "value": "a value"}},
+			},
+			expects: expects{
+				output: map[string]string{
+					"aStringField": "a value",
+					"aSliceField":  `[{"key":"a key","value":"a value"}]`,
+				},
+			},
+		},
+		{
+			description: "happy path - nested values of any type encoded as JSON",
+			uut: struct {
+				AParamsField map[string]any      `btpcli:"aParamsField,encodeasjson"`
+				ALabelsField map[string][]string `btpcli:"aLabelsField,encodeasjson"`
+				AnIntField   int                 `btpcli:"anIntField, encodeasjson"`
+				AStructField *struct {
+					Enabled bool `json:"enabled"`
+				} `btpcli:"aStructField,someotheroption,encodeasjson"`
+			}{
+				AParamsField: map[string]any{"xsappname": "my-app", "oauth2-configuration": map[string]any{"redirect-uris": []string{"https://*.example.com/**"}}},
+				ALabelsField: map[string][]string{},
+				AnIntField:   3,
+				AStructField: &struct {
+					Enabled bool `json:"enabled"`
+				}{Enabled: true},
+			},
+			expects: expects{
+				output: map[string]string{
+					"aParamsField": `{"oauth2-configuration":{"redirect-uris":["https://*.example.com/**"]},"xsappname":"my-app"}`,
+					"aLabelsField": `{}`,
+					"anIntField":   `3`,
+					"aStructField": `{"enabled":true}`,
+				},
+			},
+		},
+		{
+			description: "happy path - nil pointers get skipped",
+			uut: struct {
+				ABoolField    *bool   `btpcli:"aBoolField"`
+				ANilBoolField *bool   `btpcli:"aNilBoolField"`
+				ANilString    *string `btpcli:"aNilString"`
+				AnIntField    *int    `btpcli:"anIntField"`
+				ANilIntField  *int    `btpcli:"aNilIntField"`
+			}{
+				ABoolField: &[]bool{false}[0],
+				AnIntField: &[]int{-1}[0],
+			},
+			expects: expects{
+				output: map[string]string{
+					"aBoolField": "false",
+					"anIntField": "-1",
+				},
+			},
+		},
+		{
+			description: "happy path - int64 values",
+			uut: struct {
+				AZero     types.Int64 `tfsdk:"a_zero" btpcli:"aZero"`
+				ANegative types.Int64 `tfsdk:"a_negative" btpcli:"aNegative"`
+				ALarge    types.Int64 `tfsdk:"a_large" btpcli:"aLarge"`
+				ANull     types.Int64 `tfsdk:"a_null" btpcli:"aNull"`
+				AnUnknown types.Int64 `tfsdk:"an_unknown" btpcli:"anUnknown"`
+			}{
+				AZero:     types.Int64Value(0),
+				ANegative: types.Int64Value(-1),
+				ALarge:    types.Int64Value(9223372036854775807),
+				ANull:     types.Int64Null(),
+				AnUnknown: types.Int64Unknown(),
+			},
+			expects: expects{
+				output: map[string]string{
+					"aZero":     "0",
+					"aNegative": "-1",
+					"aLarge":    "9223372036854775807",
+				},
+			},
+		},
+		{
+			description: "happy path - float64 values are formatted without scientific notation",
+			uut: struct {
+				AZero     types.Float64 `tfsdk:"a_zero" btpcli:"aZero"`
+				ANegative types.Float64 `tfsdk:"a_negative" btpcli:"aNegative"`
+				ALarge    types.Float64 `tfsdk:"a_large" btpcli:"aLarge"`
+				ASmall    types.Float64 `tfsdk:"a_small" btpcli:"aSmall"`
+				ANull     types.Float64 `tfsdk:"a_null" btpcli:"aNull"`
+				AnUnknown types.Float64 `tfsdk:"an_unknown" btpcli:"anUnknown"`
+			}{
+				AZero:     types.Float64Value(0),
+				ANegative: types.Float64Value(-2.5),
+				ALarge:    types.Float64Value(1e21),
+				ASmall:    types.Float64Value(0.000001),
+				ANull:     types.Float64Null(),
+				AnUnknown: types.Float64Unknown(),
+			},
+			expects: expects{
+				output: map[string]string{
+					"aZero":     "0",
+					"aNegative": "-2.5",
+					"aLarge":    "1000000000000000000000",
+					"aSmall":    "0.000001",
+				},
+			},
+		},
+		{
+			description: "happy path - lists of strings are joined",
+			uut: struct {
+				ASliceField    []string   `btpcli:"aSliceField"`
+				AListField     types.List `tfsdk:"a_list" btpcli:"aListField"`
+				ASetField      types.Set  `tfsdk:"a_set" btpcli:"aSetField"`
+				ANilSlice      []string   `btpcli:"aNilSlice"`
+				AnEmptySlice   []string   `btpcli:"anEmptySlice"`
+				ANullList      types.List `tfsdk:"a_null_list" btpcli:"aNullList"`
+				AnUnknownSet   types.Set  `tfsdk:"an_unknown_set" btpcli:"anUnknownSet"`
+				AnEmptyList    types.List `tfsdk:"an_empty_list" btpcli:"anEmptyList"`
+				AnUnknownValue types.List `tfsdk:"an_unknown_value" btpcli:"anUnknownValue"`
+			}{
+				ASliceField:    []string{"john.doe@test.com", "jane.doe@test.com"},
+				AListField:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Subaccount Viewer"), types.StringValue("Subaccount Administrator")}),
+				ASetField:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("a value")}),
+				AnEmptySlice:   []string{},
+				ANullList:      types.ListNull(types.StringType),
+				AnUnknownSet:   types.SetUnknown(types.StringType),
+				AnEmptyList:    types.ListValueMust(types.StringType, []attr.Value{}),
+				AnUnknownValue: types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()}),
+			},
+			expects: expects{
+				output: map[string]string{
+					"aSliceField": "john.doe@test.com,jane.doe@test.com",
+					"aListField":  "Subaccount Viewer,Subaccount Administrator",
+					"aSetField":   "a value",
+				},
+			},
+		},
+		{
+			description: "happy path - lists of strings encoded as JSON",
+			uut: struct {
+				AListField   types.List `tfsdk:"a_list" btpcli:"aListField,encodeasjson"`
+				AnEmptySet   types.Set  `tfsdk:"an_empty_set" btpcli:"anEmptySet,encodeasjson"`
+				ANullList    types.List `tfsdk:"a_null_list" btpcli:"aNullList,encodeasjson"`
+				AnEmptySlice []string   `btpcli:"anEmptySlice,encodeasjson"`
+			}{
+				AListField:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a,b"), types.StringValue("c")}),
+				AnEmptySet:   types.SetValueMust(types.StringType, []attr.Value{}),
+				ANullList:    types.ListNull(types.StringType),
+				AnEmptySlice: []string{},
+			},
+			expects: expects{
+				output: map[string]string{
+					"aListField":   `["a,b","c"]`,
+					"anEmptySet":   `[]`,
+					"anEmptySlice": `[]`,
+				},
+			},
+		},
+		{
+			description: "error case - list of other elements than strings",
 			uut: struct {
 				AListField types.List `tfsdk:"a_list" btpcli:"aList"`
+			}{
+				AListField: types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1)}),
+			},
+			expects: expects{
+				errorMessage: "the elements assigned to 'aList' must be strings, got: basetypes.Int64Value",
+			},
+		},
+		{
+			description: "error case - unsupported attribute type",
+			uut: struct {
+				AMapField types.Map `tfsdk:"a_map" btpcli:"aMap"`
 			}{},
 			expects: expects{
-				errorMessage: "the type 'basetypes.ListValue' assigned to 'aList' is not yet supported",
+				errorMessage: "the type 'basetypes.MapValue' assigned to 'aMap' is not yet supported",
 			},
 		},
 		// TODO check that strings get properly escaped
@@ -122,3 +296,113 @@ func TestToBTPCLIParamsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestFromBTPCLIParamsMap(t *testing.T) {
+	type params struct {
+		AStringField  types.String        `btpcli:"aStringField"`
+		ABoolField    types.Bool          `btpcli:"aBoolField"`
+		ANullField    types.String        `btpcli:"aNullField"`
+		ANullBool     types.Bool          `btpcli:"aNullBool"`
+		APlainBool    *bool               `btpcli:"aPlainBool"`
+		AnIntField    *int                `btpcli:"anIntField"`
+		ALabelsField  map[string][]string `btpcli:"aLabelsField"`
+		AJSONField    []string            `btpcli:"aJSONField,encodeasjson"`
+		AnIgnoredTag  types.String        `tfsdk:"an_ignored_tag"`
+		ANilPlainBool *bool               `btpcli:"aNilPlainBool"`
+		AnInt64Field  types.Int64         `btpcli:"anInt64Field"`
+		AFloat64Field types.Float64       `btpcli:"aFloat64Field"`
+		ASliceField   []string            `btpcli:"aSliceField"`
+		AListField    types.List          `btpcli:"aListField"`
+		ASetField     types.Set           `btpcli:"aSetField,encodeasjson"`
+	}
+
+	t.Run("happy path - values are converted and missing keys are null", func(t *testing.T) {
+		var uut params
+
+		err := FromBTPCLIParamsMap(map[string]string{
+			"aStringField": "a value",
+			"aBoolField":   "true",
+			"aPlainBool":   "false",
+			"anIntField":   "-1",
+			"aLabelsField": `{"owner":["alice"]}`,
+			"aJSONField":   `["a","b"]`,
+		}, &uut)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, types.StringValue("a value"), uut.AStringField)
+			assert.Equal(t, types.BoolValue(true), uut.ABoolField)
+			assert.Equal(t, types.StringNull(), uut.ANullField)
+			assert.Equal(t, types.BoolNull(), uut.ANullBool)
+			assert.Equal(t, &[]bool{false}[0], uut.APlainBool)
+			assert.Equal(t, &[]int{-1}[0], uut.AnIntField)
+			assert.Equal(t, map[string][]string{"owner": {"alice"}}, uut.ALabelsField)
+			assert.Equal(t, []string{"a", "b"}, uut.AJSONField)
+			assert.Nil(t, uut.ANilPlainBool)
+			assert.Equal(t, types.Int64Null(), uut.AnInt64Field)
+			assert.Equal(t, types.Float64Null(), uut.AFloat64Field)
+			assert.Nil(t, uut.ASliceField)
+			assert.Equal(t, types.ListNull(types.StringType), uut.AListField)
+			assert.Equal(t, types.SetNull(types.StringType), uut.ASetField)
+			// fields without btpcli tag are left untouched
+			assert.Equal(t, types.String{}, uut.AnIgnoredTag)
+		}
+	})
+
+	t.Run("happy path - round trip", func(t *testing.T) {
+		in := params{
+			AStringField:  types.StringValue("a value"),
+			ABoolField:    types.BoolValue(false),
+			ANullField:    types.StringNull(),
+			ANullBool:     types.BoolNull(),
+			APlainBool:    &[]bool{true}[0],
+			AnIntField:    &[]int{3600}[0],
+			ALabelsField:  map[string][]string{"owner": {"alice", "bob"}},
+			AJSONField:    []string{"a"},
+			AnInt64Field:  types.Int64Value(-1),
+			AFloat64Field: types.Float64Value(1e21),
+			ASliceField:   []string{"a", "b"},
+			AListField:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("c")}),
+			ASetField:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("d,e")}),
+		}
+
+		m, err := ToBTPCLIParamsMap(in)
+		assert.NoError(t, err)
+
+		var out params
+		if assert.NoError(t, FromBTPCLIParamsMap(m, &out)) {
+			assert.Equal(t, in, out)
+		}
+	})
+
+	t.Run("error path - invalid boolean", func(t *testing.T) {
+		var uut params
+
+		err := FromBTPCLIParamsMap(map[string]string{"aBoolField": "yes"}, &uut)
+
+		assert.EqualError(t, err, "the value 'yes' of 'aBoolField' is not a valid boolean")
+	})
+
+	t.Run("error path - invalid number", func(t *testing.T) {
+		var uut params
+
+		err := FromBTPCLIParamsMap(map[string]string{"anInt64Field": "1.5"}, &uut)
+
+		assert.EqualError(t, err, "the value '1.5' of 'anInt64Field' is not a valid integer")
+	})
+
+	t.Run("error path - unsupported attribute type", func(t *testing.T) {
+		uut := struct {
+			AMapField types.Map `tfsdk:"a_map" btpcli:"aMap"`
+		}{}
+
+		err := FromBTPCLIParamsMap(map[string]string{}, &uut)
+
+		assert.EqualError(t, err, "the type 'basetypes.MapValue' assigned to 'aMap' is not yet supported")
+	})
+
+	t.Run("error path - no pointer to a struct", func(t *testing.T) {
+		err := FromBTPCLIParamsMap(map[string]string{}, params{})
+
+		assert.EqualError(t, err, "unsupported type: expected a pointer to a struct, got tfutils.params")
+	})
+}
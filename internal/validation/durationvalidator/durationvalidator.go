@@ -0,0 +1,53 @@
+package durationvalidator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type durationValidator struct {
+	allowZero bool
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v durationValidator) MarkdownDescription(_ context.Context) string {
+	if v.allowZero {
+		return "value must be a duration of zero or more, e.g. \"0s\", \"5s\" or \"1m30s\""
+	}
+
+	return "value must be a positive duration, e.g. \"5s\" or \"1m30s\""
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue
+
+	if duration, err := time.ParseDuration(value.ValueString()); err == nil && (duration > 0 || (v.allowZero && duration == 0)) {
+		return
+	}
+
+	response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+		request.Path,
+		v.Description(ctx),
+		value.String(),
+	))
+}
+
+// PositiveDuration checks that the String held in the attribute is a duration greater than zero as parsed by time.ParseDuration
+func PositiveDuration() validator.String {
+	return durationValidator{}
+}
+
+// NonNegativeDuration checks that the String held in the attribute is a duration of zero or more as parsed by time.ParseDuration
+func NonNegativeDuration() validator.String {
+	return durationValidator{allowZero: true}
+}
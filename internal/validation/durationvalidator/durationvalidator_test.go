@@ -0,0 +1,143 @@
+package durationvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPositiveDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		in        types.String
+		expErrors int
+	}
+
+	testCases := map[string]testCase{
+		"simple-match-seconds": {
+			in:        types.StringValue("5s"),
+			expErrors: 0,
+		},
+		"simple-match-composite": {
+			in:        types.StringValue("1m30s"),
+			expErrors: 0,
+		},
+		"simple-mismatch": {
+			in:        types.StringValue("five seconds"),
+			expErrors: 1,
+		},
+		"missing-unit": {
+			in:        types.StringValue("5"),
+			expErrors: 1,
+		},
+		"zero": {
+			in:        types.StringValue("0s"),
+			expErrors: 1,
+		},
+		"negative": {
+			in:        types.StringValue("-5s"),
+			expErrors: 1,
+		},
+		"skip-validation-on-null": {
+			in:        types.StringNull(),
+			expErrors: 0,
+		},
+		"skip-validation-on-unknown": {
+			in:        types.StringUnknown(),
+			expErrors: 0,
+		},
+	}
+
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: test.in,
+			}
+			res := validator.StringResponse{}
+			PositiveDuration().ValidateString(context.TODO(), req, &res)
+
+			if test.expErrors > 0 && !res.Diagnostics.HasError() {
+				t.Fatalf("expected %d error(s), got none", test.expErrors)
+			}
+
+			if test.expErrors > 0 && test.expErrors != res.Diagnostics.ErrorsCount() {
+				t.Fatalf("expected %d error(s), got %d: %v", test.expErrors, res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+
+			if test.expErrors == 0 && res.Diagnostics.HasError() {
+				t.Fatalf("expected no error(s), got %d: %v", res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestNonNegativeDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		in        types.String
+		expErrors int
+	}
+
+	testCases := map[string]testCase{
+		"simple-match-seconds": {
+			in:        types.StringValue("5s"),
+			expErrors: 0,
+		},
+		"simple-match-composite": {
+			in:        types.StringValue("1m30s"),
+			expErrors: 0,
+		},
+		"simple-mismatch": {
+			in:        types.StringValue("five seconds"),
+			expErrors: 1,
+		},
+		"missing-unit": {
+			in:        types.StringValue("5"),
+			expErrors: 1,
+		},
+		"zero": {
+			in:        types.StringValue("0s"),
+			expErrors: 0,
+		},
+		"negative": {
+			in:        types.StringValue("-5s"),
+			expErrors: 1,
+		},
+		"skip-validation-on-null": {
+			in:        types.StringNull(),
+			expErrors: 0,
+		},
+		"skip-validation-on-unknown": {
+			in:        types.StringUnknown(),
+			expErrors: 0,
+		},
+	}
+
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: test.in,
+			}
+			res := validator.StringResponse{}
+			NonNegativeDuration().ValidateString(context.TODO(), req, &res)
+
+			if test.expErrors > 0 && !res.Diagnostics.HasError() {
+				t.Fatalf("expected %d error(s), got none", test.expErrors)
+			}
+
+			if test.expErrors > 0 && test.expErrors != res.Diagnostics.ErrorsCount() {
+				t.Fatalf("expected %d error(s), got %d: %v", test.expErrors, res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+
+			if test.expErrors == 0 && res.Diagnostics.HasError() {
+				t.Fatalf("expected no error(s), got %d: %v", res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package subdomainvalidator
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// SubdomainOrUUIDRegexp matches a single DNS label of up to 63 letters, digits and hyphens, which neither starts nor
+// ends with a hyphen. UUIDs are matched as well.
+var SubdomainOrUUIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// ValidSubdomainOrUUID checks that the String held in the attribute is either a valid subdomain or a valid UUID
+func ValidSubdomainOrUUID() validator.String {
+	return stringvalidator.RegexMatches(SubdomainOrUUIDRegexp, "value must be a valid subdomain (e.g. \"my-globalaccount\") or UUID")
+}
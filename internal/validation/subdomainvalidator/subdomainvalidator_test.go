@@ -0,0 +1,88 @@
+package subdomainvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSubdomainOrUUIDValidator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		in        types.String
+		expErrors int
+	}
+
+	testCases := map[string]testCase{
+		"simple-match-subdomain": {
+			in:        types.StringValue("my-globalaccount"),
+			expErrors: 0,
+		},
+		"simple-match-single-character": {
+			in:        types.StringValue("a"),
+			expErrors: 0,
+		},
+		"simple-match-uuid": {
+			in:        types.StringValue("dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0"),
+			expErrors: 0,
+		},
+		"mismatch-url": {
+			in:        types.StringValue("https://my-globalaccount.accounts.ondemand.com"),
+			expErrors: 1,
+		},
+		"mismatch-whitespace": {
+			in:        types.StringValue(" my-globalaccount"),
+			expErrors: 1,
+		},
+		"mismatch-leading-hyphen": {
+			in:        types.StringValue("-my-globalaccount"),
+			expErrors: 1,
+		},
+		"mismatch-trailing-hyphen": {
+			in:        types.StringValue("my-globalaccount-"),
+			expErrors: 1,
+		},
+		"mismatch-too-long": {
+			in:        types.StringValue("a123456789012345678901234567890123456789012345678901234567890123"),
+			expErrors: 1,
+		},
+		"mismatch-empty": {
+			in:        types.StringValue(""),
+			expErrors: 1,
+		},
+		"skip-validation-on-null": {
+			in:        types.StringNull(),
+			expErrors: 0,
+		},
+		"skip-validation-on-unknown": {
+			in:        types.StringUnknown(),
+			expErrors: 0,
+		},
+	}
+
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: test.in,
+			}
+			res := validator.StringResponse{}
+			ValidSubdomainOrUUID().ValidateString(context.TODO(), req, &res)
+
+			if test.expErrors > 0 && !res.Diagnostics.HasError() {
+				t.Fatalf("expected %d error(s), got none", test.expErrors)
+			}
+
+			if test.expErrors > 0 && test.expErrors != res.Diagnostics.ErrorsCount() {
+				t.Fatalf("expected %d error(s), got %d: %v", test.expErrors, res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+
+			if test.expErrors == 0 && res.Diagnostics.HasError() {
+				t.Fatalf("expected no error(s), got %d: %v", res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+		})
+	}
+}
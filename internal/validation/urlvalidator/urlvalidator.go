@@ -0,0 +1,55 @@
+package urlvalidator
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type httpsURLValidator struct {
+}
+
+func (v httpsURLValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v httpsURLValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a valid URL with the scheme \"https\" and a host, e.g. \"https://cpcli.cf.eu10.hana.ondemand.com\""
+}
+
+func (v httpsURLValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue
+
+	if u, err := url.Parse(value.ValueString()); err == nil && u.Hostname() != "" && (u.Scheme == "https" || (u.Scheme == "http" && isLoopback(u.Hostname()))) {
+		return
+	}
+
+	response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+		request.Path,
+		v.Description(ctx),
+		value.String(),
+	))
+}
+
+// isLoopback reports whether the host refers to the local machine, for which unencrypted connections are acceptable.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ValidHTTPSURL checks that the String held in the attribute is an absolute URL with the scheme https and a non-empty
+// host. Plain http is only accepted for localhost and loopback addresses, e.g. for local test servers.
+func ValidHTTPSURL() validator.String {
+	return httpsURLValidator{}
+}
@@ -0,0 +1,84 @@
+package urlvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHTTPSURLValidator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		in        types.String
+		expErrors int
+	}
+
+	testCases := map[string]testCase{
+		"simple-match": {
+			in:        types.StringValue("https://cpcli.cf.eu10.hana.ondemand.com"),
+			expErrors: 0,
+		},
+		"simple-match-with-port-and-path": {
+			in:        types.StringValue("https://cpcli.cf.eu10.hana.ondemand.com:443/some/path"),
+			expErrors: 0,
+		},
+		"simple-match-http-loopback": {
+			in:        types.StringValue("http://127.0.0.1:8080"),
+			expErrors: 0,
+		},
+		"simple-match-http-localhost": {
+			in:        types.StringValue("http://localhost:8080"),
+			expErrors: 0,
+		},
+		"mismatch-http": {
+			in:        types.StringValue("http://cpcli.cf.eu10.hana.ondemand.com"),
+			expErrors: 1,
+		},
+		"mismatch-missing-scheme": {
+			in:        types.StringValue("cpcli.cf.eu10.hana.ondemand.com"),
+			expErrors: 1,
+		},
+		"mismatch-missing-host": {
+			in:        types.StringValue("https://"),
+			expErrors: 1,
+		},
+		"mismatch-unparsable": {
+			in:        types.StringValue("https://cpcli.cf.eu10.hana.ondemand.com:port"),
+			expErrors: 1,
+		},
+		"skip-validation-on-null": {
+			in:        types.StringNull(),
+			expErrors: 0,
+		},
+		"skip-validation-on-unknown": {
+			in:        types.StringUnknown(),
+			expErrors: 0,
+		},
+	}
+
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: test.in,
+			}
+			res := validator.StringResponse{}
+			ValidHTTPSURL().ValidateString(context.TODO(), req, &res)
+
+			if test.expErrors > 0 && !res.Diagnostics.HasError() {
+				t.Fatalf("expected %d error(s), got none", test.expErrors)
+			}
+
+			if test.expErrors > 0 && test.expErrors != res.Diagnostics.ErrorsCount() {
+				t.Fatalf("expected %d error(s), got %d: %v", test.expErrors, res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+
+			if test.expErrors == 0 && res.Diagnostics.HasError() {
+				t.Fatalf("expected no error(s), got %d: %v", res.Diagnostics.ErrorsCount(), res.Diagnostics)
+			}
+		})
+	}
+}